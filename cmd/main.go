@@ -6,17 +6,26 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"product-catalog-service/internal/api"
+	"product-catalog-service/internal/blob"
 	"product-catalog-service/internal/config" // Using the robust config package
+	"product-catalog-service/internal/jobs"
+	"product-catalog-service/internal/migrate"
 	"product-catalog-service/internal/store"
 
 	"github.com/go-chi/chi/v5"
@@ -37,6 +46,17 @@ const (
 	defaultAppName = "ProductCatalogService" // App name for logger
 )
 
+// catalogStore is implemented by every store backend (store.PostgresStore, store.MemoryStore).
+type catalogStore interface {
+	store.CategoryStorer
+	store.ProductStorer
+	store.LocationStorer
+	store.PriceListStorer
+	store.ReservationStorer
+	store.IdempotencyStorer
+	io.Closer
+}
+
 func main() {
 	err := godotenv.Load() // Loads .env from the current directory by default
     if err != nil {
@@ -58,31 +78,111 @@ func main() {
 	}
 	logger.Printf("INFO: Configuration loaded for APP_ENV: %s, LogLevel: %s", cfg.AppEnv, cfg.LogLevel)
 
-	// --- Database Connection ---
-	// dbStore now directly holds *store.PostgresStore
-	db, err := sql.Open("postgres", cfg.Postgres.DSN())
-	if err != nil {
-		logger.Fatalf("FATAL: Failed to initialize database connection: %v", err)
-	}
-	defer func() {
-		// This defer is a fallback if setupDB or other parts fail before graceful shutdown takes over.
-		// Graceful shutdown will also try to close it.
-		if err := db.Close(); err != nil {
-			logger.Printf("WARN: Error closing database on deferred cleanup: %v", err)
+	// --- Store Selection ---
+	var (
+		db        *sql.DB // Stays nil when the in-memory backend is used
+		dataStore catalogStore
+	)
+	if cfg.StoreBackend == config.StoreBackendMemory {
+		if len(os.Args) > 1 && (os.Args[1] == "migrate" || os.Args[1] == "import") {
+			logger.Fatalf("FATAL: The %s command requires STORE_BACKEND=%s", os.Args[1], config.StoreBackendPostgres)
 		}
-	}()
+		logger.Println("WARN: Using the in-memory store; all data is lost when the service stops.")
+		dataStore = store.NewMemoryStore()
+	} else {
+		// --- Database Connection ---
+		db, err = sql.Open("postgres", cfg.Postgres.DSN())
+		if err != nil {
+			logger.Fatalf("FATAL: Failed to initialize database connection: %v", err)
+		}
+		defer func() {
+			// This defer is a fallback if setupDB or other parts fail before graceful shutdown takes over.
+			// Graceful shutdown will also try to close it.
+			if err := db.Close(); err != nil {
+				logger.Printf("WARN: Error closing database on deferred cleanup: %v", err)
+			}
+		}()
+
+		if err := db.PingContext(context.Background()); err != nil { // Ping DB to ensure connection is live
+			logger.Fatalf("FATAL: Failed to ping database: %v", err)
+		}
+		// Apply connection pool settings from config
+
+		logger.Println("INFO: Database connection established and configured successfully.")
 
-	if err := db.PingContext(context.Background()); err != nil { // Ping DB to ensure connection is live
-		logger.Fatalf("FATAL: Failed to ping database: %v", err)
+		// --- Schema Migrations ---
+		// "server migrate <up|down|status>" runs migrations and exits without starting the servers.
+		if len(os.Args) > 1 && os.Args[1] == "migrate" {
+			if err := runMigrateCommand(context.Background(), logger, db, os.Args[2:]); err != nil {
+				logger.Fatalf("FATAL: Migration command failed: %v", err)
+			}
+			return
+		}
+		if cfg.Postgres.AutoMigrate {
+			migrator, err := migrate.New(db, logger)
+			if err != nil {
+				logger.Fatalf("FATAL: Failed to load schema migrations: %v", err)
+			}
+			applied, err := migrator.Up(context.Background())
+			if err != nil {
+				logger.Fatalf("FATAL: Failed to apply schema migrations: %v", err)
+			}
+			logger.Printf("INFO: Schema is up to date (%d migration(s) applied on startup).", applied)
+		}
+
+		postgresStore := store.NewPostgresStore(db) // Pass the *sql.DB to the store constructor
+		reindexed, err := postgresStore.SetSearchLanguage(context.Background(), cfg.Search.Language)
+		if err != nil {
+			logger.Fatalf("FATAL: Failed to configure product search: %v", err)
+		}
+		if reindexed {
+			logger.Printf("INFO: Product search language changed to %q; products were reindexed.", cfg.Search.Language)
+		}
+		dataStore = postgresStore
 	}
-	// Apply connection pool settings from config
+	logger.Printf("INFO: Using %s store backend.", cfg.StoreBackend)
 
-	logger.Println("INFO: Database connection established and configured successfully.")
-	dbStore := store.NewPostgresStore(db) // Pass the *sql.DB to the store constructor
+	// "server import [flags] <file>" imports products and exits without starting the servers.
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImportCommand(context.Background(), logger, dataStore, os.Args[2:]); err != nil {
+			logger.Fatalf("FATAL: Import command failed: %v", err)
+		}
+		return
+	}
 
 	// --- Initialize API Handlers ---
-	httpAPIHandler := api.NewHTTPHandler(dbStore, dbStore) // dbStore implements both interfaces
-	grpcAPIHandler := api.NewGRPCHandler(dbStore, dbStore) // dbStore implements both interfaces
+	pageTokens := api.NewPageTokenCodec([]byte(cfg.Pagination.TokenSecret)) // Shared so HTTP and gRPC accept each other's cursors
+	httpAPIHandler := api.NewHTTPHandler(dataStore, dataStore, dataStore, dataStore, pageTokens) // dataStore implements all four interfaces
+	imageStorage, err := blob.NewLocalStorage(cfg.Images.StorageDir)
+	if err != nil {
+		logger.Fatalf("FATAL: Failed to set up image storage: %v", err)
+	}
+	httpAPIHandler.EnableImageUploads(api.ImageUploadConfig{
+		Storage:       imageStorage,
+		MaxBytes:      cfg.Images.MaxUploadBytes,
+		ThumbnailSize: cfg.Images.ThumbnailSize,
+		PublicBaseURL: cfg.Images.PublicBaseURL,
+	})
+	grpcAPIHandler := api.NewGRPCHandler(dataStore, dataStore, dataStore, dataStore, dataStore, dataStore, cfg.Idempotency.Retention, pageTokens) // dataStore implements all store interfaces
+
+	// --- Start Background Jobs ---
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	var jobsWG sync.WaitGroup
+	for _, job := range []*jobs.Periodic{
+		jobs.NewReservationSweeper(dataStore, cfg.Jobs.ReservationSweepInterval, logger),
+		jobs.NewIdempotencyKeyPurger(dataStore, cfg.Idempotency.PurgeInterval, logger),
+		jobs.NewTrashPurger(dataStore, dataStore, cfg.Trash.Retention, cfg.Trash.PurgeInterval, logger),
+	} {
+		jobsWG.Add(1)
+		go func(job *jobs.Periodic) {
+			defer jobsWG.Done()
+			job.Run(jobsCtx)
+		}(job)
+	}
+	stopJobs := func() {
+		cancelJobs()
+		jobsWG.Wait()
+	}
 
 	// --- Setup & Start HTTP Server ---
 	httpRouter := chi.NewRouter()
@@ -123,12 +223,130 @@ func main() {
 
 	// --- Graceful Shutdown ---
 	shutdownComplete := make(chan struct{})
-	go waitForShutdown(logger, httpServer, grpcServer, dbStore, shutdownComplete)
+	go waitForShutdown(logger, httpServer, grpcServer, stopJobs, dataStore, shutdownComplete)
 
 	<-shutdownComplete // Block until graceful shutdown is complete
 	logger.Println("INFO: Service shutdown sequence finished.")
 }
 
+// runMigrateCommand handles the "migrate" subcommand: up, down [steps] or status.
+func runMigrateCommand(ctx context.Context, logger *log.Logger, db *sql.DB, args []string) error {
+	migrator, err := migrate.New(db, logger)
+	if err != nil {
+		return err
+	}
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Printf("INFO: Applied %d migration(s).", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		logger.Printf("INFO: Reverted %d migration(s).", reverted)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range statuses {
+			state := "pending"
+			if st.Applied {
+				state = "applied at " + st.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", st.Version, st.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown migrate action %q (expected up, down or status)", action)
+	}
+	return nil
+}
+
+// runImportCommand handles the "import" subcommand: it imports the products of a CSV or NDJSON file
+// ("-" for standard input) and writes the report as JSON to standard output.
+func runImportCommand(ctx context.Context, logger *log.Logger, ps store.ProductStorer, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", "csv or ndjson; by default from the file extension")
+	dryRun := flags.Bool("dry-run", false, "validate and report without writing anything")
+	columns := columnMapFlag{}
+	flags.Var(columns, "map", "map a CSV column to a product field, as Column=field (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import [-format csv|ndjson] [-map Column=field]... [-dry-run] <file|->")
+	}
+
+	path := flags.Arg(0)
+	input := io.Reader(os.Stdin)
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			*format = api.ProductImportCSV
+		case ".ndjson", ".jsonl":
+			*format = api.ProductImportNDJSON
+		default:
+			return errors.New("cannot tell the format from the file name; set -format")
+		}
+	}
+
+	opts := api.ProductImportOptions{Format: *format, Columns: columns, DryRun: *dryRun}
+	report, err := api.NewProductImporter(ps).Import(store.WithActor(ctx, "import"), input, opts)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	logger.Printf("INFO: Import finished (dry run: %t): %d created, %d updated, %d failed.", report.DryRun, report.Created, report.Updated, report.Failed)
+	if report.Failed > 0 {
+		return fmt.Errorf("%d row(s) failed", report.Failed)
+	}
+	return nil
+}
+
+// columnMapFlag collects the -map flags of the import command.
+type columnMapFlag map[string]string
+
+func (m columnMapFlag) String() string {
+	return fmt.Sprint(map[string]string(m))
+}
+
+func (m columnMapFlag) Set(value string) error {
+	i := strings.LastIndex(value, "=") // Field names have no "=", column names might
+	if i <= 0 {
+		return fmt.Errorf("expected Column=field, got %q", value)
+	}
+	m[value[:i]] = value[i+1:]
+	return nil
+}
+
 func setupBaseMiddleware(router *chi.Mux, logger *log.Logger) {
 	router.Use(middleware.RequestID)
 	router.Use(middleware.RealIP)
@@ -147,7 +365,9 @@ func registerHealthCheck(router *chi.Mux, logger *log.Logger, db *sql.DB) {
 		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
 		defer cancel()
 		dbStatus := "healthy"
-		if err := db.PingContext(ctx); err != nil {
+		if db == nil {
+			dbStatus = "not configured" // In-memory store backend
+		} else if err := db.PingContext(ctx); err != nil {
 			dbStatus = "unhealthy"
 			logger.Printf("WARN: Health check DB ping failed: %v", err)
 		}
@@ -197,7 +417,8 @@ func waitForShutdown(
 	logger *log.Logger,
 	httpServer *http.Server,
 	grpcServer *grpc.Server,
-	dbStore *store.PostgresStore, // Or a generic interface with Close() if preferred
+	stopJobs func(),
+	dataStore io.Closer,
 	shutdownComplete chan struct{},
 ) {
 	defer close(shutdownComplete) // Ensure channel is closed when function exits
@@ -241,12 +462,16 @@ func waitForShutdown(
 		logger.Println("INFO: gRPC server forced stop.")
 	}
 
-	// Close database connection pool
-	if dbStore != nil {
-		if err := dbStore.Close(); err != nil { // Assumes dbStore has a Close() method
+	// Background jobs use the store, so they must stop before it is closed
+	logger.Println("INFO: Stopping background jobs...")
+	stopJobs()
+
+	// Close database connection pool (no-op for the in-memory store)
+	if dataStore != nil {
+		if err := dataStore.Close(); err != nil {
 			logger.Printf("WARN: Error closing database connection: %v", err)
 		}
-		// The underlying *sql.DB is also closed by dbStore.Close() if implemented correctly
+		// The underlying *sql.DB is also closed by PostgresStore.Close()
 	}
 
 	logger.Println("INFO: Graceful shutdown sequence completed.")
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+)
+
+// Limits on attribute filters, which each add a condition to the listing query.
+const (
+	maxAttributeFilters      = 10
+	maxAttributeFilterValues = 20
+)
+
+const attributeFilterPrefix = "attr."
+
+// parseAttributeFilterQuery reads the attr.<key> query parameters of a product listing:
+//
+//	attr.color=red                         equality; repeating the parameter matches any of the values
+//	attr.weight[gte]=1&attr.weight[lte]=5  numeric range, either bound optional
+//	attr.warranty[exists]=true             the attribute is present
+//
+// It returns an error message if a parameter is malformed.
+func parseAttributeFilterQuery(query url.Values) ([]store.AttributeFilter, string) {
+	names := make([]string, 0)
+	for name := range query {
+		if strings.HasPrefix(name, attributeFilterPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names) // Deterministic conditions, and thus query plans
+
+	var filters []store.AttributeFilter
+	ranges := make(map[string]int) // Key to the index of its range filter
+	for _, name := range names {
+		key, op := strings.TrimPrefix(name, attributeFilterPrefix), ""
+		if open := strings.LastIndex(key, "["); open >= 0 && strings.HasSuffix(key, "]") {
+			key, op = key[:open], key[open+1:len(key)-1]
+		}
+		if key == "" || len(key) > maxAttributeKeyLen {
+			return nil, fmt.Sprintf("Invalid %s: attribute keys must be 1 to %d characters long", name, maxAttributeKeyLen)
+		}
+		values := query[name]
+		switch op {
+		case "":
+			if len(values) > maxAttributeFilterValues {
+				return nil, fmt.Sprintf("Invalid %s: at most %d values are allowed", name, maxAttributeFilterValues)
+			}
+			f := store.AttributeFilter{Key: key, Op: store.AttributeEquals, Values: values}
+			if len(values) > 1 {
+				f.Op = store.AttributeIn
+			}
+			filters = append(filters, f)
+		case "gte", "lte":
+			if len(values) != 1 {
+				return nil, fmt.Sprintf("Invalid %s: must be a single number", name)
+			}
+			bound, err := strconv.ParseFloat(values[0], 64)
+			if err != nil {
+				return nil, fmt.Sprintf("Invalid %s: must be a single number", name)
+			}
+			i, ok := ranges[key]
+			if !ok {
+				i = len(filters)
+				ranges[key] = i
+				filters = append(filters, store.AttributeFilter{Key: key, Op: store.AttributeRange})
+			}
+			if op == "gte" {
+				filters[i].Min = &bound
+			} else {
+				filters[i].Max = &bound
+			}
+		case "exists":
+			if len(values) != 1 || values[0] != "true" {
+				return nil, fmt.Sprintf("Invalid %s: must be true", name)
+			}
+			filters = append(filters, store.AttributeFilter{Key: key, Op: store.AttributeExists})
+		default:
+			return nil, fmt.Sprintf("Invalid %s: unknown operator %q. Allowed: gte, lte, exists", name, op)
+		}
+	}
+	if len(filters) > maxAttributeFilters {
+		return nil, fmt.Sprintf("At most %d attribute filters are allowed", maxAttributeFilters)
+	}
+	return filters, ""
+}
+
+var attributeFilterOps = map[productpb.AttributeFilterOperator]store.AttributeFilterOp{
+	productpb.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_EQUALS: store.AttributeEquals,
+	productpb.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_IN:     store.AttributeIn,
+	productpb.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_RANGE:  store.AttributeRange,
+	productpb.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_EXISTS: store.AttributeExists,
+}
+
+// attributeFiltersFromProto converts the attribute filters of a gRPC listing request. The store
+// checks that each has what its operator needs.
+func attributeFiltersFromProto(pbFilters []*productpb.AttributeFilter) ([]store.AttributeFilter, string) {
+	if len(pbFilters) > maxAttributeFilters {
+		return nil, fmt.Sprintf("At most %d attribute filters are allowed", maxAttributeFilters)
+	}
+	filters := make([]store.AttributeFilter, 0, len(pbFilters))
+	for _, pf := range pbFilters {
+		op, ok := attributeFilterOps[pf.GetOperator()]
+		if !ok {
+			return nil, fmt.Sprintf("Attribute filter on %q has no valid operator", pf.GetKey())
+		}
+		if len(pf.GetKey()) > maxAttributeKeyLen {
+			return nil, fmt.Sprintf("Attribute keys must be at most %d characters long", maxAttributeKeyLen)
+		}
+		if len(pf.GetValues()) > maxAttributeFilterValues {
+			return nil, fmt.Sprintf("Attribute filter on %q has more than %d values", pf.GetKey(), maxAttributeFilterValues)
+		}
+		filters = append(filters, store.AttributeFilter{Key: pf.GetKey(), Op: op, Values: pf.GetValues(), Min: pf.Min, Max: pf.Max})
+	}
+	return filters, ""
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseAttributeFilterQuery(t *testing.T) {
+	query := url.Values{
+		"attr.color":         {"red", "blue"},
+		"attr.size":          {"M"},
+		"attr.weight[gte]":   {"1"},
+		"attr.weight[lte]":   {"2.5"},
+		"attr.gift[exists]":  {"true"},
+		"attr.odd[key][gte]": {"3"}, // Only the last bracket pair is an operator
+		"category_id":        {"1"},
+	}
+	filters, errMsg := parseAttributeFilterQuery(query)
+	require.Empty(t, errMsg)
+	assert.Equal(t, []store.AttributeFilter{
+		{Key: "color", Op: store.AttributeIn, Values: []string{"red", "blue"}},
+		{Key: "gift", Op: store.AttributeExists},
+		{Key: "odd[key]", Op: store.AttributeRange, Min: PtrTo(3.0)},
+		{Key: "size", Op: store.AttributeEquals, Values: []string{"M"}},
+		{Key: "weight", Op: store.AttributeRange, Min: PtrTo(1.0), Max: PtrTo(2.5)},
+	}, filters)
+
+	for _, bad := range []url.Values{
+		{"attr.": {"x"}},
+		{"attr.weight[gt]": {"1"}},
+		{"attr.weight[gte]": {"heavy"}},
+		{"attr.weight[lte]": {"1", "2"}},
+		{"attr.gift[exists]": {"false"}},
+	} {
+		_, errMsg := parseAttributeFilterQuery(bad)
+		assert.NotEmpty(t, errMsg, bad.Encode())
+	}
+}
+
+func TestHTTPHandler_ListProducts_AttributeFilters(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	seedFacetProducts(t, memStore)
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/products?" + url.Values{"attr.color": {"red"}, "is_active": {"true"}}.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var body struct {
+		Data []domain.Product `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, "SHIRT-0", body.Data[0].SKU)
+
+	resp, err = http.Get(server.URL + "/api/v1/products?" + url.Values{"attr.price[gte]": {"5"}, "attr.price[lte]": {"1"}}.Encode())
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "the store rejects an empty range")
+}
+
+func TestGRPCHandler_ListProductsInternal_AttributeFilters(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	seedFacetProducts(t, memStore)
+
+	resp, err := handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+		IncludeInactive: PtrTo(true),
+		AttributeFilters: []*productpb.AttributeFilter{
+			{Key: "color", Operator: productpb.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_IN, Values: []string{"red", "green"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.GetPageInfo().GetTotalSize())
+
+	for _, bad := range []*productpb.AttributeFilter{
+		{Key: "color", Values: []string{"red"}}, // No operator
+		{Key: "color", Operator: productpb.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_EQUALS},
+	} {
+		_, err := handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+			AttributeFilters: []*productpb.AttributeFilter{bad},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), bad.String())
+	}
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+)
+
+// Limits on what a single listing may ask for, since every facet is a query of its own.
+const (
+	maxFacetAttributeKeys   = 10
+	maxFacetPriceBoundaries = 20
+	maxAttributeKeyLen      = 100 // Also applies to attribute filters
+)
+
+// parseFacetsQuery reads the facets and price_buckets query parameters of a product listing:
+// facets is a comma-separated list of category, price, is_active and attributes.<key>, and
+// price_buckets optionally replaces the default price range boundaries, which are amounts of
+// currency. It returns nil if no facets were asked for, or an error message.
+func parseFacetsQuery(facetsParam, priceBucketsParam, currency string) (*store.FacetRequest, string) {
+	if facetsParam == "" {
+		if priceBucketsParam != "" {
+			return nil, "price_buckets requires facets=price"
+		}
+		return nil, ""
+	}
+	req := &store.FacetRequest{}
+	price := false
+	for _, name := range strings.Split(facetsParam, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "category":
+			req.Categories = true
+		case name == "price":
+			price = true
+		case name == "is_active":
+			req.IsActive = true
+		case strings.HasPrefix(name, "attributes."):
+			req.AttributeKeys = appendUnique(req.AttributeKeys, strings.TrimPrefix(name, "attributes."))
+		default:
+			return nil, fmt.Sprintf("Invalid facet %q. Allowed: category, price, is_active, attributes.<key>", name)
+		}
+	}
+	if priceBucketsParam != "" {
+		if !price {
+			return nil, "price_buckets requires facets=price"
+		}
+		for _, s := range strings.Split(priceBucketsParam, ",") {
+			boundary, err := domain.ParseMoney(strings.TrimSpace(s), currency)
+			if err != nil {
+				return nil, fmt.Sprintf("Invalid price_buckets: must be comma-separated prices in %s", currency)
+			}
+			req.PriceBoundaries = append(req.PriceBoundaries, boundary)
+		}
+	} else if price {
+		req.PriceBoundaries = store.DefaultPriceFacetBoundaries(currency)
+	}
+	return req, validateFacetRequest(req)
+}
+
+// facetRequestFromProto converts the facets of a gRPC listing request; it returns nil if none were
+// asked for, or an error message.
+func facetRequestFromProto(pbReq *productpb.ProductFacetsRequest) (*store.FacetRequest, string) {
+	if pbReq == nil {
+		return nil, ""
+	}
+	currency := domain.DefaultCurrency
+	if pbReq.PriceCurrency != nil {
+		if !domain.ValidCurrency(pbReq.GetPriceCurrency()) {
+			return nil, "price_currency must be an ISO 4217 code such as USD"
+		}
+		currency = pbReq.GetPriceCurrency()
+	}
+	req := &store.FacetRequest{Categories: pbReq.GetCategories(), IsActive: pbReq.GetIsActive()}
+	for _, key := range pbReq.GetAttributeKeys() {
+		req.AttributeKeys = appendUnique(req.AttributeKeys, key)
+	}
+	switch {
+	case (len(pbReq.GetPriceBoundariesMoney()) > 0 || len(pbReq.GetPriceBoundaries()) > 0) && !pbReq.GetPriceRanges():
+		return nil, "price_boundaries_money requires price_ranges"
+	case len(pbReq.GetPriceBoundariesMoney()) > 0:
+		for _, pbBoundary := range pbReq.GetPriceBoundariesMoney() {
+			boundary, err := convertProtoMoneyToDomain(pbBoundary)
+			if err != nil || boundary.Currency != currency {
+				return nil, fmt.Sprintf("price_boundaries_money must be prices in price_currency (%s)", currency)
+			}
+			req.PriceBoundaries = append(req.PriceBoundaries, boundary)
+		}
+	case len(pbReq.GetPriceBoundaries()) > 0: // Deprecated: approximate prices in price_currency
+		for _, b := range pbReq.GetPriceBoundaries() {
+			boundary, err := domain.ParseMoney(strconv.FormatFloat(b, 'f', -1, 64), currency)
+			if err != nil {
+				return nil, fmt.Sprintf("price_boundaries must be prices in price_currency (%s)", currency)
+			}
+			req.PriceBoundaries = append(req.PriceBoundaries, boundary)
+		}
+	case pbReq.GetPriceRanges():
+		req.PriceBoundaries = store.DefaultPriceFacetBoundaries(currency)
+	}
+	return req, validateFacetRequest(req)
+}
+
+// validateFacetRequest checks the limits shared by the HTTP and gRPC APIs.
+func validateFacetRequest(req *store.FacetRequest) string {
+	if len(req.PriceBoundaries) > maxFacetPriceBoundaries {
+		return fmt.Sprintf("At most %d price bucket boundaries are allowed", maxFacetPriceBoundaries)
+	}
+	for i, boundary := range req.PriceBoundaries {
+		if boundary.Amount < 0 {
+			return "Price bucket boundaries must be non-negative prices"
+		}
+		if i > 0 && boundary.Amount <= req.PriceBoundaries[i-1].Amount {
+			return "Price bucket boundaries must be in ascending order"
+		}
+	}
+	if len(req.AttributeKeys) > maxFacetAttributeKeys {
+		return fmt.Sprintf("At most %d attribute facets are allowed", maxFacetAttributeKeys)
+	}
+	for _, key := range req.AttributeKeys {
+		if key == "" || len(key) > maxAttributeKeyLen {
+			return fmt.Sprintf("Attribute facet keys must be 1 to %d characters long", maxAttributeKeyLen)
+		}
+	}
+	return ""
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func convertFacetsToProto(facets *domain.ProductFacets, attributeKeys []string) *productpb.ProductFacets {
+	pb := &productpb.ProductFacets{}
+	for _, c := range facets.Categories {
+		pb.Categories = append(pb.Categories, &productpb.CategoryFacet{CategoryId: c.CategoryID, Count: int32(c.Count)})
+	}
+	for _, r := range facets.PriceRanges {
+		pbRange := &productpb.PriceRangeFacet{Count: int32(r.Count)}
+		if r.Min != nil {
+			min := approximatePrice(*r.Min)
+			pbRange.Min, pbRange.MinMoney = &min, convertDomainMoneyToProto(*r.Min)
+		}
+		if r.Max != nil {
+			max := approximatePrice(*r.Max)
+			pbRange.Max, pbRange.MaxMoney = &max, convertDomainMoneyToProto(*r.Max)
+		}
+		pb.PriceRanges = append(pb.PriceRanges, pbRange)
+	}
+	for _, a := range facets.IsActive {
+		pb.IsActive = append(pb.IsActive, &productpb.IsActiveFacet{Value: a.Value, Count: int32(a.Count)})
+	}
+	for _, key := range attributeKeys { // The map has no order of its own
+		attribute := &productpb.AttributeFacet{Key: key}
+		for _, v := range facets.Attributes[key] {
+			attribute.Values = append(attribute.Values, &productpb.AttributeValueCount{Value: v.Value, Count: int32(v.Count)})
+		}
+		pb.Attributes = append(pb.Attributes, attribute)
+	}
+	return pb
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	commonpb "product-catalog-service/proto/v1/common"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func seedFacetProducts(t *testing.T, memStore *store.MemoryStore) {
+	t.Helper()
+	for i, attrs := range []string{`{"color": "red"}`, `{"color": "blue"}`, `{"color": "red"}`} {
+		raw := json.RawMessage(attrs)
+		_, err := memStore.CreateProduct(context.Background(), &domain.Product{
+			Name: "Shirt", SKU: fmt.Sprintf("SHIRT-%d", i), Price: usd(int64(2000 * (i + 1))), IsActive: i < 2, Attributes: &raw,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestHTTPHandler_ListProducts_Facets(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	seedFacetProducts(t, memStore)
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+
+	get := func(query url.Values) (int, map[string]json.RawMessage) {
+		resp, err := http.Get(server.URL + "/api/v1/products?" + query.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	code, body := get(url.Values{"limit": {"1"}})
+	require.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, body, "facets", "facets are opt-in")
+
+	code, body = get(url.Values{"limit": {"1"}, "is_active": {"true"}, "facets": {"price,is_active,attributes.color"}, "price_buckets": {"30"}})
+	require.Equal(t, http.StatusOK, code)
+	var facets domain.ProductFacets
+	require.NoError(t, json.Unmarshal(body["facets"], &facets))
+	require.Len(t, facets.PriceRanges, 2)
+	assert.Equal(t, 1, facets.PriceRanges[0].Count)
+	assert.Equal(t, 1, facets.PriceRanges[1].Count)
+	assert.Equal(t, usd(3000), *facets.PriceRanges[1].Min)
+	assert.Equal(t, []domain.IsActiveFacet{{Value: true, Count: 2}, {Value: false, Count: 0}}, facets.IsActive, "facets follow the filters, not the page")
+	assert.Equal(t, []domain.AttributeValueFacet{{Value: "blue", Count: 1}, {Value: "red", Count: 1}}, facets.Attributes["color"])
+	assert.Nil(t, facets.Categories)
+
+	// The price facet is in price_currency and only counts products priced in it.
+	code, body = get(url.Values{"facets": {"price"}, "price_buckets": {"30"}, "price_currency": {"EUR"}})
+	require.Equal(t, http.StatusOK, code)
+	facets = domain.ProductFacets{}
+	require.NoError(t, json.Unmarshal(body["facets"], &facets))
+	require.Len(t, facets.PriceRanges, 2)
+	assert.Equal(t, domain.Money{Amount: 3000, Currency: "EUR"}, *facets.PriceRanges[0].Max)
+	assert.Zero(t, facets.PriceRanges[0].Count+facets.PriceRanges[1].Count)
+
+	for _, bad := range []url.Values{
+		{"facets": {"colour"}},
+		{"facets": {"attributes."}},
+		{"facets": {"price"}, "price_buckets": {"50,10"}},
+		{"facets": {"price"}, "price_buckets": {"10.005"}},
+		{"facets": {"category"}, "price_buckets": {"10"}},
+		{"price_buckets": {"10"}},
+	} {
+		code, _ := get(bad)
+		assert.Equal(t, http.StatusBadRequest, code, bad.Encode())
+	}
+}
+
+func TestGRPCHandler_ListProductsInternal_Facets(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	seedFacetProducts(t, memStore)
+
+	resp, err := handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+		PageInfo:        &commonpb.PageInfoRequest{PageSize: 1},
+		IncludeInactive: PtrTo(true),
+		Facets:          &productpb.ProductFacetsRequest{Categories: true, PriceRanges: true, AttributeKeys: []string{"color", "size"}},
+	})
+	require.NoError(t, err)
+	facets := resp.GetFacets()
+	require.Len(t, facets.GetCategories(), 1)
+	assert.Nil(t, facets.GetCategories()[0].CategoryId, "all are uncategorized")
+	assert.EqualValues(t, 5, facets.GetCategories()[0].GetCount(), "the shirts and the two seeded products")
+	require.Len(t, facets.GetPriceRanges(), len(store.DefaultPriceFacetBoundaries(domain.DefaultCurrency))+1)
+	last := facets.GetPriceRanges()[len(facets.GetPriceRanges())-1]
+	assert.Equal(t, "1000.00", last.GetMinMoney().GetAmount())
+	assert.Equal(t, "USD", last.GetMinMoney().GetCurrencyCode())
+	assert.Nil(t, last.GetMaxMoney())
+	require.Len(t, facets.GetAttributes(), 2)
+	assert.Equal(t, "color", facets.GetAttributes()[0].GetKey(), "attributes keep the requested order")
+	assert.Equal(t, "red", facets.GetAttributes()[0].GetValues()[0].GetValue())
+	assert.EqualValues(t, 2, facets.GetAttributes()[0].GetValues()[0].GetCount())
+	assert.Empty(t, facets.GetAttributes()[1].GetValues())
+
+	resp, err = handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, resp.GetFacets())
+
+	_, err = handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+		Facets: &productpb.ProductFacetsRequest{PriceBoundaries: []float64{10}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "boundaries without price_ranges")
+	_, err = handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+		Facets: &productpb.ProductFacetsRequest{PriceRanges: true, PriceBoundariesMoney: []*commonpb.Money{{CurrencyCode: "EUR", Amount: "10"}}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "boundaries in another currency than price_currency")
+	_, err = handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+		Currency: PtrTo("EUR"),
+		Facets:   &productpb.ProductFacetsRequest{PriceRanges: true, PriceCurrency: PtrTo("EUR")},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "price ranges count base prices, not selected ones")
+
+	resp, err = handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+		IncludeInactive: PtrTo(true),
+		Facets:          &productpb.ProductFacetsRequest{PriceRanges: true, PriceBoundaries: []float64{45.5}},
+	})
+	require.NoError(t, err, "deprecated boundaries are prices in price_currency")
+	require.Len(t, resp.GetFacets().GetPriceRanges(), 2)
+	assert.Equal(t, "45.50", resp.GetFacets().GetPriceRanges()[1].GetMinMoney().GetAmount())
+	assert.EqualValues(t, 2, resp.GetFacets().GetPriceRanges()[1].GetCount(), "the keyboard and the third shirt")
+}
@@ -2,10 +2,15 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json" // For converting domain.Product.Attributes
 	"errors"
+	"fmt"
 	"log"
-	"strconv" // For basic pagination token example
+	"strconv"
+	"strings"
+	"time"
 
 	"product-catalog-service/internal/domain"
 	"product-catalog-service/internal/store"
@@ -14,7 +19,9 @@ import (
 	productpb "product-catalog-service/proto/v1/product"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb" // For product attributes
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -23,18 +30,37 @@ import (
 type GRPCHandler struct {
 	productpb.UnimplementedProductCatalogServiceServer // Essential for forward compatibility
 
-	categoryStore store.CategoryStorer
-	productStore  store.ProductStorer
+	categoryStore        store.CategoryStorer
+	productStore         store.ProductStorer
+	locationStore        store.LocationStorer
+	priceListStore       store.PriceListStorer
+	reservationStore     store.ReservationStorer
+	idempotencyStore     store.IdempotencyStorer
+	idempotencyRetention time.Duration // How long a processed order_id is replayed
+	pageTokens           *PageTokenCodec
 }
 
-// NewGRPCHandler creates a new GRPCHandler.
-func NewGRPCHandler(cs store.CategoryStorer, ps store.ProductStorer) *GRPCHandler {
+// NewGRPCHandler creates a new GRPCHandler. Processed UpdateStock order IDs are remembered
+// for idempotencyRetention, and list page tokens are signed with pageTokens.
+func NewGRPCHandler(cs store.CategoryStorer, ps store.ProductStorer, ls store.LocationStorer, pls store.PriceListStorer, rs store.ReservationStorer, is store.IdempotencyStorer, idempotencyRetention time.Duration, pageTokens *PageTokenCodec) *GRPCHandler {
 	return &GRPCHandler{
-		categoryStore: cs,
-		productStore:  ps,
+		categoryStore:        cs,
+		productStore:         ps,
+		locationStore:        ls,
+		priceListStore:       pls,
+		reservationStore:     rs,
+		idempotencyStore:     is,
+		idempotencyRetention: idempotencyRetention,
+		pageTokens:           pageTokens,
 	}
 }
 
+const (
+	updateStockOperation    = "update_stock" // Operation name of UpdateStock idempotency records
+	maxIdempotencyKeyLength = 255            // idempotency_keys.idempotency_key is VARCHAR(255)
+	actorMetadataKey        = "x-actor"      // gRPC metadata entry naming the caller, recorded in the stock ledger
+)
+
 // --- Helper: Error Mapping ---
 func mapStoreErrorToGrpcStatus(err error, resourceName string, resourceID interface{}) error {
 	if err == nil {
@@ -49,13 +75,54 @@ func mapStoreErrorToGrpcStatus(err error, resourceName string, resourceID interf
 		return status.Errorf(codes.AlreadyExists, "A %s with the given name already exists", resourceName)
 	case errors.Is(err, store.ErrProductSKUExists):
 		return status.Errorf(codes.AlreadyExists, "A %s with the given SKU already exists", resourceName)
+	case errors.Is(err, store.ErrInvalidPrice):
+		return status.Error(codes.InvalidArgument, strings.TrimPrefix(err.Error(), "store: "))
 	case errors.Is(err, store.ErrInsufficientStock):
 		return status.Errorf(codes.FailedPrecondition, "Insufficient stock for %s ID %v, or operation violates constraints", resourceName, resourceID)
+	case errors.Is(err, store.ErrReservationNotFound):
+		return status.Errorf(codes.NotFound, "No active %s for reference %v", resourceName, resourceID)
+	case errors.Is(err, store.ErrReservationExists):
+		return status.Errorf(codes.AlreadyExists, "Reference %v already holds an active %s", resourceID, resourceName)
+	case errors.Is(err, store.ErrReservationExpired):
+		return status.Errorf(codes.FailedPrecondition, "The %s for reference %v has expired", resourceName, resourceID)
+	case errors.Is(err, store.ErrLocationNotFound):
+		return status.Errorf(codes.NotFound, "Location not found for %s ID %v", resourceName, resourceID)
+	case errors.Is(err, store.ErrLocationCodeExists):
+		return status.Errorf(codes.AlreadyExists, "A %s with the given code already exists", resourceName)
+	case errors.Is(err, store.ErrLocationInUse):
+		return status.Errorf(codes.FailedPrecondition, "%s ID %v still holds stock", resourceName, resourceID)
+	case errors.Is(err, store.ErrNoLocations):
+		return status.Errorf(codes.FailedPrecondition, "No location exists to receive stock for %s ID %v", resourceName, resourceID)
+	case errors.Is(err, store.ErrSameLocation):
+		return status.Errorf(codes.InvalidArgument, "Source and destination location must differ")
+	case errors.Is(err, store.ErrInvalidCursor):
+		return status.Errorf(codes.InvalidArgument, "page_token does not belong to this listing")
+	case errors.Is(err, store.ErrInvalidAttributeFilter):
+		return status.Error(codes.InvalidArgument, strings.TrimPrefix(err.Error(), "store: "))
+	case errors.Is(err, store.ErrCategoryNotEmpty):
+		return status.Errorf(codes.FailedPrecondition, "%s ID %v still has subcategories or products; choose a delete strategy", resourceName, resourceID)
+	case errors.Is(err, store.ErrInvalidDeleteOptions), errors.Is(err, store.ErrCategoryCycle):
+		return status.Error(codes.InvalidArgument, strings.TrimPrefix(err.Error(), "store: "))
+	case errors.Is(err, store.ErrAttributeSchemaViolation):
+		return attributeSchemaStatus(err)
 	default:
 		return status.Errorf(codes.Internal, "Failed to process request for %s ID %v: %v", resourceName, resourceID, err)
 	}
 }
 
+// decodePageToken returns the cursor encoded in a page_token, or nil for the first page.
+func (s *GRPCHandler) decodePageToken(token string) (*store.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	after, err := s.pageTokens.Decode(token)
+	if err != nil {
+		log.Printf("WARN: Rejected page_token '%s': %v", token, err)
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid page_token")
+	}
+	return after, nil
+}
+
 // --- Category gRPC Methods Implementation ---
 
 func (s *GRPCHandler) GetCategoryDetails(ctx context.Context, req *productpb.GetCategoryDetailsRequest) (*productpb.GetCategoryDetailsResponse, error) {
@@ -92,40 +159,39 @@ func (s *GRPCHandler) ListCategoriesInternal(ctx context.Context, req *productpb
 	}
 	limit := int(limit32)
 
-	offset := 0
-	if req.GetPageInfo().GetPageToken() != "" {
-		parsedOffset, err := strconv.Atoi(req.GetPageInfo().GetPageToken())
-		if err == nil && parsedOffset >= 0 { // Allow offset 0
-			offset = parsedOffset
-		} else if err != nil {
-			log.Printf("WARN: Could not parse page_token '%s' as offset: %v. Defaulting to offset 0.", req.GetPageInfo().GetPageToken(), err)
-		}
+	after, err := s.decodePageToken(req.GetPageInfo().GetPageToken())
+	if err != nil {
+		return nil, err
 	}
 
 	storeParams := store.ListCategoriesParams{
-		Limit:  limit,
-		Offset: offset,
-		// Note: To filter by parent_category_id, ListCategoriesParams in store/interfaces.go
-		// and its implementation in store/postgres.go would need to support it.
-		// Example: if parentCatID > 0 { storeParams.ParentID = &parentCatID }
+		Limit:  limit + 1, // One extra row tells whether another page follows
+		After:  after,
+	}
+	if req.ParentCategoryId != nil {
+		storeParams.ParentCategoryID = &parentCatID
 	}
 
 	domainCategories, totalCount, err := s.categoryStore.ListCategories(ctx, storeParams)
 	if err != nil {
 		log.Printf("ERROR: Error listing categories from store: %v", err)
+		if errors.Is(err, store.ErrInvalidCursor) {
+			return nil, mapStoreErrorToGrpcStatus(err, "Category", 0)
+		}
 		return nil, status.Errorf(codes.Internal, "Failed to list categories: %v", err)
 	}
 
+	var nextPageToken string
+	if len(domainCategories) > limit {
+		domainCategories = domainCategories[:limit]
+		nextPageToken = s.pageTokens.Encode(store.CategoryCursor(&domainCategories[limit-1]))
+	}
+
 	protoCategories := make([]*productpb.Category, len(domainCategories))
 	for i := range domainCategories {
 		protoCategories[i] = convertDomainCategoryToProto(&domainCategories[i])
 	}
 
-	var nextPageToken string
-	if offset+len(protoCategories) < totalCount {
-		nextPageToken = strconv.Itoa(offset + len(protoCategories))
-	}
-
 	log.Printf("INFO: Returning %d categories, total available: %d, next page token: '%s'", len(protoCategories), totalCount, nextPageToken)
 	return &productpb.ListCategoriesInternalResponse{
 		Categories: protoCategories,
@@ -146,11 +212,26 @@ func (s *GRPCHandler) GetProductDetails(ctx context.Context, req *productpb.GetP
 		log.Printf("WARN: Invalid Product ID received: %d", productID)
 		return nil, status.Errorf(codes.InvalidArgument, "Product ID must be a positive integer")
 	}
+	priceSelection, err := priceSelectionFromProto(req.Currency, req.PriceListId)
+	if err != nil {
+		return nil, err
+	}
 
-	domainProduct, err := s.productStore.GetProductByID(ctx, productID)
+	domainProduct, err := getProduct(ctx, s.productStore, productID, req.GetIncludeDeleted())
 	if err != nil {
 		return nil, mapStoreErrorToGrpcStatus(err, "Product", productID)
 	}
+	resolved := []domain.Product{*domainProduct}
+	if err := s.resolvePrices(ctx, priceSelection, resolved); err != nil {
+		return nil, err
+	}
+	if err := s.attachVariants(ctx, priceSelection, resolved); err != nil {
+		return nil, err
+	}
+	if err := s.attachMedia(ctx, resolved); err != nil {
+		return nil, err
+	}
+	domainProduct = &resolved[0]
 
 	protoProduct, err := convertDomainProductToProto(domainProduct)
 	if err != nil {
@@ -177,24 +258,42 @@ func (s *GRPCHandler) ListProductsInternal(ctx context.Context, req *productpb.L
 	}
 	limit := int(limit32)
 
-	offset := 0
-	if req.GetPageInfo().GetPageToken() != "" {
-		parsedOffset, err := strconv.Atoi(req.GetPageInfo().GetPageToken())
-		if err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		} else if err != nil {
-			log.Printf("WARN: Could not parse page_token '%s' as offset: %v. Defaulting to offset 0.", req.GetPageInfo().GetPageToken(), err)
-		}
+	after, err := s.decodePageToken(req.GetPageInfo().GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	priceSelection, err := priceSelectionFromProto(req.Currency, req.PriceListId)
+	if err != nil {
+		return nil, err
+	}
+	facetRequest, errMsg := facetRequestFromProto(req.GetFacets())
+	if errMsg != "" {
+		return nil, status.Error(codes.InvalidArgument, errMsg)
+	}
+	if facetRequest != nil && len(facetRequest.PriceBoundaries) > 0 && !priceSelection.IsZero() {
+		return nil, status.Error(codes.InvalidArgument, "price_ranges count base prices, so they cannot be combined with currency or price_list_id")
+	}
+	attributeFilters, errMsg := attributeFiltersFromProto(req.GetAttributeFilters())
+	if errMsg != "" {
+		return nil, status.Error(codes.InvalidArgument, errMsg)
 	}
 
 	storeParams := store.ListProductsParams{
-		Limit:      limit,
-		Offset:     offset,
+		Limit:      limit + 1, // One extra row tells whether another page follows
+		After:      after,
 		ProductIDs: req.GetProductIds(), // Pass through if store supports it
+		Attributes: attributeFilters,
+		ParentID:   req.ParentId,
+		// Grouped by parent, variants come in the variants of their parent rather than on their own.
+		ExcludeVariants: req.GetGroupByParent(),
+	}
+	if req.GetIncludeDeleted() {
+		storeParams.Deleted = store.DeletedIncluded
 	}
 	if req.GetCategoryId() > 0 {
 		catID := req.GetCategoryId()
 		storeParams.CategoryID = &catID
+		storeParams.IncludeDescendants = req.GetIncludeDescendants()
 	}
 	if req.GetIncludeInactive() { // If true, we want to fetch all; if false or not set, filter by active (store default or explicit)
 		// The store.ListProductsParams.IsActive is *bool.
@@ -215,9 +314,30 @@ func (s *GRPCHandler) ListProductsInternal(ctx context.Context, req *productpb.L
 	domainProducts, totalCount, err := s.productStore.ListProducts(ctx, storeParams)
 	if err != nil {
 		log.Printf("ERROR: Error listing products from store: %v", err)
+		if errors.Is(err, store.ErrInvalidCursor) || errors.Is(err, store.ErrInvalidAttributeFilter) {
+			return nil, mapStoreErrorToGrpcStatus(err, "Product", 0)
+		}
 		return nil, status.Errorf(codes.Internal, "Failed to list products: %v", err)
 	}
 
+	// The token is taken from the last row of the page even if it fails conversion below.
+	var nextPageToken string
+	if len(domainProducts) > limit {
+		domainProducts = domainProducts[:limit]
+		nextPageToken = s.pageTokens.Encode(store.ProductCursor(storeParams, &domainProducts[limit-1]))
+	}
+	if err := s.resolvePrices(ctx, priceSelection, domainProducts); err != nil {
+		return nil, err
+	}
+	if req.GetGroupByParent() {
+		if err := s.attachVariants(ctx, priceSelection, domainProducts); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.attachMedia(ctx, domainProducts); err != nil {
+		return nil, err
+	}
+
 	protoProducts := make([]*productpb.Product, len(domainProducts))
 	for i := range domainProducts {
 		convertedProduct, convErr := convertDomainProductToProto(&domainProducts[i])
@@ -238,82 +358,153 @@ func (s *GRPCHandler) ListProductsInternal(ctx context.Context, req *productpb.L
     }
 
 
-	var nextPageToken string
-	if offset+len(actualProtoProducts) < totalCount {
-		nextPageToken = strconv.Itoa(offset + len(actualProtoProducts))
-	}
-
-	log.Printf("INFO: Returning %d products, total available: %d, next page token: '%s'", len(actualProtoProducts), totalCount, nextPageToken)
-	return &productpb.ListProductsInternalResponse{
+	resp := &productpb.ListProductsInternalResponse{
 		Products: actualProtoProducts,
 		PageInfo: &commonpb.PageInfoResponse{
 			NextPageToken: nextPageToken,
 			TotalSize:     int32(totalCount),
 		},
-	}, nil
+	}
+	if facetRequest != nil {
+		facets, err := s.productStore.ListProductFacets(ctx, storeParams, *facetRequest)
+		if err != nil {
+			log.Printf("ERROR: Error computing product facets: %v", err)
+			return nil, status.Errorf(codes.Internal, "Failed to compute product facets: %v", err)
+		}
+		resp.Facets = convertFacetsToProto(facets, facetRequest.AttributeKeys)
+	}
+
+	log.Printf("INFO: Returning %d products, total available: %d, next page token: '%s'", len(actualProtoProducts), totalCount, nextPageToken)
+	return resp, nil
 }
 
 func (s *GRPCHandler) UpdateStock(ctx context.Context, req *productpb.UpdateStockRequest) (*productpb.UpdateStockResponse, error) {
-	log.Printf("INFO: Received gRPC UpdateStock request with %d items. OrderID: '%s'", len(req.GetItems()), req.GetOrderId())
+	ctx = withActorFromMetadata(ctx)
+	log.Printf("INFO: Received gRPC UpdateStock request with %d items. OrderID: '%s', Mode: %s", len(req.GetItems()), req.GetOrderId(), req.GetMode())
 	if len(req.GetItems()) == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "No items provided for stock update")
 	}
 
-	// IMPORTANT: This iterative approach is NOT ATOMIC.
-	// For production, a batch update method in the store layer that handles all items
-	// within a single transaction is highly recommended for atomicity and performance.
-	updatedProductsProto := make([]*productpb.Product, 0, len(req.GetItems()))
-	var firstError error
+	mode := req.GetMode()
+	if mode == productpb.StockUpdateMode_STOCK_UPDATE_MODE_UNSPECIFIED {
+		mode = productpb.StockUpdateMode_STOCK_UPDATE_MODE_ATOMIC // Never leave half an order decremented by default
+	}
 
-	for _, item := range req.GetItems() {
+	orderID := req.GetOrderId()
+	if orderID == "" {
+		return s.applyStockUpdates(ctx, req.GetItems(), mode, nil)
+	}
+	if len(orderID) > maxIdempotencyKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "order_id must be at most %d characters", maxIdempotencyKeyLength)
+	}
+
+	// The order ID is claimed before any stock changes, so concurrent retries cannot both apply the batch.
+	requestHash := updateStockRequestHash(req.GetItems(), mode)
+	expiresAt := time.Now().UTC().Add(s.idempotencyRetention)
+	record, claimed, err := s.idempotencyStore.ClaimIdempotencyKey(ctx, orderID, updateStockOperation, requestHash, expiresAt)
+	if err != nil {
+		log.Printf("ERROR: Failed to claim idempotency key for OrderID '%s': %v", orderID, err)
+		return nil, status.Errorf(codes.Internal, "Failed to update stock: %v", err)
+	}
+	if !claimed {
+		return replayUpdateStock(record, requestHash)
+	}
+
+	resp, err := s.applyStockUpdates(ctx, req.GetItems(), mode, req.OrderId)
+	if err != nil {
+		// Nothing was applied, so the caller may retry with the same order ID.
+		if releaseErr := s.idempotencyStore.ReleaseIdempotencyKey(ctx, orderID, updateStockOperation); releaseErr != nil {
+			log.Printf("ERROR: Failed to release idempotency key for OrderID '%s': %v", orderID, releaseErr)
+		}
+		return nil, err
+	}
+
+	// If the response cannot be stored the key stays in flight until it expires: retries are then
+	// rejected rather than risking a second decrement.
+	payload, err := proto.Marshal(resp)
+	if err != nil {
+		log.Printf("ERROR: Failed to serialize UpdateStock response for OrderID '%s': %v", orderID, err)
+	} else if err := s.idempotencyStore.CompleteIdempotencyKey(ctx, orderID, updateStockOperation, payload); err != nil {
+		log.Printf("ERROR: Failed to store UpdateStock response for OrderID '%s': %v", orderID, err)
+	}
+	return resp, nil
+}
+
+// applyStockUpdates validates items and applies them as one batch in the given (resolved) mode.
+// orderID is recorded on the resulting stock ledger entries.
+func (s *GRPCHandler) applyStockUpdates(ctx context.Context, items []*productpb.StockUpdateItem, mode productpb.StockUpdateMode, orderID *string) (*productpb.UpdateStockResponse, error) {
+	atomic := mode == productpb.StockUpdateMode_STOCK_UPDATE_MODE_ATOMIC
+
+	// Items that are malformed never reach the store; the rest are applied as one batch.
+	results := make([]*productpb.StockUpdateItemResult, len(items))
+	updates := make([]store.StockUpdate, 0, len(items))
+	requestIndexes := make([]int, 0, len(items)) // updates[j] came from items[requestIndexes[j]]
+	hasInvalidItem := false
+	for i, item := range items {
+		var invalidReason string
 		if item.GetProductId() <= 0 {
-			log.Printf("WARN: Invalid Product ID %d in UpdateStock item", item.GetProductId())
-			if firstError == nil { // Capture first error
-				firstError = status.Errorf(codes.InvalidArgument, "Item has invalid Product ID: %d", item.GetProductId())
-			}
-			continue // Or fail entire batch
+			invalidReason = fmt.Sprintf("Item has invalid Product ID: %d", item.GetProductId())
+		} else if item.LocationId != nil && item.GetLocationId() <= 0 {
+			invalidReason = fmt.Sprintf("Item has invalid Location ID: %d", item.GetLocationId())
 		}
-		// quantityChange is int32, matches store method
-		domainProduct, err := s.productStore.UpdateStock(ctx, item.GetProductId(), item.GetQuantityChange())
-		if err != nil {
-			log.Printf("ERROR: Failed to update stock for product ID %d: %v", item.GetProductId(), err)
-			// Map specific errors like NotFound or InsufficientStock
-			grpcErr := mapStoreErrorToGrpcStatus(err, "Product", item.GetProductId())
-			if firstError == nil {
-				firstError = grpcErr
+		if invalidReason != "" {
+			log.Printf("WARN: Invalid UpdateStock item: %s", invalidReason)
+			results[i] = &productpb.StockUpdateItemResult{
+				ProductId: item.GetProductId(),
+				Status:    productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_INVALID_ITEM,
+				Reason:    &invalidReason,
 			}
-			// Decide on batch failure strategy: stop on first error, or collect all errors?
-			// For now, we'll continue processing other items but return the first significant error.
-			// The response will only contain successfully updated products.
+			hasInvalidItem = true
 			continue
 		}
-		protoProd, convErr := convertDomainProductToProto(domainProduct)
-		if convErr != nil {
-			log.Printf("ERROR: Failed to convert updated product ID %d to proto: %v", domainProduct.ID, convErr)
-			if firstError == nil {
-				firstError = status.Errorf(codes.Internal, "Failed to process data for product ID %d", domainProduct.ID)
-			}
-			continue
-		}
-		updatedProductsProto = append(updatedProductsProto, protoProd)
+		updates = append(updates, store.StockUpdate{ProductID: item.GetProductId(), QuantityChange: item.GetQuantityChange(), LocationID: item.LocationId})
+		requestIndexes = append(requestIndexes, i)
 	}
 
-	if firstError != nil && len(updatedProductsProto) < len(req.GetItems()) {
-		// Partial success, but an error occurred. Return the error.
-		// The client can inspect updated_products to see which ones succeeded.
-		log.Printf("WARN: UpdateStock finished with partial success and an error: %v", firstError)
-		// To be more granular, the response could include per-item statuses.
-		// For now, if any error, we return it.
-		return &productpb.UpdateStockResponse{UpdatedProducts: updatedProductsProto}, firstError
-	}
-	if firstError != nil { // All items failed
-		return nil, firstError
+	if hasInvalidItem && atomic {
+		for j, i := range requestIndexes {
+			results[i] = convertStockUpdateResultToProto(store.StockUpdateResult{ProductID: updates[j].ProductID, Err: store.ErrStockBatchAborted})
+		}
+	} else if len(updates) > 0 {
+		info := store.StockMovementInfo{Reason: domain.StockReasonStockUpdate, OrderID: orderID}
+		storeResults, err := s.productStore.BatchUpdateStock(ctx, updates, atomic, info)
+		if err != nil {
+			log.Printf("ERROR: BatchUpdateStock failed: %v", err)
+			return nil, status.Errorf(codes.Internal, "Failed to update stock: %v", err)
+		}
+		for j, r := range storeResults {
+			results[requestIndexes[j]] = convertStockUpdateResultToProto(r)
+		}
 	}
 
+	// updated_products keeps its original meaning: one entry per product whose stock changed.
+	allApplied := true
+	updatedProductsProto := make([]*productpb.Product, 0, len(results))
+	seen := make(map[int64]bool, len(results))
+	for _, r := range results {
+		if r.GetStatus() != productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_APPLIED {
+			allApplied = false
+			continue
+		}
+		if r.Product == nil {
+			return nil, status.Errorf(codes.Internal, "Failed to process data for product ID %d", r.GetProductId())
+		}
+		if !seen[r.GetProductId()] {
+			seen[r.GetProductId()] = true
+			updatedProductsProto = append(updatedProductsProto, r.Product)
+		}
+	}
 
-	log.Printf("INFO: Successfully updated stock for %d items.", len(updatedProductsProto))
+	if allApplied {
+		log.Printf("INFO: Successfully updated stock for %d items.", len(results))
+	} else {
+		log.Printf("WARN: UpdateStock (%s) did not apply every item; %d product(s) updated.", mode, len(updatedProductsProto))
+	}
 	return &productpb.UpdateStockResponse{
 		UpdatedProducts: updatedProductsProto,
+		Results:         results,
+		Mode:            mode,
+		AllApplied:      allApplied,
 	}, nil
 }
 
@@ -334,6 +525,28 @@ func (s *GRPCHandler) CheckProductsAvailability(ctx context.Context, req *produc
 		if item.GetRequiredQuantity() <=0 {
 			return nil, status.Errorf(codes.InvalidArgument, "Item Product ID %d has invalid required quantity: %d", item.GetProductId(), item.GetRequiredQuantity())
 		}
+		if item.LocationId != nil && item.GetLocationId() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Item Product ID %d has invalid Location ID: %d", item.GetProductId(), item.GetLocationId())
+		}
+	}
+	priceSelection, err := priceSelectionFromProto(req.Currency, req.PriceListId)
+	if err != nil {
+		return nil, err
+	}
+	// Prices at another time come from the price history, which only covers base and scheduled prices.
+	var pricesAt map[int64]domain.Money
+	if req.PriceAt != nil {
+		if req.Currency != nil || req.PriceListId != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "price_at cannot be combined with currency or price_list_id")
+		}
+		if err := req.GetPriceAt().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid price_at: %v", err)
+		}
+		pricesAt, err = s.productStore.GetPricesAt(ctx, productIDs, req.GetPriceAt().AsTime())
+		if err != nil {
+			log.Printf("ERROR: Failed to fetch prices at %v for availability check: %v", req.GetPriceAt().AsTime(), err)
+			return nil, status.Errorf(codes.Internal, "Error retrieving price history for availability check")
+		}
 	}
 
 	// Fetch all requested products in one go if possible (using ListProducts with ProductIDs filter)
@@ -349,6 +562,14 @@ func (s *GRPCHandler) CheckProductsAvailability(ctx context.Context, req *produc
 		log.Printf("ERROR: Failed to fetch products for availability check: %v", err)
 		return nil, status.Errorf(codes.Internal, "Error retrieving product data for availability check")
 	}
+	if err := s.resolvePrices(ctx, priceSelection, domainProducts); err != nil {
+		return nil, err
+	}
+	// Products can only be sold in the selected currency if they have a price in it.
+	currency, err := s.selectedCurrency(ctx, priceSelection)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create a map for quick lookup of fetched domain products
 	domainProductMap := make(map[int64]domain.Product, len(domainProducts))
@@ -356,6 +577,21 @@ func (s *GRPCHandler) CheckProductsAvailability(ctx context.Context, req *produc
 		domainProductMap[p.ID] = p
 	}
 
+	// Stock held by active reservations is not available, except the caller's own hold.
+	reserved, err := s.reservationStore.GetReservedQuantities(ctx, productIDs, req.GetReservationReferenceId())
+	if err != nil {
+		log.Printf("ERROR: Failed to fetch reserved quantities for availability check: %v", err)
+		return nil, status.Errorf(codes.Internal, "Error retrieving reservation data for availability check")
+	}
+
+	// Per-location stock, in allocation order, to check location-bound items and to plan where
+	// available quantities would ship from.
+	levels, knownLocations, err := s.availabilityLocationData(ctx, req.GetItems(), productIDs)
+	if err != nil {
+		log.Printf("ERROR: Failed to fetch location stock for availability check: %v", err)
+		return nil, status.Errorf(codes.Internal, "Error retrieving location data for availability check")
+	}
+
 	statuses := make([]*productpb.ProductAvailabilityStatus, 0, len(req.GetItems()))
 	for _, item := range req.GetItems() {
 		productID := item.GetProductId()
@@ -364,6 +600,9 @@ func (s *GRPCHandler) CheckProductsAvailability(ctx context.Context, req *produc
 			ProductId:    productID,
 			IsAvailable:  false, // Default to not available
 		}
+		if price, ok := pricesAt[productID]; ok {
+			statusEntry.PriceAtMoney = convertDomainMoneyToProto(price)
+		}
 
 		domainProd, found := domainProductMap[productID]
 		if !found {
@@ -371,21 +610,46 @@ func (s *GRPCHandler) CheckProductsAvailability(ctx context.Context, req *produc
 			statusEntry.ReasonNotAvailable = &reason
 			log.Printf("WARN: Product ID %d not found during availability check.", productID)
 		} else {
+			availableQty := domainProd.StockQuantity - reserved[productID]
+			if availableQty < 0 {
+				availableQty = 0 // Stock was decremented below what is held
+			}
+			locationFound := item.LocationId == nil || knownLocations[item.GetLocationId()]
+			if item.LocationId != nil {
+				// Reservations are not tied to a location, so the location can only offer what is
+				// both held there and not reserved overall.
+				availableQty = min(availableQty, locationQuantity(levels[productID], item.GetLocationId()))
+			}
 			statusEntry.Name = domainProd.Name
-			statusEntry.CurrentPrice = domainProd.Price // domain.Price is float64, proto is double
-			statusEntry.AvailableQuantity = domainProd.StockQuantity // domain.StockQuantity is int32, proto is int32
+			statusEntry.CurrentPriceMoney = convertDomainMoneyToProto(domainProd.Price)
+			statusEntry.CurrentPrice = approximatePrice(domainProd.Price) // Deprecated double, kept for older clients
+			statusEntry.AvailableQuantity = availableQty
+			statusEntry.ReservedQuantity = reserved[productID]
 
 			if !domainProd.IsActive {
 				reason := "Product is not active."
 				statusEntry.ReasonNotAvailable = &reason
 				log.Printf("INFO: Product ID %d is not active during availability check.", productID)
-			} else if domainProd.StockQuantity < requiredQty {
+			} else if len(domainProd.Options) > 0 { // Stock is held and sold per variant
+				reason := "Product has variants; check a variant."
+				statusEntry.ReasonNotAvailable = &reason
+				log.Printf("INFO: Product ID %d has variants during availability check.", productID)
+			} else if currency != "" && domainProd.Price.Currency != currency {
+				reason := "No price in " + currency + "."
+				statusEntry.ReasonNotAvailable = &reason
+				log.Printf("INFO: Product ID %d has no price in %s during availability check.", productID, currency)
+			} else if !locationFound {
+				reason := "Location not found."
+				statusEntry.ReasonNotAvailable = &reason
+				log.Printf("INFO: Location ID %d not found during availability check.", item.GetLocationId())
+			} else if availableQty < requiredQty {
 				reason := "Insufficient stock."
 				statusEntry.ReasonNotAvailable = &reason
-				log.Printf("INFO: Product ID %d has insufficient stock (%d available, %d required).", productID, domainProd.StockQuantity, requiredQty)
+				log.Printf("INFO: Product ID %d has insufficient stock (%d available, %d reserved, %d required).", productID, availableQty, reserved[productID], requiredQty)
 			} else {
 				statusEntry.IsAvailable = true
-				log.Printf("INFO: Product ID %d is available (stock: %d, required: %d).", productID, domainProd.StockQuantity, requiredQty)
+				statusEntry.Allocations = planStockAllocation(levels[productID], item.LocationId, requiredQty)
+				log.Printf("INFO: Product ID %d is available (available: %d, required: %d).", productID, availableQty, requiredQty)
 			}
 		}
 		statuses = append(statuses, statusEntry)
@@ -397,8 +661,91 @@ func (s *GRPCHandler) CheckProductsAvailability(ctx context.Context, req *produc
 	}, nil
 }
 
+// withActorFromMetadata attaches the caller identity sent in the actorMetadataKey metadata entry
+// to ctx, so that stock ledger entries record who made the change.
+func withActorFromMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	if values := md.Get(actorMetadataKey); len(values) > 0 {
+		return store.WithActor(ctx, values[0])
+	}
+	return ctx
+}
+
+// replayUpdateStock answers a retried UpdateStock from the record of the original request.
+func replayUpdateStock(record *domain.IdempotencyRecord, requestHash string) (*productpb.UpdateStockResponse, error) {
+	if record.RequestHash != requestHash {
+		log.Printf("WARN: OrderID '%s' was reused with different stock update items", record.Key)
+		return nil, status.Errorf(codes.AlreadyExists, "order_id %q was already used for a stock update with different items", record.Key)
+	}
+	if !record.Completed() {
+		return nil, status.Errorf(codes.Aborted, "A stock update for order_id %q is still being processed; retry later", record.Key)
+	}
+	resp := &productpb.UpdateStockResponse{}
+	if err := proto.Unmarshal(record.Response, resp); err != nil {
+		log.Printf("ERROR: Failed to decode stored UpdateStock response for OrderID '%s': %v", record.Key, err)
+		return nil, status.Errorf(codes.Internal, "Failed to load the stored result for order_id %q", record.Key)
+	}
+	resp.Replayed = true
+	log.Printf("INFO: Replayed stored UpdateStock response for OrderID '%s'.", record.Key)
+	return resp, nil
+}
+
+// updateStockRequestHash fingerprints the items (in request order) and the resolved mode of an
+// UpdateStock request, so that a replay can be told apart from a different request reusing the order ID.
+func updateStockRequestHash(items []*productpb.StockUpdateItem, mode productpb.StockUpdateMode) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "mode=%d;", mode)
+	for _, item := range items {
+		if item.LocationId != nil {
+			fmt.Fprintf(h, "%d:%d@%d;", item.GetProductId(), item.GetQuantityChange(), item.GetLocationId())
+		} else {
+			fmt.Fprintf(h, "%d:%d;", item.GetProductId(), item.GetQuantityChange()) // Same as before locations existed
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // --- Helper Functions for Conversion ---
 
+func convertStockUpdateResultToProto(r store.StockUpdateResult) *productpb.StockUpdateItemResult {
+	result := &productpb.StockUpdateItemResult{ProductId: r.ProductID}
+	var reason string
+	switch {
+	case r.Err == nil:
+		protoProd, err := convertDomainProductToProto(r.Product)
+		if err != nil {
+			log.Printf("ERROR: Failed to convert updated product ID %d to proto: %v", r.ProductID, err)
+		}
+		result.Status = productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_APPLIED
+		result.Product = protoProd // nil on conversion failure; reported as Internal by the caller
+		result.Allocations = convertDomainAllocationsToProto(r.Allocations)
+		return result
+	case errors.Is(r.Err, store.ErrProductNotFound):
+		result.Status = productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_PRODUCT_NOT_FOUND
+		reason = fmt.Sprintf("Product with ID %d not found", r.ProductID)
+	case errors.Is(r.Err, store.ErrInsufficientStock):
+		result.Status = productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_INSUFFICIENT_STOCK
+		reason = fmt.Sprintf("Insufficient stock for product ID %d", r.ProductID)
+	case errors.Is(r.Err, store.ErrLocationNotFound):
+		result.Status = productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_LOCATION_NOT_FOUND
+		reason = fmt.Sprintf("Location not found for product ID %d", r.ProductID)
+	case errors.Is(r.Err, store.ErrNoLocations):
+		result.Status = productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_NO_LOCATION
+		reason = "No location exists to receive stock"
+	case errors.Is(r.Err, store.ErrStockBatchAborted):
+		result.Status = productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_ABORTED
+		reason = "Not applied because another item in the batch failed"
+	default:
+		result.Status = productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_UNSPECIFIED
+		reason = r.Err.Error()
+	}
+	result.Reason = &reason
+	return result
+}
+
 func convertDomainCategoryToProto(domainCat *domain.Category) *productpb.Category {
 	if domainCat == nil {
 		return nil
@@ -427,7 +774,9 @@ func convertDomainProductToProto(domainProd *domain.Product) (*productpb.Product
 		Id:             domainProd.ID,
 		Name:           domainProd.Name,
 		Sku:            domainProd.SKU,
-		Price:          domainProd.Price, // float64 to double is fine
+		Price:          approximatePrice(domainProd.Price), // Deprecated double, kept for older clients
+		PriceMoney:     convertDomainMoneyToProto(domainProd.Price),
+		PriceListId:    domainProd.PriceListID,
 		StockQuantity:  domainProd.StockQuantity, // int32 to int32
 		IsActive:       domainProd.IsActive,
 		CreatedAt:      timestamppb.New(domainProd.CreatedAt),
@@ -443,6 +792,24 @@ func convertDomainProductToProto(domainProd *domain.Product) (*productpb.Product
 	if domainProd.ImageURL != nil {
 		pbProd.ImageUrl = domainProd.ImageURL
 	}
+	if domainProd.CompareAtPrice != nil {
+		pbProd.CompareAtPrice = convertDomainMoneyToProto(*domainProd.CompareAtPrice)
+	}
+	pbProd.ParentId = domainProd.ParentID
+	pbProd.Options = domainProd.Options
+	pbProd.OptionValues = domainProd.OptionValues
+	pbProd.InheritsPrice = domainProd.InheritsPrice
+	for i := range domainProd.Variants {
+		variant, err := convertDomainProductToProto(&domainProd.Variants[i])
+		if err != nil {
+			return nil, err
+		}
+		pbProd.Variants = append(pbProd.Variants, variant)
+	}
+	pbProd.Media = convertDomainMediaToProto(domainProd.Media)
+	if domainProd.DeletedAt != nil {
+		pbProd.DeletedAt = timestamppb.New(*domainProd.DeletedAt)
+	}
 
 	if domainProd.Attributes != nil && len(*domainProd.Attributes) > 0 {
 		// Ensure it's not just "null" as a string from the DB if sql.NullString was used
@@ -464,4 +831,30 @@ func convertDomainProductToProto(domainProd *domain.Product) (*productpb.Product
         }
 	}
 	return pbProd, nil
+}
+
+func convertDomainMoneyToProto(m domain.Money) *commonpb.Money {
+	return &commonpb.Money{CurrencyCode: m.Currency, AmountMinor: m.Amount, Amount: m.Decimal()}
+}
+
+// convertProtoMoneyToDomain reads an amount of money from a request: its decimal amount if set, else
+// its amount in minor units.
+func convertProtoMoneyToDomain(m *commonpb.Money) (domain.Money, error) {
+	if m.GetAmount() != "" {
+		return domain.ParseMoney(m.GetAmount(), m.GetCurrencyCode())
+	}
+	if !domain.ValidCurrency(m.GetCurrencyCode()) {
+		return domain.Money{}, fmt.Errorf("%w: currency %q is not an ISO 4217 code", domain.ErrInvalidMoney, m.GetCurrencyCode())
+	}
+	money := domain.Money{Amount: m.GetAmountMinor(), Currency: m.GetCurrencyCode()}
+	if !money.InRange() {
+		return domain.Money{}, fmt.Errorf("%w: %d minor units is out of range", domain.ErrInvalidMoney, m.GetAmountMinor())
+	}
+	return money, nil
+}
+
+// approximatePrice returns the float nearest to an exact price, for the deprecated double fields.
+func approximatePrice(m domain.Money) float64 {
+	price, _ := strconv.ParseFloat(m.Decimal(), 64) // A decimal always parses
+	return price
 }
\ No newline at end of file
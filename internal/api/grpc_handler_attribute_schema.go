@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// --- Category Attribute Schema gRPC Methods Implementation ---
+
+func (s *GRPCHandler) GetCategoryAttributeSchema(ctx context.Context, req *productpb.GetCategoryAttributeSchemaRequest) (*productpb.GetCategoryAttributeSchemaResponse, error) {
+	categoryID := req.GetCategoryId()
+	log.Printf("INFO: Received gRPC GetCategoryAttributeSchema request for ID: %d", categoryID)
+
+	if categoryID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Category ID must be a positive integer")
+	}
+
+	schema, err := s.categoryStore.GetCategoryAttributeSchema(ctx, categoryID)
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Category", categoryID)
+	}
+
+	pbAttributes := make([]*productpb.CategoryAttribute, 0, len(schema))
+	for i := range schema {
+		pbAttributes = append(pbAttributes, convertDomainCategoryAttributeToProto(&schema[i]))
+	}
+	return &productpb.GetCategoryAttributeSchemaResponse{Attributes: pbAttributes}, nil
+}
+
+// attributeSchemaStatus reports the violations of an *store.AttributeSchemaError as
+// InvalidArgument with BadRequest field violations, the gRPC counterpart of the HTTP fields list.
+func attributeSchemaStatus(err error) error {
+	st := status.New(codes.InvalidArgument, "Attributes do not match the category's attribute schema")
+	var schemaErr *store.AttributeSchemaError
+	if !errors.As(err, &schemaErr) {
+		return st.Err()
+	}
+	details := &errdetails.BadRequest{}
+	for _, v := range schemaErr.Violations {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Message,
+		})
+	}
+	if withDetails, detailErr := st.WithDetails(details); detailErr == nil {
+		return withDetails.Err()
+	}
+	return st.Err()
+}
+
+var attributeTypeToProto = map[domain.AttributeType]productpb.AttributeType{
+	domain.AttributeTypeString:     productpb.AttributeType_ATTRIBUTE_TYPE_STRING,
+	domain.AttributeTypeNumber:     productpb.AttributeType_ATTRIBUTE_TYPE_NUMBER,
+	domain.AttributeTypeBoolean:    productpb.AttributeType_ATTRIBUTE_TYPE_BOOLEAN,
+	domain.AttributeTypeStringList: productpb.AttributeType_ATTRIBUTE_TYPE_STRING_LIST,
+}
+
+func convertDomainCategoryAttributeToProto(a *domain.CategoryAttribute) *productpb.CategoryAttribute {
+	return &productpb.CategoryAttribute{
+		CategoryId: a.CategoryID,
+		Key:        a.Key,
+		Type:       attributeTypeToProto[a.Type],
+		EnumValues: a.EnumValues,
+		Required:   a.Required,
+		Unit:       a.Unit,
+		CreatedAt:  timestamppb.New(a.CreatedAt),
+		UpdatedAt:  timestamppb.New(a.UpdatedAt),
+	}
+}
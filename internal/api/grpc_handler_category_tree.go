@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"log"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// --- Category Hierarchy gRPC Methods Implementation ---
+
+func (s *GRPCHandler) GetCategoryTree(ctx context.Context, req *productpb.GetCategoryTreeRequest) (*productpb.GetCategoryTreeResponse, error) {
+	log.Printf("INFO: Received gRPC GetCategoryTree request. RootCategoryID: %d (0 if not set), MaxDepth: %d", req.GetRootCategoryId(), req.GetMaxDepth())
+
+	if req.RootCategoryId != nil && req.GetRootCategoryId() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Root category ID must be a positive integer")
+	}
+	if req.GetMaxDepth() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "max_depth must not be negative")
+	}
+
+	tree, err := s.categoryStore.GetCategoryTree(ctx, req.RootCategoryId, int(req.GetMaxDepth()))
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Category", req.GetRootCategoryId())
+	}
+	return &productpb.GetCategoryTreeResponse{Roots: convertDomainCategoryNodesToProto(tree)}, nil
+}
+
+func (s *GRPCHandler) GetCategoryAncestors(ctx context.Context, req *productpb.GetCategoryAncestorsRequest) (*productpb.GetCategoryAncestorsResponse, error) {
+	categoryID := req.GetCategoryId()
+	log.Printf("INFO: Received gRPC GetCategoryAncestors request for ID: %d", categoryID)
+
+	if categoryID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Category ID must be a positive integer")
+	}
+
+	ancestors, err := s.categoryStore.GetCategoryAncestors(ctx, categoryID)
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Category", categoryID)
+	}
+	return &productpb.GetCategoryAncestorsResponse{Ancestors: convertDomainCategoriesToProto(ancestors)}, nil
+}
+
+func (s *GRPCHandler) GetCategoryDescendants(ctx context.Context, req *productpb.GetCategoryDescendantsRequest) (*productpb.GetCategoryDescendantsResponse, error) {
+	categoryID := req.GetCategoryId()
+	log.Printf("INFO: Received gRPC GetCategoryDescendants request for ID: %d, MaxDepth: %d", categoryID, req.GetMaxDepth())
+
+	if categoryID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Category ID must be a positive integer")
+	}
+	if req.GetMaxDepth() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "max_depth must not be negative")
+	}
+
+	descendants, err := s.categoryStore.GetCategoryDescendants(ctx, categoryID, int(req.GetMaxDepth()))
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Category", categoryID)
+	}
+	return &productpb.GetCategoryDescendantsResponse{Descendants: convertDomainCategoriesToProto(descendants)}, nil
+}
+
+var categoryDeleteStrategyFromProto = map[productpb.CategoryDeleteStrategy]store.CategoryDeleteStrategy{
+	productpb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_UNSPECIFIED:   store.CategoryDeleteRestrict,
+	productpb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_RESTRICT:      store.CategoryDeleteRestrict,
+	productpb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_REPARENT:      store.CategoryDeleteReparent,
+	productpb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_MOVE_PRODUCTS: store.CategoryDeleteMoveProducts,
+	productpb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_CASCADE:       store.CategoryDeleteCascade,
+}
+
+func (s *GRPCHandler) DeleteCategory(ctx context.Context, req *productpb.DeleteCategoryRequest) (*productpb.DeleteCategoryResponse, error) {
+	categoryID := req.GetCategoryId()
+	log.Printf("INFO: Received gRPC DeleteCategory request for ID: %d, Strategy: %s", categoryID, req.GetStrategy())
+
+	if categoryID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Category ID must be a positive integer")
+	}
+	strategy, ok := categoryDeleteStrategyFromProto[req.GetStrategy()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown delete strategy %d", req.GetStrategy())
+	}
+
+	opts := store.DeleteCategoryOptions{Strategy: strategy, TargetCategoryID: req.TargetCategoryId}
+	if err := s.categoryStore.DeleteCategory(ctx, categoryID, opts); err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Category", categoryID)
+	}
+	log.Printf("INFO: Deleted category ID %d", categoryID)
+	return &productpb.DeleteCategoryResponse{}, nil
+}
+
+func convertDomainCategoriesToProto(categories []domain.Category) []*productpb.Category {
+	pbCategories := make([]*productpb.Category, 0, len(categories))
+	for i := range categories {
+		pbCategories = append(pbCategories, convertDomainCategoryToProto(&categories[i]))
+	}
+	return pbCategories
+}
+
+func convertDomainCategoryNodesToProto(nodes []domain.CategoryNode) []*productpb.CategoryTreeNode {
+	pbNodes := make([]*productpb.CategoryTreeNode, 0, len(nodes))
+	for i := range nodes {
+		pbNodes = append(pbNodes, &productpb.CategoryTreeNode{
+			Category:    convertDomainCategoryToProto(&nodes[i].Category),
+			Children:    convertDomainCategoryNodesToProto(nodes[i].Children),
+			HasChildren: nodes[i].HasChildren,
+		})
+	}
+	return pbNodes
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"log"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportProducts sends the products matching the filters of ListProductsInternal, a message per store
+// batch. As there, only active products are exported unless include_inactive is set.
+func (s *GRPCHandler) ExportProducts(req *productpb.ExportProductsRequest, stream grpc.ServerStreamingServer[productpb.ExportProductsResponse]) error {
+	log.Printf("INFO: Received gRPC ExportProducts request. CategoryID: %d, ProductIDs: %v, IncludeInactive: %t",
+		req.GetCategoryId(), req.GetProductIds(), req.GetIncludeInactive())
+
+	attributeFilters, errMsg := attributeFiltersFromProto(req.GetAttributeFilters())
+	if errMsg != "" {
+		return status.Error(codes.InvalidArgument, errMsg)
+	}
+	priceSelection, err := priceSelectionFromProto(req.Currency, req.PriceListId)
+	if err != nil {
+		return err
+	}
+	params := store.ListProductsParams{
+		ProductIDs: req.GetProductIds(),
+		Attributes: attributeFilters,
+		ParentID:   req.ParentId,
+	}
+	if req.GetCategoryId() > 0 {
+		catID := req.GetCategoryId()
+		params.CategoryID = &catID
+		params.IncludeDescendants = req.GetIncludeDescendants()
+	}
+	if !req.GetIncludeInactive() {
+		isActive := true
+		params.IsActive = &isActive
+	}
+
+	ctx := stream.Context()
+	sent := 0
+	err = s.productStore.ExportProducts(ctx, params, func(batch []domain.Product) error {
+		if err := s.resolvePrices(ctx, priceSelection, batch); err != nil {
+			return err
+		}
+		if err := s.attachMedia(ctx, batch); err != nil {
+			return err
+		}
+		resp := &productpb.ExportProductsResponse{Products: make([]*productpb.Product, 0, len(batch))}
+		for i := range batch {
+			converted, err := convertDomainProductToProto(&batch[i])
+			if err != nil {
+				log.Printf("ERROR: Failed to convert domain product to proto during ExportProducts for ID %d: %v", batch[i].ID, err)
+				return status.Errorf(codes.Internal, "Failed to convert product %d", batch[i].ID)
+			}
+			resp.Products = append(resp.Products, converted)
+		}
+		sent += len(resp.Products)
+		return stream.Send(resp)
+	})
+	if err != nil {
+		log.Printf("ERROR: ExportProducts stopped after %d products: %v", sent, err)
+		if _, ok := status.FromError(err); ok { // From resolvePrices, attachMedia or Send
+			return err
+		}
+		if errors.Is(err, store.ErrInvalidAttributeFilter) {
+			return mapStoreErrorToGrpcStatus(err, "Product", 0)
+		}
+		return status.Errorf(codes.Internal, "Failed to export products: %v", err)
+	}
+	log.Printf("INFO: Exported %d products", sent)
+	return nil
+}
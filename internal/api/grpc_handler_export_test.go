@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportStream collects the responses of ExportProducts. Only the methods the handler uses are
+// implemented.
+type exportStream struct {
+	grpc.ServerStream
+	responses []*productpb.ExportProductsResponse
+}
+
+func (s *exportStream) Context() context.Context { return context.Background() }
+
+func (s *exportStream) Send(resp *productpb.ExportProductsResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func TestGRPCHandler_ExportProducts(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	_, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Cable", SKU: "CB-1", Price: usd(999), StockQuantity: 4})
+	require.NoError(t, err)
+
+	// Only active products by default, in ID order.
+	stream := &exportStream{}
+	require.NoError(t, handler.ExportProducts(&productpb.ExportProductsRequest{}, stream))
+	require.Len(t, stream.responses, 1)
+	var skus []string
+	for _, p := range stream.responses[0].GetProducts() {
+		skus = append(skus, p.GetSku())
+	}
+	assert.Equal(t, []string{"KB-1", "MS-1"}, skus)
+
+	stream = &exportStream{}
+	require.NoError(t, handler.ExportProducts(&productpb.ExportProductsRequest{IncludeInactive: PtrTo(true), ProductIds: []int64{1, 3}}, stream))
+	require.Len(t, stream.responses, 1)
+	assert.Len(t, stream.responses[0].GetProducts(), 2)
+
+	// Nothing matching sends nothing.
+	stream = &exportStream{}
+	require.NoError(t, handler.ExportProducts(&productpb.ExportProductsRequest{ProductIds: []int64{3}}, stream))
+	assert.Empty(t, stream.responses)
+
+	err = handler.ExportProducts(&productpb.ExportProductsRequest{PriceListId: PtrTo(int64(99))}, &exportStream{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"log"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxStockLevelsProducts = 100  // Upper bound on GetStockLevelsRequest.product_ids
+	maxStockNoteLength     = 1000 // Same limit as the HTTP stock adjustment note
+)
+
+// --- Location gRPC Methods Implementation ---
+
+func (s *GRPCHandler) ListLocations(ctx context.Context, req *productpb.ListLocationsRequest) (*productpb.ListLocationsResponse, error) {
+	log.Printf("INFO: Received gRPC ListLocations request.")
+
+	locations, err := s.locationStore.ListLocations(ctx)
+	if err != nil {
+		log.Printf("ERROR: Failed to list locations from store: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve locations: %v", err)
+	}
+
+	pbLocations := make([]*productpb.Location, 0, len(locations))
+	for i := range locations {
+		pbLocations = append(pbLocations, convertDomainLocationToProto(&locations[i]))
+	}
+	return &productpb.ListLocationsResponse{Locations: pbLocations}, nil
+}
+
+func (s *GRPCHandler) GetStockLevels(ctx context.Context, req *productpb.GetStockLevelsRequest) (*productpb.GetStockLevelsResponse, error) {
+	log.Printf("INFO: Received gRPC GetStockLevels request for %d products.", len(req.GetProductIds()))
+
+	if len(req.GetProductIds()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "No product IDs provided")
+	}
+	if len(req.GetProductIds()) > maxStockLevelsProducts {
+		return nil, status.Errorf(codes.InvalidArgument, "At most %d product IDs can be requested at once", maxStockLevelsProducts)
+	}
+	for _, id := range req.GetProductIds() {
+		if id <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid Product ID: %d", id)
+		}
+	}
+
+	levels, err := s.locationStore.ListLocationStock(ctx, req.GetProductIds())
+	if err != nil {
+		log.Printf("ERROR: Failed to list location stock from store: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve stock levels: %v", err)
+	}
+	return &productpb.GetStockLevelsResponse{Levels: convertDomainLocationStockToProto(levels)}, nil
+}
+
+func (s *GRPCHandler) TransferStock(ctx context.Context, req *productpb.TransferStockRequest) (*productpb.TransferStockResponse, error) {
+	ctx = withActorFromMetadata(ctx)
+	productID := req.GetProductId()
+	log.Printf("INFO: Received gRPC TransferStock request for product ID %d: %d from location %d to %d.",
+		productID, req.GetQuantity(), req.GetFromLocationId(), req.GetToLocationId())
+
+	if productID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Product ID: %d", productID)
+	}
+	if req.GetFromLocationId() <= 0 || req.GetToLocationId() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "from_location_id and to_location_id are required")
+	}
+	if req.GetFromLocationId() == req.GetToLocationId() {
+		return nil, status.Errorf(codes.InvalidArgument, "Source and destination location must differ")
+	}
+	if req.GetQuantity() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "quantity must be positive")
+	}
+	if len(req.GetNote()) > maxStockNoteLength {
+		return nil, status.Errorf(codes.InvalidArgument, "note must be at most %d characters", maxStockNoteLength)
+	}
+
+	info := store.StockMovementInfo{Reason: domain.StockReasonTransfer, Note: req.Note}
+	levels, err := s.locationStore.TransferStock(ctx, productID, req.GetFromLocationId(), req.GetToLocationId(), req.GetQuantity(), info)
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Product", productID)
+	}
+
+	log.Printf("INFO: Transferred %d of product ID %d from location %d to %d.", req.GetQuantity(), productID, req.GetFromLocationId(), req.GetToLocationId())
+	return &productpb.TransferStockResponse{Levels: convertDomainLocationStockToProto(levels)}, nil
+}
+
+// --- Helpers ---
+
+// availabilityLocationData loads the location rows of productIDs grouped by product (in allocation
+// order) and, if any item names a location, the set of existing location IDs.
+func (s *GRPCHandler) availabilityLocationData(ctx context.Context, items []*productpb.ProductAvailabilityItemInput, productIDs []int64) (map[int64][]domain.LocationStock, map[int64]bool, error) {
+	rows, err := s.locationStore.ListLocationStock(ctx, productIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	levels := make(map[int64][]domain.LocationStock, len(productIDs))
+	for _, row := range rows {
+		levels[row.ProductID] = append(levels[row.ProductID], row)
+	}
+
+	known := make(map[int64]bool)
+	for _, item := range items {
+		if item.LocationId == nil {
+			continue
+		}
+		locations, err := s.locationStore.ListLocations(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, l := range locations {
+			known[l.ID] = true
+		}
+		break
+	}
+	return levels, known, nil
+}
+
+// locationQuantity returns the quantity held at locationID among a product's levels.
+func locationQuantity(levels []domain.LocationStock, locationID int64) int32 {
+	for _, l := range levels {
+		if l.LocationID == locationID {
+			return l.Quantity
+		}
+	}
+	return 0
+}
+
+// planStockAllocation returns where quantity of a product would be taken from: the given location,
+// or else its locations in allocation order (levels must be in that order), mirroring how the store
+// allocates a decrease without a location.
+func planStockAllocation(levels []domain.LocationStock, locationID *int64, quantity int32) []*productpb.StockAllocation {
+	if locationID != nil {
+		return []*productpb.StockAllocation{{LocationId: *locationID, Quantity: quantity}}
+	}
+	var plan []*productpb.StockAllocation
+	for _, l := range levels {
+		if quantity == 0 {
+			break
+		}
+		take := min(l.Quantity, quantity)
+		if take > 0 {
+			plan = append(plan, &productpb.StockAllocation{LocationId: l.LocationID, Quantity: take})
+			quantity -= take
+		}
+	}
+	return plan
+}
+
+func convertDomainLocationToProto(l *domain.Location) *productpb.Location {
+	return &productpb.Location{
+		Id:        l.ID,
+		Code:      l.Code,
+		Name:      l.Name,
+		Priority:  l.Priority,
+		CreatedAt: timestamppb.New(l.CreatedAt),
+		UpdatedAt: timestamppb.New(l.UpdatedAt),
+	}
+}
+
+func convertDomainLocationStockToProto(levels []domain.LocationStock) []*productpb.LocationStock {
+	pbLevels := make([]*productpb.LocationStock, 0, len(levels))
+	for _, l := range levels {
+		pbLevels = append(pbLevels, &productpb.LocationStock{
+			ProductId:  l.ProductID,
+			LocationId: l.LocationID,
+			Quantity:   l.Quantity,
+			UpdatedAt:  timestamppb.New(l.UpdatedAt),
+		})
+	}
+	return pbLevels
+}
+
+func convertDomainAllocationsToProto(allocations []domain.StockAllocation) []*productpb.StockAllocation {
+	if len(allocations) == 0 {
+		return nil
+	}
+	pbAllocations := make([]*productpb.StockAllocation, 0, len(allocations))
+	for _, a := range allocations {
+		pbAllocations = append(pbAllocations, &productpb.StockAllocation{LocationId: a.LocationID, Quantity: a.Quantity})
+	}
+	return pbAllocations
+}
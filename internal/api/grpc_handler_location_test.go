@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCHandler_StockLocations(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	east, err := memStore.CreateLocation(ctx, &domain.Location{Code: "east", Name: "East", Priority: 1})
+	require.NoError(t, err)
+
+	// Product 1 holds its 10 units at the default location; move 6 of them east.
+	transferResp, err := handler.TransferStock(ctx, &productpb.TransferStockRequest{ProductId: 1, FromLocationId: 1, ToLocationId: east.ID, Quantity: 6})
+	require.NoError(t, err)
+	require.Len(t, transferResp.GetLevels(), 2)
+	assert.Equal(t, int32(4), transferResp.GetLevels()[0].GetQuantity())
+	assert.Equal(t, int32(6), transferResp.GetLevels()[1].GetQuantity())
+
+	_, err = handler.TransferStock(ctx, &productpb.TransferStockRequest{ProductId: 1, FromLocationId: 1, ToLocationId: 1, Quantity: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = handler.TransferStock(ctx, &productpb.TransferStockRequest{ProductId: 1, FromLocationId: 1, ToLocationId: 42, Quantity: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	availability, err := handler.CheckProductsAvailability(ctx, &productpb.CheckProductsAvailabilityRequest{
+		Items: []*productpb.ProductAvailabilityItemInput{
+			{ProductId: 1, RequiredQuantity: 7},
+			{ProductId: 1, RequiredQuantity: 5, LocationId: &east.ID},
+			{ProductId: 1, RequiredQuantity: 7, LocationId: &east.ID},
+		},
+	})
+	require.NoError(t, err)
+	statuses := availability.GetStatuses()
+	require.True(t, statuses[0].GetIsAvailable())
+	require.Len(t, statuses[0].GetAllocations(), 2, "the default location is drained first")
+	assert.Equal(t, int32(4), statuses[0].GetAllocations()[0].GetQuantity())
+	assert.Equal(t, int32(3), statuses[0].GetAllocations()[1].GetQuantity())
+	assert.True(t, statuses[1].GetIsAvailable())
+	assert.Equal(t, int32(6), statuses[1].GetAvailableQuantity())
+	assert.False(t, statuses[2].GetIsAvailable())
+
+	updateResp, err := handler.UpdateStock(ctx, &productpb.UpdateStockRequest{
+		Items: []*productpb.StockUpdateItem{{ProductId: 1, QuantityChange: -2, LocationId: &east.ID}},
+	})
+	require.NoError(t, err)
+	require.True(t, updateResp.GetAllApplied())
+	assert.Equal(t, int32(8), updateResp.GetUpdatedProducts()[0].GetStockQuantity())
+	assert.Equal(t, east.ID, updateResp.GetResults()[0].GetAllocations()[0].GetLocationId())
+
+	unknown := int64(42)
+	updateResp, err = handler.UpdateStock(ctx, &productpb.UpdateStockRequest{
+		Items: []*productpb.StockUpdateItem{{ProductId: 1, QuantityChange: 1, LocationId: &unknown}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_LOCATION_NOT_FOUND, updateResp.GetResults()[0].GetStatus())
+
+	levels, err := handler.GetStockLevels(ctx, &productpb.GetStockLevelsRequest{ProductIds: []int64{1}})
+	require.NoError(t, err)
+	require.Len(t, levels.GetLevels(), 2)
+	assert.Equal(t, int32(4), levels.GetLevels()[1].GetQuantity())
+
+	locations, err := handler.ListLocations(ctx, &productpb.ListLocationsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, locations.GetLocations(), 2)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"log"
+
+	"product-catalog-service/internal/domain"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// attachMedia sets the images of products and of their variants.
+func (s *GRPCHandler) attachMedia(ctx context.Context, products []domain.Product) error {
+	var productIDs []int64
+	for _, p := range products {
+		productIDs = append(productIDs, p.ID)
+		for _, v := range p.Variants {
+			productIDs = append(productIDs, v.ID)
+		}
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+	media, err := s.productStore.ListMedia(ctx, productIDs)
+	if err != nil {
+		log.Printf("ERROR: Failed to list product media: %v", err)
+		return status.Errorf(codes.Internal, "Failed to retrieve product media")
+	}
+	for i := range products {
+		products[i].Media = media[products[i].ID]
+		for j := range products[i].Variants {
+			products[i].Variants[j].Media = media[products[i].Variants[j].ID]
+		}
+	}
+	return nil
+}
+
+func convertDomainMediaToProto(media []domain.ProductMedia) []*productpb.ProductMedia {
+	var pbMedia []*productpb.ProductMedia
+	for _, m := range media {
+		pbMedia = append(pbMedia, &productpb.ProductMedia{
+			Id:        m.ID,
+			ProductId: m.ProductID,
+			Url:       m.URL,
+			AltText:   m.AltText,
+			Role:      string(m.Role),
+			Position:  m.Position,
+			Width:     m.Width,
+			Height:    m.Height,
+			CreatedAt: timestamppb.New(m.CreatedAt),
+			UpdatedAt: timestamppb.New(m.UpdatedAt),
+		})
+	}
+	return pbMedia
+}
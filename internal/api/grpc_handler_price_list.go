@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// priceSelectionFromProto builds the price selection of a request's optional currency and
+// price_list_id fields.
+func priceSelectionFromProto(currency *string, priceListID *int64) (store.PriceSelection, error) {
+	var sel store.PriceSelection
+	if currency != nil {
+		if !domain.ValidCurrency(*currency) {
+			return sel, status.Errorf(codes.InvalidArgument, "currency must be an ISO 4217 code such as USD")
+		}
+		sel.Currency = *currency
+	}
+	if priceListID != nil {
+		if *priceListID <= 0 {
+			return sel, status.Errorf(codes.InvalidArgument, "price_list_id must be a positive integer")
+		}
+		sel.PriceListID = priceListID
+	}
+	return sel, nil
+}
+
+// resolvePrices applies a price selection to products read from the store.
+func (s *GRPCHandler) resolvePrices(ctx context.Context, sel store.PriceSelection, products []domain.Product) error {
+	if sel.IsZero() {
+		return nil
+	}
+	err := s.priceListStore.ResolvePrices(ctx, sel, products)
+	if err == nil {
+		return nil
+	}
+	log.Printf("ERROR: Failed to resolve prices: %v", err)
+	switch {
+	case errors.Is(err, store.ErrPriceListNotFound):
+		return status.Errorf(codes.InvalidArgument, "Price list %d not found", *sel.PriceListID)
+	case errors.Is(err, store.ErrInvalidPriceSelection):
+		return status.Error(codes.InvalidArgument, strings.TrimPrefix(err.Error(), "store: "))
+	default:
+		return status.Errorf(codes.Internal, "Failed to resolve prices: %v", err)
+	}
+}
+
+// selectedCurrency returns the currency a price selection resolves prices in, or "" if it selects
+// base prices.
+func (s *GRPCHandler) selectedCurrency(ctx context.Context, sel store.PriceSelection) (string, error) {
+	if sel.Currency != "" || sel.PriceListID == nil {
+		return sel.Currency, nil
+	}
+	list, err := s.priceListStore.GetPriceListByID(ctx, *sel.PriceListID)
+	if err != nil {
+		log.Printf("ERROR: Failed to get price list %d: %v", *sel.PriceListID, err)
+		if errors.Is(err, store.ErrPriceListNotFound) {
+			return "", status.Errorf(codes.InvalidArgument, "Price list %d not found", *sel.PriceListID)
+		}
+		return "", status.Errorf(codes.Internal, "Failed to get price list: %v", err)
+	}
+	return list.Currency, nil
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	DefaultReservationTTL = 15 * time.Minute // Used when ReserveStockRequest.ttl_seconds is not set
+	MaxReservationTTL     = 24 * time.Hour
+	maxReferenceIDLength  = 255 // stock_reservations.reference_id is VARCHAR(255)
+)
+
+// --- Reservation gRPC Methods Implementation ---
+
+func (s *GRPCHandler) ReserveStock(ctx context.Context, req *productpb.ReserveStockRequest) (*productpb.ReserveStockResponse, error) {
+	referenceID := req.GetReferenceId()
+	log.Printf("INFO: Received gRPC ReserveStock request for reference '%s' with %d items.", referenceID, len(req.GetItems()))
+
+	if err := validateReferenceID(referenceID); err != nil {
+		return nil, err
+	}
+	if len(req.GetItems()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "No items provided for reservation")
+	}
+	items := make([]store.ReservationItem, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		if item.GetProductId() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Item contains invalid Product ID: %d", item.GetProductId())
+		}
+		if item.GetQuantity() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Item Product ID %d has invalid quantity: %d", item.GetProductId(), item.GetQuantity())
+		}
+		items = append(items, store.ReservationItem{ProductID: item.GetProductId(), Quantity: item.GetQuantity()})
+	}
+
+	ttl := DefaultReservationTTL
+	if req.TtlSeconds != nil {
+		ttl = time.Duration(req.GetTtlSeconds()) * time.Second
+		if ttl <= 0 || ttl > MaxReservationTTL {
+			return nil, status.Errorf(codes.InvalidArgument, "ttl_seconds must be between 1 and %d", int(MaxReservationTTL.Seconds()))
+		}
+	}
+	expiresAt := time.Now().UTC().Add(ttl)
+
+	reservations, err := s.reservationStore.ReserveStock(ctx, referenceID, items, expiresAt)
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Reservation", referenceID)
+	}
+
+	log.Printf("INFO: Reserved %d product(s) for reference '%s' until %s.", len(reservations), referenceID, expiresAt.Format(time.RFC3339))
+	return &productpb.ReserveStockResponse{
+		Reservations: convertDomainReservationsToProto(reservations),
+		ExpiresAt:    timestamppb.New(expiresAt),
+	}, nil
+}
+
+func (s *GRPCHandler) CommitReservation(ctx context.Context, req *productpb.CommitReservationRequest) (*productpb.CommitReservationResponse, error) {
+	ctx = withActorFromMetadata(ctx)
+	referenceID := req.GetReferenceId()
+	log.Printf("INFO: Received gRPC CommitReservation request for reference '%s'.", referenceID)
+
+	if err := validateReferenceID(referenceID); err != nil {
+		return nil, err
+	}
+
+	reservations, products, err := s.reservationStore.CommitReservation(ctx, referenceID)
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Reservation", referenceID)
+	}
+
+	updatedProductsProto := make([]*productpb.Product, 0, len(products))
+	for i := range products {
+		protoProd, err := convertDomainProductToProto(&products[i])
+		if err != nil {
+			log.Printf("ERROR: Failed to convert committed product ID %d to proto: %v", products[i].ID, err)
+			return nil, status.Errorf(codes.Internal, "Failed to process data for product ID %d", products[i].ID)
+		}
+		updatedProductsProto = append(updatedProductsProto, protoProd)
+	}
+
+	log.Printf("INFO: Committed reservation for reference '%s' (%d product(s)).", referenceID, len(reservations))
+	return &productpb.CommitReservationResponse{
+		Reservations:    convertDomainReservationsToProto(reservations),
+		UpdatedProducts: updatedProductsProto,
+	}, nil
+}
+
+func (s *GRPCHandler) ReleaseReservation(ctx context.Context, req *productpb.ReleaseReservationRequest) (*productpb.ReleaseReservationResponse, error) {
+	referenceID := req.GetReferenceId()
+	log.Printf("INFO: Received gRPC ReleaseReservation request for reference '%s'.", referenceID)
+
+	if err := validateReferenceID(referenceID); err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.reservationStore.ReleaseReservation(ctx, referenceID)
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Reservation", referenceID)
+	}
+
+	log.Printf("INFO: Released reservation for reference '%s' (%d product(s)).", referenceID, len(reservations))
+	return &productpb.ReleaseReservationResponse{
+		Reservations: convertDomainReservationsToProto(reservations),
+	}, nil
+}
+
+// --- Helpers ---
+
+func validateReferenceID(referenceID string) error {
+	if referenceID == "" {
+		return status.Errorf(codes.InvalidArgument, "reference_id is required")
+	}
+	if len(referenceID) > maxReferenceIDLength {
+		return status.Errorf(codes.InvalidArgument, "reference_id must be at most %d characters", maxReferenceIDLength)
+	}
+	return nil
+}
+
+func convertDomainReservationsToProto(reservations []domain.StockReservation) []*productpb.StockReservation {
+	pbReservations := make([]*productpb.StockReservation, 0, len(reservations))
+	for _, r := range reservations {
+		pbReservations = append(pbReservations, &productpb.StockReservation{
+			Id:          r.ID,
+			ReferenceId: r.ReferenceID,
+			ProductId:   r.ProductID,
+			Quantity:    r.Quantity,
+			Status:      convertReservationStatusToProto(r.Status),
+			ExpiresAt:   timestamppb.New(r.ExpiresAt),
+			CreatedAt:   timestamppb.New(r.CreatedAt),
+			UpdatedAt:   timestamppb.New(r.UpdatedAt),
+		})
+	}
+	return pbReservations
+}
+
+func convertReservationStatusToProto(s domain.ReservationStatus) productpb.ReservationStatus {
+	switch s {
+	case domain.ReservationActive:
+		return productpb.ReservationStatus_RESERVATION_STATUS_ACTIVE
+	case domain.ReservationCommitted:
+		return productpb.ReservationStatus_RESERVATION_STATUS_COMMITTED
+	case domain.ReservationReleased:
+		return productpb.ReservationStatus_RESERVATION_STATUS_RELEASED
+	case domain.ReservationExpired:
+		return productpb.ReservationStatus_RESERVATION_STATUS_EXPIRED
+	default:
+		log.Printf("WARN: Unknown reservation status %q", s)
+		return productpb.ReservationStatus_RESERVATION_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCHandler_ReserveStock_AvailabilitySubtractsHolds(t *testing.T) {
+	handler, _ := newStockTestHandler(t) // Product 1 has 10 units, product 2 has 1
+	ctx := context.Background()
+
+	resp, err := handler.ReserveStock(ctx, &productpb.ReserveStockRequest{
+		ReferenceId: "cart-1",
+		Items:       []*productpb.ReservationItem{{ProductId: 1, Quantity: 7}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetReservations(), 1)
+	assert.Equal(t, productpb.ReservationStatus_RESERVATION_STATUS_ACTIVE, resp.GetReservations()[0].GetStatus())
+	assert.True(t, resp.GetExpiresAt().AsTime().After(resp.GetReservations()[0].GetCreatedAt().AsTime()))
+
+	check := &productpb.CheckProductsAvailabilityRequest{
+		Items: []*productpb.ProductAvailabilityItemInput{{ProductId: 1, RequiredQuantity: 5}},
+	}
+	avail, err := handler.CheckProductsAvailability(ctx, check)
+	require.NoError(t, err)
+	assert.False(t, avail.GetStatuses()[0].GetIsAvailable())
+	assert.Equal(t, int32(3), avail.GetStatuses()[0].GetAvailableQuantity())
+	assert.Equal(t, int32(7), avail.GetStatuses()[0].GetReservedQuantity())
+
+	ref := "cart-1"
+	check.ReservationReferenceId = &ref
+	avail, err = handler.CheckProductsAvailability(ctx, check)
+	require.NoError(t, err)
+	assert.True(t, avail.GetStatuses()[0].GetIsAvailable(), "the caller's own hold is not subtracted")
+}
+
+func TestGRPCHandler_ReserveStock_Errors(t *testing.T) {
+	handler, _ := newStockTestHandler(t)
+	ctx := context.Background()
+	ttl := int32(0)
+
+	tests := []struct {
+		name string
+		req  *productpb.ReserveStockRequest
+		code codes.Code
+	}{
+		{"missing reference", &productpb.ReserveStockRequest{Items: []*productpb.ReservationItem{{ProductId: 1, Quantity: 1}}}, codes.InvalidArgument},
+		{"no items", &productpb.ReserveStockRequest{ReferenceId: "r"}, codes.InvalidArgument},
+		{"zero quantity", &productpb.ReserveStockRequest{ReferenceId: "r", Items: []*productpb.ReservationItem{{ProductId: 1}}}, codes.InvalidArgument},
+		{"invalid ttl", &productpb.ReserveStockRequest{ReferenceId: "r", Items: []*productpb.ReservationItem{{ProductId: 1, Quantity: 1}}, TtlSeconds: &ttl}, codes.InvalidArgument},
+		{"unknown product", &productpb.ReserveStockRequest{ReferenceId: "r", Items: []*productpb.ReservationItem{{ProductId: 99, Quantity: 1}}}, codes.NotFound},
+		{"insufficient stock", &productpb.ReserveStockRequest{ReferenceId: "r", Items: []*productpb.ReservationItem{{ProductId: 2, Quantity: 2}}}, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.ReserveStock(ctx, tt.req)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCHandler_CommitAndReleaseReservation(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+
+	for _, ref := range []string{"order-1", "order-2"} {
+		_, err := handler.ReserveStock(ctx, &productpb.ReserveStockRequest{
+			ReferenceId: ref,
+			Items:       []*productpb.ReservationItem{{ProductId: 1, Quantity: 4}},
+		})
+		require.NoError(t, err)
+	}
+	_, err := handler.ReserveStock(ctx, &productpb.ReserveStockRequest{
+		ReferenceId: "order-1",
+		Items:       []*productpb.ReservationItem{{ProductId: 1, Quantity: 1}},
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	commitResp, err := handler.CommitReservation(ctx, &productpb.CommitReservationRequest{ReferenceId: "order-1"})
+	require.NoError(t, err)
+	require.Len(t, commitResp.GetUpdatedProducts(), 1)
+	assert.Equal(t, int32(6), commitResp.GetUpdatedProducts()[0].GetStockQuantity())
+
+	releaseResp, err := handler.ReleaseReservation(ctx, &productpb.ReleaseReservationRequest{ReferenceId: "order-2"})
+	require.NoError(t, err)
+	assert.Equal(t, productpb.ReservationStatus_RESERVATION_STATUS_RELEASED, releaseResp.GetReservations()[0].GetStatus())
+
+	_, err = handler.ReleaseReservation(ctx, &productpb.ReleaseReservationRequest{ReferenceId: "order-2"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	p, err := memStore.GetProductByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(6), p.StockQuantity, "only the committed reservation decrements stock")
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"log"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	commonpb "product-catalog-service/proto/v1/common"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// --- Stock History gRPC Methods Implementation ---
+
+func (s *GRPCHandler) GetStockHistory(ctx context.Context, req *productpb.GetStockHistoryRequest) (*productpb.GetStockHistoryResponse, error) {
+	productID := req.GetProductId()
+	log.Printf("INFO: Received gRPC GetStockHistory request for product ID %d. PageSize: %d, PageToken: '%s'",
+		productID, req.GetPageInfo().GetPageSize(), req.GetPageInfo().GetPageToken())
+
+	if productID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Product ID must be a positive integer")
+	}
+
+	limit := int(req.GetPageInfo().GetPageSize())
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	after, err := s.decodePageToken(req.GetPageInfo().GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	params := store.ListStockMovementsParams{
+		ProductID: productID,
+		Limit:     limit + 1, // One extra entry tells whether another page follows
+		After:     after,
+	}
+	if req.From != nil {
+		from := req.GetFrom().AsTime()
+		params.From = &from
+	}
+	if req.To != nil {
+		to := req.GetTo().AsTime()
+		params.To = &to
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, status.Errorf(codes.InvalidArgument, "from must be before to")
+	}
+
+	if _, err := getProduct(ctx, s.productStore, productID, true); err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Product", productID)
+	}
+	movements, totalCount, err := s.productStore.ListStockMovements(ctx, params)
+	if err != nil {
+		return nil, mapStoreErrorToGrpcStatus(err, "Product", productID)
+	}
+
+	var nextPageToken string
+	if len(movements) > limit {
+		movements = movements[:limit]
+		nextPageToken = s.pageTokens.Encode(store.StockMovementCursor(&movements[limit-1]))
+	}
+	log.Printf("INFO: Returning %d stock movements for product ID %d, total: %d", len(movements), productID, totalCount)
+	return &productpb.GetStockHistoryResponse{
+		Movements: convertDomainStockMovementsToProto(movements),
+		PageInfo: &commonpb.PageInfoResponse{
+			NextPageToken: nextPageToken,
+			TotalSize:     int32(totalCount),
+		},
+	}, nil
+}
+
+// --- Helpers ---
+
+var stockMovementReasonsToProto = map[domain.StockMovementReason]productpb.StockMovementReason{
+	domain.StockReasonInitialStock:      productpb.StockMovementReason_STOCK_MOVEMENT_REASON_INITIAL_STOCK,
+	domain.StockReasonProductUpdate:     productpb.StockMovementReason_STOCK_MOVEMENT_REASON_PRODUCT_UPDATE,
+	domain.StockReasonStockUpdate:       productpb.StockMovementReason_STOCK_MOVEMENT_REASON_STOCK_UPDATE,
+	domain.StockReasonReservationCommit: productpb.StockMovementReason_STOCK_MOVEMENT_REASON_RESERVATION_COMMIT,
+	domain.StockReasonAdjustment:        productpb.StockMovementReason_STOCK_MOVEMENT_REASON_ADJUSTMENT,
+	domain.StockReasonTransfer:          productpb.StockMovementReason_STOCK_MOVEMENT_REASON_TRANSFER,
+}
+
+func convertDomainStockMovementsToProto(movements []domain.StockMovement) []*productpb.StockMovement {
+	pbMovements := make([]*productpb.StockMovement, 0, len(movements))
+	for _, m := range movements {
+		pbMovements = append(pbMovements, &productpb.StockMovement{
+			Id:            m.ID,
+			ProductId:     m.ProductID,
+			LocationId:    m.LocationID,
+			QuantityDelta: m.QuantityDelta,
+			BalanceAfter:  m.BalanceAfter,
+			Reason:        stockMovementReasonsToProto[m.Reason], // UNSPECIFIED for unknown reasons
+			OrderId:       m.OrderID,
+			Actor:         m.Actor,
+			Note:          m.Note,
+			CreatedAt:     timestamppb.New(m.CreatedAt),
+		})
+	}
+	return pbMovements
+}
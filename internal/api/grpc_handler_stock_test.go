@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+	commonpb "product-catalog-service/proto/v1/common"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newStockTestHandler returns a GRPCHandler backed by a memory store holding two products:
+// ID 1 with 10 units and ID 2 with 1 unit.
+func newStockTestHandler(t *testing.T) (*GRPCHandler, *store.MemoryStore) {
+	t.Helper()
+	memStore := store.NewMemoryStore()
+	for _, p := range []domain.Product{
+		{Name: "Keyboard", SKU: "KB-1", Price: usd(4999), StockQuantity: 10, IsActive: true},
+		{Name: "Mouse", SKU: "MS-1", Price: usd(1999), StockQuantity: 1, IsActive: true},
+	} {
+		_, err := memStore.CreateProduct(context.Background(), &p)
+		require.NoError(t, err)
+	}
+	return NewGRPCHandler(memStore, memStore, memStore, memStore, memStore, memStore, time.Hour, NewPageTokenCodec([]byte("test-secret"))), memStore
+}
+
+func TestGRPCHandler_UpdateStock_AtomicByDefault(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+
+	resp, err := handler.UpdateStock(context.Background(), &productpb.UpdateStockRequest{
+		Items: []*productpb.StockUpdateItem{
+			{ProductId: 1, QuantityChange: -3},
+			{ProductId: 2, QuantityChange: -2},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, productpb.StockUpdateMode_STOCK_UPDATE_MODE_ATOMIC, resp.GetMode())
+	assert.False(t, resp.GetAllApplied())
+	assert.Empty(t, resp.GetUpdatedProducts())
+	require.Len(t, resp.GetResults(), 2)
+	assert.Equal(t, productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_ABORTED, resp.GetResults()[0].GetStatus())
+	assert.Equal(t, productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_INSUFFICIENT_STOCK, resp.GetResults()[1].GetStatus())
+	assert.NotEmpty(t, resp.GetResults()[1].GetReason())
+
+	p, err := memStore.GetProductByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(10), p.StockQuantity)
+}
+
+func TestGRPCHandler_UpdateStock_BestEffort(t *testing.T) {
+	handler, _ := newStockTestHandler(t)
+
+	resp, err := handler.UpdateStock(context.Background(), &productpb.UpdateStockRequest{
+		Mode: productpb.StockUpdateMode_STOCK_UPDATE_MODE_BEST_EFFORT,
+		Items: []*productpb.StockUpdateItem{
+			{ProductId: 1, QuantityChange: -3},
+			{ProductId: 99, QuantityChange: -1},
+			{ProductId: -1, QuantityChange: -1},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.GetAllApplied())
+	require.Len(t, resp.GetResults(), 3)
+	assert.Equal(t, productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_APPLIED, resp.GetResults()[0].GetStatus())
+	assert.Equal(t, int32(7), resp.GetResults()[0].GetProduct().GetStockQuantity())
+	assert.Equal(t, productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_PRODUCT_NOT_FOUND, resp.GetResults()[1].GetStatus())
+	assert.Equal(t, productpb.StockUpdateStatus_STOCK_UPDATE_STATUS_INVALID_ITEM, resp.GetResults()[2].GetStatus())
+	require.Len(t, resp.GetUpdatedProducts(), 1)
+	assert.Equal(t, int64(1), resp.GetUpdatedProducts()[0].GetId())
+}
+
+func TestGRPCHandler_UpdateStock_AllApplied(t *testing.T) {
+	handler, _ := newStockTestHandler(t)
+
+	resp, err := handler.UpdateStock(context.Background(), &productpb.UpdateStockRequest{
+		Items: []*productpb.StockUpdateItem{
+			{ProductId: 2, QuantityChange: -1},
+			{ProductId: 1, QuantityChange: 5},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, resp.GetAllApplied())
+	assert.Len(t, resp.GetUpdatedProducts(), 2)
+}
+
+func TestGRPCHandler_UpdateStock_NoItems(t *testing.T) {
+	handler, _ := newStockTestHandler(t)
+
+	_, err := handler.UpdateStock(context.Background(), &productpb.UpdateStockRequest{})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCHandler_UpdateStock_ReplaysByOrderID(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	orderID := "order-42"
+	req := &productpb.UpdateStockRequest{
+		OrderId: &orderID,
+		Items:   []*productpb.StockUpdateItem{{ProductId: 1, QuantityChange: -3}},
+	}
+
+	first, err := handler.UpdateStock(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, first.GetReplayed())
+
+	retry, err := handler.UpdateStock(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, retry.GetReplayed())
+	assert.Equal(t, first.GetResults()[0].GetProduct().GetStockQuantity(), retry.GetResults()[0].GetProduct().GetStockQuantity())
+
+	p, err := memStore.GetProductByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), p.StockQuantity, "a retried order must not decrement twice")
+
+	_, err = handler.UpdateStock(ctx, &productpb.UpdateStockRequest{
+		OrderId: &orderID,
+		Items:   []*productpb.StockUpdateItem{{ProductId: 1, QuantityChange: -4}},
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestGRPCHandler_UpdateStock_InFlightOrderID(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	orderID := "order-43"
+	items := []*productpb.StockUpdateItem{{ProductId: 1, QuantityChange: -1}}
+
+	// Simulate a first attempt that claimed the key but has not stored its response yet.
+	_, claimed, err := memStore.ClaimIdempotencyKey(ctx, orderID, updateStockOperation,
+		updateStockRequestHash(items, productpb.StockUpdateMode_STOCK_UPDATE_MODE_ATOMIC), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	_, err = handler.UpdateStock(ctx, &productpb.UpdateStockRequest{OrderId: &orderID, Items: items})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestGRPCHandler_GetStockHistory_RecordsUpdateStock(t *testing.T) {
+	handler, _ := newStockTestHandler(t)
+	orderID := "order-7"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-actor", "order-service"))
+
+	_, err := handler.UpdateStock(ctx, &productpb.UpdateStockRequest{
+		OrderId: &orderID,
+		Items: []*productpb.StockUpdateItem{
+			{ProductId: 1, QuantityChange: -2},
+			{ProductId: 1, QuantityChange: -3},
+		},
+	})
+	require.NoError(t, err)
+
+	resp, err := handler.GetStockHistory(context.Background(), &productpb.GetStockHistoryRequest{
+		ProductId: 1,
+		PageInfo:  &commonpb.PageInfoRequest{PageSize: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), resp.GetPageInfo().GetTotalSize(), "initial stock plus one entry per item")
+	require.NotEmpty(t, resp.GetPageInfo().GetNextPageToken())
+	require.Len(t, resp.GetMovements(), 2)
+	latest := resp.GetMovements()[0]
+	assert.Equal(t, productpb.StockMovementReason_STOCK_MOVEMENT_REASON_STOCK_UPDATE, latest.GetReason())
+	assert.Equal(t, int32(-3), latest.GetQuantityDelta())
+	assert.Equal(t, int32(5), latest.GetBalanceAfter())
+	assert.Equal(t, "order-7", latest.GetOrderId())
+	assert.Equal(t, "order-service", latest.GetActor())
+	assert.Equal(t, int32(8), resp.GetMovements()[1].GetBalanceAfter())
+
+	next, err := handler.GetStockHistory(context.Background(), &productpb.GetStockHistoryRequest{
+		ProductId: 1,
+		PageInfo:  &commonpb.PageInfoRequest{PageSize: 2, PageToken: resp.GetPageInfo().GetNextPageToken()},
+	})
+	require.NoError(t, err)
+	require.Len(t, next.GetMovements(), 1)
+	assert.Equal(t, productpb.StockMovementReason_STOCK_MOVEMENT_REASON_INITIAL_STOCK, next.GetMovements()[0].GetReason())
+	assert.Empty(t, next.GetPageInfo().GetNextPageToken())
+
+	for _, token := range []string{"2", handler.pageTokens.Encode(store.Cursor{SortBy: "created_at", Desc: true, Key: "x", ID: 1})} {
+		_, err = handler.GetStockHistory(context.Background(), &productpb.GetStockHistoryRequest{
+			ProductId: 1,
+			PageInfo:  &commonpb.PageInfoRequest{PageToken: token},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "page_token %q", token)
+	}
+
+	_, err = handler.GetStockHistory(context.Background(), &productpb.GetStockHistoryRequest{ProductId: 99})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
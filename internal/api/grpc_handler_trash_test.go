@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCHandler_IncludeDeleted(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	require.NoError(t, memStore.DeleteProduct(ctx, 1))
+
+	_, err := handler.GetProductDetails(ctx, &productpb.GetProductDetailsRequest{ProductId: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	resp, err := handler.GetProductDetails(ctx, &productpb.GetProductDetailsRequest{ProductId: 1, IncludeDeleted: PtrTo(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "KB-1", resp.GetProduct().GetSku())
+	assert.NotNil(t, resp.GetProduct().GetDeletedAt(), "order history can tell the product is gone")
+	_, err = handler.GetProductDetails(ctx, &productpb.GetProductDetailsRequest{ProductId: 9, IncludeDeleted: PtrTo(true)})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	list, err := handler.ListProductsInternal(ctx, &productpb.ListProductsInternalRequest{ProductIds: []int64{1, 2}})
+	require.NoError(t, err)
+	require.Len(t, list.GetProducts(), 1)
+	assert.Nil(t, list.GetProducts()[0].GetDeletedAt())
+	list, err = handler.ListProductsInternal(ctx, &productpb.ListProductsInternalRequest{ProductIds: []int64{1, 2}, IncludeDeleted: PtrTo(true)})
+	require.NoError(t, err)
+	assert.Len(t, list.GetProducts(), 2)
+
+	history, err := handler.GetStockHistory(ctx, &productpb.GetStockHistoryRequest{ProductId: 1})
+	require.NoError(t, err, "the ledger of a product in the trash stays readable")
+	assert.NotEmpty(t, history.GetMovements())
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"log"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// attachVariants sets the variants of the parents among products, priced by the same selection.
+func (s *GRPCHandler) attachVariants(ctx context.Context, sel store.PriceSelection, products []domain.Product) error {
+	var parentIDs []int64
+	for _, p := range products {
+		if len(p.Options) > 0 {
+			parentIDs = append(parentIDs, p.ID)
+		}
+	}
+	if len(parentIDs) == 0 {
+		return nil
+	}
+	variants, err := s.productStore.ListVariants(ctx, parentIDs)
+	if err != nil {
+		log.Printf("ERROR: Failed to list product variants: %v", err)
+		return status.Errorf(codes.Internal, "Failed to retrieve product variants")
+	}
+	for i := range products {
+		if list := variants[products[i].ID]; len(list) > 0 {
+			if err := s.resolvePrices(ctx, sel, list); err != nil {
+				return err
+			}
+			products[i].Variants = list
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings" // Required for string manipulation functions like ToLower
 
@@ -17,17 +18,24 @@ import (
 
 // HTTPHandler holds dependencies for HTTP handlers.
 type HTTPHandler struct {
-	categoryStore store.CategoryStorer
-	productStore  store.ProductStorer
-	validate      *validator.Validate
+	categoryStore  store.CategoryStorer
+	productStore   store.ProductStorer
+	locationStore  store.LocationStorer
+	priceListStore store.PriceListStorer
+	pageTokens     *PageTokenCodec
+	validate       *validator.Validate
+	images         *ImageUploadConfig // Nil unless EnableImageUploads was called
 }
 
-// NewHTTPHandler creates a new HTTPHandler with dependencies.
-func NewHTTPHandler(cs store.CategoryStorer, ps store.ProductStorer) *HTTPHandler {
+// NewHTTPHandler creates a new HTTPHandler with dependencies. List cursors are signed with pageTokens.
+func NewHTTPHandler(cs store.CategoryStorer, ps store.ProductStorer, ls store.LocationStorer, pls store.PriceListStorer, pageTokens *PageTokenCodec) *HTTPHandler {
 	return &HTTPHandler{
-		categoryStore: cs,
-		productStore:  ps,
-		validate:      validator.New(),
+		categoryStore:  cs,
+		productStore:   ps,
+		locationStore:  ls,
+		priceListStore: pls,
+		pageTokens:     pageTokens,
+		validate:       validator.New(),
 	}
 }
 
@@ -35,8 +43,14 @@ func NewHTTPHandler(cs store.CategoryStorer, ps store.ProductStorer) *HTTPHandle
 
 // ErrorResponse defines the structure for JSON error responses.
 type ErrorResponse struct {
-	Error string `json:"error"`
-	// Details interface{} `json:"details,omitempty"` // Optional: for more detailed validation errors
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"` // Set when individual fields of the request are invalid
+}
+
+// FieldError is a problem with one field of a request body.
+type FieldError struct {
+	Field   string `json:"field"` // e.g. "attributes.color"
+	Message string `json:"message"`
 }
 
 func respondWithError(w http.ResponseWriter, code int, message string) {
@@ -55,6 +69,88 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	}
 }
 
+// PaginationInfo matches the OpenAPI PaginationInfo schema of paginated list responses.
+type PaginationInfo struct {
+	Page       int    `json:"page,omitempty"` // Omitted when the page was requested by cursor
+	Limit      int    `json:"limit"`
+	TotalItems int    `json:"total_items"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"` // Set while more items follow
+}
+
+// listPage is the window requested by a list endpoint: either a page number (OFFSET) or, if the
+// cursor query parameter is set, the position after the last item of the previous page (keyset).
+type listPage struct {
+	page   int // 0 when a cursor is used
+	limit  int
+	offset int
+	after  *store.Cursor
+}
+
+// parseListPage reads the limit, page and cursor query parameters. It returns an error message
+// suitable for a 400 response if they are invalid.
+func (h *HTTPHandler) parseListPage(r *http.Request) (listPage, string) {
+	return h.parseListPageWithDefault(r, 10)
+}
+
+// parseListPageWithDefault is parseListPage for a list whose limit defaults to defaultLimit.
+func (h *HTTPHandler) parseListPageWithDefault(r *http.Request, defaultLimit int) (listPage, string) {
+	qParams := r.URL.Query()
+	limit, err := strconv.Atoi(qParams.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > 100 { // Max limit
+		limit = 100
+	}
+
+	if token := qParams.Get("cursor"); token != "" {
+		if qParams.Get("page") != "" {
+			return listPage{}, "page and cursor cannot be combined"
+		}
+		after, err := h.pageTokens.Decode(token)
+		if err != nil {
+			return listPage{}, "Invalid cursor"
+		}
+		return listPage{limit: limit, after: after}, ""
+	}
+
+	page, err := strconv.Atoi(qParams.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1 // Default page
+	}
+	return listPage{page: page, limit: limit, offset: (page - 1) * limit}, ""
+}
+
+// storeLimit is the number of rows to ask the store for. With a cursor the offset of the page is
+// unknown, so one extra row is fetched to tell whether another page follows.
+func (p listPage) storeLimit() int {
+	if p.after != nil {
+		return p.limit + 1
+	}
+	return p.limit
+}
+
+// trimListPage cuts the extra row fetched for storeLimit and reports whether more items follow the page.
+func trimListPage[T any](p listPage, items []T, totalCount int) ([]T, bool) {
+	if p.after != nil {
+		if len(items) > p.limit {
+			return items[:p.limit], true
+		}
+		return items, false
+	}
+	return items, p.offset+len(items) < totalCount
+}
+
+// pagination builds the pagination block of a list response.
+func (p listPage) pagination(totalCount int, nextCursor string) PaginationInfo {
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + p.limit - 1) / p.limit
+	}
+	return PaginationInfo{Page: p.page, Limit: p.limit, TotalItems: totalCount, TotalPages: totalPages, NextCursor: nextCursor}
+}
+
 // --- Category Handlers ---
 
 // CategoryCreateInput defines the expected input for creating a category.
@@ -98,62 +194,50 @@ func (h *HTTPHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *HTTPHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
-	limitStr := r.URL.Query().Get("limit")
-	pageStr := r.URL.Query().Get("page")
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
-		limit = 10 // Default limit
-	}
-	if limit > 100 { // Max limit
-		limit = 100
-	}
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page <= 0 {
-		page = 1 // Default page
+	window, errMsg := h.parseListPage(r)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
 	}
-	offset := (page - 1) * limit
 
 	params := store.ListCategoriesParams{
-		Limit:  limit,
-		Offset: offset,
+		Limit:  window.storeLimit(),
+		Offset: window.offset,
+		After:  window.after,
+	}
+	if idStr := r.URL.Query().Get("parent_category_id"); idStr != "" {
+		if id, err := strconv.ParseInt(idStr, 10, 64); err == nil && id > 0 {
+			params.ParentCategoryID = &id
+		} else {
+			respondWithError(w, http.StatusBadRequest, "Invalid parent_category_id format")
+			return
+		}
 	}
 
 	categories, totalCount, err := h.categoryStore.ListCategories(r.Context(), params)
 	if err != nil {
 		log.Printf("ERROR: ListCategories store operation failed: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve categories")
+		if errors.Is(err, store.ErrInvalidCursor) {
+			respondWithError(w, http.StatusBadRequest, "Invalid cursor")
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve categories")
+		}
 		return
 	}
 
-	totalPages := 0
-	if totalCount > 0 {
-		totalPages = (totalCount + limit - 1) / limit
+	categories, more := trimListPage(window, categories, totalCount)
+	var nextCursor string
+	if more && len(categories) > 0 {
+		nextCursor = h.pageTokens.Encode(store.CategoryCursor(&categories[len(categories)-1]))
 	}
 	
 	// Matches OpenAPI PaginationInfo
 	response := struct {
 		Data       []domain.Category `json:"data"`
-		Pagination struct {
-			Page       int `json:"page"`
-			Limit      int `json:"limit"`
-			TotalItems int `json:"total_items"`
-			TotalPages int `json:"total_pages"`
-		} `json:"pagination"`
+		Pagination PaginationInfo    `json:"pagination"`
 	}{
-		Data: categories,
-		Pagination: struct {
-			Page       int `json:"page"`
-			Limit      int `json:"limit"`
-			TotalItems int `json:"total_items"`
-			TotalPages int `json:"total_pages"`
-		}{
-			Page:       page,
-			Limit:      limit,
-			TotalItems: totalCount,
-			TotalPages: totalPages,
-		},
+		Data:       categories,
+		Pagination: window.pagination(totalCount, nextCursor),
 	}
 
 	respondWithJSON(w, http.StatusOK, response)
@@ -228,6 +312,8 @@ func (h *HTTPHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
 			respondWithError(w, http.StatusNotFound, store.ErrCategoryNotFound.Error())
 		} else if errors.Is(err, store.ErrCategoryNameExists) {
 			respondWithError(w, http.StatusConflict, store.ErrCategoryNameExists.Error())
+		} else if errors.Is(err, store.ErrCategoryCycle) {
+			respondWithError(w, http.StatusBadRequest, "Category cannot be moved under itself or one of its subcategories")
 		} else {
 			respondWithError(w, http.StatusInternalServerError, "Failed to update category")
 		}
@@ -245,11 +331,26 @@ func (h *HTTPHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.categoryStore.DeleteCategory(r.Context(), categoryID)
+	// The strategy query parameter selects what happens to subcategories and products; restrict by default.
+	opts := store.DeleteCategoryOptions{Strategy: store.CategoryDeleteStrategy(r.URL.Query().Get("strategy"))}
+	if targetStr := r.URL.Query().Get("target_category_id"); targetStr != "" {
+		targetID, err := strconv.ParseInt(targetStr, 10, 64)
+		if err != nil || targetID <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid target_category_id format")
+			return
+		}
+		opts.TargetCategoryID = &targetID
+	}
+
+	err = h.categoryStore.DeleteCategory(r.Context(), categoryID, opts)
 	if err != nil {
 		log.Printf("ERROR: DeleteCategory store operation for ID %d failed: %v", categoryID, err)
 		if errors.Is(err, store.ErrCategoryNotFound) {
 			respondWithError(w, http.StatusNotFound, store.ErrCategoryNotFound.Error())
+		} else if errors.Is(err, store.ErrInvalidDeleteOptions) {
+			respondWithError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), "store: "))
+		} else if errors.Is(err, store.ErrCategoryNotEmpty) {
+			respondWithError(w, http.StatusConflict, "Category still has subcategories or products; choose a delete strategy")
 		} else {
 			respondWithError(w, http.StatusInternalServerError, "Failed to delete category")
 		}
@@ -266,12 +367,17 @@ type ProductCreateInput struct {
 	Name          string           `json:"name" validate:"required,max=255"`
 	Description   *string          `json:"description" validate:"omitempty"`
 	SKU           string           `json:"sku" validate:"required,max=100"` // Max length from DB
-	Price         float64          `json:"price" validate:"required,gte=0"`
+	Price         domain.Money     `json:"price"` // Checked by validatePrice; omitted by variants that inherit it
 	StockQuantity int32            `json:"stock_quantity" validate:"required,gte=0"` // Changed to int32
 	CategoryID    *int64           `json:"category_id" validate:"omitempty,gt=0"`
 	ImageURL      *string          `json:"image_url" validate:"omitempty,url,max=2048"`
 	IsActive      *bool            `json:"is_active"` // Pointer to distinguish between not set and false
 	Attributes    *json.RawMessage `json:"attributes,omitempty" validate:"omitempty"` // Changed to json.RawMessage
+	// Variants: a parent names its options; a variant names its parent and a value for each option,
+	// and omits price to inherit the parent's.
+	ParentID      *int64            `json:"parent_id" validate:"omitempty,gt=0"`
+	Options       []string          `json:"options" validate:"omitempty,max=10,dive,required,max=64"`
+	OptionValues  map[string]string `json:"option_values" validate:"omitempty,dive,keys,max=64,endkeys,max=255"`
 }
 
 func (h *HTTPHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
@@ -286,13 +392,40 @@ func (h *HTTPHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
 		return
 	}
+	if errMsg := validatePrice(input.Price); errMsg != "" && !inheritsPrice(input.ParentID, input.Price) {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	createdProduct, err := h.productStore.CreateProduct(requestContext(r), input.product())
+	if err != nil {
+		log.Printf("ERROR: CreateProduct store operation failed: %v", err)
+		if errors.Is(err, store.ErrProductSKUExists) {
+			respondWithError(w, http.StatusConflict, store.ErrProductSKUExists.Error())
+		} else if errors.Is(err, store.ErrCategoryNotFound) { // If category_id FK fails
+			respondWithError(w, http.StatusBadRequest, "Invalid category_id: category does not exist.")
+		} else if errors.Is(err, store.ErrAttributeSchemaViolation) {
+			respondWithAttributeSchemaError(w, err)
+		} else if errors.Is(err, store.ErrInvalidVariant) || errors.Is(err, store.ErrInvalidPrice) {
+			respondWithError(w, http.StatusBadRequest, "Validation failed: "+strings.TrimPrefix(err.Error(), "store: "))
+		} else if errors.Is(err, store.ErrVariantExists) {
+			respondWithError(w, http.StatusConflict, store.ErrVariantExists.Error())
+		}else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to create product")
+		}
+		return
+	}
 
+	respondWithJSON(w, http.StatusCreated, createdProduct)
+}
+
+// product returns the product to create from a validated input.
+func (input ProductCreateInput) product() *domain.Product {
 	isActive := true // Default to true if not provided
 	if input.IsActive != nil {
 		isActive = *input.IsActive
 	}
-
-	product := &domain.Product{
+	return &domain.Product{
 		Name:          input.Name,
 		Description:   input.Description,
 		SKU:           input.SKU,
@@ -302,45 +435,136 @@ func (h *HTTPHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		ImageURL:      input.ImageURL,
 		IsActive:      isActive,
 		Attributes:    input.Attributes,
+		ParentID:      input.ParentID,
+		Options:       input.Options,
+		OptionValues:  input.OptionValues,
+		InheritsPrice: inheritsPrice(input.ParentID, input.Price),
 	}
+}
 
-	createdProduct, err := h.productStore.CreateProduct(r.Context(), product)
-	if err != nil {
-		log.Printf("ERROR: CreateProduct store operation failed: %v", err)
-		if errors.Is(err, store.ErrProductSKUExists) {
-			respondWithError(w, http.StatusConflict, store.ErrProductSKUExists.Error())
-		} else if errors.Is(err, store.ErrCategoryNotFound) { // If category_id FK fails
-			respondWithError(w, http.StatusBadRequest, "Invalid category_id: category does not exist.")
-		}else {
-			respondWithError(w, http.StatusInternalServerError, "Failed to create product")
-		}
-		return
-	}
-
-	respondWithJSON(w, http.StatusCreated, createdProduct)
+// validatePrice checks the price of a product input; a price without a currency was absent. As
+// before prices were exact, a base price of zero is rejected.
+func validatePrice(price domain.Money) string {
+	switch {
+	case price.Currency == "":
+		return "Validation failed: price is required"
+	case price.Amount <= 0:
+		return "Validation failed: price must be positive"
+	case !price.InRange():
+		return "Validation failed: price must be less than 1000000000000"
+	}
+	return ""
 }
 
 func (h *HTTPHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 	qParams := r.URL.Query()
 	
-	limitStr := qParams.Get("limit")
-	pageStr := qParams.Get("page")
+	window, errMsg := h.parseListPage(r)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
-		limit = 10
+	params := store.ListProductsParams{Limit: window.storeLimit(), Offset: window.offset, After: window.after}
+
+	if errMsg := parseProductFilters(qParams, &params); errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
 	}
-	if limit > 100 {
-		limit = 100
+	// group_by_parent lists each parent once, with its variants, instead of the variants on their own.
+	var groupByParent bool
+	if groupStr := qParams.Get("group_by_parent"); groupStr != "" {
+		if b, err := strconv.ParseBool(groupStr); err == nil {
+			groupByParent, params.ExcludeVariants = b, b
+		} else {
+			respondWithError(w, http.StatusBadRequest, "Invalid group_by_parent value: must be true or false")
+			return
+		}
 	}
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page <= 0 {
-		page = 1
+	params.SortBy = qParams.Get("sort_by") // Validation happens in store or can be added here
+	params.SortOrder = qParams.Get("sort_order") // Validation happens in store or can be added here
+
+	// Whitelist sort fields and order here for better API contract enforcement
+	allowedSortFields := map[string]bool{"name": true, "price": true, "created_at": true, "updated_at": true, store.ProductSortRelevance: true, "":true} // "" for default
+	if !allowedSortFields[params.SortBy] {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid sort_by field. Allowed: %v", getMapKeys(allowedSortFields)))
+		return
+	}
+	if params.SortBy == store.ProductSortRelevance && params.SearchQuery == nil { // Searches sort by relevance by default
+		respondWithError(w, http.StatusBadRequest, "sort_by=relevance requires a search query (q)")
+		return
+	}
+	if params.SortOrder != "" && strings.ToLower(params.SortOrder) != "asc" && strings.ToLower(params.SortOrder) != "desc" {
+		respondWithError(w, http.StatusBadRequest, "Invalid sort_order value. Allowed: asc, desc")
+		return
+	}
+	facetRequest, errMsg := parseFacetsQuery(qParams.Get("facets"), qParams.Get("price_buckets"), priceFilterCurrency(qParams))
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	// Filters and sorting use effective prices (scheduled or base); the selection only changes the prices returned.
+	priceSelection, errMsg := parsePriceSelection(qParams)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	if errMsg := basePriceConflict(priceSelection, params, facetRequest); errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
+
+	products, totalCount, err := h.productStore.ListProducts(r.Context(), params)
+	if err != nil {
+		log.Printf("ERROR: ListProducts store operation failed: %v", err)
+		if errors.Is(err, store.ErrInvalidCursor) { // Issued for another sort_by or sort_order
+			respondWithError(w, http.StatusBadRequest, "Invalid cursor: it does not match sort_by and sort_order")
+		} else if errors.Is(err, store.ErrInvalidAttributeFilter) {
+			respondWithError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), "store: "))
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve products")
+		}
+		return
 	}
-	offset := (page - 1) * limit
 
-	params := store.ListProductsParams{Limit: limit, Offset: offset}
+	products, more := trimListPage(window, products, totalCount)
+	var nextCursor string
+	if more && len(products) > 0 {
+		nextCursor = h.pageTokens.Encode(store.ProductCursor(params, &products[len(products)-1]))
+	}
+	if !h.resolvePrices(w, r, priceSelection, products) {
+		return
+	}
+	if groupByParent && !h.attachVariants(w, r, priceSelection, products) {
+		return
+	}
+	if !h.attachMedia(w, r, products) {
+		return
+	}
+	response := struct {
+		Data       []domain.Product      `json:"data"`
+		Pagination PaginationInfo        `json:"pagination"`
+		Facets     *domain.ProductFacets `json:"facets,omitempty"`
+	}{
+		Data:       products,
+		Pagination: window.pagination(totalCount, nextCursor),
+	}
+	if facetRequest != nil {
+		// Facets summarize every matching product, whatever page was requested.
+		if response.Facets, err = h.productStore.ListProductFacets(r.Context(), params, *facetRequest); err != nil {
+			log.Printf("ERROR: ListProductFacets store operation failed: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to compute product facets")
+			return
+		}
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
 
+// parseProductFilters reads the filters of a product listing from the query into params: q,
+// category_id, include_descendants, currency with min_price and max_price, is_active, parent_id and
+// the attribute filters. It returns what is wrong with the first invalid one.
+func parseProductFilters(qParams url.Values, params *store.ListProductsParams) string {
 	if q := qParams.Get("q"); q != "" {
 		params.SearchQuery = &q
 	}
@@ -348,88 +572,68 @@ func (h *HTTPHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 		if id, err := strconv.ParseInt(idStr, 10, 64); err == nil && id > 0 {
 			params.CategoryID = &id
 		} else {
-			respondWithError(w, http.StatusBadRequest, "Invalid category_id format")
-			return
+			return "Invalid category_id format"
+		}
+	}
+	if descStr := qParams.Get("include_descendants"); descStr != "" {
+		if b, err := strconv.ParseBool(descStr); err == nil {
+			params.IncludeDescendants = b
+		} else {
+			return "Invalid include_descendants value: must be true or false"
 		}
 	}
+	// Price bounds are exact decimals in price_currency, and only match prices in that currency.
+	currency := priceFilterCurrency(qParams)
+	if !domain.ValidCurrency(currency) {
+		return "Invalid price_currency: must be an ISO 4217 code such as USD"
+	}
 	if priceStr := qParams.Get("min_price"); priceStr != "" {
-		if price, err := strconv.ParseFloat(priceStr, 64); err == nil && price >= 0 {
+		if price, err := domain.ParseMoney(priceStr, currency); err == nil && price.Amount >= 0 {
 			params.MinPrice = &price
 		} else {
-			respondWithError(w, http.StatusBadRequest, "Invalid min_price format")
-			return
+			return "Invalid min_price format"
 		}
 	}
 	if priceStr := qParams.Get("max_price"); priceStr != "" {
-		if price, err := strconv.ParseFloat(priceStr, 64); err == nil && price >= 0 {
+		if price, err := domain.ParseMoney(priceStr, currency); err == nil && price.Amount >= 0 {
 			params.MaxPrice = &price
 		} else {
-			respondWithError(w, http.StatusBadRequest, "Invalid max_price format")
-			return
+			return "Invalid max_price format"
 		}
 	}
-	if params.MinPrice != nil && params.MaxPrice != nil && *params.MinPrice > *params.MaxPrice {
-		respondWithError(w, http.StatusBadRequest, "min_price cannot exceed max_price")
-		return
+	if params.MinPrice != nil && params.MaxPrice != nil && params.MinPrice.Amount > params.MaxPrice.Amount {
+		return "min_price cannot exceed max_price"
 	}
 	if activeStr := qParams.Get("is_active"); activeStr != "" {
 		if b, err := strconv.ParseBool(activeStr); err == nil {
 			params.IsActive = &b
 		} else {
-			respondWithError(w, http.StatusBadRequest, "Invalid is_active value: must be true or false")
-			return
+			return "Invalid is_active value: must be true or false"
 		}
 	}
-
-	params.SortBy = qParams.Get("sort_by") // Validation happens in store or can be added here
-	params.SortOrder = qParams.Get("sort_order") // Validation happens in store or can be added here
-
-	// Whitelist sort fields and order here for better API contract enforcement
-	allowedSortFields := map[string]bool{"name": true, "price": true, "created_at": true, "updated_at": true, "":true} // "" for default
-	if !allowedSortFields[params.SortBy] {
-		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid sort_by field. Allowed: %v", getMapKeys(allowedSortFields)))
-		return
-	}
-	if params.SortOrder != "" && strings.ToLower(params.SortOrder) != "asc" && strings.ToLower(params.SortOrder) != "desc" {
-		respondWithError(w, http.StatusBadRequest, "Invalid sort_order value. Allowed: asc, desc")
-		return
+	if idStr := qParams.Get("parent_id"); idStr != "" {
+		if id, err := strconv.ParseInt(idStr, 10, 64); err == nil && id > 0 {
+			params.ParentID = &id
+		} else {
+			return "Invalid parent_id format"
+		}
 	}
 
-
-	products, totalCount, err := h.productStore.ListProducts(r.Context(), params)
-	if err != nil {
-		log.Printf("ERROR: ListProducts store operation failed: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve products")
-		return
+	var errMsg string
+	if params.Attributes, errMsg = parseAttributeFilterQuery(qParams); errMsg != "" {
+		return errMsg
 	}
+	return ""
+}
 
-	totalPages := 0
-	if totalCount > 0 {
-		totalPages = (totalCount + limit - 1) / limit
+// priceFilterCurrency returns the currency of the price bounds and the price facet of a product
+// listing, which parseProductFilters validates. It is separate from the currency parameter, which
+// selects the prices returned (see basePriceConflict).
+func priceFilterCurrency(qParams url.Values) string {
+	if c := qParams.Get("price_currency"); c != "" {
+		return c
 	}
-	response := struct {
-		Data       []domain.Product `json:"data"`
-		Pagination struct {
-			Page       int `json:"page"`
-			Limit      int `json:"limit"`
-			TotalItems int `json:"total_items"`
-			TotalPages int `json:"total_pages"`
-		} `json:"pagination"`
-	}{
-		Data: products,
-		Pagination: struct {
-			Page       int `json:"page"`
-			Limit      int `json:"limit"`
-			TotalItems int `json:"total_items"`
-			TotalPages int `json:"total_pages"`
-		}{
-			Page:       page,
-			Limit:      limit,
-			TotalItems: totalCount,
-			TotalPages: totalPages,
-		},
-	}
-	respondWithJSON(w, http.StatusOK, response)
+	return domain.DefaultCurrency
 }
 
 // Helper to get keys from a map for error messages
@@ -451,6 +655,11 @@ func (h *HTTPHandler) GetProductByID(w http.ResponseWriter, r *http.Request) {
 		respondWithError(w, http.StatusBadRequest, "Invalid product ID format")
 		return
 	}
+	priceSelection, errMsg := parsePriceSelection(r.URL.Query())
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
 
 	product, err := h.productStore.GetProductByID(r.Context(), productID)
 	if err != nil {
@@ -462,7 +671,12 @@ func (h *HTTPHandler) GetProductByID(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	respondWithJSON(w, http.StatusOK, product)
+	products := []domain.Product{*product}
+	if !h.resolvePrices(w, r, priceSelection, products) || !h.attachVariants(w, r, priceSelection, products) ||
+		!h.attachMedia(w, r, products) {
+		return
+	}
+	respondWithJSON(w, http.StatusOK, products[0])
 }
 
 // ProductUpdateInput defines the expected input for updating a product.
@@ -470,12 +684,17 @@ type ProductUpdateInput struct {
 	Name          string           `json:"name" validate:"required,max=255"`
 	Description   *string          `json:"description" validate:"omitempty"`
 	SKU           string           `json:"sku" validate:"required,max=100"`
-	Price         float64          `json:"price" validate:"required,gte=0"`
+	Price         domain.Money     `json:"price"` // Checked by validatePrice; omitted by variants that inherit it
 	StockQuantity int32            `json:"stock_quantity" validate:"required,gte=0"` // Changed to int32
 	CategoryID    *int64           `json:"category_id" validate:"omitempty,gt=0"`
 	ImageURL      *string          `json:"image_url" validate:"omitempty,url,max=2048"`
 	IsActive      *bool            `json:"is_active"`
 	Attributes    *json.RawMessage `json:"attributes,omitempty" validate:"omitempty"` // Changed to json.RawMessage
+	// Variants: a parent names its options; a variant names its parent and a value for each option,
+	// and omits price to inherit the parent's.
+	ParentID      *int64            `json:"parent_id" validate:"omitempty,gt=0"`
+	Options       []string          `json:"options" validate:"omitempty,max=10,dive,required,max=64"`
+	OptionValues  map[string]string `json:"option_values" validate:"omitempty,dive,keys,max=64,endkeys,max=255"`
 }
 
 func (h *HTTPHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
@@ -497,6 +716,10 @@ func (h *HTTPHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
 		return
 	}
+	if errMsg := validatePrice(input.Price); errMsg != "" && !inheritsPrice(input.ParentID, input.Price) {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
 
 	// Get existing product to ensure it exists before update,
 	// and to handle partial updates gracefully if needed (though current store.UpdateProduct updates all fields)
@@ -535,9 +758,13 @@ func (h *HTTPHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		ImageURL:      input.ImageURL,
 		IsActive:      isActive, // Use the determined isActive value
 		Attributes:    input.Attributes,
+		ParentID:      input.ParentID,
+		Options:       input.Options,
+		OptionValues:  input.OptionValues,
+		InheritsPrice: inheritsPrice(input.ParentID, input.Price),
 	}
 
-	updatedProduct, err := h.productStore.UpdateProduct(r.Context(), productToUpdate)
+	updatedProduct, err := h.productStore.UpdateProduct(requestContext(r), productToUpdate)
 	if err != nil {
 		log.Printf("ERROR: UpdateProduct store operation for ID %d failed: %v", productID, err)
 		if errors.Is(err, store.ErrProductNotFound) { // Should have been caught by GetProductByID above
@@ -546,6 +773,12 @@ func (h *HTTPHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 			respondWithError(w, http.StatusConflict, store.ErrProductSKUExists.Error())
 		} else if errors.Is(err, store.ErrCategoryNotFound) { // If category_id FK fails
 			respondWithError(w, http.StatusBadRequest, "Invalid category_id: category does not exist.")
+		} else if errors.Is(err, store.ErrAttributeSchemaViolation) {
+			respondWithAttributeSchemaError(w, err)
+		} else if errors.Is(err, store.ErrInvalidVariant) || errors.Is(err, store.ErrInvalidPrice) {
+			respondWithError(w, http.StatusBadRequest, "Validation failed: "+strings.TrimPrefix(err.Error(), "store: "))
+		} else if errors.Is(err, store.ErrVariantExists) {
+			respondWithError(w, http.StatusConflict, store.ErrVariantExists.Error())
 		} else {
 			respondWithError(w, http.StatusInternalServerError, "Failed to update product")
 		}
@@ -586,6 +819,11 @@ func (h *HTTPHandler) GetProductRecommendations(w http.ResponseWriter, r *http.R
 	if limit > 20 { // Max limit for recommendations
 		limit = 20
 	}
+	priceSelection, errMsg := parsePriceSelection(r.URL.Query())
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
 
 	// For now, using GetRecentProducts as the recommendation strategy
 	// Your OpenAPI spec also had optional product_id or user_id for recommendations,
@@ -600,6 +838,9 @@ func (h *HTTPHandler) GetProductRecommendations(w http.ResponseWriter, r *http.R
 	if recommendations == nil { // Ensure empty list instead of null if store returns nil slice
 		recommendations = []domain.Product{}
 	}
+	if !h.resolvePrices(w, r, priceSelection, recommendations) || !h.attachMedia(w, r, recommendations) {
+		return
+	}
 
 	respondWithJSON(w, http.StatusOK, recommendations)
 }
@@ -615,10 +856,41 @@ func (h *HTTPHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/api/v1/categories", func(r chi.Router) {
 		r.Post("/", h.CreateCategory)      // POST /api/v1/categories
 		r.Get("/", h.ListCategories)        // GET /api/v1/categories
+		r.Get("/tree", h.GetCategoryTree)   // GET /api/v1/categories/tree
+		r.Get("/trash", h.ListCategoryTrash) // GET /api/v1/categories/trash
 		r.Route("/{categoryId}", func(r chi.Router) {
 			r.Get("/", h.GetCategoryByID)   // GET /api/v1/categories/{categoryId}
 			r.Put("/", h.UpdateCategory)    // PUT /api/v1/categories/{categoryId}
 			r.Delete("/", h.DeleteCategory) // DELETE /api/v1/categories/{categoryId}
+			r.Post("/restore", h.RestoreCategory) // POST /api/v1/categories/{categoryId}/restore
+			r.Get("/ancestors", h.GetCategoryAncestors)     // GET /api/v1/categories/{categoryId}/ancestors
+			r.Get("/descendants", h.GetCategoryDescendants) // GET /api/v1/categories/{categoryId}/descendants
+			r.Get("/attribute-schema", h.GetCategoryAttributeSchema)        // GET /api/v1/categories/{categoryId}/attribute-schema
+			r.Put("/attribute-schema/{key}", h.PutCategoryAttribute)       // PUT /api/v1/categories/{categoryId}/attribute-schema/{key}
+			r.Delete("/attribute-schema/{key}", h.DeleteCategoryAttribute) // DELETE /api/v1/categories/{categoryId}/attribute-schema/{key}
+		})
+	})
+
+	r.Route("/api/v1/locations", func(r chi.Router) {
+		r.Post("/", h.CreateLocation)      // POST /api/v1/locations
+		r.Get("/", h.ListLocations)        // GET /api/v1/locations
+		r.Route("/{locationId}", func(r chi.Router) {
+			r.Get("/", h.GetLocationByID)   // GET /api/v1/locations/{locationId}
+			r.Put("/", h.UpdateLocation)    // PUT /api/v1/locations/{locationId}
+			r.Delete("/", h.DeleteLocation) // DELETE /api/v1/locations/{locationId}
+		})
+	})
+
+	r.Route("/api/v1/price-lists", func(r chi.Router) {
+		r.Post("/", h.CreatePriceList) // POST /api/v1/price-lists
+		r.Get("/", h.ListPriceLists)   // GET /api/v1/price-lists
+		r.Route("/{priceListId}", func(r chi.Router) {
+			r.Get("/", h.GetPriceListByID)   // GET /api/v1/price-lists/{priceListId}
+			r.Put("/", h.UpdatePriceList)    // PUT /api/v1/price-lists/{priceListId}
+			r.Delete("/", h.DeletePriceList) // DELETE /api/v1/price-lists/{priceListId}
+			r.Get("/entries", h.ListPriceListEntries)      // GET /api/v1/price-lists/{priceListId}/entries
+			r.Put("/entries", h.PutPriceListEntries)       // PUT /api/v1/price-lists/{priceListId}/entries
+			r.Delete("/entries", h.DeletePriceListEntries) // DELETE /api/v1/price-lists/{priceListId}/entries
 		})
 	})
 
@@ -627,11 +899,34 @@ func (h *HTTPHandler) RegisterRoutes(r chi.Router) {
 		r.Get("/", h.ListProducts)          // GET /api/v1/products
 		// Ensure this is before the {productId} route to avoid "recommendations" being treated as an ID
 		r.Get("/recommendations", h.GetProductRecommendations) // GET /api/v1/products/recommendations
+		r.Post("/import", h.ImportProducts)                    // POST /api/v1/products/import
+		r.Get("/export", h.ExportProducts)                     // GET /api/v1/products/export
+		r.Get("/trash", h.ListProductTrash)                    // GET /api/v1/products/trash
 
 		r.Route("/{productId}", func(r chi.Router) {
 			r.Get("/", h.GetProductByID)     // GET /api/v1/products/{productId}
 			r.Put("/", h.UpdateProduct)      // PUT /api/v1/products/{productId}
 			r.Delete("/", h.DeleteProduct)   // DELETE /api/v1/products/{productId}
+			r.Post("/restore", h.RestoreProduct) // POST /api/v1/products/{productId}/restore
+			r.Get("/stock-history", h.GetStockHistory)      // GET /api/v1/products/{productId}/stock-history
+			r.Post("/stock-adjustments", h.AdjustStock)     // POST /api/v1/products/{productId}/stock-adjustments
+			r.Get("/stock-levels", h.GetStockLevels)        // GET /api/v1/products/{productId}/stock-levels
+			r.Post("/stock-transfers", h.TransferStock)     // POST /api/v1/products/{productId}/stock-transfers
+			r.Get("/scheduled-prices", h.ListScheduledPrices)                          // GET /api/v1/products/{productId}/scheduled-prices
+			r.Post("/scheduled-prices", h.CreateScheduledPrice)                        // POST /api/v1/products/{productId}/scheduled-prices
+			r.Delete("/scheduled-prices/{scheduledPriceId}", h.DeleteScheduledPrice) // DELETE /api/v1/products/{productId}/scheduled-prices/{scheduledPriceId}
+			r.Get("/price-history", h.GetPriceHistory)                                  // GET /api/v1/products/{productId}/price-history
+			r.Get("/media", h.ListProductMedia)                  // GET /api/v1/products/{productId}/media
+			r.Post("/media", h.AddProductMedia)                  // POST /api/v1/products/{productId}/media
+			r.Put("/media/{mediaId}", h.UpdateProductMedia)      // PUT /api/v1/products/{productId}/media/{mediaId}
+			r.Delete("/media/{mediaId}", h.DeleteProductMedia)   // DELETE /api/v1/products/{productId}/media/{mediaId}
+			if h.images != nil {
+				r.Post("/images", h.UploadProductImage) // POST /api/v1/products/{productId}/images
+			}
 		})
 	})
+
+	if h.images != nil {
+		r.Get(imagesPath+"*", h.ServeImage) // GET /api/v1/images/{key}
+	}
 }
\ No newline at end of file
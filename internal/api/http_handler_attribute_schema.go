@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Category Attribute Schema Handlers ---
+
+// CategoryAttributeInput defines the expected input for defining an attribute of a category. The
+// key comes from the path.
+type CategoryAttributeInput struct {
+	Type       domain.AttributeType `json:"type" validate:"required,oneof=string number boolean string_list"`
+	EnumValues []string             `json:"enum_values" validate:"omitempty,max=200,dive,required,max=255"`
+	Required   bool                 `json:"required"`
+	Unit       *string              `json:"unit" validate:"omitempty,min=1,max=32"` // Max length from DB schema
+}
+
+// GetCategoryAttributeSchema returns the effective schema of a category: its own definitions and
+// the ones it inherits, ordered by key. The category_id of each entry names the defining category.
+func (h *HTTPHandler) GetCategoryAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+
+	schema, err := h.categoryStore.GetCategoryAttributeSchema(r.Context(), categoryID)
+	if err != nil {
+		log.Printf("ERROR: GetCategoryAttributeSchema store operation for ID %d failed: %v", categoryID, err)
+		if errors.Is(err, store.ErrCategoryNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrCategoryNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve attribute schema")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, schema)
+}
+
+// PutCategoryAttribute creates or replaces the definition of an attribute on a category. Existing
+// products are not revalidated; the definition applies when they are next written.
+func (h *HTTPHandler) PutCategoryAttribute(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+	key := chi.URLParam(r, "key")
+
+	var input CategoryAttributeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	attr := &domain.CategoryAttribute{
+		CategoryID: categoryID,
+		Key:        key,
+		Type:       input.Type,
+		EnumValues: input.EnumValues,
+		Required:   input.Required,
+		Unit:       input.Unit,
+	}
+	stored, err := h.categoryStore.PutCategoryAttribute(r.Context(), attr)
+	if err != nil {
+		log.Printf("ERROR: PutCategoryAttribute store operation for category ID %d, key '%s' failed: %v", categoryID, key, err)
+		if errors.Is(err, store.ErrCategoryNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrCategoryNotFound.Error())
+		} else if errors.Is(err, store.ErrInvalidAttributeDefinition) {
+			respondWithError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), "store: "))
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to save attribute definition")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, stored)
+}
+
+// DeleteCategoryAttribute removes a definition made by the category itself. Inherited definitions
+// are deleted on the category that defines them.
+func (h *HTTPHandler) DeleteCategoryAttribute(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+	key := chi.URLParam(r, "key")
+
+	if err := h.categoryStore.DeleteCategoryAttribute(r.Context(), categoryID, key); err != nil {
+		log.Printf("ERROR: DeleteCategoryAttribute store operation for category ID %d, key '%s' failed: %v", categoryID, key, err)
+		if errors.Is(err, store.ErrCategoryAttributeNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrCategoryAttributeNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to delete attribute definition")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusNoContent, nil)
+}
+
+// respondWithAttributeSchemaError reports the attributes of a product that break its category's
+// schema, one FieldError per violation.
+func respondWithAttributeSchemaError(w http.ResponseWriter, err error) {
+	response := ErrorResponse{Error: "Attributes do not match the category's attribute schema"}
+	var schemaErr *store.AttributeSchemaError
+	if errors.As(err, &schemaErr) {
+		for _, v := range schemaErr.Violations {
+			response.Fields = append(response.Fields, FieldError{Field: v.Field, Message: v.Message})
+		}
+	}
+	respondWithJSON(w, http.StatusBadRequest, response)
+}
+
+// parseCategoryID reads the categoryId path parameter, responding with 400 if it is invalid.
+func parseCategoryID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	categoryID, err := strconv.ParseInt(chi.URLParam(r, "categoryId"), 10, 64)
+	if err != nil || categoryID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid category ID format")
+		return 0, false
+	}
+	return categoryID, true
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func putJSON(t *testing.T, url string, payload interface{}) *http.Response {
+	t.Helper()
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestHTTPHandler_CategoryAttributeSchema(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	ctx := context.Background()
+	clothing, err := memStore.CreateCategory(ctx, &domain.Category{Name: "Clothing"})
+	require.NoError(t, err)
+	shirts, err := memStore.CreateCategory(ctx, &domain.Category{Name: "Shirts", ParentCategoryID: &clothing.ID})
+	require.NoError(t, err)
+	schemaURL := func(id int64) string { return fmt.Sprintf("%s/api/v1/categories/%d/attribute-schema", server.URL, id) }
+
+	resp := putJSON(t, schemaURL(clothing.ID)+"/color", map[string]interface{}{"type": "string", "enum_values": []string{"red", "blue"}, "required": true})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = putJSON(t, schemaURL(clothing.ID)+"/color", map[string]interface{}{"type": "colour"})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = putJSON(t, schemaURL(clothing.ID)+"/weight", map[string]interface{}{"type": "boolean", "unit": "kg"})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "only number attributes have a unit")
+	resp = putJSON(t, schemaURL(99)+"/color", map[string]interface{}{"type": "string"})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get(schemaURL(shirts.ID))
+	require.NoError(t, err)
+	var schema []domain.CategoryAttribute
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&schema))
+	resp.Body.Close()
+	require.Len(t, schema, 1)
+	assert.Equal(t, clothing.ID, schema[0].CategoryID, "inherited from the parent")
+
+	resp = postJSON(t, server.URL+"/api/v1/products", map[string]interface{}{
+		"name": "Shirt", "sku": "S-1", "price": 10, "stock_quantity": 1, "category_id": shirts.ID,
+		"attributes": map[string]interface{}{"colour": "red"},
+	})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	var errResp ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, []FieldError{
+		{Field: "attributes.color", Message: "is required"},
+		{Field: "attributes.colour", Message: "is not defined for this category"},
+	}, errResp.Fields)
+
+	req, err := http.NewRequest(http.MethodDelete, schemaURL(shirts.ID)+"/color", nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "color is defined on the parent")
+}
+
+func TestGRPCHandler_CategoryAttributeSchema(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	category, err := memStore.CreateCategory(ctx, &domain.Category{Name: "Scales"})
+	require.NoError(t, err)
+	_, err = memStore.PutCategoryAttribute(ctx, &domain.CategoryAttribute{CategoryID: category.ID, Key: "capacity", Type: domain.AttributeTypeNumber, Unit: PtrTo("kg"), Required: true})
+	require.NoError(t, err)
+
+	resp, err := handler.GetCategoryAttributeSchema(ctx, &productpb.GetCategoryAttributeSchemaRequest{CategoryId: category.ID})
+	require.NoError(t, err)
+	require.Len(t, resp.GetAttributes(), 1)
+	assert.Equal(t, productpb.AttributeType_ATTRIBUTE_TYPE_NUMBER, resp.GetAttributes()[0].GetType())
+	assert.Equal(t, "kg", resp.GetAttributes()[0].GetUnit())
+
+	_, err = handler.GetCategoryAttributeSchema(ctx, &productpb.GetCategoryAttributeSchemaRequest{CategoryId: 99})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = memStore.CreateProduct(ctx, &domain.Product{Name: "Scale", SKU: "SC-1", CategoryID: &category.ID})
+	st := status.Convert(mapStoreErrorToGrpcStatus(err, "Product", "SC-1"))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "attributes.capacity", badRequest.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "is required", badRequest.GetFieldViolations()[0].GetDescription())
+}
@@ -58,14 +58,76 @@ func (m *MockCategoryStorer) UpdateCategory(ctx context.Context, category *domai
 	return args.Get(0).(*domain.Category), args.Error(1)
 }
 
-func (m *MockCategoryStorer) DeleteCategory(ctx context.Context, id int64) error {
+func (m *MockCategoryStorer) DeleteCategory(ctx context.Context, id int64, opts store.DeleteCategoryOptions) error {
+	args := m.Called(ctx, id, opts)
+	return args.Error(0)
+}
+
+func (m *MockCategoryStorer) RestoreCategory(ctx context.Context, id int64) (*domain.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (m *MockCategoryStorer) PurgeDeletedCategories(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCategoryStorer) GetCategoryTree(ctx context.Context, rootID *int64, maxDepth int) ([]domain.CategoryNode, error) {
+	args := m.Called(ctx, rootID, maxDepth)
+	var tree []domain.CategoryNode
+	if arg0 := args.Get(0); arg0 != nil {
+		tree = arg0.([]domain.CategoryNode)
+	}
+	return tree, args.Error(1)
+}
+
+func (m *MockCategoryStorer) GetCategoryAncestors(ctx context.Context, id int64) ([]domain.Category, error) {
 	args := m.Called(ctx, id)
+	var categories []domain.Category
+	if arg0 := args.Get(0); arg0 != nil {
+		categories = arg0.([]domain.Category)
+	}
+	return categories, args.Error(1)
+}
+
+func (m *MockCategoryStorer) GetCategoryDescendants(ctx context.Context, id int64, maxDepth int) ([]domain.Category, error) {
+	args := m.Called(ctx, id, maxDepth)
+	var categories []domain.Category
+	if arg0 := args.Get(0); arg0 != nil {
+		categories = arg0.([]domain.Category)
+	}
+	return categories, args.Error(1)
+}
+
+func (m *MockCategoryStorer) GetCategoryAttributeSchema(ctx context.Context, categoryID int64) ([]domain.CategoryAttribute, error) {
+	args := m.Called(ctx, categoryID)
+	var schema []domain.CategoryAttribute
+	if arg0 := args.Get(0); arg0 != nil {
+		schema = arg0.([]domain.CategoryAttribute)
+	}
+	return schema, args.Error(1)
+}
+
+func (m *MockCategoryStorer) PutCategoryAttribute(ctx context.Context, attr *domain.CategoryAttribute) (*domain.CategoryAttribute, error) {
+	args := m.Called(ctx, attr)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CategoryAttribute), args.Error(1)
+}
+
+func (m *MockCategoryStorer) DeleteCategoryAttribute(ctx context.Context, categoryID int64, key string) error {
+	args := m.Called(ctx, categoryID, key)
 	return args.Error(0)
 }
 
 // Helper for setting up tests with a chi router and handler
 func setupTestChiServer(t *testing.T, cs store.CategoryStorer, ps store.ProductStorer) *httptest.Server {
-	handler := NewHTTPHandler(cs, ps) // Assuming NewHTTPHandler takes both, pass nil for productStore if not used
+	handler := NewHTTPHandler(cs, ps, nil, nil, NewPageTokenCodec([]byte("test-secret"))) // Pass nil for stores a test does not use
 	router := chi.NewRouter()
 	handler.RegisterRoutes(router) // Use the unified RegisterRoutes method
 
@@ -78,6 +140,11 @@ func PtrTo[T any](v T) *T {
 	return &v
 }
 
+// usd returns an amount of US dollars given in cents.
+func usd(cents int64) domain.Money {
+	return domain.Money{Amount: cents, Currency: "USD"}
+}
+
 func TestHTTPHandler_CreateCategory_Success(t *testing.T) {
 	mockCatStore := new(MockCategoryStorer)
 	// For category-specific tests, productStore can be nil if not used by category handlers
@@ -352,7 +419,7 @@ func TestHTTPHandler_DeleteCategory_Success(t *testing.T) {
 
 	categoryID := int64(1)
 
-	mockCatStore.On("DeleteCategory", mock.Anything, categoryID).Return(nil).Once()
+	mockCatStore.On("DeleteCategory", mock.Anything, categoryID, store.DeleteCategoryOptions{}).Return(nil).Once()
 
 	req, err := http.NewRequest(http.MethodDelete, server.URL+fmt.Sprintf("/api/v1/categories/%d", categoryID), nil)
 	require.NoError(t, err)
@@ -372,7 +439,7 @@ func TestHTTPHandler_DeleteCategory_NotFound(t *testing.T) {
 	defer server.Close()
 
 	categoryID := int64(99)
-	mockCatStore.On("DeleteCategory", mock.Anything, categoryID).Return(store.ErrCategoryNotFound).Once()
+	mockCatStore.On("DeleteCategory", mock.Anything, categoryID, store.DeleteCategoryOptions{}).Return(store.ErrCategoryNotFound).Once()
 
 	req, err := http.NewRequest(http.MethodDelete, server.URL+fmt.Sprintf("/api/v1/categories/%d", categoryID), nil)
 	require.NoError(t, err)
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"product-catalog-service/internal/store"
+)
+
+// --- Category Hierarchy Handlers ---
+
+// GetCategoryTree returns the nested category hierarchy: every root category's tree, or only the
+// tree under root_id. max_depth limits the levels returned (1 returns just the roots).
+func (h *HTTPHandler) GetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	maxDepth, errMsg := parseMaxDepth(r)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	var rootID *int64
+	if rootStr := r.URL.Query().Get("root_id"); rootStr != "" {
+		id, err := strconv.ParseInt(rootStr, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid root_id format")
+			return
+		}
+		rootID = &id
+	}
+
+	tree, err := h.categoryStore.GetCategoryTree(r.Context(), rootID, maxDepth)
+	if err != nil {
+		log.Printf("ERROR: GetCategoryTree store operation failed: %v", err)
+		if errors.Is(err, store.ErrCategoryNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrCategoryNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve category tree")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, tree)
+}
+
+// GetCategoryAncestors returns the categories above a category, root first, for breadcrumbs.
+func (h *HTTPHandler) GetCategoryAncestors(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+
+	ancestors, err := h.categoryStore.GetCategoryAncestors(r.Context(), categoryID)
+	if err != nil {
+		log.Printf("ERROR: GetCategoryAncestors store operation for ID %d failed: %v", categoryID, err)
+		if errors.Is(err, store.ErrCategoryNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrCategoryNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve category ancestors")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, ancestors)
+}
+
+// GetCategoryDescendants returns every category below a category as a flat list, ordered by depth
+// and then name. max_depth limits the levels returned (1 returns the direct subcategories).
+func (h *HTTPHandler) GetCategoryDescendants(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+	maxDepth, errMsg := parseMaxDepth(r)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	descendants, err := h.categoryStore.GetCategoryDescendants(r.Context(), categoryID, maxDepth)
+	if err != nil {
+		log.Printf("ERROR: GetCategoryDescendants store operation for ID %d failed: %v", categoryID, err)
+		if errors.Is(err, store.ErrCategoryNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrCategoryNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve category descendants")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, descendants)
+}
+
+// parseMaxDepth reads the optional max_depth query parameter; 0 (unset) means no limit.
+func parseMaxDepth(r *http.Request) (int, string) {
+	depthStr := r.URL.Query().Get("max_depth")
+	if depthStr == "" {
+		return 0, ""
+	}
+	maxDepth, err := strconv.Atoi(depthStr)
+	if err != nil || maxDepth <= 0 {
+		return 0, "max_depth must be a positive integer"
+	}
+	return maxDepth, ""
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// seedCategoryHierarchy creates Electronics > Phones > Android and returns their IDs.
+func seedCategoryHierarchy(t *testing.T, memStore *store.MemoryStore) (electronics, phones, android int64) {
+	t.Helper()
+	var parent *int64
+	ids := make([]int64, 0, 3)
+	for _, name := range []string{"Electronics", "Phones", "Android"} {
+		c, err := memStore.CreateCategory(context.Background(), &domain.Category{Name: name, ParentCategoryID: parent})
+		require.NoError(t, err)
+		ids = append(ids, c.ID)
+		parent = &c.ID
+	}
+	return ids[0], ids[1], ids[2]
+}
+
+func getJSON(t *testing.T, url string, target interface{}) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(target))
+	}
+	return resp.StatusCode
+}
+
+func TestHTTPHandler_CategoryHierarchy(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	electronics, phones, android := seedCategoryHierarchy(t, memStore)
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+
+	var tree []domain.CategoryNode
+	require.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/categories/tree?max_depth=2", &tree))
+	require.Len(t, tree, 1)
+	assert.Equal(t, "Electronics", tree[0].Name)
+	require.Len(t, tree[0].Children, 1)
+	assert.Empty(t, tree[0].Children[0].Children)
+	assert.True(t, tree[0].Children[0].HasChildren)
+
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/categories/tree?root_id=%d", server.URL, phones), &tree))
+	require.Len(t, tree, 1)
+	assert.Equal(t, android, tree[0].Children[0].ID)
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/categories/tree?max_depth=0", &tree))
+	assert.Equal(t, http.StatusNotFound, getJSON(t, server.URL+"/api/v1/categories/tree?root_id=99", &tree))
+
+	var categories []domain.Category
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/categories/%d/ancestors", server.URL, android), &categories))
+	require.Len(t, categories, 2)
+	assert.Equal(t, electronics, categories[0].ID)
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/categories/%d/descendants?max_depth=1", server.URL, electronics), &categories))
+	require.Len(t, categories, 1)
+	assert.Equal(t, phones, categories[0].ID)
+	assert.Equal(t, http.StatusNotFound, getJSON(t, server.URL+"/api/v1/categories/99/descendants", &categories))
+}
+
+func TestGRPCHandler_CategoryHierarchy(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	electronics, phones, android := seedCategoryHierarchy(t, memStore)
+	ctx := context.Background()
+
+	treeResp, err := handler.GetCategoryTree(ctx, &productpb.GetCategoryTreeRequest{RootCategoryId: &electronics})
+	require.NoError(t, err)
+	require.Len(t, treeResp.GetRoots(), 1)
+	assert.Equal(t, android, treeResp.GetRoots()[0].GetChildren()[0].GetChildren()[0].GetCategory().GetId())
+
+	ancestorsResp, err := handler.GetCategoryAncestors(ctx, &productpb.GetCategoryAncestorsRequest{CategoryId: android})
+	require.NoError(t, err)
+	require.Len(t, ancestorsResp.GetAncestors(), 2)
+	assert.Equal(t, phones, ancestorsResp.GetAncestors()[1].GetId())
+
+	descendantsResp, err := handler.GetCategoryDescendants(ctx, &productpb.GetCategoryDescendantsRequest{CategoryId: electronics})
+	require.NoError(t, err)
+	assert.Len(t, descendantsResp.GetDescendants(), 2)
+
+	_, err = handler.GetCategoryDescendants(ctx, &productpb.GetCategoryDescendantsRequest{CategoryId: 99})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = handler.GetCategoryTree(ctx, &productpb.GetCategoryTreeRequest{MaxDepth: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHTTPHandler_CategoryHierarchyChanges(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	electronics, phones, android := seedCategoryHierarchy(t, memStore)
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	_, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Phone", SKU: "P-1", CategoryID: &phones})
+	require.NoError(t, err)
+
+	resp := putJSON(t, fmt.Sprintf("%s/api/v1/categories/%d", server.URL, electronics), map[string]interface{}{"name": "Electronics", "parent_category_id": android})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "moving a category under its descendant is a cycle")
+
+	deleteCategory := func(query string) int {
+		req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/v1/categories/%d%s", server.URL, phones, query), nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusConflict, deleteCategory(""), "restrict by default")
+	assert.Equal(t, http.StatusBadRequest, deleteCategory("?strategy=move_products"))
+	assert.Equal(t, http.StatusBadRequest, deleteCategory("?strategy=reparent&target_category_id=1"))
+	assert.Equal(t, http.StatusNoContent, deleteCategory("?strategy=reparent"))
+
+	moved, err := memStore.GetCategoryByID(context.Background(), android)
+	require.NoError(t, err)
+	assert.Equal(t, electronics, *moved.ParentCategoryID)
+	product, err := memStore.GetProductByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, electronics, *product.CategoryID)
+}
+
+func TestGRPCHandler_DeleteCategory(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	electronics, _, android := seedCategoryHierarchy(t, memStore)
+	ctx := context.Background()
+
+	_, err := handler.DeleteCategory(ctx, &productpb.DeleteCategoryRequest{CategoryId: electronics})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = handler.DeleteCategory(ctx, &productpb.DeleteCategoryRequest{
+		CategoryId: electronics, Strategy: productpb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_MOVE_PRODUCTS,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = handler.DeleteCategory(ctx, &productpb.DeleteCategoryRequest{
+		CategoryId: electronics, Strategy: productpb.CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_CASCADE,
+	})
+	require.NoError(t, err)
+	_, err = memStore.GetCategoryByID(ctx, android)
+	assert.ErrorIs(t, err, store.ErrCategoryNotFound)
+}
+
+func TestHTTPHandler_CategoryFilters(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	electronics, phones, android := seedCategoryHierarchy(t, memStore)
+	_, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Pixel", SKU: "PIXEL", Price: usd(50000), IsActive: true, CategoryID: &android})
+	require.NoError(t, err)
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+
+	var page struct {
+		Data []domain.Product `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/products?category_id=%d", server.URL, electronics), &page))
+	assert.Empty(t, page.Data)
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/products?category_id=%d&include_descendants=true", server.URL, electronics), &page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "PIXEL", page.Data[0].SKU)
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/products?include_descendants=maybe", &page))
+
+	var categories struct {
+		Data []domain.Category `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/categories?parent_category_id=%d", server.URL, electronics), &categories))
+	require.Len(t, categories.Data, 1)
+	assert.Equal(t, phones, categories.Data[0].ID)
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/categories?parent_category_id=x", &categories))
+}
+
+func TestGRPCHandler_CategoryFilters(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	electronics, phones, android := seedCategoryHierarchy(t, memStore)
+	ctx := context.Background()
+	_, err := memStore.CreateProduct(ctx, &domain.Product{Name: "Pixel", SKU: "PIXEL", Price: usd(50000), IsActive: true, CategoryID: &android})
+	require.NoError(t, err)
+
+	productsResp, err := handler.ListProductsInternal(ctx, &productpb.ListProductsInternalRequest{CategoryId: &phones})
+	require.NoError(t, err)
+	assert.Empty(t, productsResp.GetProducts())
+	productsResp, err = handler.ListProductsInternal(ctx, &productpb.ListProductsInternalRequest{CategoryId: &phones, IncludeDescendants: PtrTo(true)})
+	require.NoError(t, err)
+	require.Len(t, productsResp.GetProducts(), 1)
+	assert.Equal(t, "PIXEL", productsResp.GetProducts()[0].GetSku())
+
+	categoriesResp, err := handler.ListCategoriesInternal(ctx, &productpb.ListCategoriesInternalRequest{ParentCategoryId: &electronics})
+	require.NoError(t, err)
+	require.Len(t, categoriesResp.GetCategories(), 1)
+	assert.Equal(t, phones, categoriesResp.GetCategories()[0].GetId())
+}
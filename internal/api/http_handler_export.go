@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+)
+
+// --- Product Export Handlers ---
+
+// productExportColumns is the header of a CSV export. ImportProducts reads such a file back, ignoring
+// the columns it does not know.
+var productExportColumns = []string{
+	"id", "sku", "name", "description", "price", "currency", "compare_at_price", "stock_quantity", "category_id",
+	"image_url", "is_active", "attributes", "parent_id", "options", "option_values", "created_at", "updated_at",
+}
+
+// ExportProducts streams every product matching the filters of ListProducts, in ID order, as CSV or
+// NDJSON ("format", default ndjson). NDJSON rows are products as GetProductByID returns them; CSV
+// rows have productExportColumns. Prices follow currency and price_list_id as in ListProducts.
+//
+// Exports are not bound by the request timeouts: they run until the last product is written or a
+// write fails because the client went away. Should the store fail after the first rows were sent,
+// the response is aborted so that the client cannot take it for a complete export.
+func (h *HTTPHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	qParams := r.URL.Query()
+	format := qParams.Get("format")
+	if format == "" {
+		format = ProductImportNDJSON
+	}
+	if format != ProductImportCSV && format != ProductImportNDJSON {
+		respondWithError(w, http.StatusBadRequest, "Invalid format: must be csv or ndjson")
+		return
+	}
+	var params store.ListProductsParams
+	if errMsg := parseProductFilters(qParams, &params); errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	priceSelection, errMsg := parsePriceSelection(qParams)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	if errMsg := basePriceConflict(priceSelection, params, nil); errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	ctx := context.WithoutCancel(r.Context())
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{}) // Not supported by every ResponseWriter
+	out := newProductExportWriter(w, format)
+	started := false
+	err := h.productStore.ExportProducts(ctx, params, func(batch []domain.Product) error {
+		if err := h.prepareExportBatch(ctx, priceSelection, batch, format == ProductImportNDJSON); err != nil {
+			return err
+		}
+		if !started {
+			out.start()
+			started = true
+		}
+		return out.write(batch)
+	})
+	switch {
+	case err == nil && !started: // Nothing matched
+		out.start()
+		if err := out.write(nil); err != nil {
+			log.Printf("ERROR: ExportProducts failed to write: %v", err)
+		}
+	case err != nil && !started:
+		log.Printf("ERROR: ExportProducts failed: %v", err)
+		respondWithExportError(w, err)
+	case err != nil:
+		log.Printf("ERROR: ExportProducts stopped: %v", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// prepareExportBatch resolves the prices of a batch and, with media, attaches the product media,
+// like ListProducts does for a page.
+func (h *HTTPHandler) prepareExportBatch(ctx context.Context, sel store.PriceSelection, batch []domain.Product, withMedia bool) error {
+	if !sel.IsZero() {
+		if err := h.priceListStore.ResolvePrices(ctx, sel, batch); err != nil {
+			return err
+		}
+	}
+	if !withMedia {
+		return nil
+	}
+	productIDs := make([]int64, len(batch))
+	for i, p := range batch {
+		productIDs[i] = p.ID
+	}
+	media, err := h.productStore.ListMedia(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	for i := range batch {
+		batch[i].Media = media[batch[i].ID]
+	}
+	return nil
+}
+
+// respondWithExportError maps the errors of an export that failed before anything was sent to
+// responses.
+func respondWithExportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrInvalidAttributeFilter), errors.Is(err, store.ErrInvalidPriceSelection):
+		respondWithError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), "store: "))
+	case errors.Is(err, store.ErrPriceListNotFound):
+		respondWithError(w, http.StatusBadRequest, "Invalid price_list_id: price list does not exist.")
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Failed to export products")
+	}
+}
+
+// productExportWriter writes the products of an export in its format, flushing after each batch so
+// that the client receives them as they are read.
+type productExportWriter struct {
+	w    http.ResponseWriter
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+func newProductExportWriter(w http.ResponseWriter, format string) *productExportWriter {
+	if format == ProductImportCSV {
+		return &productExportWriter{w: w, csv: csv.NewWriter(w)}
+	}
+	return &productExportWriter{w: w, json: json.NewEncoder(w)}
+}
+
+// start sends the response headers and, for CSV, the header row.
+func (e *productExportWriter) start() {
+	if e.csv != nil {
+		e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		e.w.Header().Set("Content-Disposition", `attachment; filename="products.csv"`)
+		e.w.WriteHeader(http.StatusOK)
+		_ = e.csv.Write(productExportColumns) // Errors resurface on Flush
+		return
+	}
+	e.w.Header().Set("Content-Type", "application/x-ndjson")
+	e.w.Header().Set("Content-Disposition", `attachment; filename="products.ndjson"`)
+	e.w.WriteHeader(http.StatusOK)
+}
+
+func (e *productExportWriter) write(batch []domain.Product) error {
+	for i := range batch {
+		var err error
+		if e.csv != nil {
+			err = e.csv.Write(productExportRecord(&batch[i]))
+		} else {
+			err = e.json.Encode(&batch[i])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := http.NewResponseController(e.w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// productExportRecord returns the CSV record of p, in the order of productExportColumns.
+func productExportRecord(p *domain.Product) []string {
+	record := []string{
+		strconv.FormatInt(p.ID, 10), p.SKU, p.Name, "", p.Price.Decimal(), p.Price.Currency, "",
+		strconv.FormatInt(int64(p.StockQuantity), 10), "", "", strconv.FormatBool(p.IsActive), "", "", "", "",
+		p.CreatedAt.UTC().Format(time.RFC3339), p.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if p.Description != nil {
+		record[3] = *p.Description
+	}
+	if p.CompareAtPrice != nil {
+		record[6] = p.CompareAtPrice.Decimal()
+	}
+	if p.CategoryID != nil {
+		record[8] = strconv.FormatInt(*p.CategoryID, 10)
+	}
+	if p.ImageURL != nil {
+		record[9] = *p.ImageURL
+	}
+	if p.Attributes != nil {
+		record[11] = string(*p.Attributes)
+	}
+	if p.ParentID != nil {
+		record[12] = strconv.FormatInt(*p.ParentID, 10)
+	}
+	if len(p.Options) > 0 {
+		options, _ := json.Marshal(p.Options)
+		record[13] = string(options)
+	}
+	if len(p.OptionValues) > 0 {
+		optionValues, _ := json.Marshal(p.OptionValues)
+		record[14] = string(optionValues)
+	}
+	return record
+}
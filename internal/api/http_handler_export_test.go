@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getExport(t *testing.T, url string) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestHTTPHandler_ExportProducts(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	ctx := context.Background()
+	attributes := json.RawMessage(`{"color":"red"}`)
+	description := "Warm, with a \"fringe\""
+	scarf, err := memStore.CreateProduct(ctx, &domain.Product{
+		Name: "Scarf", SKU: "SCARF", Description: &description, Price: usd(1999), StockQuantity: 5, IsActive: true, Attributes: &attributes,
+	})
+	require.NoError(t, err)
+	_, err = memStore.CreateProduct(ctx, &domain.Product{Name: "Hat", SKU: "HAT", Price: usd(999), StockQuantity: 2})
+	require.NoError(t, err)
+	exportURL := server.URL + "/api/v1/products/export"
+
+	// NDJSON by default, one product per line, filtered like ListProducts.
+	resp, body := getExport(t, exportURL+"?is_active=true")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), `filename="products.ndjson"`)
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	var lines []domain.Product
+	for scanner.Scan() {
+		var p domain.Product
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &p))
+		lines = append(lines, p)
+	}
+	require.Len(t, lines, 1)
+	assert.Equal(t, scarf.ID, lines[0].ID)
+	assert.Equal(t, usd(1999), lines[0].Price)
+
+	// CSV with a header row, in ID order.
+	resp, body = getExport(t, exportURL+"?format=csv")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, productExportColumns, records[0])
+	assert.Equal(t, []string{"SCARF", "Scarf", description, "19.99", "USD"}, records[1][1:6])
+	assert.Equal(t, `{"color":"red"}`, records[1][11])
+	assert.Equal(t, []string{"HAT", "false"}, []string{records[2][1], records[2][10]})
+
+	// The CSV imports back unchanged.
+	status, report := postImport(t, server.URL+"/api/v1/products/import?dry_run=true", "text/csv", body)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, [3]int{0, 2, 0}, [3]int{report.Created, report.Updated, report.Failed}, report.Rows)
+
+	// Nothing matching still yields the header.
+	resp, body = getExport(t, exportURL+"?format=csv&q=umbrella")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, strings.Join(productExportColumns, ",")+"\n", body)
+
+	for _, query := range []string{"?format=xml", "?min_price=abc", "?attr.weight[gte]=heavy", "?price_list_id=999"} {
+		resp, _ := getExport(t, exportURL+query)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
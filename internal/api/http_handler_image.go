@@ -0,0 +1,295 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"product-catalog-service/internal/blob"
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/imaging"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Product Image Upload Handlers ---
+
+// ImageUploadConfig configures product image uploads (see EnableImageUploads).
+type ImageUploadConfig struct {
+	Storage       blob.Storage
+	MaxBytes      int64  // Largest accepted image file
+	ThumbnailSize int    // Longer side of generated thumbnails, in pixels
+	PublicBaseURL string // Prefix of image URLs, e.g. "https://catalog.example.com"; if empty, URLs are relative paths
+}
+
+// ProductImageUpload is the response to an image upload: the product media recorded for the image
+// and for its thumbnail.
+type ProductImageUpload struct {
+	Image     domain.ProductMedia `json:"image"`
+	Thumbnail domain.ProductMedia `json:"thumbnail"`
+}
+
+// imagesPath is where uploaded images are served, followed by their blob key.
+const imagesPath = "/api/v1/images/"
+
+// maxImagePixels bounds the decoded size of an upload, so that a small file cannot expand into a
+// huge image in memory.
+const maxImagePixels = 50_000_000
+
+// imageExtensions maps the accepted image types, as sniffed from the content, to file extensions.
+var imageExtensions = map[string]string{"image/jpeg": ".jpg", "image/png": ".png", "image/gif": ".gif"}
+
+// EnableImageUploads makes RegisterRoutes register the image upload endpoint and the endpoint
+// serving uploaded images.
+func (h *HTTPHandler) EnableImageUploads(cfg ImageUploadConfig) {
+	h.images = &cfg
+}
+
+// UploadProductImage stores a JPEG, PNG or GIF image sent as the "file" field of a multipart form,
+// generates a thumbnail, and adds both to the product's media with URLs served by ServeImage. The
+// optional "alt_text" and "role" (primary or gallery, the default) fields describe the image.
+func (h *HTTPHandler) UploadProductImage(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	upload, ok := h.readImageUpload(w, r)
+	if !ok {
+		return
+	}
+
+	contentType := http.DetectContentType(upload.data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		respondWithError(w, http.StatusUnsupportedMediaType, fmt.Sprintf("Unsupported image type %s: expected JPEG, PNG or GIF", contentType))
+		return
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(upload.data))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid image: "+err.Error())
+		return
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		respondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Image is too large: at most %d pixels are accepted", maxImagePixels))
+		return
+	}
+	img, _, err := image.Decode(bytes.NewReader(upload.data))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid image: "+err.Error())
+		return
+	}
+
+	if _, err := h.productStore.GetProductByID(r.Context(), productID); err != nil {
+		if errors.Is(err, store.ErrProductNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrProductNotFound.Error())
+		} else {
+			log.Printf("ERROR: GetProductByID store operation for ID %d failed: %v", productID, err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to upload image")
+		}
+		return
+	}
+
+	// Thumbnails of JPEG photos stay JPEG; PNG and GIF images may be transparent, so theirs are PNG.
+	thumb := imaging.Thumbnail(img, h.images.ThumbnailSize)
+	var thumbData bytes.Buffer
+	thumbExt := ".png"
+	if contentType == "image/jpeg" {
+		thumbExt = ".jpg"
+		err = jpeg.Encode(&thumbData, thumb, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(&thumbData, thumb)
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to encode thumbnail for product ID %d: %v", productID, err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to upload image")
+		return
+	}
+
+	// Keys are random, so the URL of an image never serves other content and can be cached forever.
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		log.Printf("ERROR: Failed to generate image key: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to upload image")
+		return
+	}
+	prefix := fmt.Sprintf("products/%d/%s", productID, hex.EncodeToString(name))
+	key, thumbKey := prefix+ext, prefix+"_thumb"+thumbExt
+	for _, b := range []struct {
+		key  string
+		data []byte
+	}{{key, upload.data}, {thumbKey, thumbData.Bytes()}} {
+		if err := h.images.Storage.Put(r.Context(), b.key, bytes.NewReader(b.data)); err != nil {
+			log.Printf("ERROR: Failed to store image %s: %v", b.key, err)
+			h.deleteImages(key, thumbKey)
+			respondWithError(w, http.StatusInternalServerError, "Failed to upload image")
+			return
+		}
+	}
+
+	baseURL := h.imageBaseURL()
+	width, height := int32(cfg.Width), int32(cfg.Height)
+	created, err := h.productStore.AddProductMedia(r.Context(), &domain.ProductMedia{
+		ProductID: productID, URL: baseURL + key, AltText: upload.altText, Role: upload.role, Width: &width, Height: &height,
+	})
+	if err != nil {
+		log.Printf("ERROR: AddProductMedia store operation for product ID %d failed: %v", productID, err)
+		h.deleteImages(key, thumbKey)
+		respondWithMediaError(w, err, "Failed to upload image")
+		return
+	}
+	thumbWidth, thumbHeight := int32(thumb.Bounds().Dx()), int32(thumb.Bounds().Dy())
+	createdThumb, err := h.productStore.AddProductMedia(r.Context(), &domain.ProductMedia{
+		ProductID: productID, URL: baseURL + thumbKey, AltText: upload.altText, Role: domain.MediaRoleThumbnail,
+		Width: &thumbWidth, Height: &thumbHeight,
+	})
+	if err != nil {
+		log.Printf("ERROR: AddProductMedia store operation for product ID %d failed: %v", productID, err)
+		if err := h.productStore.DeleteProductMedia(context.Background(), productID, created.ID); err != nil {
+			log.Printf("WARN: Failed to remove media ID %d of a failed upload: %v", created.ID, err)
+		}
+		h.deleteImages(key, thumbKey)
+		respondWithMediaError(w, err, "Failed to upload image")
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, ProductImageUpload{Image: *created, Thumbnail: *createdThumb})
+}
+
+// ServeImage serves an uploaded image or thumbnail by its blob key.
+func (h *HTTPHandler) ServeImage(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "*")
+	if !blob.ValidKey(key) {
+		respondWithError(w, http.StatusNotFound, "Image not found")
+		return
+	}
+	content, err := h.images.Storage.Open(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			respondWithError(w, http.StatusNotFound, "Image not found")
+		} else {
+			log.Printf("ERROR: Failed to open image %s: %v", key, err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve image")
+		}
+		return
+	}
+	defer content.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable") // Keys are never reused
+	if _, err := io.Copy(w, content); err != nil {
+		log.Printf("WARN: Failed to send image %s: %v", key, err)
+	}
+}
+
+// imageUpload holds the fields of an image upload form.
+type imageUpload struct {
+	data    []byte
+	altText string
+	role    domain.MediaRole
+}
+
+// readImageUpload reads the multipart form of an image upload, enforcing the size limit. It
+// reports whether it succeeded; otherwise it has written the error response.
+func (h *HTTPHandler) readImageUpload(w http.ResponseWriter, r *http.Request) (*imageUpload, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.images.MaxBytes+1<<20) // Room for the other fields and the multipart framing
+	reader, err := r.MultipartReader()
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Expected a multipart/form-data body: "+err.Error())
+		return nil, false
+	}
+	tooLarge := func() (*imageUpload, bool) {
+		respondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Image file is too large: at most %d bytes are accepted", h.images.MaxBytes))
+		return nil, false
+	}
+	upload := &imageUpload{role: domain.MediaRoleGallery}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return tooLarge()
+		} else if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid multipart body: "+err.Error())
+			return nil, false
+		}
+
+		limit := int64(255) // alt_text and role
+		if part.FormName() == "file" {
+			limit = h.images.MaxBytes
+		}
+		value, err := io.ReadAll(io.LimitReader(part, limit+1))
+		if errors.As(err, &maxBytesErr) {
+			return tooLarge()
+		} else if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid multipart body: "+err.Error())
+			return nil, false
+		}
+		switch part.FormName() {
+		case "file":
+			if int64(len(value)) > limit {
+				return tooLarge()
+			}
+			upload.data = value
+		case "alt_text":
+			if len(value) > int(limit) {
+				respondWithError(w, http.StatusBadRequest, "Validation failed: alt_text must be at most 255 bytes")
+				return nil, false
+			}
+			upload.altText = string(value)
+		case "role":
+			switch role := domain.MediaRole(strings.TrimSpace(string(value))); role {
+			case domain.MediaRolePrimary, domain.MediaRoleGallery:
+				upload.role = role
+			default:
+				respondWithError(w, http.StatusBadRequest, "Validation failed: role must be primary or gallery")
+				return nil, false
+			}
+		}
+	}
+	if len(upload.data) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: the file field is required")
+		return nil, false
+	}
+	return upload, true
+}
+
+// imageBaseURL returns the prefix of the URLs of uploaded images, up to their blob key. The URLs are
+// stored, so they never come from the request: its Host header is client-controlled, and behind a
+// TLS-terminating proxy neither it nor the scheme is what clients use. Without PublicBaseURL they
+// are paths, which clients resolve against the API's own address.
+func (h *HTTPHandler) imageBaseURL() string {
+	if h.images.PublicBaseURL == "" {
+		return imagesPath
+	}
+	return strings.TrimSuffix(h.images.PublicBaseURL, "/") + imagesPath
+}
+
+// deleteImages removes the blobs of a failed upload. It runs on a fresh context, as the request's
+// may be what failed.
+func (h *HTTPHandler) deleteImages(keys ...string) {
+	for _, key := range keys {
+		if err := h.images.Storage.Delete(context.Background(), key); err != nil {
+			log.Printf("WARN: Failed to delete image %s of a failed upload: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"product-catalog-service/internal/blob"
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postImage(t *testing.T, url string, file []byte, fields map[string]string) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, form.WriteField(name, value))
+	}
+	if file != nil {
+		part, err := form.CreateFormFile("file", "upload")
+		require.NoError(t, err)
+		_, err = part.Write(file)
+		require.NoError(t, err)
+	}
+	require.NoError(t, form.Close())
+	resp, err := http.Post(url, form.FormDataContentType(), &body)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestHTTPHandler_UploadProductImage(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	storage, err := blob.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	handler := NewHTTPHandler(memStore, memStore, memStore, memStore, NewPageTokenCodec([]byte("test-secret")))
+	handler.EnableImageUploads(ImageUploadConfig{Storage: storage, MaxBytes: 64 << 10, ThumbnailSize: 40})
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	scarf, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), IsActive: true})
+	require.NoError(t, err)
+	uploadURL := fmt.Sprintf("%s/api/v1/products/%d/images", server.URL, scarf.ID)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	for x := 0; x < 200; x++ {
+		for y := 0; y < 100; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: 128, A: 255})
+		}
+	}
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, img))
+
+	resp := postImage(t, uploadURL, pngData.Bytes(), map[string]string{"alt_text": "Red scarf", "role": "primary"})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var upload ProductImageUpload
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&upload))
+	assert.Equal(t, domain.MediaRolePrimary, upload.Image.Role)
+	assert.Equal(t, "Red scarf", upload.Image.AltText)
+	assert.Equal(t, int32(200), *upload.Image.Width)
+	assert.Equal(t, domain.MediaRoleThumbnail, upload.Thumbnail.Role)
+	assert.Equal(t, int32(40), *upload.Thumbnail.Width)
+	assert.Equal(t, int32(20), *upload.Thumbnail.Height)
+	assert.True(t, strings.HasPrefix(upload.Image.URL, "/api/v1/images/products/"), upload.Image.URL)
+
+	var product domain.Product
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/products/%d", server.URL, scarf.ID), &product))
+	assert.Equal(t, upload.Image.URL, *product.ImageURL, "recorded on the product")
+	assert.Len(t, product.Media, 2)
+
+	// Both are served from their URL.
+	for _, m := range []domain.ProductMedia{upload.Image, upload.Thumbnail} {
+		resp, err := http.Get(server.URL + m.URL)
+		require.NoError(t, err)
+		served, err := png.Decode(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, int(*m.Width), served.Bounds().Dx())
+	}
+	resp, err = http.Get(server.URL + "/api/v1/images/products/1/missing.png")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	for name, tc := range map[string]struct {
+		file   []byte
+		fields map[string]string
+		status int
+	}{
+		"not an image":    {[]byte("<html>hello</html>"), nil, http.StatusUnsupportedMediaType},
+		"truncated image": {pngData.Bytes()[:100], nil, http.StatusBadRequest},
+		"too large":       {append(pngData.Bytes(), make([]byte, 64<<10)...), nil, http.StatusRequestEntityTooLarge},
+		"no file":         {nil, map[string]string{"alt_text": "x"}, http.StatusBadRequest},
+		"thumbnail role":  {pngData.Bytes(), map[string]string{"role": "thumbnail"}, http.StatusBadRequest},
+	} {
+		resp := postImage(t, uploadURL, tc.file, tc.fields)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, tc.status, resp.StatusCode, name)
+	}
+	resp = postImage(t, server.URL+"/api/v1/products/99/images", pngData.Bytes(), nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	media, err := memStore.ListProductMedia(context.Background(), scarf.ID)
+	require.NoError(t, err)
+	assert.Len(t, media, 2, "failed uploads record nothing")
+}
+
+func TestHTTPHandler_UploadProductImage_IgnoresHost(t *testing.T) {
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, image.NewNRGBA(image.Rect(0, 0, 8, 8))))
+
+	for _, tc := range []struct {
+		publicBaseURL string
+		wantPrefix    string
+	}{
+		{"", "/api/v1/images/products/"},
+		{"https://catalog.example.com/", "https://catalog.example.com/api/v1/images/products/"},
+	} {
+		memStore := store.NewMemoryStore()
+		storage, err := blob.NewLocalStorage(t.TempDir())
+		require.NoError(t, err)
+		handler := NewHTTPHandler(memStore, memStore, memStore, memStore, NewPageTokenCodec([]byte("test-secret")))
+		handler.EnableImageUploads(ImageUploadConfig{Storage: storage, MaxBytes: 64 << 10, ThumbnailSize: 4, PublicBaseURL: tc.publicBaseURL})
+		router := chi.NewRouter()
+		handler.RegisterRoutes(router)
+		server := httptest.NewServer(router)
+		scarf, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), IsActive: true})
+		require.NoError(t, err)
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("file", "upload")
+		require.NoError(t, err)
+		_, err = part.Write(pngData.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/products/%d/images", server.URL, scarf.ID), &body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Host = "attacker.example"
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		media, err := memStore.ListProductMedia(context.Background(), scarf.ID)
+		require.NoError(t, err)
+		require.Len(t, media, 2)
+		for _, m := range media {
+			assert.True(t, strings.HasPrefix(m.URL, tc.wantPrefix), "persisted URL %s", m.URL)
+			assert.NotContains(t, m.URL, "attacker.example")
+		}
+		server.Close()
+	}
+}
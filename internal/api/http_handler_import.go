@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// --- Product Import Handlers ---
+
+// maxProductImportBytes bounds the body of an import request.
+const maxProductImportBytes = 32 << 20
+
+// ImportProducts creates or updates products by SKU from a CSV or NDJSON body and responds with a
+// report of every row. The format is the "format" query parameter, or else follows the
+// Content-Type (text/csv or application/x-ndjson). Repeated "map" parameters of the form
+// Column=field map CSV columns to product fields, and "dry_run=true" only validates.
+func (h *HTTPHandler) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := ProductImportOptions{Format: query.Get("format")}
+	if opts.Format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/csv":
+			opts.Format = ProductImportCSV
+		case "application/x-ndjson", "application/ndjson":
+			opts.Format = ProductImportNDJSON
+		default:
+			respondWithError(w, http.StatusUnsupportedMediaType, "Send text/csv or application/x-ndjson, or set the format parameter")
+			return
+		}
+	}
+	if v := query.Get("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid dry_run value")
+			return
+		}
+		opts.DryRun = dryRun
+	}
+	for _, mapping := range query["map"] {
+		i := strings.LastIndex(mapping, "=") // Field names have no "=", column names might
+		if i <= 0 {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid map value %q: expected Column=field", mapping))
+			return
+		}
+		if opts.Columns == nil {
+			opts.Columns = make(map[string]string)
+		}
+		opts.Columns[mapping[:i]] = mapping[i+1:]
+	}
+	defer r.Body.Close()
+	body := http.MaxBytesReader(w, r.Body, maxProductImportBytes)
+
+	importer := &ProductImporter{productStore: h.productStore, validate: h.validate}
+	report, err := importer.Import(requestContext(r), body, opts)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		var stopped *ImportStoppedError
+		switch {
+		case errors.As(err, &tooLarge):
+			respondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Import too large: at most %d bytes", maxProductImportBytes))
+		case errors.Is(err, ErrInvalidImport):
+			respondWithError(w, http.StatusBadRequest, "Invalid import: "+strings.TrimPrefix(err.Error(), ErrInvalidImport.Error()+": "))
+		case errors.As(err, &stopped):
+			log.Printf("ERROR: ImportProducts failed: %v", err)
+			respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Import failed: rows from line %d on were not imported", stopped.Line))
+		default:
+			log.Printf("ERROR: ImportProducts failed: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Import failed")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
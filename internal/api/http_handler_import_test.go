@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postImport(t *testing.T, url, contentType, body string) (int, ProductImportReport) {
+	t.Helper()
+	resp, err := http.Post(url, contentType, strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var report ProductImportReport
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	}
+	return resp.StatusCode, report
+}
+
+func TestHTTPHandler_ImportProducts(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	scarf, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), StockQuantity: 5, IsActive: true})
+	require.NoError(t, err)
+	importURL := server.URL + "/api/v1/products/import"
+
+	csvBody := "Item Code,Title,price,currency,stock_quantity,is_active,Supplier\n" +
+		"SCARF,Red Scarf,24.99,USD,7,true,Acme\n" +
+		"HAT,Hat,9.99,,3,,Acme\n" +
+		"GLOVES,,14.99,USD,2,true,Acme\n" +
+		"SOCKS,Socks,abc,USD,1,true,Acme\n" +
+		"BELT,Belt\n"
+	mapping := "?map=Item%20Code%3Dsku&map=Title%3Dname"
+
+	// A dry run reports what would happen without writing.
+	status, report := postImport(t, importURL+mapping+"&dry_run=true", "text/csv", csvBody)
+	require.Equal(t, http.StatusOK, status)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, [3]int{1, 1, 3}, [3]int{report.Created, report.Updated, report.Failed})
+	require.Len(t, report.Rows, 5)
+	assert.Equal(t, ProductImportRow{Line: 2, SKU: "SCARF", Status: ImportRowUpdated, ProductID: scarf.ID}, report.Rows[0])
+	assert.Equal(t, ProductImportRow{Line: 3, SKU: "HAT", Status: ImportRowCreated}, report.Rows[1])
+	assert.Equal(t, ImportRowFailed, report.Rows[2].Status)
+	assert.Contains(t, report.Rows[2].Error, "Name", "validated like ProductCreateInput")
+	assert.Contains(t, report.Rows[3].Error, "price")
+	assert.Equal(t, ProductImportRow{Line: 6, SKU: "BELT", Status: ImportRowFailed, Error: "Invalid row: expected 7 fields, found 2"}, report.Rows[4])
+	stored, err := memStore.GetProductByID(context.Background(), scarf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Scarf", stored.Name)
+
+	status, report = postImport(t, importURL+mapping, "text/csv", csvBody)
+	require.Equal(t, http.StatusOK, status)
+	assert.False(t, report.DryRun)
+	assert.Equal(t, [3]int{1, 1, 3}, [3]int{report.Created, report.Updated, report.Failed})
+	stored, err = memStore.GetProductByID(context.Background(), scarf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Red Scarf", stored.Name)
+	assert.Equal(t, usd(2499), stored.Price)
+	hat, err := memStore.GetProductByID(context.Background(), report.Rows[1].ProductID)
+	require.NoError(t, err)
+	assert.Equal(t, "HAT", hat.SKU)
+	assert.True(t, hat.IsActive, "defaults like CreateProduct")
+	assert.Equal(t, int32(3), hat.StockQuantity)
+
+	// NDJSON rows are ProductCreateInput bodies; the store's checks are reported per row.
+	ndjson := `{"name":"Hat","sku":"HAT","price":{"amount":"12.50","currency":"USD"},"stock_quantity":4}` + "\n\n" +
+		`{"name":"Mittens","sku":"MITTENS","price":"5.00","stock_quantity":1,"category_id":99}` + "\n" +
+		`{"name": "broken"` + "\n"
+	status, report = postImport(t, importURL, "application/x-ndjson", ndjson)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, report.Rows, 3)
+	assert.Equal(t, ProductImportRow{Line: 1, SKU: "HAT", Status: ImportRowUpdated, ProductID: hat.ID}, report.Rows[0])
+	assert.Equal(t, ProductImportRow{Line: 3, SKU: "MITTENS", Status: ImportRowFailed, Error: "Invalid category_id: category does not exist."}, report.Rows[1])
+	assert.Equal(t, 4, report.Rows[2].Line)
+	assert.Contains(t, report.Rows[2].Error, "Invalid row")
+
+	for name, tc := range map[string]struct {
+		query, contentType string
+		status             int
+	}{
+		"unknown field":       {"?map=Title%3Dtitle", "text/csv", http.StatusBadRequest},
+		"missing column":      {"?map=Label%3Dname", "text/csv", http.StatusBadRequest},
+		"mapping for ndjson":  {"?format=ndjson&map=Title%3Dname", "text/csv", http.StatusBadRequest},
+		"unknown format":      {"?format=xml", "text/csv", http.StatusBadRequest},
+		"unknown media type":  {"", "application/json", http.StatusUnsupportedMediaType},
+		"invalid dry_run":     {"?dry_run=maybe", "text/csv", http.StatusBadRequest},
+		"malformed map value": {"?map=name", "text/csv", http.StatusBadRequest},
+	} {
+		status, _ := postImport(t, importURL+tc.query, tc.contentType, "Title,sku\nHat,HAT\n")
+		assert.Equal(t, tc.status, status, name)
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Location Handlers ---
+
+// LocationInput defines the expected input for creating or updating a location.
+type LocationInput struct {
+	Code     string `json:"code" validate:"required,max=64"`  // Max length from DB schema
+	Name     string `json:"name" validate:"required,max=255"` // Max length from DB schema
+	Priority int32  `json:"priority"`                         // Lower ships first
+}
+
+func (h *HTTPHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var input LocationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	location := &domain.Location{Code: input.Code, Name: input.Name, Priority: input.Priority}
+	createdLocation, err := h.locationStore.CreateLocation(r.Context(), location)
+	if err != nil {
+		log.Printf("ERROR: CreateLocation store operation failed: %v", err)
+		if errors.Is(err, store.ErrLocationCodeExists) {
+			respondWithError(w, http.StatusConflict, store.ErrLocationCodeExists.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to create location")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, createdLocation)
+}
+
+// ListLocations returns every location in allocation order. There are few enough that the list is
+// not paginated.
+func (h *HTTPHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.locationStore.ListLocations(r.Context())
+	if err != nil {
+		log.Printf("ERROR: ListLocations store operation failed: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve locations")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, locations)
+}
+
+func (h *HTTPHandler) GetLocationByID(w http.ResponseWriter, r *http.Request) {
+	locationID, ok := parseLocationID(w, r)
+	if !ok {
+		return
+	}
+
+	location, err := h.locationStore.GetLocationByID(r.Context(), locationID)
+	if err != nil {
+		log.Printf("ERROR: GetLocationByID store operation for ID %d failed: %v", locationID, err)
+		if errors.Is(err, store.ErrLocationNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrLocationNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve location")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, location)
+}
+
+func (h *HTTPHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	locationID, ok := parseLocationID(w, r)
+	if !ok {
+		return
+	}
+
+	var input LocationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	location := &domain.Location{ID: locationID, Code: input.Code, Name: input.Name, Priority: input.Priority}
+	updatedLocation, err := h.locationStore.UpdateLocation(r.Context(), location)
+	if err != nil {
+		log.Printf("ERROR: UpdateLocation store operation for ID %d failed: %v", locationID, err)
+		if errors.Is(err, store.ErrLocationNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrLocationNotFound.Error())
+		} else if errors.Is(err, store.ErrLocationCodeExists) {
+			respondWithError(w, http.StatusConflict, store.ErrLocationCodeExists.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to update location")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, updatedLocation)
+}
+
+func (h *HTTPHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	locationID, ok := parseLocationID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.locationStore.DeleteLocation(r.Context(), locationID); err != nil {
+		log.Printf("ERROR: DeleteLocation store operation for ID %d failed: %v", locationID, err)
+		if errors.Is(err, store.ErrLocationNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrLocationNotFound.Error())
+		} else if errors.Is(err, store.ErrLocationInUse) {
+			respondWithError(w, http.StatusConflict, "Location still holds stock; transfer it first")
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to delete location")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusNoContent, nil)
+}
+
+// --- Per-location Stock Handlers ---
+
+// StockTransferInput defines the expected input for moving stock between two locations.
+type StockTransferInput struct {
+	FromLocationID int64   `json:"from_location_id" validate:"required,gt=0"`
+	ToLocationID   int64   `json:"to_location_id" validate:"required,gt=0,nefield=FromLocationID"`
+	Quantity       int32   `json:"quantity" validate:"required,gt=0"`
+	Note           *string `json:"note" validate:"omitempty,max=1000"`
+}
+
+// GetStockLevels returns the stock of a product per location, in allocation order.
+func (h *HTTPHandler) GetStockLevels(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "productId")
+	productID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || productID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID format")
+		return
+	}
+
+	if _, err := h.productStore.GetProductByID(r.Context(), productID); err != nil {
+		log.Printf("ERROR: GetStockLevels product lookup for ID %d failed: %v", productID, err)
+		if errors.Is(err, store.ErrProductNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrProductNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve product")
+		}
+		return
+	}
+	levels, err := h.locationStore.ListLocationStock(r.Context(), []int64{productID})
+	if err != nil {
+		log.Printf("ERROR: GetStockLevels store operation for ID %d failed: %v", productID, err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve stock levels")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, levels)
+}
+
+func (h *HTTPHandler) TransferStock(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "productId")
+	productID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || productID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID format")
+		return
+	}
+
+	var input StockTransferInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	info := store.StockMovementInfo{Reason: domain.StockReasonTransfer, Note: input.Note}
+	levels, err := h.locationStore.TransferStock(requestContext(r), productID, input.FromLocationID, input.ToLocationID, input.Quantity, info)
+	if err != nil {
+		log.Printf("ERROR: TransferStock store operation for ID %d failed: %v", productID, err)
+		if errors.Is(err, store.ErrProductNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrProductNotFound.Error())
+		} else if errors.Is(err, store.ErrLocationNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrLocationNotFound.Error())
+		} else if errors.Is(err, store.ErrInsufficientStock) {
+			respondWithError(w, http.StatusConflict, "Not enough stock at the source location")
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to transfer stock")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, levels)
+}
+
+// parseLocationID reads the locationId URL parameter, answering 400 if it is not a positive integer.
+func parseLocationID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	locationID, err := strconv.ParseInt(chi.URLParam(r, "locationId"), 10, 64)
+	if err != nil || locationID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID format")
+		return 0, false
+	}
+	return locationID, true
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupMemoryTestServer serves the HTTP API backed entirely by memStore.
+func setupMemoryTestServer(t *testing.T, memStore *store.MemoryStore) *httptest.Server {
+	t.Helper()
+	router := chi.NewRouter()
+	NewHTTPHandler(memStore, memStore, memStore, memStore, NewPageTokenCodec([]byte("test-secret"))).RegisterRoutes(router)
+	return httptest.NewServer(router)
+}
+
+func postJSON(t *testing.T, url string, payload interface{}) *http.Response {
+	t.Helper()
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	return resp
+}
+
+func TestHTTPHandler_LocationsAndTransfers(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	_, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Widget", SKU: "W-1", Price: usd(500), StockQuantity: 10, IsActive: true})
+	require.NoError(t, err)
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+
+	resp := postJSON(t, server.URL+"/api/v1/locations", LocationInput{Code: "east", Name: "East", Priority: 1})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var east domain.Location
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&east))
+	resp.Body.Close()
+
+	resp = postJSON(t, server.URL+"/api/v1/locations", LocationInput{Code: "east", Name: "Duplicate"})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = postJSON(t, server.URL+"/api/v1/products/1/stock-transfers", StockTransferInput{FromLocationID: 1, ToLocationID: east.ID, Quantity: 11})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp = postJSON(t, server.URL+"/api/v1/products/1/stock-transfers", StockTransferInput{FromLocationID: 1, ToLocationID: 1, Quantity: 1})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = postJSON(t, server.URL+"/api/v1/products/1/stock-transfers", StockTransferInput{FromLocationID: 1, ToLocationID: east.ID, Quantity: 3})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/api/v1/products/1/stock-levels")
+	require.NoError(t, err)
+	var levels []domain.LocationStock
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&levels))
+	resp.Body.Close()
+	require.Len(t, levels, 2)
+	assert.Equal(t, int32(7), levels[0].Quantity)
+	assert.Equal(t, int32(3), levels[1].Quantity)
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/v1/locations/%d", server.URL, east.ID), nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "the location still holds stock")
+
+	resp = postJSON(t, server.URL+"/api/v1/products/1/stock-adjustments", StockAdjustmentInput{QuantityChange: -3, LocationID: &east.ID})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Product Media Handlers ---
+
+// ProductMediaInput defines the expected input for adding or replacing a product image.
+type ProductMediaInput struct {
+	URL      string           `json:"url" validate:"required,url,max=2048"`
+	AltText  string           `json:"alt_text" validate:"max=255"`
+	Role     domain.MediaRole `json:"role" validate:"omitempty,oneof=primary gallery thumbnail"` // Defaults to gallery
+	Position int32            `json:"position" validate:"gte=0"`                                 // 0 or omitted: after the others (on update: unchanged)
+	Width    *int32           `json:"width" validate:"omitempty,gt=0"`
+	Height   *int32           `json:"height" validate:"omitempty,gt=0"`
+}
+
+// ListProductMedia returns the images of a product in display order.
+func (h *HTTPHandler) ListProductMedia(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	media, err := h.productStore.ListProductMedia(r.Context(), productID)
+	if err != nil {
+		log.Printf("ERROR: ListProductMedia store operation for product ID %d failed: %v", productID, err)
+		respondWithMediaError(w, err, "Failed to retrieve product media")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, struct {
+		Data []domain.ProductMedia `json:"data"`
+	}{media})
+}
+
+// AddProductMedia adds an image to a product. A primary image replaces the previous one, which
+// stays as a gallery image, and becomes the product's image_url.
+func (h *HTTPHandler) AddProductMedia(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	m, ok := h.decodeProductMediaInput(w, r)
+	if !ok {
+		return
+	}
+	m.ProductID = productID
+	created, err := h.productStore.AddProductMedia(r.Context(), m)
+	if err != nil {
+		log.Printf("ERROR: AddProductMedia store operation for product ID %d failed: %v", productID, err)
+		respondWithMediaError(w, err, "Failed to add product media")
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+// UpdateProductMedia replaces the fields of a product image.
+func (h *HTTPHandler) UpdateProductMedia(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	mediaID, ok := parseMediaID(w, r)
+	if !ok {
+		return
+	}
+	m, ok := h.decodeProductMediaInput(w, r)
+	if !ok {
+		return
+	}
+	m.ID, m.ProductID = mediaID, productID
+	updated, err := h.productStore.UpdateProductMedia(r.Context(), m)
+	if err != nil {
+		log.Printf("ERROR: UpdateProductMedia store operation for media ID %d failed: %v", mediaID, err)
+		respondWithMediaError(w, err, "Failed to update product media")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// DeleteProductMedia removes a product image. The product's image_url falls back to the next
+// image, or is cleared with the last one.
+func (h *HTTPHandler) DeleteProductMedia(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	mediaID, ok := parseMediaID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.productStore.DeleteProductMedia(r.Context(), productID, mediaID); err != nil {
+		log.Printf("ERROR: DeleteProductMedia store operation for media ID %d failed: %v", mediaID, err)
+		respondWithMediaError(w, err, "Failed to delete product media")
+		return
+	}
+	respondWithJSON(w, http.StatusNoContent, nil)
+}
+
+func (h *HTTPHandler) decodeProductMediaInput(w http.ResponseWriter, r *http.Request) (*domain.ProductMedia, bool) {
+	var input ProductMediaInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return nil, false
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return nil, false
+	}
+	role := input.Role
+	if role == "" {
+		role = domain.MediaRoleGallery
+	}
+	return &domain.ProductMedia{
+		URL: input.URL, AltText: input.AltText, Role: role, Position: input.Position, Width: input.Width, Height: input.Height,
+	}, true
+}
+
+// attachMedia sets the images of products and of their variants. It reports whether it succeeded;
+// otherwise it has written the error response.
+func (h *HTTPHandler) attachMedia(w http.ResponseWriter, r *http.Request, products []domain.Product) bool {
+	var productIDs []int64
+	for _, p := range products {
+		productIDs = append(productIDs, p.ID)
+		for _, v := range p.Variants {
+			productIDs = append(productIDs, v.ID)
+		}
+	}
+	if len(productIDs) == 0 {
+		return true
+	}
+	media, err := h.productStore.ListMedia(r.Context(), productIDs)
+	if err != nil {
+		log.Printf("ERROR: ListMedia store operation failed: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve product media")
+		return false
+	}
+	for i := range products {
+		products[i].Media = media[products[i].ID]
+		for j := range products[i].Variants {
+			products[i].Variants[j].Media = media[products[i].Variants[j].ID]
+		}
+	}
+	return true
+}
+
+// respondWithMediaError maps the errors of the media methods to responses.
+func respondWithMediaError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, store.ErrProductNotFound):
+		respondWithError(w, http.StatusNotFound, store.ErrProductNotFound.Error())
+	case errors.Is(err, store.ErrMediaNotFound):
+		respondWithError(w, http.StatusNotFound, store.ErrMediaNotFound.Error())
+	case errors.Is(err, store.ErrInvalidMedia):
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+strings.TrimPrefix(err.Error(), "store: "))
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func parseMediaID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	mediaID, err := strconv.ParseInt(chi.URLParam(r, "mediaId"), 10, 64)
+	if err != nil || mediaID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid media ID format")
+		return 0, false
+	}
+	return mediaID, true
+}
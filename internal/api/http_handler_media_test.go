@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_ProductMedia(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	scarf, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), IsActive: true})
+	require.NoError(t, err)
+	mediaURL := fmt.Sprintf("%s/api/v1/products/%d/media", server.URL, scarf.ID)
+
+	add := func(payload map[string]interface{}) (int, domain.ProductMedia) {
+		resp := postJSON(t, mediaURL, payload)
+		defer resp.Body.Close()
+		var m domain.ProductMedia
+		if resp.StatusCode == http.StatusCreated {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&m))
+		}
+		return resp.StatusCode, m
+	}
+	status, front := add(map[string]interface{}{"url": "https://cdn.example.com/front.jpg", "alt_text": "Front", "width": 1200, "height": 800})
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, domain.MediaRoleGallery, front.Role, "the default role")
+	status, side := add(map[string]interface{}{"url": "https://cdn.example.com/side.jpg", "role": "primary"})
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, int32(2), side.Position)
+
+	for _, bad := range []map[string]interface{}{
+		{"url": "not a url"},
+		{"url": "https://cdn.example.com/x.jpg", "role": "banner"},
+		{"url": "https://cdn.example.com/x.jpg", "width": 0, "height": -1},
+	} {
+		status, _ := add(bad)
+		assert.Equal(t, http.StatusBadRequest, status, bad)
+	}
+
+	var product domain.Product
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/products/%d", server.URL, scarf.ID), &product))
+	require.Len(t, product.Media, 2)
+	assert.Equal(t, "Front", product.Media[0].AltText)
+	assert.Equal(t, "https://cdn.example.com/side.jpg", *product.ImageURL, "the primary image")
+
+	// Moving the front view last.
+	resp := putJSON(t, fmt.Sprintf("%s/%d", mediaURL, front.ID), map[string]interface{}{"url": front.URL, "alt_text": "Front", "position": 3})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var list struct {
+		Data []domain.ProductMedia `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, mediaURL, &list))
+	require.Len(t, list.Data, 2)
+	assert.Equal(t, side.ID, list.Data[0].ID)
+	assert.Equal(t, domain.MediaRolePrimary, list.Data[0].Role)
+	resp = putJSON(t, mediaURL+"/99", map[string]interface{}{"url": side.URL})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", mediaURL, side.ID), nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	var page struct {
+		Data []domain.Product `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/products", &page))
+	require.Len(t, page.Data, 1)
+	require.Len(t, page.Data[0].Media, 1)
+	assert.Equal(t, "https://cdn.example.com/front.jpg", *page.Data[0].ImageURL, "the first gallery image")
+	assert.Equal(t, http.StatusNotFound, getJSON(t, server.URL+"/api/v1/products/99/media", &list))
+}
+
+func TestGRPCHandler_GetProductDetails_Media(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	width := int32(640)
+	_, err := memStore.AddProductMedia(ctx, &domain.ProductMedia{
+		ProductID: 1, URL: "https://cdn.example.com/keyboard.jpg", AltText: "Keyboard", Role: domain.MediaRolePrimary, Width: &width,
+	})
+	require.NoError(t, err)
+
+	details, err := handler.GetProductDetails(ctx, &productpb.GetProductDetailsRequest{ProductId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/keyboard.jpg", details.GetProduct().GetImageUrl())
+	require.Len(t, details.GetProduct().GetMedia(), 1)
+	media := details.GetProduct().GetMedia()[0]
+	assert.Equal(t, "primary", media.GetRole())
+	assert.Equal(t, int32(1), media.GetPosition())
+	assert.Equal(t, int32(640), media.GetWidth())
+	assert.False(t, media.Height != nil)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+)
+
+// --- Price History Handlers ---
+
+// GetPriceHistory returns a product's price changes, latest effective first. from and to bound the
+// time the changes took effect.
+func (h *HTTPHandler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+
+	qParams := r.URL.Query()
+	limit, err := strconv.Atoi(qParams.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	page, err := strconv.Atoi(qParams.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	params := store.ListPriceChangesParams{ProductID: productID, Limit: limit, Offset: (page - 1) * limit}
+
+	if fromStr := qParams.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid from: must be an RFC 3339 timestamp")
+			return
+		}
+		params.From = &from
+	}
+	if toStr := qParams.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid to: must be an RFC 3339 timestamp")
+			return
+		}
+		params.To = &to
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		respondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	if _, err := getProduct(r.Context(), h.productStore, productID, true); err != nil {
+		log.Printf("ERROR: Product for price history (ID %d) not found: %v", productID, err)
+		if errors.Is(err, store.ErrProductNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrProductNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Error checking product existence")
+		}
+		return
+	}
+
+	changes, totalCount, err := h.productStore.ListPriceChanges(r.Context(), params)
+	if err != nil {
+		log.Printf("ERROR: ListPriceChanges store operation for ID %d failed: %v", productID, err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve price history")
+		return
+	}
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + limit - 1) / limit
+	}
+	response := struct {
+		Data       []domain.PriceChange `json:"data"`
+		Pagination struct {
+			Page       int `json:"page"`
+			Limit      int `json:"limit"`
+			TotalItems int `json:"total_items"`
+			TotalPages int `json:"total_pages"`
+		} `json:"pagination"`
+	}{Data: changes}
+	response.Pagination.Page = page
+	response.Pagination.Limit = limit
+	response.Pagination.TotalItems = totalCount
+	response.Pagination.TotalPages = totalPages
+	respondWithJSON(w, http.StatusOK, response)
+}
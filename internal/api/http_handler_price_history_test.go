@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestHTTPHandler_PriceHistory(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	scarf, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), IsActive: true})
+	require.NoError(t, err)
+	historyURL := fmt.Sprintf("%s/api/v1/products/%d/price-history", server.URL, scarf.ID)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"price": map[string]string{"amount": "9.99", "currency": "USD"}, "valid_from": time.Now().UTC().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/products/%d/scheduled-prices", server.URL, scarf.ID), bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("X-Actor", "pricing-team")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var history struct {
+		Data       []domain.PriceChange `json:"data"`
+		Pagination struct {
+			TotalItems int `json:"total_items"`
+		} `json:"pagination"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, historyURL+"?limit=1", &history))
+	assert.Equal(t, 2, history.Pagination.TotalItems, "the initial price and the sale")
+	require.Len(t, history.Data, 1)
+	sale := history.Data[0]
+	assert.Equal(t, domain.PriceReasonScheduledPrice, sale.Reason, "the sale takes effect last")
+	assert.Equal(t, usd(1999), *sale.OldPrice)
+	assert.Equal(t, usd(999), sale.NewPrice)
+	require.NotNil(t, sale.Actor)
+	assert.Equal(t, "pricing-team", *sale.Actor)
+
+	require.Equal(t, http.StatusOK, getJSON(t, historyURL+"?to="+time.Now().UTC().Format(time.RFC3339Nano), &history))
+	assert.Equal(t, 1, history.Pagination.TotalItems, "only the initial price took effect already")
+
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, historyURL+"?from=yesterday", &history))
+	assert.Equal(t, http.StatusNotFound, getJSON(t, server.URL+"/api/v1/products/99/price-history", &history))
+}
+
+func TestGRPCHandler_CheckProductsAvailability_PriceAt(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	quotedAt := time.Now()
+
+	mouse, err := memStore.GetProductByID(ctx, 2)
+	require.NoError(t, err)
+	mouse.Price = usd(2499)
+	_, err = memStore.UpdateProduct(ctx, mouse)
+	require.NoError(t, err)
+
+	availability, err := handler.CheckProductsAvailability(ctx, &productpb.CheckProductsAvailabilityRequest{
+		Items:   []*productpb.ProductAvailabilityItemInput{{ProductId: 2, RequiredQuantity: 1}, {ProductId: 99, RequiredQuantity: 1}},
+		PriceAt: timestamppb.New(quotedAt),
+	})
+	require.NoError(t, err)
+	require.Len(t, availability.GetStatuses(), 2)
+	assert.Equal(t, int64(2499), availability.GetStatuses()[0].GetCurrentPriceMoney().GetAmountMinor())
+	assert.Equal(t, int64(1999), availability.GetStatuses()[0].GetPriceAtMoney().GetAmountMinor(), "the price when it was quoted")
+	assert.Nil(t, availability.GetStatuses()[1].PriceAtMoney)
+
+	_, err = handler.CheckProductsAvailability(ctx, &productpb.CheckProductsAvailabilityRequest{
+		Items:    []*productpb.ProductAvailabilityItemInput{{ProductId: 2, RequiredQuantity: 1}},
+		PriceAt:  timestamppb.New(quotedAt),
+		Currency: PtrTo("USD"),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "price lists have no history")
+}
@@ -0,0 +1,317 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxPriceListEntryBatch is the most entries one request may write or delete.
+const maxPriceListEntryBatch = 1000
+
+// --- Price List Handlers ---
+
+// PriceListInput defines the expected input for creating or updating a price list.
+type PriceListInput struct {
+	Name          string  `json:"name" validate:"required,max=255"`           // Max length from DB schema
+	Currency      string  `json:"currency" validate:"required,len=3"`         // ISO 4217 code
+	Market        *string `json:"market" validate:"omitempty,max=64"`         // Max length from DB schema
+	CustomerGroup *string `json:"customer_group" validate:"omitempty,max=64"` // Max length from DB schema
+	IsDefault     bool    `json:"is_default"`                                 // Fallback list of the currency
+}
+
+// PriceListEntriesInput defines the expected input for writing price list entries in bulk.
+type PriceListEntriesInput struct {
+	Entries []PriceListEntryInput `json:"entries" validate:"required,min=1,max=1000,dive"`
+}
+
+// PriceListEntryInput is the price of one product in a price list.
+type PriceListEntryInput struct {
+	ProductID int64        `json:"product_id" validate:"required,gt=0"`
+	Price     domain.Money `json:"price"` // Must be in the currency of the list
+}
+
+func (h *HTTPHandler) CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	list, ok := h.decodePriceListInput(w, r)
+	if !ok {
+		return
+	}
+	created, err := h.priceListStore.CreatePriceList(r.Context(), list)
+	if err != nil {
+		log.Printf("ERROR: CreatePriceList store operation failed: %v", err)
+		respondWithPriceListError(w, err, "Failed to create price list")
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+// ListPriceLists returns every price list ordered by name. There are few enough that the list is
+// not paginated.
+func (h *HTTPHandler) ListPriceLists(w http.ResponseWriter, r *http.Request) {
+	lists, err := h.priceListStore.ListPriceLists(r.Context())
+	if err != nil {
+		log.Printf("ERROR: ListPriceLists store operation failed: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve price lists")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, lists)
+}
+
+func (h *HTTPHandler) GetPriceListByID(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := parsePriceListID(w, r)
+	if !ok {
+		return
+	}
+	list, err := h.priceListStore.GetPriceListByID(r.Context(), priceListID)
+	if err != nil {
+		log.Printf("ERROR: GetPriceListByID store operation for ID %d failed: %v", priceListID, err)
+		respondWithPriceListError(w, err, "Failed to retrieve price list")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, list)
+}
+
+func (h *HTTPHandler) UpdatePriceList(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := parsePriceListID(w, r)
+	if !ok {
+		return
+	}
+	list, ok := h.decodePriceListInput(w, r)
+	if !ok {
+		return
+	}
+	list.ID = priceListID
+	updated, err := h.priceListStore.UpdatePriceList(r.Context(), list)
+	if err != nil {
+		log.Printf("ERROR: UpdatePriceList store operation for ID %d failed: %v", priceListID, err)
+		respondWithPriceListError(w, err, "Failed to update price list")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+func (h *HTTPHandler) DeletePriceList(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := parsePriceListID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.priceListStore.DeletePriceList(r.Context(), priceListID); err != nil {
+		log.Printf("ERROR: DeletePriceList store operation for ID %d failed: %v", priceListID, err)
+		respondWithPriceListError(w, err, "Failed to delete price list")
+		return
+	}
+	respondWithJSON(w, http.StatusNoContent, nil)
+}
+
+// ListPriceListEntries returns a page of the entries of a price list, ordered by product ID.
+func (h *HTTPHandler) ListPriceListEntries(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := parsePriceListID(w, r)
+	if !ok {
+		return
+	}
+	qParams := r.URL.Query()
+	limit, err := strconv.Atoi(qParams.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	page, err := strconv.Atoi(qParams.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	params := store.ListPriceListEntriesParams{PriceListID: priceListID, Limit: limit, Offset: (page - 1) * limit}
+
+	entries, totalCount, err := h.priceListStore.ListPriceListEntries(r.Context(), params)
+	if err != nil {
+		log.Printf("ERROR: ListPriceListEntries store operation for ID %d failed: %v", priceListID, err)
+		respondWithPriceListError(w, err, "Failed to retrieve price list entries")
+		return
+	}
+	window := listPage{page: page, limit: limit, offset: params.Offset}
+	respondWithJSON(w, http.StatusOK, struct {
+		Data       []domain.PriceListEntry `json:"data"`
+		Pagination PaginationInfo          `json:"pagination"`
+	}{entries, window.pagination(totalCount, "")})
+}
+
+// PutPriceListEntries creates or replaces the prices of up to maxPriceListEntryBatch products in a
+// list. Either every entry is written or none is.
+func (h *HTTPHandler) PutPriceListEntries(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := parsePriceListID(w, r)
+	if !ok {
+		return
+	}
+	var input PriceListEntriesInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	entries := make([]domain.PriceListEntry, len(input.Entries))
+	for i, e := range input.Entries {
+		entries[i] = domain.PriceListEntry{ProductID: e.ProductID, Price: e.Price}
+	}
+	stored, err := h.priceListStore.SetPriceListEntries(r.Context(), priceListID, entries)
+	if err != nil {
+		log.Printf("ERROR: SetPriceListEntries store operation for ID %d failed: %v", priceListID, err)
+		respondWithPriceListError(w, err, "Failed to write price list entries")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, struct {
+		Data []domain.PriceListEntry `json:"data"`
+	}{stored})
+}
+
+// DeletePriceListEntries removes the prices of the products given by repeated product_id query
+// parameters from a list.
+func (h *HTTPHandler) DeletePriceListEntries(w http.ResponseWriter, r *http.Request) {
+	priceListID, ok := parsePriceListID(w, r)
+	if !ok {
+		return
+	}
+	idStrs := r.URL.Query()["product_id"]
+	if len(idStrs) == 0 || len(idStrs) > maxPriceListEntryBatch {
+		respondWithError(w, http.StatusBadRequest, "Between 1 and 1000 product_id parameters are required")
+		return
+	}
+	productIDs := make([]int64, len(idStrs))
+	for i, idStr := range idStrs {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid product_id format")
+			return
+		}
+		productIDs[i] = id
+	}
+
+	deleted, err := h.priceListStore.DeletePriceListEntries(r.Context(), priceListID, productIDs)
+	if err != nil {
+		log.Printf("ERROR: DeletePriceListEntries store operation for ID %d failed: %v", priceListID, err)
+		respondWithPriceListError(w, err, "Failed to delete price list entries")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, struct {
+		Deleted int `json:"deleted"`
+	}{deleted})
+}
+
+// decodePriceListInput reads and validates a PriceListInput, responding with 400 if it is invalid.
+func (h *HTTPHandler) decodePriceListInput(w http.ResponseWriter, r *http.Request) (*domain.PriceList, bool) {
+	var input PriceListInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return nil, false
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return nil, false
+	}
+	return &domain.PriceList{
+		Name:          input.Name,
+		Currency:      input.Currency,
+		Market:        input.Market,
+		CustomerGroup: input.CustomerGroup,
+		IsDefault:     input.IsDefault,
+	}, true
+}
+
+// respondWithPriceListError maps the errors of PriceListStorer methods to responses.
+func respondWithPriceListError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, store.ErrPriceListNotFound):
+		respondWithError(w, http.StatusNotFound, store.ErrPriceListNotFound.Error())
+	case errors.Is(err, store.ErrPriceListNameExists), errors.Is(err, store.ErrDefaultPriceListExists):
+		respondWithError(w, http.StatusConflict, strings.TrimPrefix(err.Error(), "store: "))
+	case errors.Is(err, store.ErrInvalidPriceList), errors.Is(err, store.ErrInvalidPriceListEntry),
+		errors.Is(err, store.ErrProductNotFound): // An entry names a product that does not exist
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+strings.TrimPrefix(err.Error(), "store: "))
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func parsePriceListID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	priceListID, err := strconv.ParseInt(chi.URLParam(r, "priceListId"), 10, 64)
+	if err != nil || priceListID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid price list ID format")
+		return 0, false
+	}
+	return priceListID, true
+}
+
+// --- Price Selection on Product Reads ---
+
+// parsePriceSelection reads the currency and price_list_id query parameters of a product read. It
+// returns an error message suitable for a 400 response if they are invalid.
+func parsePriceSelection(qParams url.Values) (store.PriceSelection, string) {
+	var sel store.PriceSelection
+	if c := qParams.Get("currency"); c != "" {
+		if !domain.ValidCurrency(c) {
+			return sel, "Invalid currency: must be an ISO 4217 code such as USD"
+		}
+		sel.Currency = c
+	}
+	if idStr := qParams.Get("price_list_id"); idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil || id <= 0 {
+			return sel, "Invalid price_list_id format"
+		}
+		sel.PriceListID = &id
+	}
+	return sel, ""
+}
+
+// basePriceConflict returns an error message if a product listing selects prices with sel but also
+// filters, sorts or counts by price: those use the effective base price, in price_currency, so they
+// would disagree with the prices returned. Otherwise it returns "".
+func basePriceConflict(sel store.PriceSelection, params store.ListProductsParams, facets *store.FacetRequest) string {
+	if sel.IsZero() {
+		return ""
+	}
+	if params.MinPrice != nil || params.MaxPrice != nil || strings.ToLower(params.SortBy) == store.ProductSortPrice ||
+		(facets != nil && len(facets.PriceBoundaries) > 0) {
+		return "min_price, max_price, sort_by=price and the price facet use base prices, so they cannot be combined with currency or price_list_id"
+	}
+	return ""
+}
+
+// resolvePrices applies a price selection to products read from the store, responding with an
+// error and returning false if it fails.
+func (h *HTTPHandler) resolvePrices(w http.ResponseWriter, r *http.Request, sel store.PriceSelection, products []domain.Product) bool {
+	if sel.IsZero() {
+		return true
+	}
+	err := h.priceListStore.ResolvePrices(r.Context(), sel, products)
+	if err == nil {
+		return true
+	}
+	log.Printf("ERROR: ResolvePrices store operation failed: %v", err)
+	switch {
+	case errors.Is(err, store.ErrPriceListNotFound):
+		respondWithError(w, http.StatusBadRequest, "Invalid price_list_id: price list does not exist.")
+	case errors.Is(err, store.ErrInvalidPriceSelection):
+		respondWithError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), "store: "))
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Failed to resolve prices")
+	}
+	return false
+}
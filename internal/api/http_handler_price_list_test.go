@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_PriceLists(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	ctx := context.Background()
+	product, err := memStore.CreateProduct(ctx, &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), IsActive: true})
+	require.NoError(t, err)
+
+	resp := postJSON(t, server.URL+"/api/v1/price-lists", map[string]interface{}{"name": "EU retail", "currency": "EUR", "market": "DE", "is_default": true})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp = postJSON(t, server.URL+"/api/v1/price-lists", map[string]interface{}{"name": "EU other", "currency": "EUR", "is_default": true})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "one default list per currency")
+	var lists []domain.PriceList
+	require.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/price-lists", &lists))
+	require.Len(t, lists, 1)
+	listURL := fmt.Sprintf("%s/api/v1/price-lists/%d", server.URL, lists[0].ID)
+
+	eur := func(amount string) map[string]string { return map[string]string{"amount": amount, "currency": "EUR"} }
+	resp = putJSON(t, listURL+"/entries", map[string]interface{}{"entries": []map[string]interface{}{
+		{"product_id": product.ID, "price": eur("17.99")},
+		{"product_id": 99, "price": eur("1.00")},
+	}})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "an unknown product rejects the whole batch")
+	resp = putJSON(t, listURL+"/entries", map[string]interface{}{"entries": []map[string]interface{}{
+		{"product_id": product.ID, "price": map[string]string{"amount": "17.99", "currency": "USD"}},
+	}})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "prices must be in the currency of the list")
+	resp = putJSON(t, listURL+"/entries", map[string]interface{}{"entries": []map[string]interface{}{
+		{"product_id": product.ID, "price": eur("17.99")},
+	}})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var entries struct {
+		Data       []domain.PriceListEntry `json:"data"`
+		Pagination PaginationInfo          `json:"pagination"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, listURL+"/entries", &entries))
+	require.Len(t, entries.Data, 1)
+	assert.Equal(t, 1, entries.Pagination.TotalItems)
+
+	productURL := fmt.Sprintf("%s/api/v1/products/%d", server.URL, product.ID)
+	var got domain.Product
+	require.Equal(t, http.StatusOK, getJSON(t, productURL+"?currency=EUR", &got))
+	assert.Equal(t, domain.Money{Amount: 1799, Currency: "EUR"}, got.Price)
+	assert.Equal(t, lists[0].ID, *got.PriceListID)
+	require.Equal(t, http.StatusOK, getJSON(t, productURL, &got))
+	assert.Equal(t, usd(1999), got.Price, "without a selection the base price is returned")
+	var page struct {
+		Data []domain.Product `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/products?price_list_id=%d", server.URL, lists[0].ID), &page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "17.99", page.Data[0].Price.Decimal())
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, productURL+"?price_list_id=99", &got))
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, fmt.Sprintf("%s?currency=USD&price_list_id=%d", productURL, lists[0].ID), &got))
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/entries?product_id=%d", listURL, product.ID), nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, http.StatusOK, getJSON(t, productURL+"?currency=EUR", &got))
+	assert.Equal(t, usd(1999), got.Price, "falls back to the base price")
+}
+
+func TestGRPCHandler_PriceLists(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	list, err := memStore.CreatePriceList(ctx, &domain.PriceList{Name: "EU retail", Currency: "EUR", IsDefault: true})
+	require.NoError(t, err)
+	_, err = memStore.SetPriceListEntries(ctx, list.ID, []domain.PriceListEntry{{ProductID: 1, Price: domain.Money{Amount: 4599, Currency: "EUR"}}})
+	require.NoError(t, err)
+
+	details, err := handler.GetProductDetails(ctx, &productpb.GetProductDetailsRequest{ProductId: 1, PriceListId: &list.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "45.99", details.GetProduct().GetPriceMoney().GetAmount())
+	assert.Equal(t, "EUR", details.GetProduct().GetPriceMoney().GetCurrencyCode())
+	assert.Equal(t, list.ID, details.GetProduct().GetPriceListId())
+
+	currency := "EUR"
+	availability, err := handler.CheckProductsAvailability(ctx, &productpb.CheckProductsAvailabilityRequest{
+		Items:    []*productpb.ProductAvailabilityItemInput{{ProductId: 1, RequiredQuantity: 1}, {ProductId: 2, RequiredQuantity: 1}},
+		Currency: &currency,
+	})
+	require.NoError(t, err)
+	require.Len(t, availability.GetStatuses(), 2)
+	assert.True(t, availability.GetStatuses()[0].GetIsAvailable())
+	assert.Equal(t, int64(4599), availability.GetStatuses()[0].GetCurrentPriceMoney().GetAmountMinor())
+	assert.False(t, availability.GetStatuses()[1].GetIsAvailable(), "the mouse has no EUR price")
+	assert.Equal(t, "No price in EUR.", availability.GetStatuses()[1].GetReasonNotAvailable())
+
+	bad := "eur"
+	_, err = handler.ListProductsInternal(ctx, &productpb.ListProductsInternalRequest{Currency: &bad})
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_ExactPrices(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+
+	create := func(sku string, price interface{}) (int, map[string]json.RawMessage) {
+		resp := postJSON(t, server.URL+"/api/v1/products", map[string]interface{}{
+			"name": "Scarf", "sku": sku, "price": price, "stock_quantity": 1,
+		})
+		defer resp.Body.Close()
+		var body map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	status, body := create("EUR-1", map[string]string{"amount": "19.99", "currency": "EUR"})
+	require.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"amount": "19.99", "currency": "EUR"}`, string(body["price"]))
+
+	// A bare number is still accepted, exactly and in the default currency.
+	status, body = create("USD-1", json.RawMessage(`0.30`))
+	require.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"amount": "0.30", "currency": "USD"}`, string(body["price"]))
+
+	for _, bad := range []interface{}{
+		map[string]string{"amount": "19.999", "currency": "USD"}, // Finer than a cent
+		map[string]string{"amount": "5", "currency": "dollars"},
+		map[string]string{"amount": "-1", "currency": "USD"},
+		map[string]string{"amount": "0", "currency": "USD"},                 // Base prices are positive
+		map[string]string{"amount": "1000000000000", "currency": "USD"},     // Beyond NUMERIC(15,3)
+		map[string]string{"amount": "99999999999999999", "currency": "JPY"}, // Would overflow when scaled
+		nil,
+	} {
+		status, _ := create("BAD", bad)
+		assert.Equal(t, http.StatusBadRequest, status, bad)
+	}
+
+	var page struct {
+		Data []domain.Product `json:"data"`
+	}
+	query := url.Values{"min_price": {"19.99"}, "max_price": {"19.99"}, "price_currency": {"EUR"}}
+	require.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/products?"+query.Encode(), &page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "EUR-1", page.Data[0].SKU)
+	// Price filters and sorting use base prices, not the prices a currency or price list selects.
+	for _, bad := range []string{"min_price=1&currency=EUR", "max_price=50&price_list_id=1", "sort_by=price&currency=EUR", "facets=price&currency=EUR"} {
+		assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/products?"+bad, &page), bad)
+	}
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/products/export?min_price=1&currency=EUR", &page))
+	require.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/products?min_price=0.3&max_price=0.3", &page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "USD-1", page.Data[0].SKU)
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/products?min_price=0.001", &page))
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/products?currency=eur", &page))
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/products?price_currency=eur", &page))
+}
+
+func TestGRPCHandler_ExactPrices(t *testing.T) {
+	handler, _ := newStockTestHandler(t)
+	ctx := context.Background()
+
+	details, err := handler.GetProductDetails(ctx, &productpb.GetProductDetailsRequest{ProductId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4999), details.GetProduct().GetPriceMoney().GetAmountMinor())
+	assert.Equal(t, "USD", details.GetProduct().GetPriceMoney().GetCurrencyCode())
+	assert.Equal(t, "49.99", details.GetProduct().GetPriceMoney().GetAmount())
+
+	availability, err := handler.CheckProductsAvailability(ctx, &productpb.CheckProductsAvailabilityRequest{
+		Items: []*productpb.ProductAvailabilityItemInput{{ProductId: 2, RequiredQuantity: 1}},
+	})
+	require.NoError(t, err)
+	require.Len(t, availability.GetStatuses(), 1)
+	assert.Equal(t, int64(1999), availability.GetStatuses()[0].GetCurrentPriceMoney().GetAmountMinor())
+	assert.Equal(t, 19.99, availability.GetStatuses()[0].GetCurrentPrice(), "the deprecated double stays populated")
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Scheduled Price Handlers ---
+
+// ScheduledPriceInput defines the expected input for scheduling a price, e.g. a sale.
+type ScheduledPriceInput struct {
+	Price      domain.Money `json:"price"`                          // Must be in the currency of the product
+	ValidFrom  time.Time    `json:"valid_from" validate:"required"` // RFC 3339, inclusive
+	ValidUntil *time.Time   `json:"valid_until"`                    // RFC 3339, exclusive; omit for no end
+}
+
+// CreateScheduledPrice schedules a price that replaces the product's base price on reads from
+// valid_from until valid_until, with the base price returned as compare_at_price meanwhile.
+func (h *HTTPHandler) CreateScheduledPrice(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	var input ScheduledPriceInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	sp := &domain.ScheduledPrice{ProductID: productID, Price: input.Price, ValidFrom: input.ValidFrom, ValidUntil: input.ValidUntil}
+	created, err := h.productStore.CreateScheduledPrice(requestContext(r), sp)
+	if err != nil {
+		log.Printf("ERROR: CreateScheduledPrice store operation for product ID %d failed: %v", productID, err)
+		respondWithScheduledPriceError(w, err, "Failed to schedule price")
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+// ListScheduledPrices returns every scheduled price of a product, past and future, by start time.
+func (h *HTTPHandler) ListScheduledPrices(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	prices, err := h.productStore.ListScheduledPrices(r.Context(), productID)
+	if err != nil {
+		log.Printf("ERROR: ListScheduledPrices store operation for product ID %d failed: %v", productID, err)
+		respondWithScheduledPriceError(w, err, "Failed to retrieve scheduled prices")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, struct {
+		Data []domain.ScheduledPrice `json:"data"`
+	}{prices})
+}
+
+// DeleteScheduledPrice cancels a scheduled price. One that has started ends now and stays listed as
+// a record of the prices charged; one that has ended cannot be cancelled (409).
+func (h *HTTPHandler) DeleteScheduledPrice(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	scheduledPriceID, err := strconv.ParseInt(chi.URLParam(r, "scheduledPriceId"), 10, 64)
+	if err != nil || scheduledPriceID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid scheduled price ID format")
+		return
+	}
+	if err := h.productStore.DeleteScheduledPrice(requestContext(r), productID, scheduledPriceID); err != nil {
+		log.Printf("ERROR: DeleteScheduledPrice store operation for ID %d failed: %v", scheduledPriceID, err)
+		respondWithScheduledPriceError(w, err, "Failed to delete scheduled price")
+		return
+	}
+	respondWithJSON(w, http.StatusNoContent, nil)
+}
+
+// respondWithScheduledPriceError maps the errors of the scheduled price methods to responses.
+func respondWithScheduledPriceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, store.ErrProductNotFound):
+		respondWithError(w, http.StatusNotFound, store.ErrProductNotFound.Error())
+	case errors.Is(err, store.ErrScheduledPriceNotFound):
+		respondWithError(w, http.StatusNotFound, store.ErrScheduledPriceNotFound.Error())
+	case errors.Is(err, store.ErrScheduledPriceEnded):
+		respondWithError(w, http.StatusConflict, strings.TrimPrefix(err.Error(), "store: "))
+	case errors.Is(err, store.ErrInvalidScheduledPrice):
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+strings.TrimPrefix(err.Error(), "store: "))
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func parseProductID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "productId"), 10, 64)
+	if err != nil || productID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID format")
+		return 0, false
+	}
+	return productID, true
+}
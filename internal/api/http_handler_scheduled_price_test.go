@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_ScheduledPrices(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	ctx := context.Background()
+	scarf, err := memStore.CreateProduct(ctx, &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), IsActive: true})
+	require.NoError(t, err)
+	_, err = memStore.CreateProduct(ctx, &domain.Product{Name: "Hat", SKU: "HAT", Price: usd(1499), IsActive: true})
+	require.NoError(t, err)
+	pricesURL := fmt.Sprintf("%s/api/v1/products/%d/scheduled-prices", server.URL, scarf.ID)
+	now := time.Now().UTC()
+
+	resp := postJSON(t, pricesURL, map[string]interface{}{
+		"price": map[string]string{"amount": "9.99", "currency": "EUR"}, "valid_from": now.Add(-time.Hour),
+	})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "must be in the product's currency")
+	resp = postJSON(t, pricesURL, map[string]interface{}{
+		"price": map[string]string{"amount": "9.99", "currency": "USD"}, "valid_from": now, "valid_until": now.Add(-time.Hour),
+	})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "valid_until must be after valid_from")
+	resp = postJSON(t, fmt.Sprintf("%s/api/v1/products/99/scheduled-prices", server.URL), map[string]interface{}{
+		"price": map[string]string{"amount": "9.99", "currency": "USD"}, "valid_from": now,
+	})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = postJSON(t, pricesURL, map[string]interface{}{
+		"price": map[string]string{"amount": "9.99", "currency": "USD"}, "valid_from": now.Add(-time.Hour), "valid_until": now.Add(time.Hour),
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var product domain.Product
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/products/%d", server.URL, scarf.ID), &product))
+	assert.Equal(t, usd(999), product.Price)
+	require.NotNil(t, product.CompareAtPrice)
+	assert.Equal(t, usd(1999), *product.CompareAtPrice)
+
+	// Listing filters and sorts by the effective price.
+	var page struct {
+		Data []domain.Product `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/products?sort_by=price&sort_order=asc&max_price=10", &page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, scarf.ID, page.Data[0].ID)
+
+	var prices struct {
+		Data []domain.ScheduledPrice `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, pricesURL, &prices))
+	require.Len(t, prices.Data, 1)
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", pricesURL, prices.Data[0].ID), nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "a started price is ended rather than deleted")
+
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/api/v1/products/%d", server.URL, scarf.ID), &product))
+	assert.Equal(t, usd(1999), product.Price)
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// actorHeader names the caller of a stock-changing request; it is recorded in the stock ledger.
+const actorHeader = "X-Actor"
+
+// requestContext returns the request context carrying the caller named in the X-Actor header.
+func requestContext(r *http.Request) context.Context {
+	return store.WithActor(r.Context(), r.Header.Get(actorHeader))
+}
+
+// --- Stock Handlers ---
+
+// StockAdjustmentInput defines the expected input for a manual stock adjustment.
+type StockAdjustmentInput struct {
+	QuantityChange int32   `json:"quantity_change" validate:"required,ne=0"` // Negative to decrease, positive to increase
+	LocationID     *int64  `json:"location_id" validate:"omitempty,gt=0"`    // Optional: defaults to allocation by location priority
+	Note           *string `json:"note" validate:"omitempty,max=1000"`       // Free text, e.g. "stocktake correction"
+}
+
+func (h *HTTPHandler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "productId")
+	productID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || productID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID format")
+		return
+	}
+
+	var input StockAdjustmentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validate.Struct(input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Validation failed: "+err.Error())
+		return
+	}
+
+	info := store.StockMovementInfo{Reason: domain.StockReasonAdjustment, Note: input.Note}
+	update := store.StockUpdate{ProductID: productID, QuantityChange: input.QuantityChange, LocationID: input.LocationID}
+	results, err := h.productStore.BatchUpdateStock(requestContext(r), []store.StockUpdate{update}, true, info)
+	if err == nil {
+		err = results[0].Err
+	}
+	if err != nil {
+		log.Printf("ERROR: AdjustStock store operation for ID %d failed: %v", productID, err)
+		if errors.Is(err, store.ErrProductNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrProductNotFound.Error())
+		} else if errors.Is(err, store.ErrLocationNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrLocationNotFound.Error())
+		} else if errors.Is(err, store.ErrInsufficientStock) {
+			respondWithError(w, http.StatusConflict, "Adjustment would take stock below zero or below the reserved quantity")
+		} else if errors.Is(err, store.ErrNoLocations) {
+			respondWithError(w, http.StatusConflict, store.ErrNoLocations.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to adjust stock")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, results[0].Product)
+}
+
+func (h *HTTPHandler) GetStockHistory(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "productId")
+	productID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || productID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID format")
+		return
+	}
+
+	qParams := r.URL.Query()
+	window, errMsg := h.parseListPageWithDefault(r, 50)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	params := store.ListStockMovementsParams{ProductID: productID, Limit: window.storeLimit(), Offset: window.offset, After: window.after}
+
+	if fromStr := qParams.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid from: must be an RFC 3339 timestamp")
+			return
+		}
+		params.From = &from
+	}
+	if toStr := qParams.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid to: must be an RFC 3339 timestamp")
+			return
+		}
+		params.To = &to
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		respondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	if _, err := getProduct(r.Context(), h.productStore, productID, true); err != nil {
+		log.Printf("ERROR: Product for stock history (ID %d) not found: %v", productID, err)
+		if errors.Is(err, store.ErrProductNotFound) {
+			respondWithError(w, http.StatusNotFound, store.ErrProductNotFound.Error())
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Error checking product existence")
+		}
+		return
+	}
+
+	movements, totalCount, err := h.productStore.ListStockMovements(r.Context(), params)
+	if err != nil {
+		log.Printf("ERROR: ListStockMovements store operation for ID %d failed: %v", productID, err)
+		if errors.Is(err, store.ErrInvalidCursor) { // Issued for another listing
+			respondWithError(w, http.StatusBadRequest, "Invalid cursor: it was not issued for a stock history")
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve stock history")
+		}
+		return
+	}
+
+	movements, more := trimListPage(window, movements, totalCount)
+	var nextCursor string
+	if more && len(movements) > 0 {
+		nextCursor = h.pageTokens.Encode(store.StockMovementCursor(&movements[len(movements)-1]))
+	}
+	response := struct {
+		Data       []domain.StockMovement `json:"data"`
+		Pagination PaginationInfo         `json:"pagination"`
+	}{
+		Data:       movements,
+		Pagination: window.pagination(totalCount, nextCursor),
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_StockAdjustmentAndHistory(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	_, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "Widget", SKU: "W-1", Price: usd(500), StockQuantity: 10, IsActive: true})
+	require.NoError(t, err)
+	server := setupTestChiServer(t, memStore, memStore)
+	defer server.Close()
+
+	body, _ := json.Marshal(StockAdjustmentInput{QuantityChange: -4, Note: PtrTo("damaged in transit")})
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/products/1/stock-adjustments", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("X-Actor", "warehouse-bot")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ = json.Marshal(StockAdjustmentInput{QuantityChange: -7})
+	resp, err = http.Post(server.URL+"/api/v1/products/1/stock-adjustments", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "stock cannot go negative")
+
+	resp, err = http.Get(server.URL + "/api/v1/products/1/stock-history?limit=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history struct {
+		Data       []domain.StockMovement `json:"data"`
+		Pagination PaginationInfo         `json:"pagination"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	assert.Equal(t, 2, history.Pagination.TotalItems, "initial stock and the adjustment")
+	require.Len(t, history.Data, 1)
+	latest := history.Data[0]
+	assert.Equal(t, domain.StockReasonAdjustment, latest.Reason)
+	assert.Equal(t, int32(-4), latest.QuantityDelta)
+	assert.Equal(t, int32(6), latest.BalanceAfter)
+	assert.Equal(t, "warehouse-bot", *latest.Actor)
+	assert.Equal(t, "damaged in transit", *latest.Note)
+
+	// The next page continues after the last entry, whatever was recorded since.
+	require.NotEmpty(t, history.Pagination.NextCursor)
+	next := history.Pagination.NextCursor
+	history.Data, history.Pagination = nil, PaginationInfo{}
+	require.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/products/1/stock-history?limit=1&cursor="+next, &history))
+	require.Len(t, history.Data, 1)
+	assert.Equal(t, domain.StockReasonInitialStock, history.Data[0].Reason)
+	assert.Empty(t, history.Pagination.NextCursor, "the last page")
+	assert.Zero(t, history.Pagination.Page)
+}
+
+func TestHTTPHandler_GetStockHistory_BadRequests(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupTestChiServer(t, memStore, memStore)
+	defer server.Close()
+
+	for path, want := range map[string]int{
+		"/api/v1/products/1/stock-history":                                                   http.StatusNotFound,
+		"/api/v1/products/abc/stock-history":                                                 http.StatusBadRequest,
+		"/api/v1/products/1/stock-history?from=yesterday":                                    http.StatusBadRequest,
+		"/api/v1/products/1/stock-history?from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z": http.StatusBadRequest,
+		"/api/v1/products/1/stock-history?cursor=forged":                                     http.StatusBadRequest,
+		"/api/v1/products/1/stock-history?cursor=forged&page=2":                              http.StatusBadRequest,
+	} {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.StatusCode, path)
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+)
+
+// --- Trash Handlers ---
+
+// ListProductTrash lists the deleted products that have not been purged yet, most recently deleted
+// first. It takes the filters and pagination of ListProducts, and can also sort by deleted_at.
+func (h *HTTPHandler) ListProductTrash(w http.ResponseWriter, r *http.Request) {
+	qParams := r.URL.Query()
+	window, errMsg := h.parseListPage(r)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	params := store.ListProductsParams{
+		Limit: window.storeLimit(), Offset: window.offset, After: window.after, Deleted: store.DeletedOnly,
+		SortBy: qParams.Get("sort_by"), SortOrder: qParams.Get("sort_order"),
+	}
+	if errMsg := parseProductFilters(qParams, &params); errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	if params.SortBy == "" {
+		params.SortBy = store.ProductSortDeletedAt
+		if params.SortOrder == "" {
+			params.SortOrder = "desc"
+		}
+	}
+	allowedSortFields := map[string]bool{"name": true, "price": true, "created_at": true, "updated_at": true, store.ProductSortDeletedAt: true}
+	if !allowedSortFields[params.SortBy] {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid sort_by field. Allowed: %v", getMapKeys(allowedSortFields)))
+		return
+	}
+	if params.SortOrder != "" && strings.ToLower(params.SortOrder) != "asc" && strings.ToLower(params.SortOrder) != "desc" {
+		respondWithError(w, http.StatusBadRequest, "Invalid sort_order value. Allowed: asc, desc")
+		return
+	}
+
+	products, totalCount, err := h.productStore.ListProducts(r.Context(), params)
+	if err != nil {
+		log.Printf("ERROR: ListProducts store operation for the trash failed: %v", err)
+		if errors.Is(err, store.ErrInvalidCursor) {
+			respondWithError(w, http.StatusBadRequest, "Invalid cursor: it does not match sort_by and sort_order")
+		} else if errors.Is(err, store.ErrInvalidAttributeFilter) {
+			respondWithError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), "store: "))
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve deleted products")
+		}
+		return
+	}
+	products, more := trimListPage(window, products, totalCount)
+	var nextCursor string
+	if more && len(products) > 0 {
+		nextCursor = h.pageTokens.Encode(store.ProductCursor(params, &products[len(products)-1]))
+	}
+	if !h.attachMedia(w, r, products) {
+		return
+	}
+	respondWithJSON(w, http.StatusOK, struct {
+		Data       []domain.Product `json:"data"`
+		Pagination PaginationInfo   `json:"pagination"`
+	}{products, window.pagination(totalCount, nextCursor)})
+}
+
+// RestoreProduct takes a product out of the trash, together with the variants deleted with it.
+func (h *HTTPHandler) RestoreProduct(w http.ResponseWriter, r *http.Request) {
+	productID, ok := parseProductID(w, r)
+	if !ok {
+		return
+	}
+	priceSelection, errMsg := parsePriceSelection(r.URL.Query())
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	product, err := h.productStore.RestoreProduct(r.Context(), productID)
+	if err != nil {
+		log.Printf("ERROR: RestoreProduct store operation for ID %d failed: %v", productID, err)
+		switch {
+		case errors.Is(err, store.ErrProductNotFound):
+			respondWithError(w, http.StatusNotFound, "product not found in the trash")
+		case errors.Is(err, store.ErrProductSKUExists), errors.Is(err, store.ErrVariantExists):
+			respondWithError(w, http.StatusConflict, "Cannot restore: "+strings.TrimPrefix(err.Error(), "store: "))
+		default:
+			respondWithTrashError(w, err, "Failed to restore product")
+		}
+		return
+	}
+	products := []domain.Product{*product}
+	if !h.resolvePrices(w, r, priceSelection, products) || !h.attachVariants(w, r, priceSelection, products) ||
+		!h.attachMedia(w, r, products) {
+		return
+	}
+	respondWithJSON(w, http.StatusOK, products[0])
+}
+
+// ListCategoryTrash lists the deleted categories that have not been purged yet, ordered by name.
+func (h *HTTPHandler) ListCategoryTrash(w http.ResponseWriter, r *http.Request) {
+	window, errMsg := h.parseListPage(r)
+	if errMsg != "" {
+		respondWithError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+	params := store.ListCategoriesParams{Limit: window.storeLimit(), Offset: window.offset, After: window.after, Deleted: store.DeletedOnly}
+
+	categories, totalCount, err := h.categoryStore.ListCategories(r.Context(), params)
+	if err != nil {
+		log.Printf("ERROR: ListCategories store operation for the trash failed: %v", err)
+		if errors.Is(err, store.ErrInvalidCursor) {
+			respondWithError(w, http.StatusBadRequest, "Invalid cursor")
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve deleted categories")
+		}
+		return
+	}
+	categories, more := trimListPage(window, categories, totalCount)
+	var nextCursor string
+	if more && len(categories) > 0 {
+		nextCursor = h.pageTokens.Encode(store.CategoryCursor(&categories[len(categories)-1]))
+	}
+	respondWithJSON(w, http.StatusOK, struct {
+		Data       []domain.Category `json:"data"`
+		Pagination PaginationInfo    `json:"pagination"`
+	}{categories, window.pagination(totalCount, nextCursor)})
+}
+
+// RestoreCategory takes a category out of the trash, together with the subcategories deleted with
+// it. Products keep whatever category they were given in the meantime.
+func (h *HTTPHandler) RestoreCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := parseCategoryID(w, r)
+	if !ok {
+		return
+	}
+	category, err := h.categoryStore.RestoreCategory(r.Context(), categoryID)
+	if err != nil {
+		log.Printf("ERROR: RestoreCategory store operation for ID %d failed: %v", categoryID, err)
+		switch {
+		case errors.Is(err, store.ErrCategoryNotFound):
+			respondWithError(w, http.StatusNotFound, "category not found in the trash")
+		case errors.Is(err, store.ErrCategoryNameExists):
+			respondWithError(w, http.StatusConflict, "Cannot restore: "+strings.TrimPrefix(err.Error(), "store: "))
+		default:
+			respondWithTrashError(w, err, "Failed to restore category")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, category)
+}
+
+// respondWithTrashError maps the errors restoring from the trash has in common to responses.
+func respondWithTrashError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, store.ErrRestoreBlocked):
+		respondWithError(w, http.StatusConflict, strings.TrimPrefix(err.Error(), "store: "))
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
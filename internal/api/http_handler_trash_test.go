@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_ProductTrash(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	productsURL := server.URL + "/api/v1/products"
+
+	create := func(sku string) (int, domain.Product) {
+		resp := postJSON(t, productsURL, map[string]interface{}{
+			"name": "Boot " + sku, "sku": sku, "price": map[string]string{"amount": "80.00", "currency": "USD"}, "stock_quantity": 2,
+		})
+		defer resp.Body.Close()
+		var p domain.Product
+		if resp.StatusCode == http.StatusCreated {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		}
+		return resp.StatusCode, p
+	}
+	remove := func(id int64) int {
+		req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", productsURL, id), nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	restore := func(id int64) (int, domain.Product) {
+		resp, err := http.Post(fmt.Sprintf("%s/%d/restore", productsURL, id), "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var p domain.Product
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		}
+		return resp.StatusCode, p
+	}
+	_, boot := create("BOOT-1")
+	_, other := create("BOOT-2")
+
+	require.Equal(t, http.StatusNoContent, remove(boot.ID))
+	assert.Equal(t, http.StatusNotFound, remove(boot.ID), "already in the trash")
+	assert.Equal(t, http.StatusNotFound, getJSON(t, fmt.Sprintf("%s/%d", productsURL, boot.ID), &domain.Product{}))
+	var history struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	for _, path := range []string{"stock-history", "price-history"} {
+		assert.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/%d/%s", productsURL, boot.ID, path), &history),
+			"%s of a product in the trash", path)
+	}
+	assert.NotEmpty(t, history.Data)
+
+	var page struct {
+		Data       []domain.Product `json:"data"`
+		Pagination struct {
+			TotalItems int `json:"total_items"`
+		} `json:"pagination"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, productsURL, &page))
+	require.Len(t, page.Data, 1, "deleted products are not listed")
+	assert.Equal(t, other.ID, page.Data[0].ID)
+	require.Equal(t, http.StatusOK, getJSON(t, productsURL+"/trash", &page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, boot.ID, page.Data[0].ID)
+	assert.NotNil(t, page.Data[0].DeletedAt)
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, productsURL+"/trash?sort_by=relevance", &page))
+
+	// The SKU is free while the product is in the trash, and blocks restoring it once taken.
+	status, replacement := create("BOOT-1")
+	require.Equal(t, http.StatusCreated, status)
+	status, _ = restore(boot.ID)
+	assert.Equal(t, http.StatusConflict, status)
+	require.Equal(t, http.StatusNoContent, remove(replacement.ID))
+
+	status, restored := restore(boot.ID)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "BOOT-1", restored.SKU)
+	assert.Nil(t, restored.DeletedAt)
+	status, _ = restore(boot.ID)
+	assert.Equal(t, http.StatusNotFound, status, "no longer in the trash")
+	require.Equal(t, http.StatusOK, getJSON(t, productsURL+"/trash", &page))
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, replacement.ID, page.Data[0].ID)
+}
+
+func TestHTTPHandler_CategoryTrash(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	categoriesURL := server.URL + "/api/v1/categories"
+
+	create := func(name string, parentID *int64) domain.Category {
+		resp := postJSON(t, categoriesURL, map[string]interface{}{"name": name, "parent_category_id": parentID})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var c domain.Category
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&c))
+		return c
+	}
+	restore := func(id int64) int {
+		resp, err := http.Post(fmt.Sprintf("%s/%d/restore", categoriesURL, id), "application/json", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	shoes := create("Shoes", nil)
+	boots := create("Boots", &shoes.ID)
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d?strategy=cascade", categoriesURL, shoes.ID), nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	var page struct {
+		Data []domain.Category `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, categoriesURL, &page))
+	assert.Empty(t, page.Data)
+	require.Equal(t, http.StatusOK, getJSON(t, categoriesURL+"/trash", &page))
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, "Boots", page.Data[0].Name)
+
+	assert.Equal(t, http.StatusConflict, restore(boots.ID), "its parent is in the trash")
+	require.Equal(t, http.StatusOK, restore(shoes.ID))
+	assert.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/%d", categoriesURL, boots.ID), &domain.Category{}),
+		"subcategories deleted with the category are restored with it")
+	assert.Equal(t, http.StatusNotFound, restore(shoes.ID))
+}
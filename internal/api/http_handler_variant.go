@@ -0,0 +1,46 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+)
+
+// --- Variant Helpers ---
+
+// attachVariants sets the variants of the parents among products, priced by the same selection. It
+// reports whether it succeeded; otherwise it has written the error response.
+func (h *HTTPHandler) attachVariants(w http.ResponseWriter, r *http.Request, sel store.PriceSelection, products []domain.Product) bool {
+	var parentIDs []int64
+	for _, p := range products {
+		if len(p.Options) > 0 {
+			parentIDs = append(parentIDs, p.ID)
+		}
+	}
+	if len(parentIDs) == 0 {
+		return true
+	}
+	variants, err := h.productStore.ListVariants(r.Context(), parentIDs)
+	if err != nil {
+		log.Printf("ERROR: ListVariants store operation failed: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve product variants")
+		return false
+	}
+	for i := range products {
+		if list := variants[products[i].ID]; len(list) > 0 {
+			if !h.resolvePrices(w, r, sel, list) {
+				return false
+			}
+			products[i].Variants = list
+		}
+	}
+	return true
+}
+
+// inheritsPrice reports whether a product input is for a variant without a price override: the
+// price is omitted, so the variant has the parent's base price.
+func inheritsPrice(parentID *int64, price domain.Money) bool {
+	return parentID != nil && price.Currency == ""
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_Variants(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+	productsURL := server.URL + "/api/v1/products"
+
+	create := func(payload map[string]interface{}) (int, domain.Product) {
+		resp := postJSON(t, productsURL, payload)
+		defer resp.Body.Close()
+		var p domain.Product
+		if resp.StatusCode == http.StatusCreated {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		}
+		return resp.StatusCode, p
+	}
+	status, tee := create(map[string]interface{}{
+		"name": "Tee", "sku": "TEE", "price": map[string]string{"amount": "15.00", "currency": "USD"}, "options": []string{"color", "size"}, "stock_quantity": 1,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	status, red := create(map[string]interface{}{
+		"name": "Tee red M", "sku": "TEE-R-M", "parent_id": tee.ID, "option_values": map[string]string{"color": "red", "size": "M"}, "stock_quantity": 3,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	assert.True(t, red.InheritsPrice)
+	assert.Equal(t, usd(1500), red.Price, "the price is omitted, so the variant has the parent's")
+	status, blue := create(map[string]interface{}{
+		"name": "Tee blue M", "sku": "TEE-B-M", "parent_id": tee.ID, "option_values": map[string]string{"color": "blue", "size": "M"},
+		"price": map[string]string{"amount": "17.00", "currency": "USD"}, "stock_quantity": 1,
+	})
+	require.Equal(t, http.StatusCreated, status)
+	assert.False(t, blue.InheritsPrice)
+
+	status, _ = create(map[string]interface{}{
+		"name": "Tee red S", "sku": "TEE-R-S", "parent_id": tee.ID, "option_values": map[string]string{"color": "red"}, "stock_quantity": 1,
+	})
+	assert.Equal(t, http.StatusBadRequest, status, "a value for each option of the parent")
+	status, _ = create(map[string]interface{}{
+		"name": "Tee red M again", "sku": "TEE-R-M-2", "parent_id": tee.ID, "option_values": map[string]string{"color": "red", "size": "M"}, "stock_quantity": 1,
+	})
+	assert.Equal(t, http.StatusConflict, status)
+	status, _ = create(map[string]interface{}{"name": "Loose", "sku": "LOOSE", "stock_quantity": 1})
+	assert.Equal(t, http.StatusBadRequest, status, "only variants may omit the price")
+
+	var details domain.Product
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s/%d", productsURL, tee.ID), &details))
+	require.Len(t, details.Variants, 2)
+	assert.Equal(t, "TEE-R-M", details.Variants[0].SKU)
+	assert.Equal(t, map[string]string{"color": "blue", "size": "M"}, details.Variants[1].OptionValues)
+	assert.Equal(t, int32(3), details.Variants[0].StockQuantity)
+
+	var page struct {
+		Data       []domain.Product `json:"data"`
+		Pagination struct {
+			TotalItems int `json:"total_items"`
+		} `json:"pagination"`
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, productsURL, &page))
+	assert.Equal(t, 3, page.Pagination.TotalItems, "variants are listed on their own by default")
+	require.Equal(t, http.StatusOK, getJSON(t, productsURL+"?group_by_parent=true", &page))
+	require.Len(t, page.Data, 1)
+	assert.Len(t, page.Data[0].Variants, 2)
+	require.Equal(t, http.StatusOK, getJSON(t, fmt.Sprintf("%s?parent_id=%d", productsURL, tee.ID), &page))
+	assert.Equal(t, 2, page.Pagination.TotalItems)
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, productsURL+"?group_by_parent=maybe", &page))
+}
+
+func TestGRPCHandler_Variants(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	ctx := context.Background()
+	tee, err := memStore.CreateProduct(ctx, &domain.Product{Name: "Tee", SKU: "TEE", Price: usd(1500), IsActive: true, Options: []string{"size"}})
+	require.NoError(t, err)
+	variant, err := memStore.CreateProduct(ctx, &domain.Product{
+		Name: "Tee M", SKU: "TEE-M", ParentID: &tee.ID, OptionValues: map[string]string{"size": "M"}, InheritsPrice: true, StockQuantity: 2, IsActive: true,
+	})
+	require.NoError(t, err)
+
+	details, err := handler.GetProductDetails(ctx, &productpb.GetProductDetailsRequest{ProductId: tee.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"size"}, details.GetProduct().GetOptions())
+	require.Len(t, details.GetProduct().GetVariants(), 1)
+	pbVariant := details.GetProduct().GetVariants()[0]
+	assert.Equal(t, tee.ID, pbVariant.GetParentId())
+	assert.Equal(t, map[string]string{"size": "M"}, pbVariant.GetOptionValues())
+	assert.True(t, pbVariant.GetInheritsPrice())
+	assert.Equal(t, "15.00", pbVariant.GetPriceMoney().GetAmount())
+
+	availability, err := handler.CheckProductsAvailability(ctx, &productpb.CheckProductsAvailabilityRequest{
+		Items: []*productpb.ProductAvailabilityItemInput{{ProductId: tee.ID, RequiredQuantity: 1}, {ProductId: variant.ID, RequiredQuantity: 2}},
+	})
+	require.NoError(t, err)
+	require.Len(t, availability.GetStatuses(), 2)
+	assert.False(t, availability.GetStatuses()[0].GetIsAvailable(), "stock is held per variant")
+	assert.True(t, availability.GetStatuses()[1].GetIsAvailable())
+}
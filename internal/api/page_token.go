@@ -0,0 +1,79 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+
+	"product-catalog-service/internal/store"
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// PageTokenCodec turns store cursors into the opaque page tokens handed to clients (gRPC
+// next_page_token, HTTP next_cursor) and back. Tokens are signed with HMAC-SHA256, so a client
+// cannot craft a position of its own; the same codec must be shared by every server that may
+// receive a token it issued.
+type PageTokenCodec struct {
+	key []byte
+}
+
+// pageTokenPayload is the signed part of a token. Field names are kept short because the token
+// travels in URLs.
+type pageTokenPayload struct {
+	SortBy string `json:"s"`
+	Desc   bool   `json:"d,omitempty"`
+	Key    string `json:"k"`
+	ID     int64  `json:"i"`
+}
+
+// NewPageTokenCodec creates a codec signing with secret. An empty secret is replaced by a random one,
+// which is fine for a single instance but invalidates tokens on restart.
+func NewPageTokenCodec(secret []byte) *PageTokenCodec {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatalf("FATAL: Failed to generate page token secret: %v", err)
+		}
+		log.Printf("WARN: PAGE_TOKEN_SECRET is not set; using a random secret. Page tokens will not survive a restart or work across instances.")
+	}
+	return &PageTokenCodec{key: secret}
+}
+
+// Encode returns the page token for cursor.
+func (c *PageTokenCodec) Encode(cursor store.Cursor) string {
+	payload, _ := json.Marshal(pageTokenPayload{SortBy: cursor.SortBy, Desc: cursor.Desc, Key: cursor.Key, ID: cursor.ID}) // Cannot fail
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload))
+}
+
+// Decode verifies token and returns the cursor it encodes.
+func (c *PageTokenCodec) Decode(token string) (*store.Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, errInvalidPageToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, c.sign(payload)) {
+		return nil, errInvalidPageToken
+	}
+	var p pageTokenPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, errInvalidPageToken
+	}
+	return &store.Cursor{SortBy: p.SortBy, Desc: p.Desc, Key: p.Key, ID: p.ID}, nil
+}
+
+func (c *PageTokenCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
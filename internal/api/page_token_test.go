@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	commonpb "product-catalog-service/proto/v1/common"
+	productpb "product-catalog-service/proto/v1/product"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPageTokenCodec_RoundTripAndTampering(t *testing.T) {
+	codec := NewPageTokenCodec([]byte("test-secret"))
+	cursor := store.Cursor{SortBy: "price", Desc: true, Key: "19.99", ID: 42}
+
+	token := codec.Encode(cursor)
+	decoded, err := codec.Decode(token)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+
+	_, err = NewPageTokenCodec([]byte("other-secret")).Decode(token)
+	assert.ErrorIs(t, err, errInvalidPageToken, "tokens are only accepted with the secret that signed them")
+
+	other := codec.Encode(store.Cursor{SortBy: "price", Desc: true, Key: "0", ID: 1})
+	payload, _, _ := strings.Cut(other, ".")
+	_, signature, _ := strings.Cut(token, ".")
+	_, err = codec.Decode(payload + "." + signature)
+	assert.ErrorIs(t, err, errInvalidPageToken, "payload and signature must belong together")
+
+	for _, bad := range []string{"", "42", "not base64.!!", token + "x"} {
+		_, err := codec.Decode(bad)
+		assert.ErrorIs(t, err, errInvalidPageToken, bad)
+	}
+}
+
+func seedPaginationProducts(t *testing.T, memStore *store.MemoryStore, n int) {
+	t.Helper()
+	for i := 1; i <= n; i++ {
+		_, err := memStore.CreateProduct(context.Background(), &domain.Product{
+			Name: fmt.Sprintf("Product %02d", i), SKU: fmt.Sprintf("P-%02d", i), Price: usd(int64(i) * 100), IsActive: true,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestHTTPHandler_ListProducts_CursorPagination(t *testing.T) {
+	memStore := store.NewMemoryStore()
+	seedPaginationProducts(t, memStore, 5)
+	server := setupMemoryTestServer(t, memStore)
+	defer server.Close()
+
+	type listResponse struct {
+		Data       []domain.Product `json:"data"`
+		Pagination PaginationInfo   `json:"pagination"`
+	}
+	get := func(query url.Values) (int, listResponse) {
+		resp, err := http.Get(server.URL + "/api/v1/products?" + query.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body listResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		}
+		return resp.StatusCode, body
+	}
+
+	query := url.Values{"limit": {"2"}, "sort_by": {"price"}, "sort_order": {"desc"}}
+	code, page := get(query)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, page.Pagination.Page)
+	require.NotEmpty(t, page.Pagination.NextCursor)
+
+	var skus []string
+	for _, p := range page.Data {
+		skus = append(skus, p.SKU)
+	}
+	// A product inserted mid-scan ahead of the cursor does not shift the following pages.
+	_, err := memStore.CreateProduct(context.Background(), &domain.Product{Name: "New", SKU: "P-99", Price: usd(9900), IsActive: true})
+	require.NoError(t, err)
+
+	for page.Pagination.NextCursor != "" {
+		query.Set("cursor", page.Pagination.NextCursor)
+		code, page = get(query)
+		require.Equal(t, http.StatusOK, code)
+		assert.Zero(t, page.Pagination.Page, "page is omitted for cursor requests")
+		assert.Equal(t, 6, page.Pagination.TotalItems)
+		for _, p := range page.Data {
+			skus = append(skus, p.SKU)
+		}
+	}
+	assert.Equal(t, []string{"P-05", "P-04", "P-03", "P-02", "P-01"}, skus)
+
+	code, asc := get(url.Values{"limit": {"2"}, "sort_by": {"price"}})
+	require.Equal(t, http.StatusOK, code)
+	code, _ = get(url.Values{"limit": {"2"}, "sort_by": {"name"}, "cursor": {asc.Pagination.NextCursor}})
+	assert.Equal(t, http.StatusBadRequest, code, "cursor issued for another sort order")
+	code, _ = get(url.Values{"cursor": {"tampered"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = get(url.Values{"page": {"2"}, "cursor": {asc.Pagination.NextCursor}, "sort_by": {"price"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestGRPCHandler_ListProductsInternal_PageTokens(t *testing.T) {
+	handler, memStore := newStockTestHandler(t)
+	seedPaginationProducts(t, memStore, 4)
+	total, err := handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{PageInfo: &commonpb.PageInfoRequest{PageSize: 100}})
+	require.NoError(t, err)
+	require.Empty(t, total.GetPageInfo().GetNextPageToken(), "the last page has no next token")
+
+	var ids []int64
+	var token string
+	for {
+		resp, err := handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+			PageInfo: &commonpb.PageInfoRequest{PageSize: 2, PageToken: token},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, total.GetPageInfo().GetTotalSize(), resp.GetPageInfo().GetTotalSize())
+		for _, p := range resp.GetProducts() {
+			ids = append(ids, p.GetId())
+		}
+		token = resp.GetPageInfo().GetNextPageToken()
+		if token == "" {
+			break
+		}
+	}
+	var want []int64
+	for _, p := range total.GetProducts() {
+		want = append(want, p.GetId())
+	}
+	assert.Equal(t, want, ids)
+
+	_, err = handler.ListProductsInternal(context.Background(), &productpb.ListProductsInternalRequest{
+		PageInfo: &commonpb.PageInfoRequest{PageSize: 2, PageToken: "2"}, // Former offset tokens are rejected
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	productToken := handler.pageTokens.Encode(store.ProductCursor(store.ListProductsParams{}, &domain.Product{ID: 1}))
+	_, err = handler.ListCategoriesInternal(context.Background(), &productpb.ListCategoriesInternalRequest{
+		PageInfo: &commonpb.PageInfoRequest{PageToken: productToken},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "a product token cannot page categories")
+}
@@ -0,0 +1,385 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// --- Product Import ---
+
+// Product import formats.
+const (
+	ProductImportCSV    = "csv"
+	ProductImportNDJSON = "ndjson"
+)
+
+// Statuses of the rows of a ProductImportReport. In a dry run, created and updated say what an
+// import would do.
+const (
+	ImportRowCreated = "created"
+	ImportRowUpdated = "updated"
+	ImportRowFailed  = "failed"
+)
+
+// productImportBatchSize is the number of rows written per transaction.
+const productImportBatchSize = 500
+
+// maxNDJSONLineBytes bounds a single NDJSON row.
+const maxNDJSONLineBytes = 1 << 20
+
+// ErrInvalidImport wraps what is wrong with an import as a whole, e.g. an unknown format, a CSV
+// column mapping that does not match the header or malformed CSV. Nothing is written then.
+var ErrInvalidImport = errors.New("invalid import")
+
+// ImportStoppedError is returned by ProductImporter.Import when a batch could not be written for
+// reasons unrelated to its rows. The rows before Line were written (unless in a dry run).
+type ImportStoppedError struct {
+	Line int // First line of the batch
+	Err  error
+}
+
+func (e *ImportStoppedError) Error() string {
+	return fmt.Sprintf("import stopped at line %d: %v", e.Line, e.Err)
+}
+
+func (e *ImportStoppedError) Unwrap() error {
+	return e.Err
+}
+
+// ProductImportOptions selects how ProductImporter.Import reads its input.
+type ProductImportOptions struct {
+	Format string // ProductImportCSV or ProductImportNDJSON
+	// Columns maps CSV header names to product fields, e.g. "Item Code" to "sku". Columns named
+	// like a field need no mapping; other columns are ignored.
+	Columns map[string]string
+	DryRun  bool // Validate and report without writing anything
+}
+
+// ProductImportReport is the outcome of an import, with a row for each product read.
+type ProductImportReport struct {
+	DryRun  bool               `json:"dry_run"`
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Failed  int                `json:"failed"`
+	Rows    []ProductImportRow `json:"rows"`
+}
+
+// ProductImportRow is the outcome of one row of an import.
+type ProductImportRow struct {
+	Line      int    `json:"line"` // Line of the input the row starts on
+	SKU       string `json:"sku,omitempty"`
+	Status    string `json:"status"`               // ImportRowCreated, ImportRowUpdated or ImportRowFailed
+	ProductID int64  `json:"product_id,omitempty"` // Absent for failed rows and rows a dry run would create
+	Error     string `json:"error,omitempty"`      // Why the row failed
+}
+
+// ProductImporter creates and updates products by SKU from CSV or NDJSON, validating each row like
+// CreateProduct. It backs both the import endpoint and the "import" command.
+type ProductImporter struct {
+	productStore store.ProductStorer
+	validate     *validator.Validate
+}
+
+// NewProductImporter creates a ProductImporter that writes to ps.
+func NewProductImporter(ps store.ProductStorer) *ProductImporter {
+	return &ProductImporter{productStore: ps, validate: validator.New()}
+}
+
+// importRow is a row read from the input, before it is written.
+type importRow struct {
+	line  int
+	input ProductCreateInput
+	err   string // Set if the row could not be read
+}
+
+// Import reads all rows of r and writes the valid ones in batches of productImportBatchSize, each
+// in its own transaction. The error wraps ErrInvalidImport if nothing was imported because of the
+// input, or is an *ImportStoppedError.
+func (im *ProductImporter) Import(ctx context.Context, r io.Reader, opts ProductImportOptions) (*ProductImportReport, error) {
+	var rows []importRow
+	var err error
+	switch opts.Format {
+	case ProductImportCSV:
+		rows, err = readCSVImport(r, opts.Columns)
+	case ProductImportNDJSON:
+		if len(opts.Columns) > 0 {
+			return nil, fmt.Errorf("%w: column mappings only apply to CSV", ErrInvalidImport)
+		}
+		rows, err = readNDJSONImport(r)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q (expected %s or %s)", ErrInvalidImport, opts.Format, ProductImportCSV, ProductImportNDJSON)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ProductImportReport{DryRun: opts.DryRun, Rows: make([]ProductImportRow, len(rows))}
+	var batch []*domain.Product
+	var batchRows []int // Indexes of the rows in batch
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		results, err := im.productStore.ImportProducts(ctx, batch, opts.DryRun)
+		if err != nil {
+			return &ImportStoppedError{Line: rows[batchRows[0]].line, Err: err}
+		}
+		for i, result := range results {
+			row := &report.Rows[batchRows[i]]
+			switch {
+			case result.Err != nil:
+				row.Status, row.Error = ImportRowFailed, importErrorMessage(result.Err, row.Line)
+			case result.Created:
+				row.Status, row.ProductID = ImportRowCreated, result.ProductID
+			default:
+				row.Status, row.ProductID = ImportRowUpdated, result.ProductID
+			}
+		}
+		batch, batchRows = batch[:0], batchRows[:0]
+		return nil
+	}
+	for i, row := range rows {
+		report.Rows[i] = ProductImportRow{Line: row.line, SKU: row.input.SKU}
+		if row.err == "" {
+			row.err = im.validateRow(row.input)
+		}
+		if row.err != "" {
+			report.Rows[i].Status, report.Rows[i].Error = ImportRowFailed, row.err
+			continue
+		}
+		batch = append(batch, row.input.product())
+		batchRows = append(batchRows, i)
+		if len(batch) == productImportBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case ImportRowCreated:
+			report.Created++
+		case ImportRowUpdated:
+			report.Updated++
+		default:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// validateRow applies the checks of CreateProduct to a row, returning what is wrong with it.
+func (im *ProductImporter) validateRow(input ProductCreateInput) string {
+	if err := im.validate.Struct(input); err != nil {
+		return "Validation failed: " + err.Error()
+	}
+	if errMsg := validatePrice(input.Price); errMsg != "" && !inheritsPrice(input.ParentID, input.Price) {
+		return errMsg
+	}
+	return ""
+}
+
+// importErrorMessage describes a row the store rejected, with the wording of CreateProduct.
+func importErrorMessage(err error, line int) string {
+	switch {
+	case errors.Is(err, store.ErrCategoryNotFound):
+		return "Invalid category_id: category does not exist."
+	case errors.Is(err, store.ErrInvalidVariant), errors.Is(err, store.ErrInvalidPrice):
+		return "Validation failed: " + strings.TrimPrefix(err.Error(), "store: ")
+	case errors.Is(err, store.ErrProductSKUExists), errors.Is(err, store.ErrVariantExists), errors.Is(err, store.ErrAttributeSchemaViolation):
+		return strings.TrimPrefix(err.Error(), "store: ")
+	}
+	log.Printf("ERROR: ImportProducts failed for the row on line %d: %v", line, err)
+	return "Failed to import product"
+}
+
+// readNDJSONImport reads one ProductCreateInput per non-blank line.
+func readNDJSONImport(r io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxNDJSONLineBytes)
+	var rows []importRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		row := importRow{line: line}
+		if err := json.Unmarshal(data, &row.input); err != nil {
+			row.err = "Invalid row: " + err.Error()
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	return rows, nil
+}
+
+// csvFieldKind says how the CSV cells of a product field are turned into its JSON value.
+type csvFieldKind int
+
+const (
+	csvText    csvFieldKind = iota // A JSON string
+	csvInteger                     // A JSON number
+	csvBool                        // true or false, as accepted by strconv.ParseBool
+	csvJSON                        // JSON, e.g. {"color":"red"} for attributes
+	csvMoney                       // The decimal price, with the currency column (default USD)
+)
+
+// csvFields are the product fields a CSV column can hold: those of ProductCreateInput by JSON name,
+// with the price split into price and currency.
+var csvFields = map[string]csvFieldKind{
+	"name": csvText, "description": csvText, "sku": csvText, "image_url": csvText,
+	"price": csvMoney, "currency": csvMoney,
+	"stock_quantity": csvInteger, "category_id": csvInteger, "parent_id": csvInteger,
+	"is_active":  csvBool,
+	"attributes": csvJSON, "options": csvJSON, "option_values": csvJSON,
+}
+
+// readCSVImport reads a product from each record after the header, which names the columns.
+func readCSVImport(r io.Reader, columns map[string]string) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the CSV has no header", ErrInvalidImport)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	fields, err := csvColumnFields(header, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+		}
+		row := importRow{line: line}
+		if err != nil {
+			row.err = fmt.Sprintf("Invalid row: expected %d fields, found %d", len(header), len(record))
+		} else if err := decodeCSVRecord(record, fields, &row.input); err != nil {
+			row.err = "Invalid row: " + err.Error()
+		}
+		if row.input.SKU == "" { // Report the SKU of rows that cannot be decoded too
+			for i, field := range fields {
+				if field == "sku" && i < len(record) {
+					row.input.SKU = record[i]
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// csvColumnFields returns the field of each column of header, "" for ignored columns.
+func csvColumnFields(header []string, columns map[string]string) ([]string, error) {
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // Byte order mark of spreadsheet exports
+	}
+	for column, field := range columns {
+		if _, ok := csvFields[field]; !ok {
+			return nil, fmt.Errorf("%w: column %q is mapped to unknown field %q", ErrInvalidImport, column, field)
+		}
+	}
+	fields := make([]string, len(header))
+	mapped := make(map[string]bool, len(columns))
+	seen := make(map[string]string, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		field, ok := columns[column]
+		if ok {
+			mapped[column] = true
+		} else if _, known := csvFields[column]; known {
+			field = column
+		}
+		if field == "" {
+			continue
+		}
+		if other, dup := seen[field]; dup {
+			return nil, fmt.Errorf("%w: columns %q and %q both hold %s", ErrInvalidImport, other, column, field)
+		}
+		seen[field] = column
+		fields[i] = field
+	}
+	for column := range columns {
+		if !mapped[column] {
+			return nil, fmt.Errorf("%w: mapped column %q is not in the header", ErrInvalidImport, column)
+		}
+	}
+	return fields, nil
+}
+
+// decodeCSVRecord sets input from the cells of a record. Empty cells leave their field unset.
+func decodeCSVRecord(record, fields []string, input *ProductCreateInput) error {
+	object := make(map[string]json.RawMessage, len(fields))
+	var amount, currency string
+	for i, field := range fields {
+		cell := strings.TrimSpace(record[i])
+		if field == "" || cell == "" {
+			continue
+		}
+		switch csvFields[field] {
+		case csvText:
+			object[field], _ = json.Marshal(cell)
+		case csvInteger:
+			if _, err := strconv.ParseInt(cell, 10, 64); err != nil {
+				return fmt.Errorf("%s: %q is not an integer", field, cell)
+			}
+			object[field] = json.RawMessage(cell)
+		case csvBool:
+			value, err := strconv.ParseBool(cell)
+			if err != nil {
+				return fmt.Errorf("%s: %q is not a boolean", field, cell)
+			}
+			object[field], _ = json.Marshal(value)
+		case csvJSON:
+			if !json.Valid([]byte(cell)) {
+				return fmt.Errorf("%s: invalid JSON", field)
+			}
+			object[field] = json.RawMessage(cell)
+		case csvMoney:
+			if field == "price" {
+				amount = cell
+			} else {
+				currency = cell
+			}
+		}
+	}
+	if amount != "" {
+		if currency == "" {
+			currency = domain.DefaultCurrency
+		}
+		price, err := domain.ParseMoney(amount, currency)
+		if err != nil {
+			return fmt.Errorf("price: %v", err)
+		}
+		object["price"], _ = json.Marshal(price)
+	}
+	data, _ := json.Marshal(object)
+	return json.Unmarshal(data, input)
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+)
+
+// getProduct returns a product like GetProductByID, or with includeDeleted also a product in the
+// trash, so that consumers such as order history can still resolve products deleted since. The
+// history endpoints use it too: a product's ledger outlives it until the product is purged.
+func getProduct(ctx context.Context, productStore store.ProductStorer, id int64, includeDeleted bool) (*domain.Product, error) {
+	if !includeDeleted {
+		return productStore.GetProductByID(ctx, id)
+	}
+	products, _, err := productStore.ListProducts(ctx, store.ListProductsParams{
+		Limit: 1, ProductIDs: []int64{id}, Deleted: store.DeletedIncluded,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, store.ErrProductNotFound
+	}
+	return &products[0], nil
+}
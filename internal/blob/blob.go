@@ -0,0 +1,45 @@
+// Package blob stores binary objects, such as uploaded product images, under slash-separated keys.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Predefined errors for blob storage
+var (
+	ErrNotFound   = errors.New("blob: not found")
+	ErrInvalidKey = errors.New("blob: invalid key")
+)
+
+// Storage is a store of blobs. Implementations must be safe for concurrent use.
+type Storage interface {
+	// Put stores the content of r under key, replacing any blob there. Readers never see a
+	// partially written blob.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open returns the content of the blob under key, or ErrNotFound. The caller closes it.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob under key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// ValidKey reports whether key can name a blob: non-empty segments separated by slashes, made of
+// ASCII letters, digits, '.', '-' and '_', and none of them "." or "..".
+func ValidKey(key string) bool {
+	if key == "" || len(key) > 512 {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+		for _, c := range segment {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
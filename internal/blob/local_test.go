@@ -0,0 +1,35 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "products/1/a.jpg", strings.NewReader("first")))
+	require.NoError(t, s.Put(ctx, "products/1/a.jpg", strings.NewReader("second")))
+	r, err := s.Open(ctx, "products/1/a.jpg")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "second", string(content), "replaced")
+
+	require.NoError(t, s.Delete(ctx, "products/1/a.jpg"))
+	require.NoError(t, s.Delete(ctx, "products/1/a.jpg"), "already gone")
+	_, err = s.Open(ctx, "products/1/a.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	for _, key := range []string{"", "../secret", "products//a.jpg", "/etc/passwd", "products/./a.jpg", `products\a.jpg`} {
+		assert.ErrorIs(t, s.Put(ctx, key, strings.NewReader("x")), ErrInvalidKey, key)
+	}
+}
@@ -15,9 +15,18 @@ import (
 type Config struct {
 	AppEnv     string `envconfig:"APP_ENV" default:"development"` // e.g., development, staging, production
 	LogLevel   string `envconfig:"LOG_LEVEL" default:"info"`    // e.g., debug, info, warn, error
+	// StoreBackend selects the storage implementation: "postgres" or "memory".
+	// The memory backend needs no database and is meant for local development and tests.
+	StoreBackend string `envconfig:"STORE_BACKEND" default:"postgres"`
 	HttpServer ServerConfig
 	GrpcServer GrpcServerConfig
 	Postgres   PostgresConfig
+	Jobs       JobsConfig
+	Idempotency IdempotencyConfig
+	Pagination PaginationConfig
+	Search     SearchConfig
+	Images     ImagesConfig
+	Trash      TrashConfig
 	// Add other configurations like JWT secrets, external service URLs, etc.
 	// JWTSecret string `envconfig:"JWT_SECRET" required:"true"`
 }
@@ -37,13 +46,71 @@ type GrpcServerConfig struct {
 	// Add other gRPC specific settings if needed, e.g., max message size
 }
 
+// JobsConfig holds settings for the background jobs started with the servers (see internal/jobs).
+type JobsConfig struct {
+	// ReservationSweepInterval is how often expired stock reservations are released.
+	ReservationSweepInterval time.Duration `envconfig:"RESERVATION_SWEEP_INTERVAL" default:"1m"`
+}
+
+// IdempotencyConfig controls how long processed request keys (e.g. UpdateStock order IDs) are remembered.
+type IdempotencyConfig struct {
+	// Retention is how long a key is replayed from its stored result before it may be reused.
+	Retention time.Duration `envconfig:"IDEMPOTENCY_KEY_RETENTION" default:"24h"`
+	// PurgeInterval is how often keys past their retention are deleted.
+	PurgeInterval time.Duration `envconfig:"IDEMPOTENCY_PURGE_INTERVAL" default:"1h"`
+}
+
+// PaginationConfig holds settings for cursor (keyset) pagination of list endpoints.
+type PaginationConfig struct {
+	// TokenSecret signs page tokens and cursors. It must be the same on every instance behind a
+	// load balancer; if empty, a random secret is generated at startup.
+	TokenSecret string `envconfig:"PAGE_TOKEN_SECRET"`
+}
+
+// SearchConfig holds settings for product full-text search.
+type SearchConfig struct {
+	// Language is the Postgres text search configuration (e.g. "english", "german", "simple") used to
+	// stem product text and queries. Changing it reindexes all products on the next startup.
+	Language string `envconfig:"SEARCH_LANGUAGE" default:"english"`
+}
+
+// ImagesConfig holds settings for product image uploads.
+type ImagesConfig struct {
+	// StorageDir is the directory uploaded images and their thumbnails are stored in.
+	StorageDir string `envconfig:"IMAGE_STORAGE_DIR" default:"data/images"`
+	// MaxUploadBytes is the largest image file accepted.
+	MaxUploadBytes int64 `envconfig:"IMAGE_MAX_UPLOAD_BYTES" default:"10485760"`
+	// ThumbnailSize is the longer side of generated thumbnails, in pixels.
+	ThumbnailSize int `envconfig:"IMAGE_THUMBNAIL_SIZE" default:"320"`
+	// PublicBaseURL prefixes the URLs of uploaded images (e.g. "https://catalog.example.com"). If
+	// empty, they are stored as paths relative to the API's address.
+	PublicBaseURL string `envconfig:"IMAGE_PUBLIC_BASE_URL"`
+}
+
+// TrashConfig controls how long deleted products and categories stay in the trash.
+type TrashConfig struct {
+	// Retention is how long a deleted product or category can be restored before it is purged.
+	Retention time.Duration `envconfig:"TRASH_RETENTION" default:"720h"`
+	// PurgeInterval is how often rows past their retention are purged.
+	PurgeInterval time.Duration `envconfig:"TRASH_PURGE_INTERVAL" default:"1h"`
+}
+
+// Supported values for Config.StoreBackend.
+const (
+	StoreBackendPostgres = "postgres"
+	StoreBackendMemory   = "memory"
+)
+
 // PostgresConfig holds PostgreSQL database connection details.
+// Host, User, Password and DBName are required when STORE_BACKEND is "postgres" (checked in Load).
 type PostgresConfig struct {
-    Host     string `envconfig:"POSTGRES_HOST" required:"true"`
+    Host     string `envconfig:"POSTGRES_HOST"`
     Port     string `envconfig:"POSTGRES_PORT" default:"5432"`
-    User     string `envconfig:"POSTGRES_USER" required:"true"`
-    Password string `envconfig:"POSTGRES_PASSWORD" required:"true"`
-    DBName   string `envconfig:"POSTGRES_DBNAME" required:"true"`
+    User     string `envconfig:"POSTGRES_USER"`
+    Password string `envconfig:"POSTGRES_PASSWORD"`
+    DBName   string `envconfig:"POSTGRES_DBNAME"`
+    // AutoMigrate applies pending schema migrations on startup (see internal/migrate).
+    AutoMigrate bool `envconfig:"POSTGRES_AUTO_MIGRATE" default:"true"`
 }
 
 // DSN constructs the Data Source Name string for connecting to PostgreSQL.
@@ -68,6 +135,31 @@ func Load() (*Config, error) {
 	// if cfg.AppEnv != "development" && cfg.AppEnv != "staging" && cfg.AppEnv != "production" {
 	// 	return nil, fmt.Errorf("invalid APP_ENV: %s", cfg.AppEnv)
 	// }
+	switch cfg.StoreBackend {
+	case StoreBackendPostgres:
+		if cfg.Postgres.Host == "" || cfg.Postgres.User == "" || cfg.Postgres.Password == "" || cfg.Postgres.DBName == "" {
+			return nil, fmt.Errorf("POSTGRES_HOST, POSTGRES_USER, POSTGRES_PASSWORD and POSTGRES_DBNAME are required when STORE_BACKEND=%s", StoreBackendPostgres)
+		}
+	case StoreBackendMemory:
+	default:
+		return nil, fmt.Errorf("invalid STORE_BACKEND: %q (expected %q or %q)", cfg.StoreBackend, StoreBackendPostgres, StoreBackendMemory)
+	}
+
+	if cfg.Jobs.ReservationSweepInterval <= 0 {
+		return nil, fmt.Errorf("RESERVATION_SWEEP_INTERVAL must be positive, got %s", cfg.Jobs.ReservationSweepInterval)
+	}
+	if cfg.Idempotency.Retention <= 0 || cfg.Idempotency.PurgeInterval <= 0 {
+		return nil, fmt.Errorf("IDEMPOTENCY_KEY_RETENTION and IDEMPOTENCY_PURGE_INTERVAL must be positive")
+	}
+	if cfg.Search.Language == "" {
+		return nil, fmt.Errorf("SEARCH_LANGUAGE must not be empty")
+	}
+	if cfg.Images.StorageDir == "" || cfg.Images.MaxUploadBytes <= 0 || cfg.Images.ThumbnailSize <= 0 {
+		return nil, fmt.Errorf("IMAGE_STORAGE_DIR must not be empty, and IMAGE_MAX_UPLOAD_BYTES and IMAGE_THUMBNAIL_SIZE must be positive")
+	}
+	if cfg.Trash.Retention <= 0 || cfg.Trash.PurgeInterval <= 0 {
+		return nil, fmt.Errorf("TRASH_RETENTION and TRASH_PURGE_INTERVAL must be positive")
+	}
 
 	log.Printf("Configuration loaded successfully for APP_ENV: %s", cfg.AppEnv)
 	// For security, avoid logging sensitive parts of the config like passwords or full DSNs in production.
@@ -78,7 +170,7 @@ func Load() (*Config, error) {
 // Get returns the loaded configuration.
 // Panics if Load() has not been called successfully.
 func Get() *Config {
-	if cfg.Postgres.Host == "" { // Simple check to see if cfg is populated
+	if cfg.StoreBackend == "" { // Simple check to see if cfg is populated (StoreBackend always has a default)
 		log.Fatal("Configuration has not been loaded. Call config.Load() first.")
 	}
 	return &cfg
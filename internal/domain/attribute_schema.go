@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// AttributeType is the JSON type a product attribute must have.
+type AttributeType string
+
+const (
+	AttributeTypeString     AttributeType = "string"
+	AttributeTypeNumber     AttributeType = "number"
+	AttributeTypeBoolean    AttributeType = "boolean"
+	AttributeTypeStringList AttributeType = "string_list" // An array of strings
+)
+
+// CategoryAttribute defines a product attribute for the products of a category and of all its
+// subcategories. A subcategory may redefine a key; the nearest definition applies.
+type CategoryAttribute struct {
+	CategoryID int64         `json:"category_id"` // The category that defines the attribute
+	Key        string        `json:"key"`
+	Type       AttributeType `json:"type"`
+	EnumValues []string      `json:"enum_values,omitempty"` // Allowed values of string and string_list attributes; empty allows any
+	Required   bool          `json:"required"`
+	Unit       *string       `json:"unit,omitempty"` // Unit of number attributes, for display (e.g. "kg")
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
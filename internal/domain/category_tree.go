@@ -0,0 +1,10 @@
+package domain
+
+// CategoryNode is a category with its subcategories, as returned by category tree listings.
+type CategoryNode struct {
+	Category
+	// HasChildren is set when the category has subcategories, even if the depth limit of the
+	// listing left Children empty.
+	HasChildren bool           `json:"has_children"`
+	Children    []CategoryNode `json:"children"` // Ordered by name
+}
@@ -0,0 +1,39 @@
+package domain
+
+// ProductFacets summarizes the products matching a listing's filters (all of them, not just one
+// page), e.g. for the filter sidebar of a storefront. Only the requested facets are set.
+type ProductFacets struct {
+	Categories  []CategoryFacet   `json:"categories,omitempty"`
+	PriceRanges []PriceRangeFacet `json:"price_ranges,omitempty"`
+	IsActive    []IsActiveFacet   `json:"is_active,omitempty"`
+	// Attributes maps each requested attribute key to the counts of its values, most frequent first.
+	Attributes map[string][]AttributeValueFacet `json:"attributes,omitempty"`
+}
+
+// CategoryFacet is the number of matching products in a category; CategoryID is nil for products
+// without one.
+type CategoryFacet struct {
+	CategoryID *int64 `json:"category_id"`
+	Count      int    `json:"count"`
+}
+
+// PriceRangeFacet is the number of matching products priced in [Min, Max), which are in the same
+// currency. The lowest range has no Min and the highest no Max.
+type PriceRangeFacet struct {
+	Min   *Money `json:"min,omitempty"`
+	Max   *Money `json:"max,omitempty"`
+	Count int    `json:"count"`
+}
+
+// IsActiveFacet is the number of matching products with the given is_active value.
+type IsActiveFacet struct {
+	Value bool `json:"value"`
+	Count int  `json:"count"`
+}
+
+// AttributeValueFacet is the number of matching products whose attribute has Value, or contains it
+// if the attribute is an array. Values are the JSON scalars as text (e.g. "red", "16", "true").
+type AttributeValueFacet struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord remembers that a request identified by Key (e.g. an order ID) was received for
+// Operation, so a retry can be answered with the stored Response instead of being applied again.
+type IdempotencyRecord struct {
+	Key         string
+	Operation   string
+	RequestHash string // Fingerprint of the request payload; a replay with a different payload is a conflict
+	Response    []byte // Serialized response; nil while the original request is still in flight
+	CreatedAt   time.Time
+	CompletedAt *time.Time // Nil until Response is stored
+	ExpiresAt   time.Time
+}
+
+// Completed reports whether the original request finished and its response was stored.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.CompletedAt != nil
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Location is a warehouse or other place that holds stock.
+type Location struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"` // Unique, stable identifier (e.g. "ams-1")
+	Name      string    `json:"name"`
+	Priority  int32     `json:"priority"` // Allocation order: lower ships first, ties broken by ID
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LocationStock is the quantity of a product held at one location.
+// A product's StockQuantity is the sum of its LocationStock quantities.
+type LocationStock struct {
+	ProductID  int64     `json:"product_id"`
+	LocationID int64     `json:"location_id"`
+	Quantity   int32     `json:"quantity"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// StockAllocation is the part of a stock change applied to (or planned for) one location.
+type StockAllocation struct {
+	LocationID int64 `json:"location_id"`
+	Quantity   int32 `json:"quantity"` // Signed, like the change it belongs to
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency of prices given without one. Prices stored before products had a
+// currency (migration 0009) are in this currency.
+const DefaultCurrency = "USD"
+
+// MaxCurrencyExponent is the largest number of decimal places of an ISO 4217 currency, and the
+// scale of the price column.
+const MaxCurrencyExponent = 3
+
+// MaxScaledAmount is the largest absolute amount, in units of 10^-MaxCurrencyExponent, that the
+// NUMERIC(15,3) price columns hold: 999,999,999,999.999.
+const MaxScaledAmount = 999_999_999_999_999
+
+// maxWholeDigits is the number of digits before the decimal point of the price columns.
+const maxWholeDigits = 12
+
+// ErrInvalidMoney is wrapped by every error parsing an amount of money.
+var ErrInvalidMoney = errors.New("invalid amount of money")
+
+// currencyExponents lists the ISO 4217 currencies whose minor unit is not a hundredth.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Money is an exact amount of a currency. Amounts are never floating point: a price of 19.99 USD is
+// Money{Amount: 1999, Currency: "USD"}.
+//
+// In JSON it is an object with the amount as a decimal string, {"amount": "19.99", "currency":
+// "USD"}. For compatibility it is also read from a bare decimal number or string, in DefaultCurrency.
+type Money struct {
+	Amount   int64  // In minor units of Currency (e.g. cents)
+	Currency string // ISO 4217 code, e.g. "USD"
+}
+
+// CurrencyExponent returns the number of decimal places of a currency's minor unit.
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// ValidCurrency reports whether code looks like an ISO 4217 code: three upper-case letters.
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseMoney parses a decimal amount such as "19.99" of a currency. It fails if the amount has more
+// decimal places than the currency's minor unit, unless the extra digits are zeros, or if it is out
+// of range (see InRange).
+func ParseMoney(amount, currency string) (Money, error) {
+	if !ValidCurrency(currency) {
+		return Money{}, fmt.Errorf("%w: currency %q is not an ISO 4217 code", ErrInvalidMoney, currency)
+	}
+	minor, err := ParseDecimal(amount, CurrencyExponent(currency))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// ParseDecimal returns a decimal string multiplied by 10^scale, failing unless that is an integer.
+// Like the price columns, it only takes decimals below 10^12 in absolute value.
+func ParseDecimal(s string, scale int) (int64, error) {
+	invalid := fmt.Errorf("%w: %q is not a decimal with at most %d decimal places", ErrInvalidMoney, s, scale)
+	digits := strings.TrimPrefix(s, "-")
+	whole, frac, _ := strings.Cut(digits, ".")
+	frac = strings.TrimRight(frac, "0")
+	if whole == "" || len(frac) > scale || strings.Trim(whole+frac, "0123456789") != "" {
+		return 0, invalid
+	}
+	if len(strings.TrimLeft(whole, "0")) > maxWholeDigits {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidMoney, s)
+	}
+	n, err := strconv.ParseInt(whole+frac+strings.Repeat("0", scale-len(frac)), 10, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	if digits != s {
+		n = -n
+	}
+	return n, nil
+}
+
+// InRange reports whether the amount fits the price columns, whose largest absolute value is
+// MaxScaledAmount.
+func (m Money) InRange() bool {
+	n := m.ScaledAmount()
+	return n >= -MaxScaledAmount && n <= MaxScaledAmount
+}
+
+// Decimal returns the amount as a decimal string with the currency's decimal places, e.g. "19.90".
+func (m Money) Decimal() string {
+	exp := CurrencyExponent(m.Currency)
+	digits := strconv.FormatInt(m.Amount, 10)
+	sign := ""
+	if m.Amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// CompareAmounts compares the decimal values of a and b, ignoring their currencies, the way the
+// numeric price column orders them.
+func CompareAmounts(a, b Money) int {
+	x, y := a.ScaledAmount(), b.ScaledAmount()
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ScaledAmount returns the amount in units of 10^-MaxCurrencyExponent, which every currency's
+// amounts can be compared in. Amounts too large for an int64 at that scale saturate, so they still
+// order correctly against every amount in range.
+func (m Money) ScaledAmount() int64 {
+	n := m.Amount
+	for i := CurrencyExponent(m.Currency); i < MaxCurrencyExponent; i++ {
+		switch {
+		case n > math.MaxInt64/10:
+			return math.MaxInt64
+		case n < math.MinInt64/10:
+			return math.MinInt64
+		}
+		n *= 10
+	}
+	return n
+}
+
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.Decimal(), m.Currency})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := decoder.Decode(&v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMoney, err)
+		}
+		if v.Amount == "" {
+			return fmt.Errorf("%w: amount is required", ErrInvalidMoney)
+		}
+	} else if err := decoder.Decode(&v.Amount); err != nil {
+		return fmt.Errorf("%w: must be a decimal or an object with amount and currency", ErrInvalidMoney)
+	}
+	if v.Currency == "" {
+		v.Currency = DefaultCurrency
+	}
+	parsed, err := ParseMoney(v.Amount.String(), v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// PriceChangeReason says why a product's price changed.
+type PriceChangeReason string
+
+const (
+	PriceReasonInitialPrice            PriceChangeReason = "initial_price"             // Product created
+	PriceReasonProductUpdate           PriceChangeReason = "product_update"            // Price edited through a product update
+	PriceReasonScheduledPrice          PriceChangeReason = "scheduled_price"           // A scheduled price (e.g. a sale) was created
+	PriceReasonScheduledPriceCancelled PriceChangeReason = "scheduled_price_cancelled" // A scheduled price was deleted before it ended
+)
+
+// PriceChange is an entry of the append-only price history: one change of a product's price. Base
+// price changes take effect when they are made; a scheduled price takes effect at its start.
+type PriceChange struct {
+	ID               int64             `json:"id"`
+	ProductID        int64             `json:"product_id"`
+	OldPrice         *Money            `json:"old_price,omitempty"` // nil for initial_price
+	NewPrice         Money             `json:"new_price"`
+	EffectiveAt      time.Time         `json:"effective_at"`
+	EffectiveUntil   *time.Time        `json:"effective_until,omitempty"` // End of a scheduled price, if it has one
+	Reason           PriceChangeReason `json:"reason"`
+	ScheduledPriceID *int64            `json:"scheduled_price_id,omitempty"`
+	Actor            *string           `json:"actor,omitempty"` // Who made the change, when known
+	CreatedAt        time.Time         `json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// PriceList is a named set of product prices in one currency, optionally for one market and
+// customer group (e.g. "EU retail" in EUR for market "DE"). Products without an entry in a list fall
+// back to the default list of its currency, and then to their base Price.
+type PriceList struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"` // Unique
+	Currency      string    `json:"currency"`
+	Market        *string   `json:"market,omitempty"`         // e.g. a country code
+	CustomerGroup *string   `json:"customer_group,omitempty"` // e.g. "wholesale"
+	IsDefault     bool      `json:"is_default"`               // At most one default list per currency
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// PriceListEntry is the price of a product in a price list. Price is in the list's currency.
+type PriceListEntry struct {
+	PriceListID int64     `json:"price_list_id"`
+	ProductID   int64     `json:"product_id"`
+	Price       Money     `json:"price"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
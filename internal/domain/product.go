@@ -14,6 +14,7 @@ type Category struct {
 	ParentCategoryID *int64     `json:"parent_category_id,omitempty"` // Pointer for nullable fields
 	CreatedAt        time.Time  `json:"created_at"`
 	UpdatedAt        time.Time  `json:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`        // Set while the category is in the trash
 }
 
 // Product represents a product in the catalog.
@@ -23,15 +24,35 @@ type Product struct {
 	Name           string           `json:"name"`
 	Description    *string          `json:"description,omitempty"`    // Pointer for nullable fields
 	SKU            string           `json:"sku"`
-	Price          float64          `json:"price"`                    // For currency, consider using a dedicated decimal type library in production for precision
+	Price          Money            `json:"price"`                    // Exact amount and currency; the effective price on reads
+	// The base price while a scheduled price (e.g. a sale) replaces it in Price; nil otherwise.
+	CompareAtPrice *Money           `json:"compare_at_price,omitempty"`
 	StockQuantity  int32            `json:"stock_quantity"`
 	CategoryID     *int64           `json:"category_id,omitempty"`    // Pointer for nullable fields
-	ImageURL       *string          `json:"image_url,omitempty"`      // Pointer for nullable fields
+	ImageURL       *string          `json:"image_url,omitempty"`      // Derived from Media once the product has any
 	IsActive       bool             `json:"is_active"`
 	Attributes     *json.RawMessage `json:"attributes,omitempty"`     // For JSONB. Use json.RawMessage to defer parsing.
 	                                                                // Alternatively, use *map[string]interface{}
 	CreatedAt      time.Time        `json:"created_at"`
 	UpdatedAt      time.Time        `json:"updated_at"`
+	// Set only in listings filtered by a search query: how well the product matches (higher is
+	// better) and an excerpt of its description (or name) with the matched words in <mark> tags.
+	SearchRank     *float32         `json:"search_rank,omitempty"`
+	SearchSnippet  *string          `json:"search_snippet,omitempty"`
+	// Set only when Price was taken from a price list rather than being the base price.
+	PriceListID    *int64           `json:"price_list_id,omitempty"`
+	// Variants: a parent product names its options (e.g. color, size); each variant is a product with
+	// its own SKU, price and stock that points to the parent and has a value for every option.
+	ParentID       *int64            `json:"parent_id,omitempty"`
+	Options        []string          `json:"options,omitempty"`       // Option names of a parent, in display order
+	OptionValues   map[string]string `json:"option_values,omitempty"` // A variant's value for each option of its parent
+	InheritsPrice  bool              `json:"inherits_price,omitempty"` // A variant without a price override: it has the parent's base price
+	// Set only where requested on a parent's reads: its variants, ordered by ID.
+	Variants       []Product         `json:"variants,omitempty"`
+	// Set on reads: the product's images in display order (see ProductMedia).
+	Media          []ProductMedia    `json:"media,omitempty"`
+	// Set while the product is in the trash: reads leave it out unless asked for deleted products.
+	DeletedAt      *time.Time        `json:"deleted_at,omitempty"`
 }
 
 // Note on Product.Attributes:
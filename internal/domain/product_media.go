@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// MediaRole is what a product image is for.
+type MediaRole string
+
+const (
+	MediaRolePrimary   MediaRole = "primary"   // The main image, at most one per product
+	MediaRoleGallery   MediaRole = "gallery"   // Further images, e.g. other angles
+	MediaRoleThumbnail MediaRole = "thumbnail" // A small rendition for listings
+)
+
+// Valid reports whether r is a known role.
+func (r MediaRole) Valid() bool {
+	switch r {
+	case MediaRolePrimary, MediaRoleGallery, MediaRoleThumbnail:
+		return true
+	}
+	return false
+}
+
+// ProductMedia is an image of a product. A product's media are ordered by Position, then ID, and
+// its ImageURL is derived from them: the URL of the primary image, or else of the first gallery
+// image.
+type ProductMedia struct {
+	ID        int64     `json:"id"`
+	ProductID int64     `json:"product_id"`
+	URL       string    `json:"url"`
+	AltText   string    `json:"alt_text"`
+	Role      MediaRole `json:"role"`
+	Position  int32     `json:"position"`         // 1-based display order; positions may have gaps
+	Width     *int32    `json:"width,omitempty"`  // In pixels, if known
+	Height    *int32    `json:"height,omitempty"` // In pixels, if known
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// ReservationStatus is the lifecycle state of a StockReservation.
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"    // Holding stock until ExpiresAt
+	ReservationCommitted ReservationStatus = "committed" // Converted into a stock decrement
+	ReservationReleased  ReservationStatus = "released"  // Returned by the caller
+	ReservationExpired   ReservationStatus = "expired"   // Returned by the sweeper after ExpiresAt
+)
+
+// StockReservation holds a quantity of a product for an order or cart (ReferenceID)
+// until it is committed, released or expires.
+type StockReservation struct {
+	ID          int64             `json:"id"`
+	ReferenceID string            `json:"reference_id"` // Order or cart ID the stock is held for
+	ProductID   int64             `json:"product_id"`
+	Quantity    int32             `json:"quantity"`
+	Status      ReservationStatus `json:"status"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// ScheduledPrice replaces a product's base price from ValidFrom until ValidUntil, e.g. a weekend
+// sale. While it applies, reads return it as the product's Price and the base price as
+// CompareAtPrice. If several apply at once, the one that started last wins.
+type ScheduledPrice struct {
+	ID         int64      `json:"id"`
+	ProductID  int64      `json:"product_id"`
+	Price      Money      `json:"price"`                 // In the currency of the product's base price
+	ValidFrom  time.Time  `json:"valid_from"`            // Inclusive
+	ValidUntil *time.Time `json:"valid_until,omitempty"` // Exclusive; nil for no end
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ActiveAt reports whether the price applies at t.
+func (sp *ScheduledPrice) ActiveAt(t time.Time) bool {
+	return !t.Before(sp.ValidFrom) && (sp.ValidUntil == nil || t.Before(*sp.ValidUntil))
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// StockMovementReason says why a product's stock changed.
+type StockMovementReason string
+
+const (
+	StockReasonInitialStock      StockMovementReason = "initial_stock"      // Product created with stock
+	StockReasonProductUpdate     StockMovementReason = "product_update"     // stock_quantity edited through a product update
+	StockReasonStockUpdate       StockMovementReason = "stock_update"       // UpdateStock RPC
+	StockReasonReservationCommit StockMovementReason = "reservation_commit" // A stock reservation was committed
+	StockReasonAdjustment        StockMovementReason = "adjustment"         // Manual adjustment (e.g. stocktake, damage)
+	StockReasonTransfer          StockMovementReason = "transfer"           // Stock moved between locations
+)
+
+// StockMovement is an entry of the append-only inventory ledger: one change of a product's stock.
+type StockMovement struct {
+	ID            int64               `json:"id"`
+	ProductID     int64               `json:"product_id"`
+	LocationID    *int64              `json:"location_id,omitempty"` // Location whose stock changed; nil for entries from before locations
+	QuantityDelta int32               `json:"quantity_delta"`
+	BalanceAfter  int32               `json:"balance_after"` // Aggregate stock_quantity right after the change
+	Reason        StockMovementReason `json:"reason"`
+	OrderID       *string             `json:"order_id,omitempty"` // Order or reservation reference that caused the change
+	Actor         *string             `json:"actor,omitempty"`    // Who made the change, when known
+	Note          *string             `json:"note,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
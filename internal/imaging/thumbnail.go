@@ -0,0 +1,42 @@
+// Package imaging resizes images in pure Go, for thumbnails of uploaded product images.
+package imaging
+
+import (
+	"image"
+	"image/color"
+)
+
+// Thumbnail returns src scaled down so that its longer side is at most size pixels, keeping the
+// aspect ratio. Each thumbnail pixel is the average of the source pixels it covers (a box filter),
+// which avoids the aliasing of nearest-neighbour sampling on photos. Images that already fit are
+// copied unscaled.
+func Thumbnail(src image.Image, size int) *image.NRGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if w > size || h > size {
+		if w >= h {
+			dw, dh = size, max(1, (h*size+w/2)/w)
+		} else {
+			dw, dh = max(1, (w*size+h/2)/h), size
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := b.Min.Y+y*h/dh, b.Min.Y+(y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			x0, x1 := b.Min.X+x*w/dw, b.Min.X+(x+1)*w/dw
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// Alpha-premultiplied, so transparent pixels do not darken their neighbours.
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
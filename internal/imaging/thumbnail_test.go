@@ -0,0 +1,42 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbnail(t *testing.T) {
+	red, blue := color.NRGBA{R: 255, A: 255}, color.NRGBA{B: 255, A: 255}
+	src := image.NewNRGBA(image.Rect(10, 10, 410, 210)) // Bounds need not start at the origin
+	for y := 10; y < 210; y++ {
+		for x := 10; x < 410; x++ {
+			if x < 210 {
+				src.Set(x, y, red)
+			} else {
+				src.Set(x, y, blue)
+			}
+		}
+	}
+
+	thumb := Thumbnail(src, 100)
+	assert.Equal(t, image.Rect(0, 0, 100, 50), thumb.Bounds(), "the aspect ratio is kept")
+	assert.Equal(t, red, thumb.NRGBAAt(0, 0))
+	assert.Equal(t, blue, thumb.NRGBAAt(99, 49))
+
+	// A pixel covering both halves is their average.
+	thumb = Thumbnail(src, 1)
+	assert.Equal(t, image.Rect(0, 0, 1, 1), thumb.Bounds())
+	assert.Equal(t, color.NRGBA{R: 127, B: 127, A: 255}, thumb.NRGBAAt(0, 0))
+
+	// Transparent pixels do not darken the average.
+	src.Set(10, 10, color.NRGBA{})
+	src.Set(11, 10, red)
+	small := Thumbnail(src.SubImage(image.Rect(10, 10, 12, 11)), 1)
+	assert.Equal(t, color.NRGBA{R: 255, A: 127}, small.NRGBAAt(0, 0))
+
+	assert.Equal(t, image.Rect(0, 0, 400, 200), Thumbnail(src, 1000).Bounds(), "never enlarged")
+	assert.Equal(t, image.Rect(0, 0, 1, 100), Thumbnail(image.NewGray(image.Rect(0, 0, 3, 1000)), 100).Bounds(), "at least a pixel wide")
+}
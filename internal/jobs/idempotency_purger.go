@@ -0,0 +1,14 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"product-catalog-service/internal/store"
+)
+
+// NewIdempotencyKeyPurger returns a job that deletes idempotency records past their retention window.
+// Expired records are already ignored when a key is claimed; purging only bounds the table size.
+func NewIdempotencyKeyPurger(is store.IdempotencyStorer, interval time.Duration, logger *log.Logger) *Periodic {
+	return NewPeriodic("idempotency key purger", interval, is.DeleteExpiredIdempotencyKeys, logger)
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+	"product-catalog-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReservationSweeper(t *testing.T) {
+	ctx := context.Background()
+	memStore := store.NewMemoryStore()
+	p, err := memStore.CreateProduct(ctx, &domain.Product{Name: "Widget", SKU: "W-1", StockQuantity: 5, IsActive: true})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	_, err = memStore.ReserveStock(ctx, "cart-1", []store.ReservationItem{{ProductID: p.ID, Quantity: 2}}, now.Add(time.Minute))
+	require.NoError(t, err)
+	_, err = memStore.ReserveStock(ctx, "cart-2", []store.ReservationItem{{ProductID: p.ID, Quantity: 3}}, now.Add(time.Hour))
+	require.NoError(t, err)
+
+	sweeper := NewReservationSweeper(memStore, time.Minute, log.New(io.Discard, "", 0))
+	assert.Equal(t, 0, sweeper.RunOnce(ctx), "nothing is overdue yet")
+
+	sweeper.now = func() time.Time { return now.Add(2 * time.Minute) }
+	assert.Equal(t, 1, sweeper.RunOnce(ctx))
+
+	_, err = memStore.ReleaseReservation(ctx, "cart-1")
+	assert.ErrorIs(t, err, store.ErrReservationNotFound, "expired holds can no longer be released")
+	_, err = memStore.ReleaseReservation(ctx, "cart-2")
+	assert.NoError(t, err)
+}
+
+func TestPeriodic_RunStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := NewPeriodic("test", time.Hour, func(context.Context, time.Time) (int, error) { return 0, nil }, log.New(io.Discard, "", 0))
+
+	done := make(chan struct{})
+	go func() {
+		job.Run(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
+
+func TestIdempotencyKeyPurger(t *testing.T) {
+	ctx := context.Background()
+	memStore := store.NewMemoryStore()
+	now := time.Now().UTC()
+	_, _, err := memStore.ClaimIdempotencyKey(ctx, "order-1", "update_stock", "hash", now.Add(time.Hour))
+	require.NoError(t, err)
+
+	purger := NewIdempotencyKeyPurger(memStore, time.Minute, log.New(io.Discard, "", 0))
+	assert.Equal(t, 0, purger.RunOnce(ctx))
+	purger.now = func() time.Time { return now.Add(2 * time.Hour) }
+	assert.Equal(t, 1, purger.RunOnce(ctx))
+}
+
+func TestTrashPurger(t *testing.T) {
+	ctx := context.Background()
+	memStore := store.NewMemoryStore()
+	c, err := memStore.CreateCategory(ctx, &domain.Category{Name: "Clearance"})
+	require.NoError(t, err)
+	p, err := memStore.CreateProduct(ctx, &domain.Product{Name: "Widget", SKU: "W-1", CategoryID: &c.ID, IsActive: true})
+	require.NoError(t, err)
+	require.NoError(t, memStore.DeleteProduct(ctx, p.ID))
+	require.NoError(t, memStore.DeleteCategory(ctx, c.ID, store.DeleteCategoryOptions{}))
+
+	now := time.Now().UTC()
+	purger := NewTrashPurger(memStore, memStore, 24*time.Hour, time.Hour, log.New(io.Discard, "", 0))
+	assert.Equal(t, 0, purger.RunOnce(ctx), "still within the retention period")
+
+	purger.now = func() time.Time { return now.Add(25 * time.Hour) }
+	assert.Equal(t, 2, purger.RunOnce(ctx))
+
+	_, err = memStore.RestoreProduct(ctx, p.ID)
+	assert.ErrorIs(t, err, store.ErrProductNotFound, "purged products can no longer be restored")
+}
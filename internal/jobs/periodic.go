@@ -0,0 +1,67 @@
+// Package jobs contains background jobs that run alongside the HTTP and gRPC servers.
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Task performs one pass of a periodic job as of now and returns how many records it affected.
+type Task func(ctx context.Context, now time.Time) (int, error)
+
+// Periodic runs a Task at a fixed interval until its context is cancelled.
+type Periodic struct {
+	name     string
+	interval time.Duration
+	task     Task
+	logger   *log.Logger
+	now      func() time.Time // Overridable in tests
+}
+
+// NewPeriodic creates a job called name (used in log lines) that runs task every interval.
+func NewPeriodic(name string, interval time.Duration, task Task, logger *log.Logger) *Periodic {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &Periodic{
+		name:     name,
+		interval: interval,
+		task:     task,
+		logger:   logger,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run executes the task once immediately and then on every tick until ctx is cancelled.
+func (p *Periodic) Run(ctx context.Context) {
+	p.logger.Printf("INFO: Job %q started (interval %s).", p.name, p.interval)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			p.logger.Printf("INFO: Job %q stopped.", p.name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce executes the task once and returns how many records it affected.
+// Errors are logged and retried on the next tick.
+func (p *Periodic) RunOnce(ctx context.Context) int {
+	affected, err := p.task(ctx, p.now())
+	if err != nil {
+		if ctx.Err() == nil {
+			p.logger.Printf("ERROR: Job %q failed: %v", p.name, err)
+		}
+		return 0
+	}
+	if affected > 0 {
+		p.logger.Printf("INFO: Job %q affected %d record(s).", p.name, affected)
+	}
+	return affected
+}
@@ -0,0 +1,15 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"product-catalog-service/internal/store"
+)
+
+// NewReservationSweeper returns a job that expires stock reservations whose expiry has passed,
+// returning the held stock to availability. Overdue reservations are already ignored by the
+// availability checks; the sweeper makes their status reflect that.
+func NewReservationSweeper(rs store.ReservationStorer, interval time.Duration, logger *log.Logger) *Periodic {
+	return NewPeriodic("reservation sweeper", interval, rs.ExpireReservations, logger)
+}
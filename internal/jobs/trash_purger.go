@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"product-catalog-service/internal/store"
+)
+
+// NewTrashPurger returns a job that permanently deletes the products and categories that have been
+// in the trash for longer than retention. Until then they can be restored.
+func NewTrashPurger(ps store.ProductStorer, cs store.CategoryStorer, retention, interval time.Duration, logger *log.Logger) *Periodic {
+	return NewPeriodic("trash purger", interval, func(ctx context.Context, now time.Time) (int, error) {
+		before := now.Add(-retention)
+		products, err := ps.PurgeDeletedProducts(ctx, before)
+		if err != nil {
+			return 0, err
+		}
+		categories, err := cs.PurgeDeletedCategories(ctx, before)
+		return products + categories, err
+	}, logger)
+}
@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_SortsAndPairsFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_add_index.up.sql":     {Data: []byte("CREATE INDEX x;")},
+		"migrations/0001_init_schema.up.sql":   {Data: []byte("CREATE TABLE a;")},
+		"migrations/0001_init_schema.down.sql": {Data: []byte("DROP TABLE a;")},
+	}
+
+	migrations, err := Load(fsys)
+
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "init_schema", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE a;", migrations[0].DownSQL)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Empty(t, migrations[1].DownSQL)
+}
+
+func TestLoad_RejectsInvalidFileName(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/init.sql": {Data: []byte("CREATE TABLE a;")},
+	}
+
+	_, err := Load(fsys)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidFileName))
+}
+
+func TestLoad_RequiresUpScript(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0001_init_schema.down.sql": {Data: []byte("DROP TABLE a;")},
+	}
+
+	_, err := Load(fsys)
+
+	require.Error(t, err)
+}
+
+func TestLoad_EmbeddedMigrationsAreValid(t *testing.T) {
+	migrations, err := Load(embeddedMigrations)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for _, m := range migrations {
+		assert.NotEmpty(t, m.DownSQL, "migration %04d_%s should be reversible", m.Version, m.Name)
+	}
+}
+
+func TestMigrator_Up_AppliesOnlyPending(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"migrations/0001_init_schema.up.sql": {Data: []byte("CREATE TABLE a;")},
+		"migrations/0002_add_index.up.sql":   {Data: []byte("CREATE INDEX x;")},
+	}
+	migrator, err := NewWithFS(db, fsys, nil)
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1);`)).WithArgs(advisoryLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, applied_at FROM schema_migrations ORDER BY version;`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(int64(1), time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX x;`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2);`)).
+		WithArgs(int64(2), "add_index").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1);`)).WithArgs(advisoryLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_RollsBackFailedMigration(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"migrations/0001_init_schema.up.sql": {Data: []byte("CREATE TABLE a;")},
+	}
+	migrator, err := NewWithFS(db, fsys, nil)
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version, applied_at FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE a;`)).WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background())
+
+	require.Error(t, err)
+	assert.Equal(t, 0, applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS products.products;
+DROP TABLE IF EXISTS products.categories;
+DROP SCHEMA IF EXISTS products;
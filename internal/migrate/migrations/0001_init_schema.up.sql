@@ -0,0 +1,47 @@
+-- 0001_init_schema: base schema for categories and products.
+-- Constraint names are spelled out explicitly because the store maps
+-- unique/foreign key violations back to domain errors by constraint name.
+
+CREATE SCHEMA IF NOT EXISTS products;
+
+CREATE TABLE products.categories (
+    id                 BIGSERIAL    PRIMARY KEY,
+    name               VARCHAR(255) NOT NULL,
+    description        TEXT,
+    parent_category_id BIGINT,
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT categories_name_key UNIQUE (name),
+    CONSTRAINT categories_parent_category_id_fkey FOREIGN KEY (parent_category_id)
+        REFERENCES products.categories (id) ON DELETE SET NULL,
+    CONSTRAINT categories_not_own_parent_check CHECK (parent_category_id IS NULL OR parent_category_id <> id)
+);
+
+CREATE INDEX categories_parent_category_id_idx ON products.categories (parent_category_id);
+
+CREATE TABLE products.products (
+    id             BIGSERIAL     PRIMARY KEY,
+    name           VARCHAR(255)  NOT NULL,
+    description    TEXT,
+    sku            VARCHAR(100)  NOT NULL,
+    price          NUMERIC(12,2) NOT NULL,
+    stock_quantity INTEGER       NOT NULL DEFAULT 0,
+    category_id    BIGINT,
+    image_url      VARCHAR(2048),
+    is_active      BOOLEAN       NOT NULL DEFAULT TRUE,
+    attributes     JSONB,
+    created_at     TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT products_sku_key UNIQUE (sku),
+    CONSTRAINT products_category_id_fkey FOREIGN KEY (category_id)
+        REFERENCES products.categories (id) ON DELETE SET NULL,
+    CONSTRAINT products_price_check CHECK (price >= 0),
+    CONSTRAINT products_stock_quantity_check CHECK (stock_quantity >= 0)
+);
+
+CREATE INDEX products_category_id_idx ON products.products (category_id);
+CREATE INDEX products_is_active_created_at_idx ON products.products (is_active, created_at DESC);
+CREATE INDEX products_price_idx ON products.products (price);
+CREATE INDEX products_name_idx ON products.products (name);
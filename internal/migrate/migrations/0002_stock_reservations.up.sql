@@ -0,0 +1,22 @@
+-- 0002_stock_reservations: quantities held for an order/cart until committed, released or expired.
+
+CREATE TABLE products.stock_reservations (
+    id           BIGSERIAL    PRIMARY KEY,
+    reference_id VARCHAR(255) NOT NULL,
+    product_id   BIGINT       NOT NULL,
+    quantity     INTEGER      NOT NULL,
+    status       VARCHAR(16)  NOT NULL DEFAULT 'active',
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT stock_reservations_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products.products (id) ON DELETE CASCADE,
+    CONSTRAINT stock_reservations_quantity_check CHECK (quantity > 0),
+    CONSTRAINT stock_reservations_status_check CHECK (status IN ('active', 'committed', 'released', 'expired'))
+);
+
+CREATE INDEX stock_reservations_reference_id_idx ON products.stock_reservations (reference_id);
+-- Serves both the availability sum per product and the expiry sweep.
+CREATE INDEX stock_reservations_active_idx ON products.stock_reservations (product_id, expires_at) WHERE status = 'active';
+CREATE INDEX stock_reservations_active_expires_at_idx ON products.stock_reservations (expires_at) WHERE status = 'active';
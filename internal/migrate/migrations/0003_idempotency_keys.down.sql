@@ -0,0 +1 @@
+DROP TABLE IF EXISTS products.idempotency_keys;
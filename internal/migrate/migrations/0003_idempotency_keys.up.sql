@@ -0,0 +1,16 @@
+-- 0003_idempotency_keys: processed (key, operation) pairs with their stored responses, so retried
+-- requests are answered from the record instead of being applied twice.
+
+CREATE TABLE products.idempotency_keys (
+    idempotency_key VARCHAR(255) NOT NULL,
+    operation       VARCHAR(64)  NOT NULL,
+    request_hash    VARCHAR(64)  NOT NULL,
+    response        BYTEA,                  -- NULL while the request is still being processed
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at    TIMESTAMPTZ,
+    expires_at      TIMESTAMPTZ  NOT NULL,
+
+    CONSTRAINT idempotency_keys_pkey PRIMARY KEY (idempotency_key, operation)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON products.idempotency_keys (expires_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS products.stock_movements;
+DROP FUNCTION IF EXISTS products.stock_movements_append_only();
@@ -0,0 +1,31 @@
+-- 0004_stock_movements: append-only ledger of every stock change.
+-- There is deliberately no foreign key to products: the history outlives the product.
+
+CREATE TABLE products.stock_movements (
+    id             BIGSERIAL    PRIMARY KEY,
+    product_id     BIGINT       NOT NULL,
+    quantity_delta INTEGER      NOT NULL,
+    balance_after  INTEGER      NOT NULL,
+    reason         VARCHAR(32)  NOT NULL,
+    order_id       VARCHAR(255),
+    actor          VARCHAR(255),
+    note           TEXT,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT stock_movements_balance_after_check CHECK (balance_after >= 0),
+    CONSTRAINT stock_movements_reason_check CHECK (reason IN
+        ('initial_stock', 'product_update', 'stock_update', 'reservation_commit', 'adjustment'))
+);
+
+CREATE INDEX stock_movements_product_id_created_at_idx ON products.stock_movements (product_id, created_at DESC, id DESC);
+
+-- Reject UPDATE and DELETE so the ledger stays append-only.
+CREATE FUNCTION products.stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'products.stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_append_only
+    BEFORE UPDATE OR DELETE ON products.stock_movements
+    FOR EACH ROW EXECUTE FUNCTION products.stock_movements_append_only();
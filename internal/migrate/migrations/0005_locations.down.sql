@@ -0,0 +1,13 @@
+-- Transfer entries cannot satisfy the old reason check; the ledger trigger forbids deleting them,
+-- so it is disabled for the cleanup.
+ALTER TABLE products.stock_movements DISABLE TRIGGER stock_movements_append_only;
+DELETE FROM products.stock_movements WHERE reason = 'transfer';
+ALTER TABLE products.stock_movements ENABLE TRIGGER stock_movements_append_only;
+
+ALTER TABLE products.stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE products.stock_movements ADD CONSTRAINT stock_movements_reason_check CHECK (reason IN
+    ('initial_stock', 'product_update', 'stock_update', 'reservation_commit', 'adjustment'));
+ALTER TABLE products.stock_movements DROP COLUMN location_id;
+
+DROP TABLE IF EXISTS products.location_stock;
+DROP TABLE IF EXISTS products.locations;
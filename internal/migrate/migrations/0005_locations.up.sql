@@ -0,0 +1,44 @@
+-- 0005_locations: stock is held per (product, location). products.stock_quantity stays as the
+-- aggregate of a product's location rows and is maintained by the service in the same transaction.
+
+CREATE TABLE products.locations (
+    id         BIGSERIAL    PRIMARY KEY,
+    code       VARCHAR(64)  NOT NULL,
+    name       VARCHAR(255) NOT NULL,
+    priority   INTEGER      NOT NULL DEFAULT 0, -- Lower ships first; ties are broken by id
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT locations_code_key UNIQUE (code)
+);
+
+CREATE TABLE products.location_stock (
+    product_id  BIGINT      NOT NULL,
+    location_id BIGINT      NOT NULL,
+    quantity    INTEGER     NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT location_stock_pkey PRIMARY KEY (product_id, location_id),
+    CONSTRAINT location_stock_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products.products (id) ON DELETE CASCADE,
+    CONSTRAINT location_stock_location_id_fkey FOREIGN KEY (location_id)
+        REFERENCES products.locations (id) ON DELETE RESTRICT,
+    CONSTRAINT location_stock_quantity_check CHECK (quantity >= 0)
+);
+
+CREATE INDEX location_stock_location_id_idx ON products.location_stock (location_id);
+
+-- Existing stock moves to a default location.
+INSERT INTO products.locations (code, name) VALUES ('default', 'Default warehouse');
+
+INSERT INTO products.location_stock (product_id, location_id, quantity)
+SELECT p.id, l.id, p.stock_quantity
+FROM products.products p, products.locations l
+WHERE l.code = 'default' AND p.stock_quantity > 0;
+
+-- Ledger entries name the location they touched. NULL for entries written before locations existed.
+ALTER TABLE products.stock_movements ADD COLUMN location_id BIGINT;
+
+ALTER TABLE products.stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE products.stock_movements ADD CONSTRAINT stock_movements_reason_check CHECK (reason IN
+    ('initial_stock', 'product_update', 'stock_update', 'reservation_commit', 'adjustment', 'transfer'));
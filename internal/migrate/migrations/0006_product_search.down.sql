@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS products.products_search_vector_idx;
+DROP TRIGGER IF EXISTS products_search_vector_update ON products.products;
+DROP FUNCTION IF EXISTS products.products_search_vector_update();
+ALTER TABLE products.products DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS products.product_search_vector(REGCONFIG, TEXT, TEXT, TEXT, JSONB);
+DROP TABLE IF EXISTS products.search_config;
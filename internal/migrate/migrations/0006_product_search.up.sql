@@ -0,0 +1,42 @@
+-- 0006_product_search: full-text search over products.
+-- search_vector is maintained by a trigger and weighted name (A) > SKU (B) > description (C) >
+-- attribute values (D). The text search configuration (language) lives in products.search_config so
+-- that the trigger and the queries always agree; the service sets it from SEARCH_LANGUAGE on startup.
+
+CREATE TABLE products.search_config (
+    singleton BOOLEAN   PRIMARY KEY DEFAULT TRUE,
+    language  REGCONFIG NOT NULL DEFAULT 'english',
+
+    CONSTRAINT search_config_singleton_check CHECK (singleton)
+);
+
+INSERT INTO products.search_config DEFAULT VALUES;
+
+-- The SKU is indexed with the 'simple' configuration so that codes are never stemmed.
+CREATE FUNCTION products.product_search_vector(lang REGCONFIG, name TEXT, sku TEXT, description TEXT, attributes JSONB)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector(lang, coalesce(name, '')), 'A')
+        || setweight(to_tsvector('simple', coalesce(sku, '')), 'B')
+        || setweight(to_tsvector(lang, coalesce(description, '')), 'C')
+        || setweight(jsonb_to_tsvector(lang, coalesce(attributes, '{}'::jsonb), '["string", "numeric"]'), 'D');
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE products.products ADD COLUMN search_vector tsvector;
+
+CREATE FUNCTION products.products_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := products.product_search_vector(
+        (SELECT language FROM products.search_config), NEW.name, NEW.sku, NEW.description, NEW.attributes);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_search_vector_update
+    BEFORE INSERT OR UPDATE OF name, sku, description, attributes ON products.products
+    FOR EACH ROW EXECUTE FUNCTION products.products_search_vector_update();
+
+UPDATE products.products
+SET search_vector = products.product_search_vector(
+    (SELECT language FROM products.search_config), name, sku, description, attributes);
+
+CREATE INDEX products_search_vector_idx ON products.products USING GIN (search_vector);
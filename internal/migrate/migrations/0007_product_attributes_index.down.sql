@@ -0,0 +1 @@
+DROP INDEX IF EXISTS products.products_attributes_idx;
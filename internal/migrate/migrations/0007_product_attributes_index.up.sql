@@ -0,0 +1,5 @@
+-- 0007_product_attributes_index: index for filtering products by attribute values.
+-- Attribute filters are phrased as containment (attributes @> '{"color": "red"}') and key existence
+-- (attributes ? 'color'). The default jsonb_ops operator class supports both; jsonb_path_ops would be
+-- smaller but cannot answer ?.
+CREATE INDEX products_attributes_idx ON products.products USING GIN (attributes);
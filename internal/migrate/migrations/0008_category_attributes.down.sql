@@ -0,0 +1 @@
+DROP TABLE IF EXISTS products.category_attributes;
@@ -0,0 +1,19 @@
+-- 0008_category_attributes: per-category attribute schemas. A category's definitions also apply to
+-- its subcategories, where the nearest definition of a key wins. The service validates product
+-- attributes against them on write; existing products are not revalidated when a schema changes.
+
+CREATE TABLE products.category_attributes (
+    category_id BIGINT       NOT NULL,
+    key         VARCHAR(100) NOT NULL,
+    type        VARCHAR(16)  NOT NULL,
+    enum_values TEXT[],                          -- Allowed values of string and string_list attributes
+    required    BOOLEAN      NOT NULL DEFAULT FALSE,
+    unit        VARCHAR(32),                     -- Unit of number attributes, for display
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT category_attributes_pkey PRIMARY KEY (category_id, key),
+    CONSTRAINT category_attributes_category_id_fkey FOREIGN KEY (category_id)
+        REFERENCES products.categories (id) ON DELETE CASCADE,
+    CONSTRAINT category_attributes_type_check CHECK (type IN ('string', 'number', 'boolean', 'string_list'))
+);
@@ -0,0 +1,4 @@
+ALTER TABLE products.products
+    DROP CONSTRAINT IF EXISTS products_currency_check,
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN price TYPE NUMERIC(12,2);
@@ -0,0 +1,7 @@
+-- 0009_product_currency: prices are exact amounts of a currency. Existing prices are in USD (the
+-- service's DefaultCurrency); the price scale grows to 3 decimal places for currencies such as KWD.
+
+ALTER TABLE products.products
+    ALTER COLUMN price TYPE NUMERIC(15,3),
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD CONSTRAINT products_currency_check CHECK (currency ~ '^[A-Z]{3}$');
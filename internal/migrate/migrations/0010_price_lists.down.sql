@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS products.price_list_entries;
+DROP TABLE IF EXISTS products.price_lists;
@@ -0,0 +1,36 @@
+-- 0010_price_lists: named price lists hold per-product prices in one currency, optionally for a
+-- market and customer group. A product without an entry in a list falls back to the default list of
+-- the list's currency, and then to its base price in products.products.
+
+CREATE TABLE products.price_lists (
+    id             BIGSERIAL    PRIMARY KEY,
+    name           VARCHAR(255) NOT NULL,
+    currency       CHAR(3)      NOT NULL,
+    market         VARCHAR(64),
+    customer_group VARCHAR(64),
+    is_default     BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT price_lists_name_key UNIQUE (name),
+    CONSTRAINT price_lists_currency_check CHECK (currency ~ '^[A-Z]{3}$')
+);
+
+-- At most one default list per currency.
+CREATE UNIQUE INDEX price_lists_default_currency_idx ON products.price_lists (currency) WHERE is_default;
+
+CREATE TABLE products.price_list_entries (
+    price_list_id BIGINT        NOT NULL,
+    product_id    BIGINT        NOT NULL,
+    price         NUMERIC(15,3) NOT NULL, -- In the currency of the list
+    updated_at    TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT price_list_entries_pkey PRIMARY KEY (price_list_id, product_id),
+    CONSTRAINT price_list_entries_price_list_id_fkey FOREIGN KEY (price_list_id)
+        REFERENCES products.price_lists (id) ON DELETE CASCADE,
+    CONSTRAINT price_list_entries_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products.products (id) ON DELETE CASCADE,
+    CONSTRAINT price_list_entries_price_check CHECK (price >= 0)
+);
+
+CREATE INDEX price_list_entries_product_id_idx ON products.price_list_entries (product_id);
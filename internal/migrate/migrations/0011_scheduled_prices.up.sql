@@ -0,0 +1,20 @@
+-- 0011_scheduled_prices: prices that replace a product's base price for a window of time, such as
+-- a sale. Reads resolve the effective price: the scheduled price in the product's currency that is
+-- active now and started last, or else the base price.
+
+CREATE TABLE products.scheduled_prices (
+    id          BIGSERIAL     PRIMARY KEY,
+    product_id  BIGINT        NOT NULL,
+    price       NUMERIC(15,3) NOT NULL,
+    currency    CHAR(3)       NOT NULL, -- The product's currency when the price was scheduled
+    valid_from  TIMESTAMPTZ   NOT NULL, -- Inclusive
+    valid_until TIMESTAMPTZ,            -- Exclusive; NULL for no end
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT scheduled_prices_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products.products (id) ON DELETE CASCADE,
+    CONSTRAINT scheduled_prices_price_check CHECK (price >= 0),
+    CONSTRAINT scheduled_prices_window_check CHECK (valid_until IS NULL OR valid_until > valid_from)
+);
+
+CREATE INDEX scheduled_prices_product_id_valid_from_idx ON products.scheduled_prices (product_id, valid_from DESC);
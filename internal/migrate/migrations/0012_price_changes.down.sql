@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS products.price_changes;
+DROP FUNCTION IF EXISTS products.price_changes_append_only();
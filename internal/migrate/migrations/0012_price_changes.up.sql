@@ -0,0 +1,40 @@
+-- 0012_price_changes: append-only history of every price change, to audit prices and to answer what
+-- a product cost at a given time. Like the stock ledger, it has no foreign key to products: the
+-- history outlives the product.
+-- Existing products get an initial_price entry with their current price, effective from their
+-- creation; their earlier changes were not recorded.
+
+CREATE TABLE products.price_changes (
+    id                 BIGSERIAL     PRIMARY KEY,
+    product_id         BIGINT        NOT NULL,
+    old_price          NUMERIC(15,3),          -- NULL for initial_price
+    old_currency       CHAR(3),
+    new_price          NUMERIC(15,3) NOT NULL,
+    new_currency       CHAR(3)       NOT NULL,
+    effective_at       TIMESTAMPTZ   NOT NULL, -- When new_price takes effect
+    effective_until    TIMESTAMPTZ,            -- End of a scheduled price; NULL otherwise
+    reason             VARCHAR(32)   NOT NULL,
+    scheduled_price_id BIGINT,                 -- For the scheduled_price reasons
+    actor              VARCHAR(255),
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT price_changes_reason_check CHECK (reason IN
+        ('initial_price', 'product_update', 'scheduled_price', 'scheduled_price_cancelled')),
+    CONSTRAINT price_changes_old_price_check CHECK ((old_price IS NULL) = (old_currency IS NULL))
+);
+
+CREATE INDEX price_changes_product_id_effective_at_idx ON products.price_changes (product_id, effective_at DESC, id DESC);
+
+INSERT INTO products.price_changes (product_id, new_price, new_currency, effective_at, reason)
+SELECT id, price, currency, created_at, 'initial_price' FROM products.products ORDER BY id;
+
+-- Reject UPDATE and DELETE so the history stays append-only.
+CREATE FUNCTION products.price_changes_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'products.price_changes is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER price_changes_append_only
+    BEFORE UPDATE OR DELETE ON products.price_changes
+    FOR EACH ROW EXECUTE FUNCTION products.price_changes_append_only();
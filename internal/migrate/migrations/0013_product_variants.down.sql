@@ -0,0 +1,11 @@
+-- Variants stay behind as standalone products.
+DROP INDEX IF EXISTS products.products_parent_id_option_values_key;
+ALTER TABLE products.products
+    DROP CONSTRAINT IF EXISTS products_inherits_price_check,
+    DROP CONSTRAINT IF EXISTS products_variant_options_check,
+    DROP CONSTRAINT IF EXISTS products_variant_check,
+    DROP CONSTRAINT IF EXISTS products_parent_id_fkey,
+    DROP COLUMN IF EXISTS inherits_price,
+    DROP COLUMN IF EXISTS option_values,
+    DROP COLUMN IF EXISTS options,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,19 @@
+-- 0013_product_variants: a parent product with option names (e.g. color, size) and variants that
+-- are products of their own, each with its SKU, price, stock and a value for every option. Stock,
+-- reservations and the ledgers work on variants as on any product. A variant without a price
+-- override inherits the parent's base price, which UpdateProduct keeps in step.
+
+ALTER TABLE products.products
+    ADD COLUMN parent_id      BIGINT,
+    ADD COLUMN options        TEXT[]  NOT NULL DEFAULT '{}', -- Option names of a parent, in display order
+    ADD COLUMN option_values  JSONB,                          -- A variant's value for each option of its parent
+    ADD COLUMN inherits_price BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT products_parent_id_fkey FOREIGN KEY (parent_id)
+        REFERENCES products.products (id) ON DELETE CASCADE, -- Variants go with their parent
+    ADD CONSTRAINT products_variant_check CHECK ((parent_id IS NULL) = (option_values IS NULL)),
+    ADD CONSTRAINT products_variant_options_check CHECK (parent_id IS NULL OR cardinality(options) = 0),
+    ADD CONSTRAINT products_inherits_price_check CHECK (parent_id IS NOT NULL OR NOT inherits_price);
+
+-- One variant per combination of option values.
+CREATE UNIQUE INDEX products_parent_id_option_values_key ON products.products (parent_id, option_values)
+    WHERE parent_id IS NOT NULL;
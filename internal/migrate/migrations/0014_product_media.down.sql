@@ -0,0 +1,2 @@
+-- products.image_url keeps the last derived primary image.
+DROP TABLE IF EXISTS products.product_media;
@@ -0,0 +1,31 @@
+-- 0014_product_media: the images of a product, ordered by position, each with alt text, a role and
+-- optional dimensions. products.image_url is kept as the derived primary image: the media writes
+-- set it to the URL of the primary image, or else of the first gallery image.
+-- Existing image URLs become the primary image of their product.
+
+CREATE TABLE products.product_media (
+    id         BIGSERIAL     PRIMARY KEY,
+    product_id BIGINT        NOT NULL,
+    url        VARCHAR(2048) NOT NULL,
+    alt_text   VARCHAR(255)  NOT NULL DEFAULT '',
+    role       VARCHAR(16)   NOT NULL DEFAULT 'gallery',
+    position   INT           NOT NULL, -- 1-based display order; gaps are allowed
+    width      INT,                    -- In pixels, if known
+    height     INT,
+    created_at TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT product_media_product_id_fkey FOREIGN KEY (product_id)
+        REFERENCES products.products (id) ON DELETE CASCADE,
+    CONSTRAINT product_media_role_check CHECK (role IN ('primary', 'gallery', 'thumbnail')),
+    CONSTRAINT product_media_position_check CHECK (position > 0),
+    CONSTRAINT product_media_dimensions_check CHECK ((width IS NULL OR width > 0) AND (height IS NULL OR height > 0))
+);
+
+CREATE INDEX product_media_product_id_position_idx ON products.product_media (product_id, position, id);
+
+-- At most one primary image per product.
+CREATE UNIQUE INDEX product_media_primary_key ON products.product_media (product_id) WHERE role = 'primary';
+
+INSERT INTO products.product_media (product_id, url, role, position)
+SELECT id, image_url, 'primary', 1 FROM products.products WHERE image_url <> '' ORDER BY id;
@@ -0,0 +1,19 @@
+-- Deleted products and categories are removed for good.
+DELETE FROM products.products WHERE deleted_at IS NOT NULL;
+DELETE FROM products.categories WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS products.categories_deleted_at_idx;
+DROP INDEX IF EXISTS products.products_deleted_at_idx;
+
+DROP INDEX IF EXISTS products.categories_name_key;
+ALTER TABLE products.categories ADD CONSTRAINT categories_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS products.products_parent_id_option_values_key;
+CREATE UNIQUE INDEX products_parent_id_option_values_key ON products.products (parent_id, option_values)
+    WHERE parent_id IS NOT NULL;
+
+DROP INDEX IF EXISTS products.products_sku_key;
+ALTER TABLE products.products ADD CONSTRAINT products_sku_key UNIQUE (sku);
+
+ALTER TABLE products.categories DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE products.products DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,24 @@
+-- 0015_soft_delete: deleting a product or category sets deleted_at instead of removing the row, so
+-- that it can be restored and historic references (e.g. order lines) can still read it. Reads leave
+-- deleted rows out unless asked for them; the trash purge job removes them for good once they are
+-- older than the retention period. A deleted parent product takes its variants with it.
+--
+-- SKUs, category names and variant option values only need to be unique among rows that are not
+-- deleted, so that a deleted product does not block its SKU. Restoring fails on a conflict.
+
+ALTER TABLE products.products ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE products.categories ADD COLUMN deleted_at TIMESTAMPTZ;
+
+ALTER TABLE products.products DROP CONSTRAINT products_sku_key;
+CREATE UNIQUE INDEX products_sku_key ON products.products (sku) WHERE deleted_at IS NULL;
+
+DROP INDEX products.products_parent_id_option_values_key;
+CREATE UNIQUE INDEX products_parent_id_option_values_key ON products.products (parent_id, option_values)
+    WHERE parent_id IS NOT NULL AND deleted_at IS NULL;
+
+ALTER TABLE products.categories DROP CONSTRAINT categories_name_key;
+CREATE UNIQUE INDEX categories_name_key ON products.categories (name) WHERE deleted_at IS NULL;
+
+-- For the trash listings and the purge job.
+CREATE INDEX products_deleted_at_idx ON products.products (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX categories_deleted_at_idx ON products.categories (deleted_at) WHERE deleted_at IS NOT NULL;
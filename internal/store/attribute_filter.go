@@ -0,0 +1,180 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"product-catalog-service/internal/domain"
+)
+
+// ErrInvalidAttributeFilter is returned when an AttributeFilter lacks what its operator needs.
+var ErrInvalidAttributeFilter = errors.New("store: invalid attribute filter")
+
+// AttributeFilterOp is how an AttributeFilter tests a product attribute.
+type AttributeFilterOp int
+
+const (
+	// AttributeEquals matches if the attribute is Values[0], or is an array containing it.
+	AttributeEquals AttributeFilterOp = iota + 1
+	// AttributeIn matches if the attribute is any of Values, or is an array containing any of them.
+	AttributeIn
+	// AttributeRange matches if the attribute is a number within [Min, Max]; either bound may be nil.
+	AttributeRange
+	// AttributeExists matches if the attribute is present, whatever its value.
+	AttributeExists
+)
+
+// AttributeFilter is a predicate on the top-level key Key of a product's JSON attributes.
+// Values are compared with the attribute as text: "16" matches both the string "16" and the number
+// 16, and "true" matches the boolean true.
+type AttributeFilter struct {
+	Key    string
+	Op     AttributeFilterOp
+	Values []string
+	Min    *float64
+	Max    *float64
+}
+
+func (f AttributeFilter) validate() error {
+	if f.Key == "" {
+		return fmt.Errorf("%w: empty key", ErrInvalidAttributeFilter)
+	}
+	switch f.Op {
+	case AttributeEquals:
+		if len(f.Values) != 1 {
+			return fmt.Errorf("%w: equality on %q needs exactly one value", ErrInvalidAttributeFilter, f.Key)
+		}
+	case AttributeIn:
+		if len(f.Values) == 0 {
+			return fmt.Errorf("%w: IN on %q needs at least one value", ErrInvalidAttributeFilter, f.Key)
+		}
+	case AttributeRange:
+		if f.Min == nil && f.Max == nil {
+			return fmt.Errorf("%w: range on %q needs a minimum or a maximum", ErrInvalidAttributeFilter, f.Key)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return fmt.Errorf("%w: range on %q has its minimum above its maximum", ErrInvalidAttributeFilter, f.Key)
+		}
+	case AttributeExists:
+	default:
+		return fmt.Errorf("%w: unknown operator %d on %q", ErrInvalidAttributeFilter, f.Op, f.Key)
+	}
+	return nil
+}
+
+func validateAttributeFilters(filters []AttributeFilter) error {
+	for _, f := range filters {
+		if err := f.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attributeJSONValues returns the JSON values that value stands for: always the string, plus the
+// number or boolean it spells, if any.
+func attributeJSONValues(value string) []interface{} {
+	values := []interface{}{value}
+	if n, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(n, 0) && !math.IsNaN(n) {
+		values = append(values, n)
+	}
+	if value == "true" || value == "false" {
+		values = append(values, value == "true")
+	}
+	return values
+}
+
+// attributeContainmentDocs returns the JSON documents an attributes column contains (@>) exactly when
+// key holds one of values or an array containing one of them. Containment is what the GIN index on
+// attributes (migration 0007) can answer.
+func attributeContainmentDocs(key string, values []string) []string {
+	var docs []string
+	for _, value := range values {
+		for _, v := range attributeJSONValues(value) {
+			scalar, _ := json.Marshal(map[string]interface{}{key: v})               // Cannot fail
+			array, _ := json.Marshal(map[string]interface{}{key: []interface{}{v}}) // Cannot fail
+			docs = append(docs, string(scalar), string(array))
+		}
+	}
+	return docs
+}
+
+// decodeAttributes returns the top-level attributes of a product, with numbers as json.Number.
+func decodeAttributes(attributes *json.RawMessage) map[string]interface{} {
+	if attributes == nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(*attributes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+func matchesAttributeFilters(p *domain.Product, filters []AttributeFilter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	fields := decodeAttributes(p.Attributes)
+	for _, f := range filters {
+		if !f.matches(fields) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches evaluates f on decoded attributes the way the Postgres conditions do.
+func (f AttributeFilter) matches(fields map[string]interface{}) bool {
+	value, ok := fields[f.Key]
+	switch f.Op {
+	case AttributeExists:
+		return ok
+	case AttributeRange:
+		number, isNumber := value.(json.Number)
+		if !isNumber {
+			return false
+		}
+		n, err := number.Float64()
+		return err == nil && (f.Min == nil || n >= *f.Min) && (f.Max == nil || n <= *f.Max)
+	}
+	items := []interface{}{value}
+	if array, isArray := value.([]interface{}); isArray {
+		items = array
+	}
+	for _, item := range items {
+		for _, want := range f.Values {
+			if attributeScalarEquals(item, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func attributeScalarEquals(item interface{}, want string) bool {
+	for _, v := range attributeJSONValues(want) {
+		switch t := item.(type) {
+		case string:
+			if s, ok := v.(string); ok && s == t {
+				return true
+			}
+		case json.Number:
+			n, err := t.Float64()
+			if f, ok := v.(float64); ok && err == nil && f == n {
+				return true
+			}
+		case bool:
+			if b, ok := v.(bool); ok && b == t {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+)
+
+// Predefined errors for category attribute schemas
+var (
+	ErrCategoryAttributeNotFound = errors.New("store: category attribute not found")
+	// ErrInvalidAttributeDefinition wraps what is wrong with a CategoryAttribute.
+	ErrInvalidAttributeDefinition = errors.New("store: invalid attribute definition")
+	// ErrAttributeSchemaViolation is matched by every *AttributeSchemaError.
+	ErrAttributeSchemaViolation = errors.New("store: product attributes do not match the category's attribute schema")
+)
+
+// maxAttributeKeyLength matches category_attributes.key.
+const maxAttributeKeyLength = 100
+
+// AttributeViolation is one way a product's attributes break its category's schema.
+type AttributeViolation struct {
+	Field   string // "attributes" or "attributes.<key>"
+	Message string
+}
+
+// AttributeSchemaError is returned by CreateProduct and UpdateProduct when the attributes do not
+// match the schema of the product's category (including inherited definitions). Categories without
+// any definitions, and products without a category, accept any attributes.
+type AttributeSchemaError struct {
+	Violations []AttributeViolation // Ordered by field
+}
+
+func (e *AttributeSchemaError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Field + ": " + v.Message
+	}
+	return fmt.Sprintf("%v: %s", ErrAttributeSchemaViolation, strings.Join(messages, "; "))
+}
+
+func (e *AttributeSchemaError) Is(target error) bool {
+	return target == ErrAttributeSchemaViolation
+}
+
+// validateAttributeDefinition checks a definition before it is stored.
+func validateAttributeDefinition(attr *domain.CategoryAttribute) error {
+	if attr.Key == "" || len(attr.Key) > maxAttributeKeyLength {
+		return fmt.Errorf("%w: key must be 1 to %d characters long", ErrInvalidAttributeDefinition, maxAttributeKeyLength)
+	}
+	switch attr.Type {
+	case domain.AttributeTypeString, domain.AttributeTypeStringList:
+	case domain.AttributeTypeNumber, domain.AttributeTypeBoolean:
+		if len(attr.EnumValues) > 0 {
+			return fmt.Errorf("%w: enum_values are only allowed for string and string_list attributes", ErrInvalidAttributeDefinition)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidAttributeDefinition, attr.Type)
+	}
+	if attr.Unit != nil && attr.Type != domain.AttributeTypeNumber {
+		return fmt.Errorf("%w: unit is only allowed for number attributes", ErrInvalidAttributeDefinition)
+	}
+	seen := make(map[string]bool, len(attr.EnumValues))
+	for _, v := range attr.EnumValues {
+		if seen[v] {
+			return fmt.Errorf("%w: duplicate enum value %q", ErrInvalidAttributeDefinition, v)
+		}
+		seen[v] = true
+	}
+	return nil
+}
+
+// validateProductAttributes checks attributes against an effective schema (one definition per key)
+// and returns an *AttributeSchemaError listing every violation, or nil.
+func validateProductAttributes(schema []domain.CategoryAttribute, attributes *json.RawMessage) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	var violations []AttributeViolation
+	fields := map[string]interface{}{}
+	if attributes != nil && len(*attributes) > 0 && string(*attributes) != "null" {
+		decoder := json.NewDecoder(bytes.NewReader(*attributes))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil || fields == nil {
+			return &AttributeSchemaError{Violations: []AttributeViolation{{Field: "attributes", Message: "must be a JSON object"}}}
+		}
+	}
+
+	defined := make(map[string]*domain.CategoryAttribute, len(schema))
+	for i := range schema {
+		defined[schema[i].Key] = &schema[i]
+	}
+	for key, value := range fields {
+		field := "attributes." + key
+		def, ok := defined[key]
+		if !ok {
+			message := "is not defined for this category"
+			for definedKey := range defined {
+				if strings.EqualFold(definedKey, key) {
+					message += fmt.Sprintf("; did you mean %q?", definedKey)
+					break
+				}
+			}
+			violations = append(violations, AttributeViolation{Field: field, Message: message})
+			continue
+		}
+		if value == nil { // Like an absent key
+			if def.Required {
+				violations = append(violations, AttributeViolation{Field: field, Message: "is required"})
+			}
+			continue
+		}
+		if message := checkAttributeValue(def, value); message != "" {
+			violations = append(violations, AttributeViolation{Field: field, Message: message})
+		}
+	}
+	for _, def := range schema {
+		if _, ok := fields[def.Key]; def.Required && !ok {
+			violations = append(violations, AttributeViolation{Field: "attributes." + def.Key, Message: "is required"})
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+	return &AttributeSchemaError{Violations: violations}
+}
+
+// checkAttributeValue returns what is wrong with a non-null value, or "".
+func checkAttributeValue(def *domain.CategoryAttribute, value interface{}) string {
+	switch def.Type {
+	case domain.AttributeTypeNumber:
+		if _, ok := value.(json.Number); !ok {
+			return "must be a number"
+		}
+	case domain.AttributeTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean"
+		}
+	case domain.AttributeTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if !allowedEnumValue(def.EnumValues, s) {
+			return fmt.Sprintf("must be one of %s", strings.Join(def.EnumValues, ", "))
+		}
+	case domain.AttributeTypeStringList:
+		items, ok := value.([]interface{})
+		if !ok {
+			return "must be an array of strings"
+		}
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return "must be an array of strings"
+			}
+			if !allowedEnumValue(def.EnumValues, s) {
+				return fmt.Sprintf("may only contain %s", strings.Join(def.EnumValues, ", "))
+			}
+		}
+	}
+	return ""
+}
+
+func allowedEnumValue(enum []string, value string) bool {
+	if len(enum) == 0 {
+		return true
+	}
+	for _, v := range enum {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
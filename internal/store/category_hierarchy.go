@@ -0,0 +1,64 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Predefined errors for changes to the category hierarchy
+var (
+	ErrCategoryCycle = errors.New("store: a category cannot be its own parent or a subcategory of one of its descendants")
+	// ErrCategoryNotEmpty is returned by the restrict strategy of DeleteCategory.
+	ErrCategoryNotEmpty = errors.New("store: category still has subcategories or products")
+	// ErrInvalidDeleteOptions wraps what is wrong with DeleteCategoryOptions.
+	ErrInvalidDeleteOptions = errors.New("store: invalid delete options")
+)
+
+// CategoryDeleteStrategy selects what DeleteCategory does with the subcategories and products of
+// the deleted category. Products are never deleted with a category.
+type CategoryDeleteStrategy string
+
+const (
+	// CategoryDeleteRestrict deletes only a category without subcategories and products.
+	CategoryDeleteRestrict CategoryDeleteStrategy = "restrict"
+	// CategoryDeleteReparent moves the subcategories and products to the parent of the deleted
+	// category. Those of a root category become roots and uncategorized products respectively.
+	CategoryDeleteReparent CategoryDeleteStrategy = "reparent"
+	// CategoryDeleteMoveProducts moves the products to DeleteCategoryOptions.TargetCategoryID and the
+	// subcategories to the parent of the deleted category.
+	CategoryDeleteMoveProducts CategoryDeleteStrategy = "move_products"
+	// CategoryDeleteCascade deletes the category and all its descendants. Their products become
+	// uncategorized.
+	CategoryDeleteCascade CategoryDeleteStrategy = "cascade"
+)
+
+// DeleteCategoryOptions holds how DeleteCategory treats the contents of the category.
+type DeleteCategoryOptions struct {
+	Strategy         CategoryDeleteStrategy // Defaults to CategoryDeleteRestrict
+	TargetCategoryID *int64                 // Required by, and only allowed with, CategoryDeleteMoveProducts
+}
+
+// validate checks the options of deleting category id, apart from the existence of the target.
+func (o DeleteCategoryOptions) validate(id int64) error {
+	switch o.Strategy {
+	case "", CategoryDeleteRestrict, CategoryDeleteReparent, CategoryDeleteCascade:
+		if o.TargetCategoryID != nil {
+			return fmt.Errorf("%w: a target category is only allowed with the %s strategy", ErrInvalidDeleteOptions, CategoryDeleteMoveProducts)
+		}
+	case CategoryDeleteMoveProducts:
+		if o.TargetCategoryID == nil {
+			return fmt.Errorf("%w: the %s strategy requires a target category", ErrInvalidDeleteOptions, CategoryDeleteMoveProducts)
+		}
+		if *o.TargetCategoryID == id {
+			return fmt.Errorf("%w: products cannot be moved to the deleted category", ErrInvalidDeleteOptions)
+		}
+	default:
+		return fmt.Errorf("%w: unknown strategy %q", ErrInvalidDeleteOptions, o.Strategy)
+	}
+	return nil
+}
+
+// missingDeleteTarget is returned when the target category of CategoryDeleteMoveProducts does not exist.
+func missingDeleteTarget(targetID int64) error {
+	return fmt.Errorf("%w: target category %d does not exist", ErrInvalidDeleteOptions, targetID)
+}
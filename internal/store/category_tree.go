@@ -0,0 +1,64 @@
+package store
+
+import (
+	"errors"
+	"sort"
+
+	"product-catalog-service/internal/domain"
+)
+
+// treeCategory is a category reached by a walk down the hierarchy. Depth is 1 for the categories
+// the walk starts from.
+type treeCategory struct {
+	category    domain.Category
+	depth       int
+	hasChildren bool
+}
+
+// ErrInvalidMaxDepth is returned for a negative depth limit; 0 means no limit.
+var ErrInvalidMaxDepth = errors.New("store: max depth must not be negative")
+
+// sortTreeCategories orders a walk by depth, then name and ID, like the recursive queries.
+func sortTreeCategories(rows []treeCategory) {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].depth != rows[j].depth {
+			return rows[i].depth < rows[j].depth
+		}
+		if rows[i].category.Name != rows[j].category.Name {
+			return rows[i].category.Name < rows[j].category.Name
+		}
+		return rows[i].category.ID < rows[j].category.ID
+	})
+}
+
+// buildCategoryTree nests a walk ordered by sortTreeCategories under its depth 1 categories.
+func buildCategoryTree(rows []treeCategory) []domain.CategoryNode {
+	children := make(map[int64][]int, len(rows))
+	var roots []int
+	for i, row := range rows {
+		if row.depth == 1 {
+			roots = append(roots, i)
+		} else {
+			parentID := *row.category.ParentCategoryID
+			children[parentID] = append(children[parentID], i)
+		}
+	}
+
+	var build func(i int) domain.CategoryNode
+	build = func(i int) domain.CategoryNode {
+		node := domain.CategoryNode{
+			Category:    rows[i].category,
+			HasChildren: rows[i].hasChildren,
+			Children:    make([]domain.CategoryNode, 0, len(children[rows[i].category.ID])),
+		}
+		for _, child := range children[rows[i].category.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+	tree := make([]domain.CategoryNode, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree
+}
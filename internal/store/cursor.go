@@ -0,0 +1,183 @@
+package store
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// ErrInvalidCursor is returned when a cursor was issued for a different sort order or its sort key
+// cannot be read back.
+var ErrInvalidCursor = errors.New("store: invalid page cursor")
+
+// Product sort fields accepted by ListProductsParams.SortBy. Anything else sorts by created_at, or by
+// relevance when a search query is given.
+const (
+	ProductSortName      = "name"
+	ProductSortPrice     = "price"
+	ProductSortCreatedAt = "created_at"
+	ProductSortUpdatedAt = "updated_at"
+	// ProductSortRelevance orders by full-text search rank. It needs a search query and sorts the
+	// best matches first unless SortOrder is "asc".
+	ProductSortRelevance = "relevance"
+	// ProductSortDeletedAt orders the trash by deletion time. It is only accepted with DeletedOnly.
+	ProductSortDeletedAt = "deleted_at"
+)
+
+// categorySortField is the only order categories are listed in.
+const categorySortField = "name"
+
+// stockMovementSortField is the only order stock movements are listed in, newest first. It differs
+// from the product sort fields so that a product listing's cursor is not taken for a ledger one.
+const stockMovementSortField = "stock_movements.created_at"
+
+// Cursor is a position in a keyset-paginated listing: the sort key and ID of the last row already
+// returned. Listings are ordered by the sort key and then by ID, so the next page starts right after
+// that row even if rows were inserted or deleted in between, and no OFFSET scan is needed.
+type Cursor struct {
+	SortBy string // Normalized sort field the cursor was issued for
+	Desc   bool   // Whether the listing is in descending order
+	Key    string // Sort key of the last row (see formatProductSortKey)
+	ID     int64  // ID of the last row
+}
+
+// ProductCursor returns the cursor that continues a product listing sorted as in params after p.
+func ProductCursor(params ListProductsParams, p *domain.Product) Cursor {
+	field, desc := productSort(params)
+	return Cursor{SortBy: field, Desc: desc, Key: formatProductSortKey(p, field), ID: p.ID}
+}
+
+// CategoryCursor returns the cursor that continues a category listing after c.
+func CategoryCursor(c *domain.Category) Cursor {
+	return Cursor{SortBy: categorySortField, Key: c.Name, ID: c.ID}
+}
+
+// StockMovementCursor returns the cursor that continues a product's stock history after m.
+func StockMovementCursor(m *domain.StockMovement) Cursor {
+	return Cursor{SortBy: stockMovementSortField, Desc: true, Key: m.CreatedAt.UTC().Format(time.RFC3339Nano), ID: m.ID}
+}
+
+// productSort normalizes the requested sort field and order the way every backend applies them.
+func productSort(params ListProductsParams) (field string, desc bool) {
+	field = strings.ToLower(params.SortBy)
+	searching := params.SearchQuery != nil && strings.TrimSpace(*params.SearchQuery) != ""
+	switch {
+	case field == ProductSortName, field == ProductSortPrice, field == ProductSortUpdatedAt, field == ProductSortCreatedAt:
+	case field == ProductSortDeletedAt && params.Deleted == DeletedOnly:
+	case searching && (field == "" || field == ProductSortRelevance):
+		return ProductSortRelevance, strings.ToUpper(params.SortOrder) != "ASC"
+	default:
+		field = ProductSortCreatedAt
+	}
+	return field, strings.ToUpper(params.SortOrder) == "DESC"
+}
+
+func formatProductSortKey(p *domain.Product, field string) string {
+	switch field {
+	case ProductSortName:
+		return p.Name
+	case ProductSortPrice:
+		return p.Price.Decimal()
+	case ProductSortUpdatedAt:
+		return p.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case ProductSortRelevance:
+		return strconv.FormatFloat(float64(searchRank(p)), 'g', -1, 32)
+	case ProductSortDeletedAt:
+		return deletedAt(p).UTC().Format(time.RFC3339Nano)
+	default:
+		return p.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+func searchRank(p *domain.Product) float32 {
+	if p.SearchRank == nil {
+		return 0
+	}
+	return *p.SearchRank
+}
+
+// deletedAt returns when p was deleted; it is only sorted by in listings of deleted products.
+func deletedAt(p *domain.Product) time.Time {
+	if p.DeletedAt == nil {
+		return time.Time{}
+	}
+	return *p.DeletedAt
+}
+
+// productSortValue checks that c continues a listing sorted by field (in the given order) and
+// returns its sort key as the Go value of that column.
+func (c *Cursor) productSortValue(field string, desc bool) (interface{}, error) {
+	if c.SortBy != field || c.Desc != desc {
+		return nil, ErrInvalidCursor
+	}
+	switch field {
+	case ProductSortName:
+		return c.Key, nil
+	case ProductSortPrice:
+		// An exact decimal, compared in the scale of the price column.
+		if _, err := domain.ParseDecimal(c.Key, domain.MaxCurrencyExponent); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return c.Key, nil
+	case ProductSortRelevance:
+		rank, err := strconv.ParseFloat(c.Key, 32)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return float32(rank), nil
+	default:
+		t, err := time.Parse(time.RFC3339Nano, c.Key)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return t, nil
+	}
+}
+
+// checkCategoryCursor checks that c continues a category listing.
+func (c *Cursor) checkCategoryCursor() error {
+	if c.SortBy != categorySortField || c.Desc {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// stockMovementTime checks that c continues a stock history and returns its created_at.
+func (c *Cursor) stockMovementTime() (time.Time, error) {
+	if c.SortBy != stockMovementSortField || !c.Desc {
+		return time.Time{}, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, c.Key)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+// compareProductSortKey compares p's sort key and ID with the cursor's, ascending; value is the
+// cursor's key as returned by productSortValue.
+func compareProductSortKey(p *domain.Product, field string, value interface{}, id int64) int {
+	var c int
+	switch field {
+	case ProductSortName:
+		c = strings.Compare(p.Name, value.(string))
+	case ProductSortPrice:
+		price, _ := domain.ParseDecimal(value.(string), domain.MaxCurrencyExponent) // Checked by productSortValue
+		c = compareInt64(p.Price.ScaledAmount(), price)
+	case ProductSortUpdatedAt:
+		c = p.UpdatedAt.Compare(value.(time.Time))
+	case ProductSortRelevance:
+		c = compareFloat(float64(searchRank(p)), float64(value.(float32)))
+	case ProductSortDeletedAt:
+		c = deletedAt(p).Compare(value.(time.Time))
+	default:
+		c = p.CreatedAt.Compare(value.(time.Time))
+	}
+	if c == 0 {
+		c = compareInt64(p.ID, id)
+	}
+	return c
+}
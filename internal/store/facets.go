@@ -0,0 +1,51 @@
+package store
+
+import (
+	"product-catalog-service/internal/domain"
+)
+
+// FacetRequest selects the facets ListProductFacets computes.
+type FacetRequest struct {
+	Categories bool
+	IsActive   bool
+	// PriceBoundaries are the ascending prices separating the price ranges: n boundaries make n+1
+	// ranges. They are all in one currency, and only products priced in it are counted. No
+	// boundaries means no price facet.
+	PriceBoundaries []domain.Money
+	AttributeKeys   []string
+}
+
+// defaultPriceFacetAmounts are the whole amounts DefaultPriceFacetBoundaries returns in any currency.
+var defaultPriceFacetAmounts = []int64{10, 25, 50, 100, 250, 500, 1000}
+
+// DefaultPriceFacetBoundaries returns the price range boundaries, in currency, used when a client
+// asks for the price facet without choosing its own.
+func DefaultPriceFacetBoundaries(currency string) []domain.Money {
+	boundaries := make([]domain.Money, len(defaultPriceFacetAmounts))
+	for i, amount := range defaultPriceFacetAmounts {
+		for exp := domain.CurrencyExponent(currency); exp > 0; exp-- {
+			amount *= 10
+		}
+		boundaries[i] = domain.Money{Amount: amount, Currency: currency}
+	}
+	return boundaries
+}
+
+// MaxAttributeFacetValues bounds the number of values returned per attribute key; the most frequent
+// ones are kept.
+const MaxAttributeFacetValues = 50
+
+// newPriceRangeFacets returns the empty ranges delimited by boundaries.
+func newPriceRangeFacets(boundaries []domain.Money) []domain.PriceRangeFacet {
+	ranges := make([]domain.PriceRangeFacet, len(boundaries)+1)
+	for i := range boundaries {
+		ranges[i].Max = &boundaries[i]
+		ranges[i+1].Min = &boundaries[i]
+	}
+	return ranges
+}
+
+// isActiveFacets returns both is_active values with their counts, true first.
+func isActiveFacets(active, inactive int) []domain.IsActiveFacet {
+	return []domain.IsActiveFacet{{Value: true, Count: active}, {Value: false, Count: inactive}}
+}
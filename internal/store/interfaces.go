@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"product-catalog-service/internal/domain"
 )
@@ -10,7 +11,11 @@ import (
 type ListCategoriesParams struct {
 	Limit  int
 	Offset int
-	// Add other filter parameters if needed in the future (e.g., ParentID)
+	After  *Cursor // Optional: keyset position from CategoryCursor; rows up to and including it are skipped
+	// ParentCategoryID optionally restricts the listing to the direct subcategories of a category.
+	ParentCategoryID *int64
+	// Deleted selects whether categories in the trash are listed (DeletedExcluded by default).
+	Deleted DeletedFilter
 }
 
 // CategoryStorer defines the database operations for categories.
@@ -18,22 +23,96 @@ type CategoryStorer interface {
 	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
 	GetCategoryByID(ctx context.Context, id int64) (*domain.Category, error)
 	ListCategories(ctx context.Context, params ListCategoriesParams) ([]domain.Category, int, error) // Returns categories and total count for pagination
+	// UpdateCategory returns ErrCategoryCycle if the new parent is the category itself or one of
+	// its descendants.
 	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
-	DeleteCategory(ctx context.Context, id int64) error
+	// DeleteCategory moves a category to the trash; opts selects what happens to its subcategories
+	// and products (see CategoryDeleteStrategy). Categories in the trash are left out of every read
+	// but ListCategories with Deleted set, and cannot be the parent or category of anything.
+	DeleteCategory(ctx context.Context, id int64, opts DeleteCategoryOptions) error
+	// RestoreCategory takes a category out of the trash, together with the descendants the cascade
+	// strategy deleted with it; their products stay uncategorized. It fails with ErrCategoryNotFound
+	// if the category is not in the trash, ErrRestoreBlocked while its parent is, and
+	// ErrCategoryNameExists if another category has taken its name.
+	RestoreCategory(ctx context.Context, id int64) (*domain.Category, error)
+	// PurgeDeletedCategories deletes the categories moved to the trash at or before before for good
+	// and returns how many.
+	PurgeDeletedCategories(ctx context.Context, before time.Time) (int, error)
+	// GetCategoryTree returns the tree under rootID, or the trees of all root categories if rootID
+	// is nil, down to maxDepth levels (0 for no limit; 1 returns just the roots). Siblings are
+	// ordered by name.
+	GetCategoryTree(ctx context.Context, rootID *int64, maxDepth int) ([]domain.CategoryNode, error)
+	// GetCategoryAncestors returns the categories above id, root first; the breadcrumb of id
+	// without id itself.
+	GetCategoryAncestors(ctx context.Context, id int64) ([]domain.Category, error)
+	// GetCategoryDescendants returns the categories below id, down to maxDepth levels (0 for no
+	// limit), ordered by depth and then name.
+	GetCategoryDescendants(ctx context.Context, id int64, maxDepth int) ([]domain.Category, error)
+	// GetCategoryAttributeSchema returns the effective attribute schema of a category: its own
+	// definitions and those inherited from its ancestors, the nearest one per key, ordered by key.
+	// CreateProduct and UpdateProduct enforce it (see AttributeSchemaError).
+	GetCategoryAttributeSchema(ctx context.Context, categoryID int64) ([]domain.CategoryAttribute, error)
+	// PutCategoryAttribute creates or replaces a category's own definition of attr.Key. Existing
+	// products are not revalidated.
+	PutCategoryAttribute(ctx context.Context, attr *domain.CategoryAttribute) (*domain.CategoryAttribute, error)
+	DeleteCategoryAttribute(ctx context.Context, categoryID int64, key string) error
 }
 
 // ListProductsParams holds parameters for listing products (for pagination, filtering, sorting).
 type ListProductsParams struct {
 	Limit       int
 	Offset      int
-	SearchQuery *string // For searching by name/description
-	CategoryID  *int64  // For filtering by category
-	MinPrice    *float64
-	MaxPrice    *float64
-	IsActive    *bool   // Filter by active status
-	SortBy      string  // e.g., "price", "name", "created_at"
-	SortOrder   string  // "asc" or "desc"
-	ProductIDs  []int64 // For fetching specific products by their IDs
+	SearchQuery *string       // For searching by name/description
+	CategoryID  *int64        // For filtering by category
+	MinPrice    *domain.Money // Inclusive; only prices in the same currency match
+	MaxPrice    *domain.Money // Inclusive; only prices in the same currency match
+	IsActive    *bool         // Filter by active status
+	SortBy      string        // e.g., "price", "name", "created_at"
+	SortOrder   string        // "asc" or "desc"
+	ProductIDs  []int64       // For fetching specific products by their IDs
+	// Attributes are predicates on the JSON attributes that must all hold (ErrInvalidAttributeFilter
+	// if one is incomplete).
+	Attributes []AttributeFilter
+	// IncludeDescendants extends the CategoryID filter to the products of all its subcategories.
+	IncludeDescendants bool
+	// After is an optional keyset position from ProductCursor. Rows up to and including it are
+	// skipped, and it must have been issued for the same SortBy and SortOrder (ErrInvalidCursor).
+	// The total count ignores it.
+	After *Cursor
+	// ParentID restricts the listing to the variants of a product.
+	ParentID *int64
+	// ExcludeVariants leaves variants out, so that a listing grouped by parent has each parent once.
+	ExcludeVariants bool
+	// Deleted selects whether products in the trash are listed (DeletedExcluded by default). Listed
+	// deleted products have DeletedAt set.
+	Deleted DeletedFilter
+}
+
+// StockUpdate is a single stock change within a batch (see ProductStorer.BatchUpdateStock).
+type StockUpdate struct {
+	ProductID      int64
+	QuantityChange int32  // Negative to decrease, positive to increase
+	LocationID     *int64 // Optional: location to change. Nil allocates across locations by priority
+}
+
+// StockUpdateResult is the per-item outcome of a batch stock update.
+// Err is nil when the change was applied; otherwise it is ErrProductNotFound,
+// ErrInsufficientStock, ErrLocationNotFound, ErrNoLocations or, in atomic mode,
+// ErrStockBatchAborted for items that were valid but rolled back because another item failed.
+type StockUpdateResult struct {
+	ProductID   int64
+	Product     *domain.Product          // Product state after the batch; nil if the change was not applied
+	Allocations []domain.StockAllocation // Per-location parts of the applied change
+	Err         error
+}
+
+// ProductImportResult is the per-product outcome of ProductStorer.ImportProducts. Err is nil when
+// the product was (or, in a dry run, would be) written; otherwise it is the error CreateProduct or
+// UpdateProduct would return for it.
+type ProductImportResult struct {
+	ProductID int64 // ID of the written product; 0 for a product a dry run would create
+	Created   bool  // Whether the SKU was new; otherwise the product with the SKU was updated
+	Err       error
 }
 
 // ProductStorer defines the database operations for products.
@@ -41,8 +120,174 @@ type ProductStorer interface {
 	CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
 	GetProductByID(ctx context.Context, id int64) (*domain.Product, error)
 	ListProducts(ctx context.Context, params ListProductsParams) ([]domain.Product, int, error) // Returns products and total count
+	// ExportProducts calls fn with every product matching the filters of params, in ID order and in
+	// batches of at most ExportBatchSize, as ListProducts returns them. Pagination and sorting are
+	// ignored. The products come from one consistent snapshot, read as fn consumes them rather than
+	// all at once. An error from fn stops the export and is returned.
+	ExportProducts(ctx context.Context, params ListProductsParams, fn func([]domain.Product) error) error
+	// ListProductFacets counts the products matching the filters of params by the facets req selects.
+	// Sorting and pagination (including After) are ignored.
+	ListProductFacets(ctx context.Context, params ListProductsParams, req FacetRequest) (*domain.ProductFacets, error)
 	UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
+	// DeleteProduct moves a product, and a parent's variants with it, to the trash. Products in the
+	// trash are left out of every read but ListProducts and ExportProducts with Deleted set (and
+	// GetPricesAt), and cannot be written to.
 	DeleteProduct(ctx context.Context, id int64) error
-	UpdateStock(ctx context.Context, productID int64, quantityChange int32) (*domain.Product, error)
+	// RestoreProduct takes a product out of the trash, together with the variants deleted with it.
+	// It fails with ErrProductNotFound if the product is not in the trash, ErrRestoreBlocked while
+	// its parent or category is, and ErrProductSKUExists or ErrVariantExists if another product has
+	// taken its place.
+	RestoreProduct(ctx context.Context, id int64) (*domain.Product, error)
+	// PurgeDeletedProducts deletes the products moved to the trash at or before before for good,
+	// with their stock, prices and media, and returns how many.
+	PurgeDeletedProducts(ctx context.Context, before time.Time) (int, error)
+	// UpdateStock applies a single stock change (see BatchUpdateStock).
+	UpdateStock(ctx context.Context, productID int64, quantityChange int32, info StockMovementInfo) (*domain.Product, error)
+	// BatchUpdateStock applies several stock changes in a single transaction, locking rows in ID order.
+	// A decrease fails with ErrInsufficientStock if it would leave less stock than is held by active
+	// reservations; CommitReservation is the way to take reserved stock.
+	// With atomic set, either every change is applied or none is. Otherwise valid changes are applied
+	// and failed ones are skipped. Results are returned in input order; the error is only non-nil
+	// for failures unrelated to individual items (e.g. the database is unreachable).
+	BatchUpdateStock(ctx context.Context, updates []StockUpdate, atomic bool, info StockMovementInfo) ([]StockUpdateResult, error)
+	// ListStockMovements returns a product's stock ledger, newest first, and the total count.
+	// Every method that changes stock (including CreateProduct and UpdateProduct) appends to the ledger
+	// in the same transaction as the change.
+	ListStockMovements(ctx context.Context, params ListStockMovementsParams) ([]domain.StockMovement, int, error)
 	GetRecentProducts(ctx context.Context, limit int) ([]domain.Product, error) // New method for recommendations
+	// ImportProducts creates or updates products by SKU in a single transaction: a product whose SKU
+	// exists replaces that product like UpdateProduct, others are created like CreateProduct, in
+	// input order. A product that fails is skipped without affecting the others. A dry run writes
+	// nothing and checks each product against the stored products alone, ignoring the others.
+	// Results are returned in input order; the error is only non-nil for failures unrelated to
+	// individual products, in which case nothing is written.
+	ImportProducts(ctx context.Context, products []*domain.Product, dryRun bool) ([]ProductImportResult, error)
+	// ListVariants returns the variants of the given parent products by parent ID, ordered by ID and
+	// at their effective price. CreateProduct and UpdateProduct check the variant fields of a product
+	// (ErrInvalidVariant, ErrVariantExists), and UpdateProduct passes a change of a parent's base
+	// price on to the variants that inherit it, including those in the trash. DeleteProduct deletes
+	// a parent with its variants.
+	ListVariants(ctx context.Context, parentIDs []int64) (map[int64][]domain.Product, error)
+	// ListProductMedia returns a product's images in display order (position, then ID).
+	ListProductMedia(ctx context.Context, productID int64) ([]domain.ProductMedia, error)
+	// ListMedia returns the images of the given products by product ID, in display order. Products
+	// without images are absent. Product reads leave Media empty.
+	ListMedia(ctx context.Context, productIDs []int64) (map[int64][]domain.ProductMedia, error)
+	// AddProductMedia adds an image to a product, after its other images if Position is 0. A new
+	// primary image moves the previous one to the gallery. The media methods keep the product's
+	// ImageURL at the derived primary image (see domain.ProductMedia), and UpdateProduct leaves
+	// ImageURL unchanged while the product has images.
+	AddProductMedia(ctx context.Context, m *domain.ProductMedia) (*domain.ProductMedia, error)
+	// UpdateProductMedia replaces the fields of an image; a zero Position keeps its position.
+	UpdateProductMedia(ctx context.Context, m *domain.ProductMedia) (*domain.ProductMedia, error)
+	DeleteProductMedia(ctx context.Context, productID, id int64) error
+	// CreateScheduledPrice schedules a price for a product, e.g. a sale. Its currency must be that of
+	// the product's base price. Product reads (GetProductByID, ListProducts, ListProductFacets and
+	// GetRecentProducts) return the effective price: the active scheduled price that started last,
+	// with the base price as CompareAtPrice, or else the base price. Price filters and sorting use
+	// the effective price too. Writes return the base price.
+	CreateScheduledPrice(ctx context.Context, sp *domain.ScheduledPrice) (*domain.ScheduledPrice, error)
+	// ListScheduledPrices returns a product's scheduled prices, past and future, by start time.
+	ListScheduledPrices(ctx context.Context, productID int64) ([]domain.ScheduledPrice, error)
+	// DeleteScheduledPrice cancels a scheduled price. One that has started is ended now rather than
+	// deleted, so that GetPricesAt keeps answering for the past; one that has ended cannot be
+	// cancelled (ErrScheduledPriceEnded).
+	DeleteScheduledPrice(ctx context.Context, productID, id int64) error
+	// ListPriceChanges returns a product's price history, latest effective first, and the total
+	// count. CreateProduct, UpdateProduct (when the price changes) and the scheduled price methods
+	// append to it in the same transaction as the change, with the actor from the context.
+	ListPriceChanges(ctx context.Context, params ListPriceChangesParams) ([]domain.PriceChange, int, error)
+	// GetPricesAt returns the effective price of the given products at time at, by product ID, as
+	// recorded by the price history and scheduled prices, including products in the trash. Products
+	// that did not exist then (or have been purged since) are absent.
+	GetPricesAt(ctx context.Context, productIDs []int64, at time.Time) (map[int64]domain.Money, error)
+}
+
+// LocationStorer defines the database operations for stock locations (warehouses) and the stock
+// held at each of them. A product's StockQuantity is the sum of its location rows; every method
+// that changes stock keeps both in step.
+type LocationStorer interface {
+	CreateLocation(ctx context.Context, location *domain.Location) (*domain.Location, error)
+	GetLocationByID(ctx context.Context, id int64) (*domain.Location, error)
+	// ListLocations returns all locations in allocation order (priority, then ID).
+	ListLocations(ctx context.Context) ([]domain.Location, error)
+	UpdateLocation(ctx context.Context, location *domain.Location) (*domain.Location, error)
+	// DeleteLocation fails with ErrLocationInUse while the location holds any stock.
+	DeleteLocation(ctx context.Context, id int64) error
+	// ListLocationStock returns the location rows of the given products, ordered by product ID and
+	// then by allocation order.
+	ListLocationStock(ctx context.Context, productIDs []int64) ([]domain.LocationStock, error)
+	// TransferStock moves quantity of a product from one location to another, leaving the aggregate
+	// stock unchanged, and returns the product's location rows afterwards.
+	TransferStock(ctx context.Context, productID, fromLocationID, toLocationID int64, quantity int32, info StockMovementInfo) ([]domain.LocationStock, error)
+}
+
+// PriceListStorer defines the database operations for price lists and their entries, and resolves
+// the price a product read returns.
+type PriceListStorer interface {
+	// CreatePriceList fails with ErrDefaultPriceListExists if the list is a default and its currency
+	// already has one.
+	CreatePriceList(ctx context.Context, list *domain.PriceList) (*domain.PriceList, error)
+	GetPriceListByID(ctx context.Context, id int64) (*domain.PriceList, error)
+	// ListPriceLists returns all price lists ordered by name.
+	ListPriceLists(ctx context.Context) ([]domain.PriceList, error)
+	// UpdatePriceList cannot change the currency of a list that has entries (ErrInvalidPriceList).
+	UpdatePriceList(ctx context.Context, list *domain.PriceList) (*domain.PriceList, error)
+	// DeletePriceList deletes the list together with its entries.
+	DeletePriceList(ctx context.Context, id int64) error
+	// ListPriceListEntries returns the entries of a list ordered by product ID, and the total count.
+	ListPriceListEntries(ctx context.Context, params ListPriceListEntriesParams) ([]domain.PriceListEntry, int, error)
+	// SetPriceListEntries creates or replaces the entries of a list in one transaction: either all
+	// are written or none is (ErrInvalidPriceListEntry or ErrProductNotFound, annotated with the
+	// product ID). It returns the stored entries in input order.
+	SetPriceListEntries(ctx context.Context, priceListID int64, entries []domain.PriceListEntry) ([]domain.PriceListEntry, error)
+	// DeletePriceListEntries removes the entries of the given products from a list and returns how
+	// many existed.
+	DeletePriceListEntries(ctx context.Context, priceListID int64, productIDs []int64) (int, error)
+	// ResolvePrices replaces the base price of each product with its price in the selected list, or
+	// failing that in the default list of the selected currency, and sets PriceListID. Products
+	// with neither keep their base price, which may be in another currency.
+	ResolvePrices(ctx context.Context, sel PriceSelection, products []domain.Product) error
+}
+
+// ReservationItem is a quantity of a product to hold for a reservation.
+type ReservationItem struct {
+	ProductID int64
+	Quantity  int32
+}
+
+// ReservationStorer defines the database operations for stock reservations.
+// A reservation is identified by its reference ID (an order or cart ID) and holds one
+// domain.StockReservation row per product. Only active, unexpired reservations count
+// against available stock.
+type ReservationStorer interface {
+	// ReserveStock holds all items for referenceID until expiresAt, or none of them.
+	// Fails with ErrReservationExists if the reference already holds an active reservation,
+	// and with ErrProductNotFound/ErrInsufficientStock if an item cannot be held.
+	ReserveStock(ctx context.Context, referenceID string, items []ReservationItem, expiresAt time.Time) ([]domain.StockReservation, error)
+	// CommitReservation converts the active reservation into a real stock decrement and
+	// returns the committed reservations together with the updated products.
+	CommitReservation(ctx context.Context, referenceID string) ([]domain.StockReservation, []domain.Product, error)
+	// ReleaseReservation returns the held quantities without changing stock.
+	ReleaseReservation(ctx context.Context, referenceID string) ([]domain.StockReservation, error)
+	// ExpireReservations marks active reservations that expired at or before now and returns how many.
+	ExpireReservations(ctx context.Context, now time.Time) (int, error)
+	// GetReservedQuantities sums active holds per product, ignoring holds for excludeReferenceID (if non-empty).
+	GetReservedQuantities(ctx context.Context, productIDs []int64, excludeReferenceID string) (map[int64]int32, error)
+}
+
+// IdempotencyStorer records processed requests by (key, operation) so that retries are not applied twice.
+// A handler claims the key before doing the work, completes it with the serialized response afterwards,
+// and releases it if the work failed in a way the caller should be able to retry.
+type IdempotencyStorer interface {
+	// ClaimIdempotencyKey inserts an in-flight record for (key, operation) unless a live one exists.
+	// It returns claimed == true if the caller now owns the key; otherwise it returns the existing
+	// record. Records past their expiry are replaced as if they did not exist.
+	ClaimIdempotencyKey(ctx context.Context, key, operation, requestHash string, expiresAt time.Time) (record *domain.IdempotencyRecord, claimed bool, err error)
+	// CompleteIdempotencyKey stores the response of a claimed key.
+	CompleteIdempotencyKey(ctx context.Context, key, operation string, response []byte) error
+	// ReleaseIdempotencyKey deletes a claimed key that has not been completed.
+	ReleaseIdempotencyKey(ctx context.Context, key, operation string) error
+	// DeleteExpiredIdempotencyKeys removes records that expired at or before now and returns how many.
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error)
 }
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// StockMovementInfo describes why a stock operation happens; it is copied onto every ledger
+// entry the operation writes. The actor is taken from the context (see WithActor).
+type StockMovementInfo struct {
+	Reason  domain.StockMovementReason
+	OrderID *string
+	Note    *string
+}
+
+// ListStockMovementsParams holds parameters for listing a product's stock history.
+type ListStockMovementsParams struct {
+	ProductID int64
+	From      *time.Time // Inclusive lower bound on created_at
+	To        *time.Time // Exclusive upper bound on created_at
+	Limit     int
+	Offset    int
+	// After is an optional keyset position from StockMovementCursor. Entries up to and including
+	// it are skipped; the total count still covers the whole range.
+	After *Cursor
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a context carrying the identity of whoever triggers the store operations made
+// with it. Stores record it on stock ledger and price history entries.
+func WithActor(ctx context.Context, actor string) context.Context {
+	if actor == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor set with WithActor, or nil.
+func ActorFromContext(ctx context.Context) *string {
+	if actor, ok := ctx.Value(actorContextKey{}).(string); ok {
+		return &actor
+	}
+	return nil
+}
+
+// ledgerEntry is a stock change to be written to the ledger.
+type ledgerEntry struct {
+	productID    int64
+	locationID   *int64
+	delta        int32
+	balanceAfter int32 // Aggregate stock of the product
+}
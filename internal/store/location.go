@@ -0,0 +1,195 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"product-catalog-service/internal/domain"
+)
+
+// Predefined errors for location operations
+var (
+	ErrLocationNotFound   = errors.New("store: location not found")
+	ErrLocationCodeExists = errors.New("store: location with this code already exists")
+	ErrLocationInUse      = errors.New("store: location still holds stock")
+	ErrNoLocations        = errors.New("store: no location to receive stock")
+	ErrSameLocation       = errors.New("store: source and destination location are the same")
+)
+
+// stockKey identifies the stock of a product at one location.
+type stockKey struct {
+	productID  int64
+	locationID int64
+}
+
+// stockLevels is a working copy of the per-location stock of some products. Changes are validated
+// and applied to it in Go before anything is written, so that every item can report its own
+// outcome. Shared by the Postgres and memory backends.
+type stockLevels struct {
+	order   []int64         // Location IDs in allocation order
+	known   map[int64]bool  // Existing location IDs
+	totals  map[int64]int32 // Aggregate stock per product; products missing here do not exist
+	held    map[int64]int32 // Stock per product that decreases must leave in place; nil holds nothing back
+	levels  map[stockKey]int32
+	changed map[stockKey]bool
+}
+
+// newStockLevels builds the working copy from the locations (in allocation order), the aggregate
+// stock of the products involved and their location rows.
+func newStockLevels(locations []domain.Location, totals map[int64]int32, rows []domain.LocationStock) *stockLevels {
+	l := &stockLevels{
+		order:   make([]int64, 0, len(locations)),
+		known:   make(map[int64]bool, len(locations)),
+		totals:  totals,
+		levels:  make(map[stockKey]int32, len(rows)),
+		changed: make(map[stockKey]bool),
+	}
+	for _, loc := range locations {
+		l.order = append(l.order, loc.ID)
+		l.known[loc.ID] = true
+	}
+	for _, r := range rows {
+		l.levels[stockKey{r.ProductID, r.LocationID}] = r.Quantity
+	}
+	return l
+}
+
+// allocate splits a change of delta to productID's stock into per-location parts without applying it.
+// A change for a given location applies there only. Otherwise increases go to the first location
+// in allocation order, and decreases take as much as possible from each location in turn.
+// Decreases fail with ErrInsufficientStock if they would leave less aggregate stock than is held.
+func (l *stockLevels) allocate(productID int64, locationID *int64, delta int32) ([]domain.StockAllocation, error) {
+	if locationID != nil {
+		if !l.known[*locationID] {
+			return nil, ErrLocationNotFound
+		}
+		if l.levels[stockKey{productID, *locationID}]+delta < 0 {
+			return nil, ErrInsufficientStock
+		}
+		if delta < 0 && l.totals[productID]+delta < l.held[productID] {
+			return nil, ErrInsufficientStock
+		}
+		return []domain.StockAllocation{{LocationID: *locationID, Quantity: delta}}, nil
+	}
+	if delta == 0 {
+		return nil, nil
+	}
+	if delta > 0 {
+		if len(l.order) == 0 {
+			return nil, ErrNoLocations
+		}
+		return []domain.StockAllocation{{LocationID: l.order[0], Quantity: delta}}, nil
+	}
+	if l.totals[productID]+delta < l.held[productID] {
+		return nil, ErrInsufficientStock
+	}
+	var allocations []domain.StockAllocation
+	remaining := -delta
+	for _, locationID := range l.order {
+		if remaining == 0 {
+			break
+		}
+		take := min(l.levels[stockKey{productID, locationID}], remaining)
+		if take > 0 {
+			allocations = append(allocations, domain.StockAllocation{LocationID: locationID, Quantity: -take})
+			remaining -= take
+		}
+	}
+	if remaining > 0 {
+		return nil, ErrInsufficientStock
+	}
+	return allocations, nil
+}
+
+// apply adds allocations to productID's stock and returns one ledger entry per non-zero part,
+// each carrying the running aggregate balance.
+func (l *stockLevels) apply(productID int64, allocations []domain.StockAllocation) []ledgerEntry {
+	entries := make([]ledgerEntry, 0, len(allocations))
+	for _, a := range allocations {
+		key := stockKey{productID, a.LocationID}
+		l.levels[key] += a.Quantity
+		l.changed[key] = true
+		l.totals[productID] += a.Quantity
+		if a.Quantity != 0 {
+			locationID := a.LocationID
+			entries = append(entries, ledgerEntry{productID: productID, locationID: &locationID, delta: a.Quantity, balanceAfter: l.totals[productID]})
+		}
+	}
+	return entries
+}
+
+// applyUpdates validates updates in input order, applying the valid ones and recording each item's
+// outcome in results. It returns the IDs of products whose stock was touched, in ascending order,
+// the ledger entries of the applied items and whether any item failed.
+func (l *stockLevels) applyUpdates(updates []StockUpdate, results []StockUpdateResult) (changed []int64, entries []ledgerEntry, failed bool) {
+	seen := make(map[int64]bool, len(updates))
+	for i, u := range updates {
+		results[i] = StockUpdateResult{ProductID: u.ProductID}
+		if _, ok := l.totals[u.ProductID]; !ok {
+			results[i].Err = ErrProductNotFound
+			failed = true
+			continue
+		}
+		allocations, err := l.allocate(u.ProductID, u.LocationID, u.QuantityChange)
+		if err != nil {
+			results[i].Err = err
+			failed = true
+			continue
+		}
+		entries = append(entries, l.apply(u.ProductID, allocations)...)
+		results[i].Allocations = allocations
+		if !seen[u.ProductID] {
+			seen[u.ProductID] = true
+			changed = append(changed, u.ProductID)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i] < changed[j] })
+	return changed, entries, failed
+}
+
+// changedRows returns the new quantity of every (product, location) pair touched, ordered by
+// product and location ID.
+func (l *stockLevels) changedRows() []domain.LocationStock {
+	rows := make([]domain.LocationStock, 0, len(l.changed))
+	for key := range l.changed {
+		rows = append(rows, domain.LocationStock{ProductID: key.productID, LocationID: key.locationID, Quantity: l.levels[key]})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].ProductID != rows[j].ProductID {
+			return rows[i].ProductID < rows[j].ProductID
+		}
+		return rows[i].LocationID < rows[j].LocationID
+	})
+	return rows
+}
+
+// transferUpdates expresses moving quantity of a product between two locations as a pair of stock
+// updates, to be applied atomically.
+func transferUpdates(productID, fromLocationID, toLocationID int64, quantity int32) []StockUpdate {
+	return []StockUpdate{
+		{ProductID: productID, LocationID: &fromLocationID, QuantityChange: -quantity},
+		{ProductID: productID, LocationID: &toLocationID, QuantityChange: quantity},
+	}
+}
+
+// firstStockError returns the error of the first item that failed on its own, annotated with its
+// product ID, skipping items that were only rolled back because of it.
+func firstStockError(results []StockUpdateResult) error {
+	for _, r := range results {
+		if r.Err != nil && !errors.Is(r.Err, ErrStockBatchAborted) {
+			return fmt.Errorf("%w (product ID %d)", r.Err, r.ProductID)
+		}
+	}
+	return nil
+}
+
+// sortLocations orders locations for allocation: by priority, then by ID.
+func sortLocations(locations []domain.Location) {
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Priority != locations[j].Priority {
+			return locations[i].Priority < locations[j].Priority
+		}
+		return locations[i].ID < locations[j].ID
+	})
+}
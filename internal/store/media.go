@@ -0,0 +1,60 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+)
+
+// Predefined errors for product media
+var (
+	ErrMediaNotFound = errors.New("store: product media not found")
+	// ErrInvalidMedia wraps what is wrong with a ProductMedia.
+	ErrInvalidMedia = errors.New("store: invalid product media")
+)
+
+// validateMedia checks an image before it is stored. A zero Position is left for the store to
+// resolve.
+func validateMedia(m *domain.ProductMedia) error {
+	switch {
+	case strings.TrimSpace(m.URL) == "":
+		return fmt.Errorf("%w: url is required", ErrInvalidMedia)
+	case !m.Role.Valid():
+		return fmt.Errorf("%w: role must be primary, gallery or thumbnail", ErrInvalidMedia)
+	case m.Position < 0:
+		return fmt.Errorf("%w: position must be positive", ErrInvalidMedia)
+	case (m.Width != nil && *m.Width <= 0) || (m.Height != nil && *m.Height <= 0):
+		return fmt.Errorf("%w: width and height must be positive", ErrInvalidMedia)
+	}
+	return nil
+}
+
+// primaryImageURL returns the image URL derived from a product's media: that of the primary image,
+// or else of the first gallery image in display order, or nil. Mirrors syncPrimaryImage.
+func primaryImageURL(media []*domain.ProductMedia) *string {
+	var first *domain.ProductMedia
+	for _, m := range media {
+		switch {
+		case m.Role == domain.MediaRolePrimary:
+			url := m.URL
+			return &url
+		case m.Role == domain.MediaRoleGallery && (first == nil || mediaBefore(m, first)):
+			first = m
+		}
+	}
+	if first == nil {
+		return nil
+	}
+	url := first.URL
+	return &url
+}
+
+// mediaBefore reports whether a comes before b in display order: by position, then ID.
+func mediaBefore(a, b *domain.ProductMedia) bool {
+	if a.Position != b.Position {
+		return a.Position < b.Position
+	}
+	return a.ID < b.ID
+}
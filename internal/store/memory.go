@@ -0,0 +1,777 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// MemoryStore implements the CategoryStorer, ProductStorer, LocationStorer, ReservationStorer and
+// IdempotencyStorer interfaces in memory.
+// It is intended for local development and tests that should not depend on PostgreSQL,
+// and mirrors the constraints enforced by the schema (unique names/SKUs, foreign keys,
+// non-negative stock) so callers observe the same sentinel errors.
+type MemoryStore struct {
+	mu                sync.RWMutex
+	categories        map[int64]*domain.Category
+	deletedCategories map[int64]*domain.Category                     // The category trash, apart so that other reads never see it
+	categoryAttrs     map[int64]map[string]*domain.CategoryAttribute // Own definitions by category and key
+	products          map[int64]*domain.Product
+	deletedProducts   map[int64]*domain.Product // The product trash, apart so that other reads never see it
+	locations         map[int64]*domain.Location
+	locationStock     map[stockKey]*domain.LocationStock
+	reservations      []*domain.StockReservation // In creation (ID) order
+	idempotencyKeys   map[idempotencyKey]*domain.IdempotencyRecord
+	stockMovements    []domain.StockMovement // Append-only, in ID order
+	priceLists        map[int64]*domain.PriceList
+	priceListEntries  map[int64]map[int64]*domain.PriceListEntry // By price list and product ID
+	scheduledPrices   map[int64][]*domain.ScheduledPrice         // By product ID, in creation (ID) order
+	priceChanges      []domain.PriceChange                       // Append-only, in ID order
+	media             map[int64][]*domain.ProductMedia           // By product ID, in ID order
+	nextCategoryID    int64
+	nextProductID     int64
+	nextLocationID    int64
+	nextReservationID int64
+	nextMovementID    int64
+	nextPriceListID   int64
+	nextScheduledID   int64
+	nextPriceChangeID int64
+	nextMediaID       int64
+}
+
+// NewMemoryStore creates a new MemoryStore instance holding only the default location,
+// like a freshly migrated database.
+func NewMemoryStore() *MemoryStore {
+	now := time.Now().UTC()
+	return &MemoryStore{
+		categories:        make(map[int64]*domain.Category),
+		deletedCategories: make(map[int64]*domain.Category),
+		categoryAttrs:     make(map[int64]map[string]*domain.CategoryAttribute),
+		products:          make(map[int64]*domain.Product),
+		deletedProducts:   make(map[int64]*domain.Product),
+		locations: map[int64]*domain.Location{
+			1: {ID: 1, Code: "default", Name: "Default warehouse", CreatedAt: now, UpdatedAt: now},
+		},
+		locationStock:     make(map[stockKey]*domain.LocationStock),
+		idempotencyKeys:   make(map[idempotencyKey]*domain.IdempotencyRecord),
+		priceLists:        make(map[int64]*domain.PriceList),
+		priceListEntries:  make(map[int64]map[int64]*domain.PriceListEntry),
+		scheduledPrices:   make(map[int64][]*domain.ScheduledPrice),
+		media:             make(map[int64][]*domain.ProductMedia),
+		nextCategoryID:    1,
+		nextProductID:     1,
+		nextLocationID:    2,
+		nextReservationID: 1,
+		nextMovementID:    1,
+		nextPriceListID:   1,
+		nextScheduledID:   1,
+		nextPriceChangeID: 1,
+		nextMediaID:       1,
+	}
+}
+
+// --- CategoryStorer Implementation ---
+
+func (s *MemoryStore) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.categoryNameTaken(category.Name, 0) {
+		return nil, ErrCategoryNameExists
+	}
+	if category.ParentCategoryID != nil {
+		if _, ok := s.categories[*category.ParentCategoryID]; !ok {
+			return nil, ErrCategoryNotFound
+		}
+	}
+
+	now := time.Now().UTC()
+	created := cloneCategory(category)
+	created.ID = s.nextCategoryID
+	created.DeletedAt = nil
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	s.nextCategoryID++
+	s.categories[created.ID] = created
+
+	return cloneCategory(created), nil
+}
+
+func (s *MemoryStore) GetCategoryByID(ctx context.Context, id int64) (*domain.Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	category, ok := s.categories[id]
+	if !ok {
+		return nil, ErrCategoryNotFound
+	}
+	return cloneCategory(category), nil
+}
+
+func (s *MemoryStore) ListCategories(ctx context.Context, params ListCategoriesParams) ([]domain.Category, int, error) {
+	if params.After != nil {
+		if err := params.After.checkCategoryCursor(); err != nil {
+			return nil, 0, err
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make([]domain.Category, 0, len(s.categories))
+	for _, c := range withDeleted(params.Deleted, s.categories, s.deletedCategories) {
+		if params.ParentCategoryID != nil && (c.ParentCategoryID == nil || *c.ParentCategoryID != *params.ParentCategoryID) {
+			continue
+		}
+		all = append(all, *cloneCategory(c))
+	}
+	sort.Slice(all, func(i, j int) bool { // Same default order as PostgresStore: name ASC
+		if all[i].Name != all[j].Name {
+			return all[i].Name < all[j].Name
+		}
+		return all[i].ID < all[j].ID
+	})
+
+	totalCount := len(all)
+	if params.After != nil {
+		after := params.After
+		all = all[sort.Search(len(all), func(i int) bool {
+			return all[i].Name > after.Key || (all[i].Name == after.Key && all[i].ID > after.ID)
+		}):]
+	}
+	return paginate(all, params.Limit, params.Offset), totalCount, nil
+}
+
+func (s *MemoryStore) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.categories[category.ID]
+	if !ok {
+		return nil, ErrCategoryNotFound
+	}
+	if s.categoryNameTaken(category.Name, category.ID) {
+		return nil, ErrCategoryNameExists
+	}
+	if category.ParentCategoryID != nil {
+		if _, ok := s.categories[*category.ParentCategoryID]; !ok {
+			return nil, ErrCategoryNotFound
+		}
+		if s.isAncestorOrSelfLocked(category.ID, *category.ParentCategoryID) {
+			return nil, ErrCategoryCycle
+		}
+	}
+
+	updated := cloneCategory(category)
+	updated.DeletedAt = nil
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now().UTC()
+	s.categories[updated.ID] = updated
+
+	return cloneCategory(updated), nil
+}
+
+func (s *MemoryStore) DeleteCategory(ctx context.Context, id int64, opts DeleteCategoryOptions) error {
+	if err := opts.validate(id); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	category, ok := s.categories[id]
+	if !ok {
+		return ErrCategoryNotFound
+	}
+	if opts.TargetCategoryID != nil {
+		if _, ok := s.categories[*opts.TargetCategoryID]; !ok {
+			return missingDeleteTarget(*opts.TargetCategoryID)
+		}
+	}
+
+	now := time.Now().UTC()
+	switch opts.Strategy {
+	case CategoryDeleteReparent, CategoryDeleteMoveProducts:
+		productsTo := category.ParentCategoryID
+		if opts.Strategy == CategoryDeleteMoveProducts {
+			productsTo = opts.TargetCategoryID
+		}
+		s.moveCategoryContentsLocked(id, category.ParentCategoryID, productsTo)
+		s.trashCategoryLocked(id, now)
+	case CategoryDeleteCascade:
+		// The descendants keep their parents and get the category's deletion time, by which
+		// RestoreCategory finds them. Products in the trash keep their categories, which they get
+		// back with them.
+		for _, row := range s.walkCategoriesLocked([]int64{id}, 0) {
+			s.moveCategoryProductsLocked(DeletedExcluded, row.category.ID, nil)
+			s.trashCategoryLocked(row.category.ID, now)
+		}
+	default: // CategoryDeleteRestrict; subcategories and products in the trash keep pointing to the category
+		for _, c := range s.categories {
+			if c.ParentCategoryID != nil && *c.ParentCategoryID == id {
+				return ErrCategoryNotEmpty
+			}
+		}
+		for _, p := range s.products {
+			if p.CategoryID != nil && *p.CategoryID == id {
+				return ErrCategoryNotEmpty
+			}
+		}
+		s.trashCategoryLocked(id, now)
+	}
+	return nil
+}
+
+// moveCategoryContentsLocked moves the subcategories of a category under parentID and its products
+// to productsTo; nil makes them roots and uncategorized. Those in the trash move too. The caller
+// must hold s.mu.
+func (s *MemoryStore) moveCategoryContentsLocked(id int64, parentID, productsTo *int64) {
+	now := time.Now().UTC()
+	for _, c := range withDeleted(DeletedIncluded, s.categories, s.deletedCategories) {
+		if c.ParentCategoryID != nil && *c.ParentCategoryID == id {
+			c.ParentCategoryID = cloneID(parentID)
+			c.UpdatedAt = now
+		}
+	}
+	s.moveCategoryProductsLocked(DeletedIncluded, id, productsTo)
+}
+
+// moveCategoryProductsLocked moves the products of a category that f selects to productsTo; nil
+// makes them uncategorized. The caller must hold s.mu.
+func (s *MemoryStore) moveCategoryProductsLocked(f DeletedFilter, id int64, productsTo *int64) {
+	now := time.Now().UTC()
+	for _, p := range withDeleted(f, s.products, s.deletedProducts) {
+		if p.CategoryID != nil && *p.CategoryID == id {
+			p.CategoryID = cloneID(productsTo)
+			p.UpdatedAt = now
+		}
+	}
+}
+
+// isAncestorOrSelfLocked reports whether id is categoryID or one of its ancestors. The caller must
+// hold s.mu.
+func (s *MemoryStore) isAncestorOrSelfLocked(id, categoryID int64) bool {
+	visited := make(map[int64]bool)
+	for current := categoryID; !visited[current]; {
+		if current == id {
+			return true
+		}
+		visited[current] = true
+		c, ok := s.categories[current]
+		if !ok || c.ParentCategoryID == nil {
+			return false
+		}
+		current = *c.ParentCategoryID
+	}
+	return false
+}
+
+// categoryNameTaken reports whether another category (other than excludeID) already uses name.
+// Callers must hold s.mu.
+func (s *MemoryStore) categoryNameTaken(name string, excludeID int64) bool {
+	for _, c := range s.categories {
+		if c.ID != excludeID && c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// --- ProductStorer Implementation ---
+
+func (s *MemoryStore) CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createProductLocked(ctx, product)
+}
+
+// createProductLocked is CreateProduct without the locking. Callers must hold s.mu for writing.
+func (s *MemoryStore) createProductLocked(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	created := cloneProduct(product)
+	created.Variants, created.Media, created.DeletedAt = nil, nil, nil
+	if err := s.checkVariantLocked(created); err != nil {
+		return nil, err
+	}
+	if err := s.checkProductConstraints(created, 0); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	created.ID = s.nextProductID
+	created.Price = storedPrice(created.Price)
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	if created.StockQuantity != 0 {
+		levels := newStockLevels(s.sortedLocationsLocked(), map[int64]int32{created.ID: 0}, nil)
+		if err := s.recordStockEditLocked(ctx, levels, created.ID, created.StockQuantity, domain.StockReasonInitialStock, now); err != nil {
+			return nil, err
+		}
+	}
+	s.nextProductID++
+	s.products[created.ID] = created
+	s.appendPriceChangeLocked(ctx, &domain.PriceChange{
+		ProductID: created.ID, NewPrice: created.Price, EffectiveAt: now, Reason: domain.PriceReasonInitialPrice,
+	}, now)
+
+	return cloneProduct(created), nil
+}
+
+func (s *MemoryStore) GetProductByID(ctx context.Context, id int64) (*domain.Product, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	product, ok := s.products[id]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	return s.effectiveProductLocked(product, time.Now()), nil
+}
+
+func (s *MemoryStore) ListProducts(ctx context.Context, params ListProductsParams) ([]domain.Product, int, error) {
+	sortField, desc := productSort(params)
+	var afterValue interface{}
+	if params.After != nil {
+		var err error
+		if afterValue, err = params.After.productSortValue(sortField, desc); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := validateAttributeFilters(params.Attributes); err != nil {
+		return nil, 0, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matched := s.filterProductsLocked(params)
+	sortProducts(matched, params)
+
+	totalCount := len(matched)
+	if params.After != nil {
+		// matched is sorted, so the rows after the cursor are a suffix.
+		matched = matched[sort.Search(len(matched), func(i int) bool {
+			c := compareProductSortKey(&matched[i], sortField, afterValue, params.After.ID)
+			if desc {
+				return c < 0
+			}
+			return c > 0
+		}):]
+	}
+	return paginate(matched, params.Limit, params.Offset), totalCount, nil
+}
+
+// filterProductsLocked returns copies of the products matching the filters of params, unsorted,
+// at their effective price and with their search rank and snippet set when searching. The caller must hold s.mu.
+func (s *MemoryStore) filterProductsLocked(params ListProductsParams) []domain.Product {
+	var idFilter map[int64]bool
+	if len(params.ProductIDs) > 0 {
+		idFilter = make(map[int64]bool, len(params.ProductIDs))
+		for _, id := range params.ProductIDs {
+			idFilter[id] = true
+		}
+	}
+	var categoryFilter map[int64]bool
+	if params.CategoryID != nil {
+		categoryFilter = map[int64]bool{*params.CategoryID: true}
+		if _, ok := s.categories[*params.CategoryID]; ok && params.IncludeDescendants {
+			for _, row := range s.walkCategoriesLocked([]int64{*params.CategoryID}, 0) {
+				categoryFilter[row.category.ID] = true
+			}
+		}
+	}
+	var search *memorySearch
+	if params.SearchQuery != nil && strings.TrimSpace(*params.SearchQuery) != "" {
+		parsed := parseMemorySearch(*params.SearchQuery)
+		search = &parsed
+	}
+
+	now := time.Now()
+	matched := make([]domain.Product, 0)
+	for _, stored := range withDeleted(params.Deleted, s.products, s.deletedProducts) {
+		p := s.effectiveProductLocked(stored, now) // Price filters and sorting use the effective price
+		var rank float32
+		if search != nil {
+			var ok bool
+			if rank, ok = search.rank(p); !ok {
+				continue
+			}
+		}
+		if categoryFilter != nil && (p.CategoryID == nil || !categoryFilter[*p.CategoryID]) {
+			continue
+		}
+		if params.MinPrice != nil && (p.Price.Currency != params.MinPrice.Currency || p.Price.Amount < params.MinPrice.Amount) {
+			continue
+		}
+		if params.MaxPrice != nil && (p.Price.Currency != params.MaxPrice.Currency || p.Price.Amount > params.MaxPrice.Amount) {
+			continue
+		}
+		if params.IsActive != nil && p.IsActive != *params.IsActive {
+			continue
+		}
+		if idFilter != nil && !idFilter[p.ID] {
+			continue
+		}
+		if params.ParentID != nil && (p.ParentID == nil || *p.ParentID != *params.ParentID) {
+			continue
+		}
+		if params.ExcludeVariants && p.ParentID != nil {
+			continue
+		}
+		if !matchesAttributeFilters(p, params.Attributes) {
+			continue
+		}
+		if search != nil {
+			snippet := search.snippet(p)
+			p.SearchRank, p.SearchSnippet = &rank, &snippet
+		}
+		matched = append(matched, *p)
+	}
+	return matched
+}
+
+func (s *MemoryStore) UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.updateProductLocked(ctx, product)
+}
+
+// updateProductLocked is UpdateProduct without the locking. Callers must hold s.mu for writing.
+func (s *MemoryStore) updateProductLocked(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	existing, ok := s.products[product.ID]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	updated := cloneProduct(product)
+	updated.Variants, updated.Media, updated.DeletedAt = nil, nil, nil
+	if err := s.checkVariantLocked(updated); err != nil {
+		return nil, err
+	}
+	if err := s.checkProductConstraints(updated, product.ID); err != nil {
+		return nil, err
+	}
+
+	updated.Price = storedPrice(updated.Price)
+	if len(s.media[updated.ID]) > 0 { // The media writes keep the image URL
+		updated.ImageURL = existing.ImageURL
+	}
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now().UTC()
+	if delta := updated.StockQuantity - existing.StockQuantity; delta != 0 {
+		levels := s.stockLevelsLocked([]int64{updated.ID})
+		if err := s.recordStockEditLocked(ctx, levels, updated.ID, delta, domain.StockReasonProductUpdate, updated.UpdatedAt); err != nil {
+			return nil, err
+		}
+	}
+	s.products[updated.ID] = updated
+	if updated.Price != existing.Price {
+		oldPrice := existing.Price
+		s.appendPriceChangeLocked(ctx, &domain.PriceChange{
+			ProductID: updated.ID, OldPrice: &oldPrice, NewPrice: updated.Price, EffectiveAt: updated.UpdatedAt, Reason: domain.PriceReasonProductUpdate,
+		}, updated.UpdatedAt)
+		s.passOnParentPriceLocked(ctx, updated, updated.UpdatedAt)
+	}
+
+	return cloneProduct(updated), nil
+}
+
+// DeleteProduct moves the variants to the trash with the product, at the same deletion time, by
+// which RestoreProduct finds them.
+func (s *MemoryStore) DeleteProduct(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.products[id]; !ok {
+		return ErrProductNotFound
+	}
+	now := time.Now().UTC()
+	for _, p := range s.products {
+		if p.ParentID != nil && *p.ParentID == id {
+			s.trashProductLocked(p.ID, now)
+		}
+	}
+	s.trashProductLocked(id, now)
+	return nil
+}
+
+// deleteProductLocked deletes a product for good, with what depends on it. Callers must hold s.mu
+// for writing.
+func (s *MemoryStore) deleteProductLocked(id int64) {
+	delete(s.products, id)
+	delete(s.deletedProducts, id)
+	// Mirrors ON DELETE CASCADE on location_stock.product_id, stock_reservations.product_id,
+	// price_list_entries.product_id, scheduled_prices.product_id and product_media.product_id.
+	for key := range s.locationStock {
+		if key.productID == id {
+			delete(s.locationStock, key)
+		}
+	}
+	for _, entries := range s.priceListEntries {
+		delete(entries, id)
+	}
+	delete(s.scheduledPrices, id)
+	delete(s.media, id)
+	kept := s.reservations[:0]
+	for _, r := range s.reservations {
+		if r.ProductID != id {
+			kept = append(kept, r)
+		}
+	}
+	s.reservations = kept
+}
+
+func (s *MemoryStore) UpdateStock(ctx context.Context, productID int64, quantityChange int32, info StockMovementInfo) (*domain.Product, error) {
+	results, err := s.BatchUpdateStock(ctx, []StockUpdate{{ProductID: productID, QuantityChange: quantityChange}}, true, info)
+	if err != nil {
+		return nil, err
+	}
+	return results[0].Product, results[0].Err
+}
+
+func (s *MemoryStore) BatchUpdateStock(ctx context.Context, updates []StockUpdate, atomic bool, info StockMovementInfo) ([]StockUpdateResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.changeStockLocked(ctx, updates, atomic, true, info, time.Now().UTC()), nil
+}
+
+func (s *MemoryStore) ListStockMovements(ctx context.Context, params ListStockMovementsParams) ([]domain.StockMovement, int, error) {
+	var afterTime time.Time
+	if params.After != nil {
+		var err error
+		if afterTime, err = params.After.stockMovementTime(); err != nil {
+			return nil, 0, err
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matched := make([]domain.StockMovement, 0)
+	totalCount := 0
+	for i := len(s.stockMovements) - 1; i >= 0; i-- { // Newest first
+		m := s.stockMovements[i]
+		if m.ProductID != params.ProductID {
+			continue
+		}
+		if params.From != nil && m.CreatedAt.Before(*params.From) {
+			continue
+		}
+		if params.To != nil && !m.CreatedAt.Before(*params.To) {
+			continue
+		}
+		totalCount++
+		if params.After != nil { // Keyset: only entries older than the cursor's (created_at, id)
+			if c := m.CreatedAt.Compare(afterTime); c > 0 || (c == 0 && m.ID >= params.After.ID) {
+				continue
+			}
+		}
+		matched = append(matched, m)
+	}
+	return paginate(matched, params.Limit, params.Offset), totalCount, nil
+}
+
+func (s *MemoryStore) GetRecentProducts(ctx context.Context, limit int) ([]domain.Product, error) {
+	if limit <= 0 {
+		return []domain.Product{}, nil
+	}
+	isActive := true
+	products, _, err := s.ListProducts(ctx, ListProductsParams{
+		IsActive:  &isActive,
+		SortBy:    "created_at",
+		SortOrder: "desc",
+		Limit:     limit,
+	})
+	return products, err
+}
+
+// Close is a no-op; it exists so MemoryStore can be shut down like PostgresStore.
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// appendMovementsLocked writes entries to the stock ledger. Callers must hold s.mu for writing.
+func (s *MemoryStore) appendMovementsLocked(ctx context.Context, info StockMovementInfo, entries []ledgerEntry, now time.Time) {
+	actor := ActorFromContext(ctx)
+	for _, e := range entries {
+		s.stockMovements = append(s.stockMovements, domain.StockMovement{
+			ID:            s.nextMovementID,
+			ProductID:     e.productID,
+			LocationID:    e.locationID,
+			QuantityDelta: e.delta,
+			BalanceAfter:  e.balanceAfter,
+			Reason:        info.Reason,
+			OrderID:       info.OrderID,
+			Actor:         actor,
+			Note:          info.Note,
+			CreatedAt:     now,
+		})
+		s.nextMovementID++
+	}
+}
+
+// checkProductConstraints enforces the schema constraints PostgreSQL would check on write:
+// unique SKU, existing category, non-negative stock and a price the price column holds. Callers must
+// hold s.mu.
+func (s *MemoryStore) checkProductConstraints(product *domain.Product, excludeID int64) error {
+	for _, p := range s.products {
+		if p.ID != excludeID && p.SKU == product.SKU {
+			return ErrProductSKUExists
+		}
+	}
+	if product.CategoryID != nil {
+		if _, ok := s.categories[*product.CategoryID]; !ok {
+			return ErrCategoryNotFound
+		}
+	}
+	if product.Price.Amount < 0 || !product.Price.InRange() {
+		return ErrInvalidPrice
+	}
+	if product.StockQuantity < 0 {
+		return ErrInsufficientStock
+	}
+	if !domain.ValidCurrency(storedPrice(product.Price).Currency) {
+		return fmt.Errorf("store: invalid currency %q", product.Price.Currency)
+	}
+	if product.CategoryID != nil {
+		return validateProductAttributes(s.attributeSchemaLocked(*product.CategoryID), product.Attributes)
+	}
+	return nil
+}
+
+// --- Helpers ---
+
+// sortProducts orders products the same way PostgresStore.ListProducts does:
+// created_at ASC by default (relevance when searching), with the ID as a tie-breaker for a stable order.
+func sortProducts(products []domain.Product, params ListProductsParams) {
+	field, desc := productSort(params)
+	less := func(a, b *domain.Product) int {
+		switch field {
+		case ProductSortName:
+			return strings.Compare(a.Name, b.Name)
+		case ProductSortPrice:
+			return domain.CompareAmounts(a.Price, b.Price)
+		case ProductSortUpdatedAt:
+			return a.UpdatedAt.Compare(b.UpdatedAt)
+		case ProductSortRelevance:
+			return compareFloat(float64(searchRank(a)), float64(searchRank(b)))
+		case ProductSortDeletedAt:
+			return deletedAt(a).Compare(deletedAt(b))
+		default:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+	}
+	sort.SliceStable(products, func(i, j int) bool {
+		c := less(&products[i], &products[j])
+		if c == 0 {
+			c = compareInt64(products[i].ID, products[j].ID)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// paginate applies LIMIT/OFFSET semantics to an already filtered and sorted slice.
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) || limit <= 0 {
+		return []T{}
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// cloneID copies an optional ID so stored entities never share it.
+func cloneID(id *int64) *int64 {
+	if id == nil {
+		return nil
+	}
+	v := *id
+	return &v
+}
+
+// cloneTime copies an optional time so stored entities never share it.
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := *t
+	return &v
+}
+
+func cloneCategory(c *domain.Category) *domain.Category {
+	clone := *c
+	if c.Description != nil {
+		v := *c.Description
+		clone.Description = &v
+	}
+	if c.ParentCategoryID != nil {
+		v := *c.ParentCategoryID
+		clone.ParentCategoryID = &v
+	}
+	clone.DeletedAt = cloneTime(c.DeletedAt)
+	return &clone
+}
+
+func cloneProduct(p *domain.Product) *domain.Product {
+	clone := *p
+	if p.Description != nil {
+		v := *p.Description
+		clone.Description = &v
+	}
+	if p.CategoryID != nil {
+		v := *p.CategoryID
+		clone.CategoryID = &v
+	}
+	if p.ImageURL != nil {
+		v := *p.ImageURL
+		clone.ImageURL = &v
+	}
+	clone.ParentID = cloneID(p.ParentID)
+	clone.Options = slices.Clone(p.Options)
+	clone.OptionValues = maps.Clone(p.OptionValues)
+	clone.Variants = slices.Clone(p.Variants)
+	clone.Media = slices.Clone(p.Media)
+	clone.DeletedAt = cloneTime(p.DeletedAt)
+	// Match PostgresStore, which never returns a JSON null for attributes.
+	if p.Attributes != nil && len(*p.Attributes) > 0 && string(*p.Attributes) != "null" {
+		v := make(json.RawMessage, len(*p.Attributes))
+		copy(v, *p.Attributes)
+		clone.Attributes = &v
+	} else {
+		clone.Attributes = nil
+	}
+	return &clone
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Attribute schema part of the CategoryStorer Implementation ---
+
+func (s *MemoryStore) GetCategoryAttributeSchema(ctx context.Context, categoryID int64) ([]domain.CategoryAttribute, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.categories[categoryID]; !ok {
+		return nil, ErrCategoryNotFound
+	}
+	return s.attributeSchemaLocked(categoryID), nil
+}
+
+func (s *MemoryStore) PutCategoryAttribute(ctx context.Context, attr *domain.CategoryAttribute) (*domain.CategoryAttribute, error) {
+	if err := validateAttributeDefinition(attr); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.categories[attr.CategoryID]; !ok {
+		return nil, ErrCategoryNotFound
+	}
+	now := time.Now().UTC()
+	stored := cloneCategoryAttribute(attr)
+	stored.CreatedAt, stored.UpdatedAt = now, now
+	if len(stored.EnumValues) == 0 {
+		stored.EnumValues = nil // Stored as NULL
+	}
+	own := s.categoryAttrs[attr.CategoryID]
+	if own == nil {
+		own = make(map[string]*domain.CategoryAttribute)
+		s.categoryAttrs[attr.CategoryID] = own
+	}
+	if existing, ok := own[attr.Key]; ok {
+		stored.CreatedAt = existing.CreatedAt
+	}
+	own[attr.Key] = stored
+	return cloneCategoryAttribute(stored), nil
+}
+
+func (s *MemoryStore) DeleteCategoryAttribute(ctx context.Context, categoryID int64, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.categoryAttrs[categoryID][key]; !ok {
+		return ErrCategoryAttributeNotFound
+	}
+	delete(s.categoryAttrs[categoryID], key)
+	return nil
+}
+
+// attributeSchemaLocked returns the effective attribute schema of a category, ordered by key, like
+// effectiveAttributeSchemaQuery. The caller must hold s.mu.
+func (s *MemoryStore) attributeSchemaLocked(categoryID int64) []domain.CategoryAttribute {
+	nearest := make(map[string]*domain.CategoryAttribute)
+	visited := make(map[int64]bool)
+	for id := categoryID; !visited[id]; { // Walk up to the root, stopping at a cycle
+		visited[id] = true
+		for key, attr := range s.categoryAttrs[id] {
+			if _, found := nearest[key]; !found {
+				nearest[key] = attr
+			}
+		}
+		category, ok := s.categories[id]
+		if !ok || category.ParentCategoryID == nil {
+			break
+		}
+		id = *category.ParentCategoryID
+	}
+
+	schema := make([]domain.CategoryAttribute, 0, len(nearest))
+	for _, attr := range nearest {
+		schema = append(schema, *cloneCategoryAttribute(attr))
+	}
+	sort.Slice(schema, func(i, j int) bool { return schema[i].Key < schema[j].Key })
+	return schema
+}
+
+func cloneCategoryAttribute(a *domain.CategoryAttribute) *domain.CategoryAttribute {
+	clone := *a
+	clone.EnumValues = append([]string(nil), a.EnumValues...)
+	if a.Unit != nil {
+		v := *a.Unit
+		clone.Unit = &v
+	}
+	return &clone
+}
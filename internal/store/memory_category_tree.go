@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Category hierarchy part of the CategoryStorer Implementation ---
+
+func (s *MemoryStore) GetCategoryTree(ctx context.Context, rootID *int64, maxDepth int) ([]domain.CategoryNode, error) {
+	if maxDepth < 0 {
+		return nil, ErrInvalidMaxDepth
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var start []int64
+	if rootID != nil {
+		if _, ok := s.categories[*rootID]; !ok {
+			return nil, ErrCategoryNotFound
+		}
+		start = []int64{*rootID}
+	} else {
+		for id, c := range s.categories {
+			if c.ParentCategoryID == nil {
+				start = append(start, id)
+			}
+		}
+	}
+	return buildCategoryTree(s.walkCategoriesLocked(start, maxDepth)), nil
+}
+
+func (s *MemoryStore) GetCategoryAncestors(ctx context.Context, id int64) ([]domain.Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	category, ok := s.categories[id]
+	if !ok {
+		return nil, ErrCategoryNotFound
+	}
+	var ancestors []domain.Category
+	visited := map[int64]bool{id: true}
+	for category.ParentCategoryID != nil && !visited[*category.ParentCategoryID] {
+		parent, ok := s.categories[*category.ParentCategoryID]
+		if !ok {
+			break
+		}
+		visited[parent.ID] = true
+		ancestors = append(ancestors, *cloneCategory(parent))
+		category = parent
+	}
+
+	rootFirst := make([]domain.Category, 0, len(ancestors))
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		rootFirst = append(rootFirst, ancestors[i])
+	}
+	return rootFirst, nil
+}
+
+func (s *MemoryStore) GetCategoryDescendants(ctx context.Context, id int64, maxDepth int) ([]domain.Category, error) {
+	if maxDepth < 0 {
+		return nil, ErrInvalidMaxDepth
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.categories[id]; !ok {
+		return nil, ErrCategoryNotFound
+	}
+	if maxDepth > 0 {
+		maxDepth++ // The walk counts the category itself as depth 1
+	}
+	rows := s.walkCategoriesLocked([]int64{id}, maxDepth)[1:]
+	descendants := make([]domain.Category, 0, len(rows))
+	for _, row := range rows {
+		descendants = append(descendants, row.category)
+	}
+	return descendants, nil
+}
+
+// walkCategoriesLocked returns the start categories and the categories below them, down to
+// maxDepth levels (0 for no limit), ordered by sortTreeCategories. A category already reached is
+// not visited again, so a cycle in the hierarchy ends the walk. The caller must hold s.mu.
+func (s *MemoryStore) walkCategoriesLocked(start []int64, maxDepth int) []treeCategory {
+	children := make(map[int64][]int64)
+	for id, c := range s.categories {
+		if c.ParentCategoryID != nil {
+			children[*c.ParentCategoryID] = append(children[*c.ParentCategoryID], id)
+		}
+	}
+
+	var rows []treeCategory
+	visited := make(map[int64]bool)
+	level := start
+	for depth := 1; len(level) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		var next []int64
+		for _, id := range level {
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			rows = append(rows, treeCategory{category: *cloneCategory(s.categories[id]), depth: depth, hasChildren: len(children[id]) > 0})
+			next = append(next, children[id]...)
+		}
+		level = next
+	}
+	sortTreeCategories(rows)
+	return rows
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Export part of the ProductStorer Implementation ---
+
+// ExportProducts takes the snapshot under the read lock and calls fn without holding it.
+func (s *MemoryStore) ExportProducts(ctx context.Context, params ListProductsParams, fn func([]domain.Product) error) error {
+	if err := validateAttributeFilters(params.Attributes); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	products := s.filterProductsLocked(params)
+	s.mu.RUnlock()
+
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+	for start := 0; start < len(products); start += ExportBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch := products[start:min(start+ExportBatchSize, len(products))]
+		for i := range batch {
+			batch[i].SearchRank, batch[i].SearchSnippet = nil, nil // Exports are not ranked
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"product-catalog-service/internal/domain"
+)
+
+func (s *MemoryStore) ListProductFacets(ctx context.Context, params ListProductsParams, req FacetRequest) (*domain.ProductFacets, error) {
+	if err := validateAttributeFilters(params.Attributes); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	matched := s.filterProductsLocked(params)
+	s.mu.RUnlock()
+
+	facets := &domain.ProductFacets{}
+	if req.Categories {
+		counts := make(map[int64]int)
+		uncategorized := 0
+		for i := range matched {
+			if id := matched[i].CategoryID; id != nil {
+				counts[*id]++
+			} else {
+				uncategorized++
+			}
+		}
+		facets.Categories = make([]domain.CategoryFacet, 0, len(counts)+1)
+		for id, count := range counts {
+			id := id
+			facets.Categories = append(facets.Categories, domain.CategoryFacet{CategoryID: &id, Count: count})
+		}
+		// Same order as Postgres: most products first, then by ID with the uncategorized last.
+		sort.Slice(facets.Categories, func(i, j int) bool {
+			a, b := facets.Categories[i], facets.Categories[j]
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			return *a.CategoryID < *b.CategoryID
+		})
+		if uncategorized > 0 {
+			facets.Categories = append(facets.Categories, domain.CategoryFacet{Count: uncategorized})
+		}
+	}
+	if len(req.PriceBoundaries) > 0 {
+		facets.PriceRanges = newPriceRangeFacets(req.PriceBoundaries)
+		for i := range matched {
+			price := matched[i].Price
+			if price.Currency != req.PriceBoundaries[0].Currency {
+				continue
+			}
+			// The number of boundaries at or below the price is its range, as with width_bucket.
+			bucket := sort.Search(len(req.PriceBoundaries), func(b int) bool { return req.PriceBoundaries[b].Amount > price.Amount })
+			facets.PriceRanges[bucket].Count++
+		}
+	}
+	if req.IsActive {
+		active := 0
+		for i := range matched {
+			if matched[i].IsActive {
+				active++
+			}
+		}
+		facets.IsActive = isActiveFacets(active, len(matched)-active)
+	}
+	if len(req.AttributeKeys) > 0 {
+		facets.Attributes = make(map[string][]domain.AttributeValueFacet, len(req.AttributeKeys))
+		for _, key := range req.AttributeKeys {
+			counts := make(map[string]int)
+			for i := range matched {
+				for _, value := range attributeFacetValues(matched[i].Attributes, key) {
+					counts[value]++
+				}
+			}
+			values := make([]domain.AttributeValueFacet, 0, len(counts))
+			for value, count := range counts {
+				values = append(values, domain.AttributeValueFacet{Value: value, Count: count})
+			}
+			sort.Slice(values, func(i, j int) bool {
+				if values[i].Count != values[j].Count {
+					return values[i].Count > values[j].Count
+				}
+				return values[i].Value < values[j].Value
+			})
+			if len(values) > MaxAttributeFacetValues {
+				values = values[:MaxAttributeFacetValues]
+			}
+			facets.Attributes[key] = values
+		}
+	}
+	return facets, nil
+}
+
+// attributeFacetValues returns the distinct scalar values of attribute key as text: the value itself,
+// or the scalar elements of an array. Numbers keep their JSON spelling.
+func attributeFacetValues(attributes *json.RawMessage, key string) []string {
+	fields := decodeAttributes(attributes)
+	items := []interface{}{fields[key]}
+	if array, ok := fields[key].([]interface{}); ok {
+		items = array
+	}
+	seen := make(map[string]bool)
+	var values []string
+	for _, item := range items {
+		var value string
+		switch t := item.(type) {
+		case string:
+			value = t
+		case json.Number:
+			value = t.String()
+		case bool:
+			value = strconv.FormatBool(t)
+		default: // null, objects and nested arrays are not facet values
+			continue
+		}
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// idempotencyKey is the primary key of an idempotency record.
+type idempotencyKey struct {
+	key, operation string
+}
+
+// --- IdempotencyStorer Implementation ---
+
+func (s *MemoryStore) ClaimIdempotencyKey(ctx context.Context, key, operation, requestHash string, expiresAt time.Time) (*domain.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	k := idempotencyKey{key, operation}
+	if existing, ok := s.idempotencyKeys[k]; ok && existing.ExpiresAt.After(now) {
+		return cloneIdempotencyRecord(existing), false, nil
+	}
+	record := &domain.IdempotencyRecord{
+		Key:         key,
+		Operation:   operation,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   expiresAt.UTC(),
+	}
+	s.idempotencyKeys[k] = record
+	return cloneIdempotencyRecord(record), true, nil
+}
+
+func (s *MemoryStore) CompleteIdempotencyKey(ctx context.Context, key, operation string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.idempotencyKeys[idempotencyKey{key, operation}]
+	if !ok || record.Completed() {
+		return ErrIdempotencyKeyNotFound
+	}
+	now := time.Now().UTC()
+	record.Response = append([]byte(nil), response...)
+	record.CompletedAt = &now
+	return nil
+}
+
+func (s *MemoryStore) ReleaseIdempotencyKey(ctx context.Context, key, operation string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := idempotencyKey{key, operation}
+	record, ok := s.idempotencyKeys[k]
+	if !ok || record.Completed() {
+		return ErrIdempotencyKeyNotFound
+	}
+	delete(s.idempotencyKeys, k)
+	return nil
+}
+
+func (s *MemoryStore) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for k, record := range s.idempotencyKeys {
+		if !record.ExpiresAt.After(now) {
+			delete(s.idempotencyKeys, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func cloneIdempotencyRecord(r *domain.IdempotencyRecord) *domain.IdempotencyRecord {
+	clone := *r
+	if r.Response != nil {
+		clone.Response = append([]byte(nil), r.Response...)
+	}
+	if r.CompletedAt != nil {
+		completedAt := *r.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+	return &clone
+}
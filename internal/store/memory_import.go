@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Import part of the ProductStorer Implementation ---
+
+func (s *MemoryStore) ImportProducts(ctx context.Context, products []*domain.Product, dryRun bool) ([]ProductImportResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make([]ProductImportResult, len(products))
+	for i, product := range products {
+		p := cloneProduct(product)
+		p.ID = 0
+		for _, stored := range s.products {
+			if stored.SKU == p.SKU {
+				p.ID = stored.ID
+				break
+			}
+		}
+		results[i].Created = p.ID == 0
+		switch {
+		case dryRun:
+			results[i].ProductID = p.ID
+			results[i].Err = s.checkImportLocked(p)
+		case p.ID == 0:
+			created, err := s.createProductLocked(ctx, p)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i].ProductID = created.ID
+		default:
+			results[i].ProductID = p.ID
+			_, results[i].Err = s.updateProductLocked(ctx, p)
+		}
+	}
+	return results, nil
+}
+
+// checkImportLocked runs the checks of createProductLocked or updateProductLocked for p without
+// writing it. Callers must hold s.mu.
+func (s *MemoryStore) checkImportLocked(p *domain.Product) error {
+	p.Variants, p.Media = nil, nil
+	if err := s.checkVariantLocked(p); err != nil {
+		return err
+	}
+	return s.checkProductConstraints(p, p.ID)
+}
@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- LocationStorer Implementation ---
+
+func (s *MemoryStore) CreateLocation(ctx context.Context, location *domain.Location) (*domain.Location, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.locationCodeTaken(location.Code, 0) {
+		return nil, ErrLocationCodeExists
+	}
+	now := time.Now().UTC()
+	created := *location
+	created.ID = s.nextLocationID
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	s.nextLocationID++
+	s.locations[created.ID] = &created
+
+	result := created
+	return &result, nil
+}
+
+func (s *MemoryStore) GetLocationByID(ctx context.Context, id int64) (*domain.Location, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	l, ok := s.locations[id]
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+	result := *l
+	return &result, nil
+}
+
+func (s *MemoryStore) ListLocations(ctx context.Context) ([]domain.Location, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sortedLocationsLocked(), nil
+}
+
+func (s *MemoryStore) UpdateLocation(ctx context.Context, location *domain.Location) (*domain.Location, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.locations[location.ID]
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+	if s.locationCodeTaken(location.Code, location.ID) {
+		return nil, ErrLocationCodeExists
+	}
+	updated := *location
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now().UTC()
+	s.locations[updated.ID] = &updated
+
+	result := updated
+	return &result, nil
+}
+
+func (s *MemoryStore) DeleteLocation(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.locations[id]; !ok {
+		return ErrLocationNotFound
+	}
+	// Mirrors PostgresStore: empty rows are removed, rows holding stock block the delete.
+	for key, ls := range s.locationStock {
+		if key.locationID == id && ls.Quantity > 0 {
+			return ErrLocationInUse
+		}
+	}
+	for key := range s.locationStock {
+		if key.locationID == id {
+			delete(s.locationStock, key)
+		}
+	}
+	delete(s.locations, id)
+	return nil
+}
+
+func (s *MemoryStore) ListLocationStock(ctx context.Context, productIDs []int64) ([]domain.LocationStock, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.locationStockLocked(productIDs), nil
+}
+
+func (s *MemoryStore) TransferStock(ctx context.Context, productID, fromLocationID, toLocationID int64, quantity int32, info StockMovementInfo) ([]domain.LocationStock, error) {
+	if fromLocationID == toLocationID {
+		return nil, ErrSameLocation
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := s.changeStockLocked(ctx, transferUpdates(productID, fromLocationID, toLocationID, quantity), true, false, info, time.Now().UTC())
+	if err := firstStockError(results); err != nil {
+		return nil, err
+	}
+	return s.locationStockLocked([]int64{productID}), nil
+}
+
+// --- Helpers ---
+
+// changeStockLocked is the in-memory counterpart of changeStock. Callers must hold s.mu for writing.
+func (s *MemoryStore) changeStockLocked(ctx context.Context, updates []StockUpdate, atomic, keepReserved bool, info StockMovementInfo, now time.Time) []StockUpdateResult {
+	results := make([]StockUpdateResult, len(updates))
+	ids := make([]int64, 0, len(updates))
+	for _, u := range updates {
+		ids = append(ids, u.ProductID)
+	}
+	levels := s.stockLevelsLocked(ids)
+	if keepReserved {
+		levels.held = s.reservedLocked(ids, "", now)
+	}
+
+	changed, entries, failed := levels.applyUpdates(updates, results)
+	if failed && atomic {
+		abortStockResults(results)
+		return results
+	}
+
+	s.writeLocationStockLocked(levels.changedRows(), now)
+	for _, id := range changed {
+		s.products[id].StockQuantity = levels.totals[id]
+		s.products[id].UpdatedAt = now
+	}
+	s.appendMovementsLocked(ctx, info, entries, now)
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Product = cloneProduct(s.products[results[i].ProductID])
+		}
+	}
+	return results
+}
+
+// recordStockEditLocked is the in-memory counterpart of recordStockEdit. Callers must hold s.mu for writing.
+func (s *MemoryStore) recordStockEditLocked(ctx context.Context, levels *stockLevels, productID int64, delta int32, reason domain.StockMovementReason, now time.Time) error {
+	results := make([]StockUpdateResult, 1)
+	_, entries, failed := levels.applyUpdates([]StockUpdate{{ProductID: productID, QuantityChange: delta}}, results)
+	if failed {
+		return results[0].Err
+	}
+	s.writeLocationStockLocked(levels.changedRows(), now)
+	s.appendMovementsLocked(ctx, StockMovementInfo{Reason: reason}, entries, now)
+	return nil
+}
+
+// stockLevelsLocked returns a working copy of the stock of the given products. Callers must hold s.mu.
+func (s *MemoryStore) stockLevelsLocked(productIDs []int64) *stockLevels {
+	totals := make(map[int64]int32, len(productIDs))
+	for _, id := range productIDs {
+		if p, ok := s.products[id]; ok {
+			totals[id] = p.StockQuantity
+		}
+	}
+	return newStockLevels(s.sortedLocationsLocked(), totals, s.locationStockLocked(productIDs))
+}
+
+// writeLocationStockLocked upserts location rows. Callers must hold s.mu for writing.
+func (s *MemoryStore) writeLocationStockLocked(rows []domain.LocationStock, now time.Time) {
+	for _, r := range rows {
+		row := r
+		row.UpdatedAt = now
+		s.locationStock[stockKey{r.ProductID, r.LocationID}] = &row
+	}
+}
+
+// sortedLocationsLocked returns copies of all locations in allocation order. Callers must hold s.mu.
+func (s *MemoryStore) sortedLocationsLocked() []domain.Location {
+	locations := make([]domain.Location, 0, len(s.locations))
+	for _, l := range s.locations {
+		locations = append(locations, *l)
+	}
+	sortLocations(locations)
+	return locations
+}
+
+// locationStockLocked returns the location rows of the given products, by product and allocation
+// order. Callers must hold s.mu.
+func (s *MemoryStore) locationStockLocked(productIDs []int64) []domain.LocationStock {
+	wanted := make(map[int64]bool, len(productIDs))
+	for _, id := range productIDs {
+		wanted[id] = true
+	}
+	position := make(map[int64]int, len(s.locations))
+	for i, l := range s.sortedLocationsLocked() {
+		position[l.ID] = i
+	}
+	rows := make([]domain.LocationStock, 0)
+	for key, ls := range s.locationStock {
+		if wanted[key.productID] {
+			rows = append(rows, *ls)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].ProductID != rows[j].ProductID {
+			return rows[i].ProductID < rows[j].ProductID
+		}
+		return position[rows[i].LocationID] < position[rows[j].LocationID]
+	})
+	return rows
+}
+
+// locationCodeTaken reports whether another location (other than excludeID) already uses code.
+// Callers must hold s.mu.
+func (s *MemoryStore) locationCodeTaken(code string, excludeID int64) bool {
+	for _, l := range s.locations {
+		if l.ID != excludeID && l.Code == code {
+			return true
+		}
+	}
+	return false
+}
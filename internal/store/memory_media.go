@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Media part of the ProductStorer Implementation ---
+
+func (s *MemoryStore) ListProductMedia(ctx context.Context, productID int64) ([]domain.ProductMedia, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.products[productID]; !ok {
+		return nil, ErrProductNotFound
+	}
+	return s.sortedMediaLocked(productID), nil
+}
+
+func (s *MemoryStore) ListMedia(ctx context.Context, productIDs []int64) (map[int64][]domain.ProductMedia, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	media := make(map[int64][]domain.ProductMedia)
+	for _, id := range productIDs {
+		if list := s.sortedMediaLocked(id); len(list) > 0 {
+			media[id] = list
+		}
+	}
+	return media, nil
+}
+
+func (s *MemoryStore) AddProductMedia(ctx context.Context, m *domain.ProductMedia) (*domain.ProductMedia, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.products[m.ProductID]; !ok {
+		return nil, ErrProductNotFound
+	}
+	if err := validateMedia(m); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	created := cloneMedia(m)
+	created.ID = s.nextMediaID
+	if created.Position == 0 {
+		created.Position = s.lastMediaPositionLocked(m.ProductID) + 1
+	}
+	created.CreatedAt, created.UpdatedAt = now, now
+	s.nextMediaID++
+	s.demotePrimaryLocked(created, now)
+	s.media[m.ProductID] = append(s.media[m.ProductID], created)
+	s.syncPrimaryImageLocked(m.ProductID, now)
+	return cloneMedia(created), nil
+}
+
+func (s *MemoryStore) UpdateProductMedia(ctx context.Context, m *domain.ProductMedia) (*domain.ProductMedia, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.products[m.ProductID]; !ok {
+		return nil, ErrProductNotFound
+	}
+	if err := validateMedia(m); err != nil {
+		return nil, err
+	}
+	for i, existing := range s.media[m.ProductID] {
+		if existing.ID != m.ID {
+			continue
+		}
+		now := time.Now().UTC()
+		updated := cloneMedia(m)
+		if updated.Position == 0 {
+			updated.Position = existing.Position
+		}
+		updated.CreatedAt, updated.UpdatedAt = existing.CreatedAt, now
+		s.demotePrimaryLocked(updated, now)
+		s.media[m.ProductID][i] = updated
+		s.syncPrimaryImageLocked(m.ProductID, now)
+		return cloneMedia(updated), nil
+	}
+	return nil, ErrMediaNotFound
+}
+
+func (s *MemoryStore) DeleteProductMedia(ctx context.Context, productID, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.products[productID]; !ok {
+		return ErrProductNotFound
+	}
+	media := s.media[productID]
+	for i, m := range media {
+		if m.ID == id {
+			s.media[productID] = append(media[:i:i], media[i+1:]...)
+			s.syncPrimaryImageLocked(productID, time.Now().UTC())
+			return nil
+		}
+	}
+	return ErrMediaNotFound
+}
+
+// sortedMediaLocked returns copies of a product's media in display order. Callers must hold s.mu.
+func (s *MemoryStore) sortedMediaLocked(productID int64) []domain.ProductMedia {
+	media := make([]domain.ProductMedia, 0, len(s.media[productID]))
+	for _, m := range s.media[productID] {
+		media = append(media, *cloneMedia(m))
+	}
+	sort.Slice(media, func(i, j int) bool { return mediaBefore(&media[i], &media[j]) })
+	return media
+}
+
+func (s *MemoryStore) lastMediaPositionLocked(productID int64) int32 {
+	var last int32
+	for _, m := range s.media[productID] {
+		last = max(last, m.Position)
+	}
+	return last
+}
+
+// demotePrimaryLocked moves the product's primary image other than m to the gallery if m is the
+// primary image, mirroring the unique index on primary images. Callers must hold s.mu for writing.
+func (s *MemoryStore) demotePrimaryLocked(m *domain.ProductMedia, now time.Time) {
+	if m.Role != domain.MediaRolePrimary {
+		return
+	}
+	for _, other := range s.media[m.ProductID] {
+		if other.ID != m.ID && other.Role == domain.MediaRolePrimary {
+			other.Role = domain.MediaRoleGallery
+			other.UpdatedAt = now
+		}
+	}
+}
+
+// syncPrimaryImageLocked sets the product's image URL to the one derived from its media. Callers
+// must hold s.mu for writing.
+func (s *MemoryStore) syncPrimaryImageLocked(productID int64, now time.Time) {
+	p := s.products[productID]
+	url := primaryImageURL(s.media[productID])
+	if (url == nil) == (p.ImageURL == nil) && (url == nil || *url == *p.ImageURL) {
+		return
+	}
+	p.ImageURL = url
+	p.UpdatedAt = now
+}
+
+func cloneMedia(m *domain.ProductMedia) *domain.ProductMedia {
+	clone := *m
+	clone.Width = cloneInt32(m.Width)
+	clone.Height = cloneInt32(m.Height)
+	return &clone
+}
+
+func cloneInt32(v *int32) *int32 {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Price history part of the ProductStorer Implementation ---
+
+func (s *MemoryStore) ListPriceChanges(ctx context.Context, params ListPriceChangesParams) ([]domain.PriceChange, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matched := make([]domain.PriceChange, 0)
+	for _, c := range s.priceChanges {
+		if c.ProductID != params.ProductID {
+			continue
+		}
+		if params.From != nil && c.EffectiveAt.Before(*params.From) {
+			continue
+		}
+		if params.To != nil && !c.EffectiveAt.Before(*params.To) {
+			continue
+		}
+		matched = append(matched, clonePriceChange(c))
+	}
+	// Latest effective first, the later recorded first on a tie.
+	sort.SliceStable(matched, func(i, j int) bool {
+		if !matched[i].EffectiveAt.Equal(matched[j].EffectiveAt) {
+			return matched[i].EffectiveAt.After(matched[j].EffectiveAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	return paginate(matched, params.Limit, params.Offset), len(matched), nil
+}
+
+func (s *MemoryStore) GetPricesAt(ctx context.Context, productIDs []int64, at time.Time) (map[int64]domain.Money, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[int64]bool, len(productIDs))
+	for _, id := range productIDs {
+		_, live := s.products[id]
+		if _, deleted := s.deletedProducts[id]; live || deleted { // Mirrors the cascade of scheduled prices: history alone is not enough
+			wanted[id] = true
+		}
+	}
+	// The base price is the latest base change effective at the time; changes are in ID order.
+	base := make(map[int64]*domain.PriceChange)
+	for i := range s.priceChanges {
+		c := &s.priceChanges[i]
+		if !wanted[c.ProductID] || !isBasePriceChange(c.Reason) || c.EffectiveAt.After(at) {
+			continue
+		}
+		if latest := base[c.ProductID]; latest == nil || !c.EffectiveAt.Before(latest.EffectiveAt) {
+			base[c.ProductID] = c
+		}
+	}
+	prices := make(map[int64]domain.Money, len(base))
+	for id, c := range base {
+		prices[id] = c.NewPrice
+		if sp := activeScheduledPrice(s.scheduledPrices[id], c.NewPrice.Currency, at); sp != nil {
+			prices[id] = sp.Price
+		}
+	}
+	return prices, nil
+}
+
+// appendPriceChangeLocked writes c to the price history, made by the actor of ctx at now. Callers
+// must hold s.mu for writing.
+func (s *MemoryStore) appendPriceChangeLocked(ctx context.Context, c *domain.PriceChange, now time.Time) {
+	entry := clonePriceChange(*c)
+	entry.ID = s.nextPriceChangeID
+	entry.Actor = ActorFromContext(ctx)
+	entry.CreatedAt = now
+	s.priceChanges = append(s.priceChanges, entry)
+	s.nextPriceChangeID++
+}
+
+func clonePriceChange(c domain.PriceChange) domain.PriceChange {
+	if c.OldPrice != nil {
+		v := *c.OldPrice
+		c.OldPrice = &v
+	}
+	if c.EffectiveUntil != nil {
+		v := *c.EffectiveUntil
+		c.EffectiveUntil = &v
+	}
+	if c.ScheduledPriceID != nil {
+		v := *c.ScheduledPriceID
+		c.ScheduledPriceID = &v
+	}
+	if c.Actor != nil {
+		v := *c.Actor
+		c.Actor = &v
+	}
+	return c
+}
@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- PriceListStorer Implementation ---
+
+func (s *MemoryStore) CreatePriceList(ctx context.Context, list *domain.PriceList) (*domain.PriceList, error) {
+	if err := validatePriceList(list); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkPriceListUniqueLocked(list, 0); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	created := clonePriceList(list)
+	created.ID = s.nextPriceListID
+	created.CreatedAt, created.UpdatedAt = now, now
+	s.nextPriceListID++
+	s.priceLists[created.ID] = created
+	return clonePriceList(created), nil
+}
+
+func (s *MemoryStore) GetPriceListByID(ctx context.Context, id int64) (*domain.PriceList, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list, ok := s.priceLists[id]
+	if !ok {
+		return nil, ErrPriceListNotFound
+	}
+	return clonePriceList(list), nil
+}
+
+func (s *MemoryStore) ListPriceLists(ctx context.Context) ([]domain.PriceList, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lists := make([]domain.PriceList, 0, len(s.priceLists))
+	for _, list := range s.priceLists {
+		lists = append(lists, *clonePriceList(list))
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Name < lists[j].Name })
+	return lists, nil
+}
+
+func (s *MemoryStore) UpdatePriceList(ctx context.Context, list *domain.PriceList) (*domain.PriceList, error) {
+	if err := validatePriceList(list); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.priceLists[list.ID]
+	if !ok {
+		return nil, ErrPriceListNotFound
+	}
+	if list.Currency != existing.Currency && len(s.priceListEntries[list.ID]) > 0 {
+		return nil, fmt.Errorf("%w: the currency of a list with entries cannot change", ErrInvalidPriceList)
+	}
+	if err := s.checkPriceListUniqueLocked(list, list.ID); err != nil {
+		return nil, err
+	}
+	updated := clonePriceList(list)
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now().UTC()
+	s.priceLists[updated.ID] = updated
+	return clonePriceList(updated), nil
+}
+
+func (s *MemoryStore) DeletePriceList(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.priceLists[id]; !ok {
+		return ErrPriceListNotFound
+	}
+	delete(s.priceLists, id)
+	delete(s.priceListEntries, id) // Mirrors ON DELETE CASCADE on price_list_entries.price_list_id
+	return nil
+}
+
+func (s *MemoryStore) ListPriceListEntries(ctx context.Context, params ListPriceListEntriesParams) ([]domain.PriceListEntry, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.priceLists[params.PriceListID]; !ok {
+		return nil, 0, ErrPriceListNotFound
+	}
+	entries := make([]domain.PriceListEntry, 0, len(s.priceListEntries[params.PriceListID]))
+	for _, e := range s.priceListEntries[params.PriceListID] {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ProductID < entries[j].ProductID })
+
+	total := len(entries)
+	start := min(params.Offset, total)
+	end := total
+	if params.Limit > 0 {
+		end = min(start+params.Limit, total)
+	}
+	return entries[start:end], total, nil
+}
+
+func (s *MemoryStore) SetPriceListEntries(ctx context.Context, priceListID int64, entries []domain.PriceListEntry) ([]domain.PriceListEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, ok := s.priceLists[priceListID]
+	if !ok {
+		return nil, ErrPriceListNotFound
+	}
+	if err := validatePriceListEntries(list, entries); err != nil {
+		return nil, err
+	}
+	for _, e := range entries { // Nothing is written unless every product exists
+		if _, ok := s.products[e.ProductID]; !ok {
+			return nil, fmt.Errorf("%w (product ID %d)", ErrProductNotFound, e.ProductID)
+		}
+	}
+
+	own := s.priceListEntries[priceListID]
+	if own == nil {
+		own = make(map[int64]*domain.PriceListEntry)
+		s.priceListEntries[priceListID] = own
+	}
+	now := time.Now().UTC()
+	stored := make([]domain.PriceListEntry, len(entries))
+	for i, e := range entries {
+		stored[i] = domain.PriceListEntry{PriceListID: priceListID, ProductID: e.ProductID, Price: e.Price, UpdatedAt: now}
+		entry := stored[i]
+		own[e.ProductID] = &entry
+	}
+	return stored, nil
+}
+
+func (s *MemoryStore) DeletePriceListEntries(ctx context.Context, priceListID int64, productIDs []int64) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.priceLists[priceListID]; !ok {
+		return 0, ErrPriceListNotFound
+	}
+	deleted := 0
+	for _, id := range productIDs {
+		if _, ok := s.priceListEntries[priceListID][id]; ok {
+			delete(s.priceListEntries[priceListID], id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *MemoryStore) ResolvePrices(ctx context.Context, sel PriceSelection, products []domain.Product) error {
+	if sel.IsZero() || len(products) == 0 {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var selected *domain.PriceList
+	if sel.PriceListID != nil {
+		list, ok := s.priceLists[*sel.PriceListID]
+		if !ok {
+			return ErrPriceListNotFound
+		}
+		selected = list
+	}
+	currency, err := selectionCurrency(sel, selected)
+	if err != nil {
+		return err
+	}
+	var fallback *domain.PriceList
+	for _, list := range s.priceLists {
+		if list.IsDefault && list.Currency == currency {
+			fallback = list
+		}
+	}
+
+	prices := make(map[int64]domain.PriceListEntry)
+	for _, list := range []*domain.PriceList{fallback, selected} { // The selected list wins
+		if list == nil {
+			continue
+		}
+		for _, p := range products {
+			if entry, ok := s.priceListEntries[list.ID][p.ID]; ok {
+				prices[p.ID] = *entry
+			}
+		}
+	}
+	applyListPrices(products, prices)
+	return nil
+}
+
+// checkPriceListUniqueLocked enforces price_lists_name_key and price_lists_default_currency_idx
+// for list, ignoring the list with excludeID. The caller must hold s.mu.
+func (s *MemoryStore) checkPriceListUniqueLocked(list *domain.PriceList, excludeID int64) error {
+	for id, other := range s.priceLists {
+		if id == excludeID {
+			continue
+		}
+		if other.Name == list.Name {
+			return ErrPriceListNameExists
+		}
+		if list.IsDefault && other.IsDefault && other.Currency == list.Currency {
+			return ErrDefaultPriceListExists
+		}
+	}
+	return nil
+}
+
+func clonePriceList(l *domain.PriceList) *domain.PriceList {
+	clone := *l
+	if l.Market != nil {
+		v := *l.Market
+		clone.Market = &v
+	}
+	if l.CustomerGroup != nil {
+		v := *l.CustomerGroup
+		clone.CustomerGroup = &v
+	}
+	return &clone
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- ReservationStorer Implementation ---
+
+func (s *MemoryStore) ReserveStock(ctx context.Context, referenceID string, items []ReservationItem, expiresAt time.Time) ([]domain.StockReservation, error) {
+	items = mergeReservationItems(items)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	s.expireLocked(now, referenceID)
+	for _, r := range s.reservations {
+		if r.ReferenceID == referenceID && r.Status == domain.ReservationActive {
+			return nil, ErrReservationExists
+		}
+	}
+
+	stock := make(map[int64]int32, len(items))
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		if p, ok := s.products[item.ProductID]; ok {
+			stock[item.ProductID] = p.StockQuantity
+		}
+		ids = append(ids, item.ProductID)
+	}
+	if err := checkReservationItems(items, stock, s.reservedLocked(ids, "", now)); err != nil {
+		return nil, err
+	}
+
+	reservations := make([]domain.StockReservation, 0, len(items))
+	for _, item := range items {
+		r := &domain.StockReservation{
+			ID:          s.nextReservationID,
+			ReferenceID: referenceID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			Status:      domain.ReservationActive,
+			ExpiresAt:   expiresAt.UTC(),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		s.nextReservationID++
+		s.reservations = append(s.reservations, r)
+		reservations = append(reservations, *r)
+	}
+	return reservations, nil
+}
+
+func (s *MemoryStore) CommitReservation(ctx context.Context, referenceID string) ([]domain.StockReservation, []domain.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	held := s.activeLocked(referenceID)
+	if len(held) == 0 {
+		return nil, nil, ErrReservationNotFound
+	}
+	for _, r := range held {
+		if !r.ExpiresAt.After(now) {
+			// Expire the whole hold now rather than waiting for the sweeper.
+			s.setStatusLocked(held, domain.ReservationExpired, now)
+			return nil, nil, ErrReservationExpired
+		}
+	}
+	updates := make([]StockUpdate, 0, len(held))
+	for _, r := range held {
+		updates = append(updates, StockUpdate{ProductID: r.ProductID, QuantityChange: -r.Quantity})
+	}
+	info := StockMovementInfo{Reason: domain.StockReasonReservationCommit, OrderID: &referenceID}
+	results := s.changeStockLocked(ctx, updates, true, false, info, now)
+	if err := firstStockError(results); err != nil {
+		// Stock was decremented outside the reservation since it was taken.
+		return nil, nil, err
+	}
+	products := make([]domain.Product, 0, len(results))
+	for _, res := range results {
+		products = append(products, *res.Product)
+	}
+	return s.setStatusLocked(held, domain.ReservationCommitted, now), products, nil
+}
+
+func (s *MemoryStore) ReleaseReservation(ctx context.Context, referenceID string) ([]domain.StockReservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	held := s.activeLocked(referenceID)
+	if len(held) == 0 {
+		return nil, ErrReservationNotFound
+	}
+	return s.setStatusLocked(held, domain.ReservationReleased, time.Now().UTC()), nil
+}
+
+func (s *MemoryStore) ExpireReservations(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.expireLocked(now, ""), nil
+}
+
+func (s *MemoryStore) GetReservedQuantities(ctx context.Context, productIDs []int64, excludeReferenceID string) (map[int64]int32, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.reservedLocked(productIDs, excludeReferenceID, time.Now().UTC()), nil
+}
+
+// activeLocked returns the active reservations of referenceID ordered by product ID.
+// Callers must hold s.mu.
+func (s *MemoryStore) activeLocked(referenceID string) []*domain.StockReservation {
+	var held []*domain.StockReservation
+	for _, r := range s.reservations {
+		if r.ReferenceID == referenceID && r.Status == domain.ReservationActive {
+			held = append(held, r)
+		}
+	}
+	sort.Slice(held, func(i, j int) bool { return held[i].ProductID < held[j].ProductID })
+	return held
+}
+
+// setStatusLocked moves held to status and returns copies of them. Callers must hold s.mu.
+func (s *MemoryStore) setStatusLocked(held []*domain.StockReservation, status domain.ReservationStatus, now time.Time) []domain.StockReservation {
+	out := make([]domain.StockReservation, 0, len(held))
+	for _, r := range held {
+		r.Status = status
+		r.UpdatedAt = now
+		out = append(out, *r)
+	}
+	return out
+}
+
+// expireLocked expires the active reservations whose expiry has passed, restricted to referenceID
+// when it is non-empty, and returns how many were expired. Callers must hold s.mu for writing.
+func (s *MemoryStore) expireLocked(now time.Time, referenceID string) int {
+	expired := 0
+	for _, r := range s.reservations {
+		if r.Status != domain.ReservationActive || r.ExpiresAt.After(now) {
+			continue
+		}
+		if referenceID != "" && r.ReferenceID != referenceID {
+			continue
+		}
+		r.Status = domain.ReservationExpired
+		r.UpdatedAt = now
+		expired++
+	}
+	return expired
+}
+
+// reservedLocked sums the active, unexpired holds per product. Callers must hold s.mu.
+func (s *MemoryStore) reservedLocked(productIDs []int64, excludeReferenceID string, now time.Time) map[int64]int32 {
+	wanted := make(map[int64]bool, len(productIDs))
+	for _, id := range productIDs {
+		wanted[id] = true
+	}
+	reserved := make(map[int64]int32, len(productIDs))
+	for _, r := range s.reservations {
+		if r.Status != domain.ReservationActive || !r.ExpiresAt.After(now) || !wanted[r.ProductID] {
+			continue
+		}
+		if excludeReferenceID != "" && r.ReferenceID == excludeReferenceID {
+			continue
+		}
+		reserved[r.ProductID] += r.Quantity
+	}
+	return reserved
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Scheduled price part of the ProductStorer Implementation ---
+
+func (s *MemoryStore) CreateScheduledPrice(ctx context.Context, sp *domain.ScheduledPrice) (*domain.ScheduledPrice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	product, ok := s.products[sp.ProductID]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	if err := validateScheduledPrice(sp, product.Price.Currency); err != nil {
+		return nil, err
+	}
+	created := cloneScheduledPrice(sp)
+	created.ID = s.nextScheduledID
+	created.ValidFrom = created.ValidFrom.UTC()
+	if created.ValidUntil != nil {
+		v := created.ValidUntil.UTC()
+		created.ValidUntil = &v
+	}
+	created.CreatedAt = time.Now().UTC()
+	s.nextScheduledID++
+	s.scheduledPrices[sp.ProductID] = append(s.scheduledPrices[sp.ProductID], created)
+	s.appendPriceChangeLocked(ctx, scheduledPriceChange(created, product.Price), created.CreatedAt)
+	return cloneScheduledPrice(created), nil
+}
+
+func (s *MemoryStore) ListScheduledPrices(ctx context.Context, productID int64) ([]domain.ScheduledPrice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.products[productID]; !ok {
+		return nil, ErrProductNotFound
+	}
+	prices := make([]domain.ScheduledPrice, 0, len(s.scheduledPrices[productID]))
+	for _, sp := range s.scheduledPrices[productID] {
+		prices = append(prices, *cloneScheduledPrice(sp))
+	}
+	sort.SliceStable(prices, func(i, j int) bool { return prices[i].ValidFrom.Before(prices[j].ValidFrom) })
+	return prices, nil
+}
+
+func (s *MemoryStore) DeleteScheduledPrice(ctx context.Context, productID, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	product, ok := s.products[productID]
+	if !ok {
+		return ErrProductNotFound
+	}
+	prices := s.scheduledPrices[productID]
+	for i, sp := range prices {
+		if sp.ID != id {
+			continue
+		}
+		now := time.Now().UTC()
+		switch {
+		case sp.ValidUntil != nil && !sp.ValidUntil.After(now):
+			return ErrScheduledPriceEnded
+		case sp.ValidFrom.Before(now): // Started: end it now
+			sp.ValidUntil = &now
+		default:
+			s.scheduledPrices[productID] = append(prices[:i:i], prices[i+1:]...)
+		}
+		scheduled, scheduledID := sp.Price, sp.ID
+		s.appendPriceChangeLocked(ctx, &domain.PriceChange{
+			ProductID: productID, OldPrice: &scheduled, NewPrice: product.Price, EffectiveAt: now,
+			Reason: domain.PriceReasonScheduledPriceCancelled, ScheduledPriceID: &scheduledID,
+		}, now)
+		return nil
+	}
+	return ErrScheduledPriceNotFound
+}
+
+// effectiveProductLocked returns a copy of p at its effective price at t. The caller must hold s.mu.
+func (s *MemoryStore) effectiveProductLocked(p *domain.Product, t time.Time) *domain.Product {
+	product := cloneProduct(p)
+	if sp := activeScheduledPrice(s.scheduledPrices[p.ID], p.Price.Currency, t); sp != nil {
+		applyScheduledPrice(product, sp)
+	}
+	return product
+}
+
+func cloneScheduledPrice(sp *domain.ScheduledPrice) *domain.ScheduledPrice {
+	clone := *sp
+	if sp.ValidUntil != nil {
+		v := *sp.ValidUntil
+		clone.ValidUntil = &v
+	}
+	return &clone
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+)
+
+// Weights of the searchable fields, as in Postgres' default ts_rank weights for A, B, C and D.
+const (
+	searchWeightName        = 1.0
+	searchWeightSKU         = 0.4
+	searchWeightDescription = 0.2
+	searchWeightAttributes  = 0.1
+)
+
+// snippetMaxWords bounds the length of a search snippet, like ts_headline's MaxWords.
+const snippetMaxWords = 35
+
+// memorySearch approximates websearch_to_tsquery for the memory backend. Every term of one of the
+// "or"-separated groups must occur in the name, SKU, description or attribute values; "quoted
+// phrases" must occur verbatim and -terms must not occur. Matching is case-insensitive on substrings,
+// without stemming.
+type memorySearch struct {
+	groups [][]searchTerm
+}
+
+type searchTerm struct {
+	text    string
+	negated bool
+}
+
+func parseMemorySearch(query string) memorySearch {
+	var groups [][]searchTerm
+	var group []searchTerm
+	rest := strings.ToLower(query)
+	for {
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			break
+		}
+		negated := strings.HasPrefix(rest, "-")
+		if negated {
+			rest = rest[1:]
+		}
+		var text string
+		if strings.HasPrefix(rest, `"`) {
+			phrase, after, _ := strings.Cut(rest[1:], `"`)
+			text, rest = strings.TrimSpace(phrase), after
+		} else {
+			end := strings.IndexAny(rest, " \t\n")
+			if end < 0 {
+				end = len(rest)
+			}
+			text, rest = rest[:end], rest[end:]
+		}
+		if text == "or" && !negated {
+			if len(group) > 0 {
+				groups = append(groups, group)
+				group = nil
+			}
+			continue
+		}
+		if text != "" {
+			group = append(group, searchTerm{text: text, negated: negated})
+		}
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return memorySearch{groups: groups}
+}
+
+// rank reports whether p matches and, if so, a score that is higher for matches in heavier fields.
+func (m memorySearch) rank(p *domain.Product) (float32, bool) {
+	fields := []struct {
+		text   string
+		weight float32
+	}{
+		{strings.ToLower(p.Name), searchWeightName},
+		{strings.ToLower(p.SKU), searchWeightSKU},
+		{strings.ToLower(derefString(p.Description)), searchWeightDescription},
+		{strings.ToLower(attributeText(p.Attributes)), searchWeightAttributes},
+	}
+	var best float32
+	matched := false
+	for _, group := range m.groups {
+		var score float32
+		ok := true
+		for _, term := range group {
+			var termScore float32
+			for _, f := range fields {
+				if strings.Contains(f.text, term.text) {
+					termScore += f.weight
+				}
+			}
+			if term.negated == (termScore > 0) {
+				ok = false
+				break
+			}
+			score += termScore
+		}
+		if ok {
+			matched = true
+			best = max(best, score)
+		}
+	}
+	return best, matched
+}
+
+// snippet returns an excerpt of p's description (or name, if it has none) with the words that
+// contain a search term wrapped in <mark> tags.
+func (m memorySearch) snippet(p *domain.Product) string {
+	source := derefString(p.Description)
+	if strings.TrimSpace(source) == "" {
+		source = p.Name
+	}
+	words := strings.Fields(source)
+	first := -1
+	for i, w := range words {
+		if m.highlights(w) {
+			words[i] = "<mark>" + w + "</mark>"
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+	start := 0
+	if first > snippetMaxWords/2 {
+		start = first - snippetMaxWords/2
+	}
+	end := min(len(words), start+snippetMaxWords)
+	return strings.Join(words[start:end], " ")
+}
+
+func (m memorySearch) highlights(word string) bool {
+	lower := strings.ToLower(word)
+	for _, group := range m.groups {
+		for _, term := range group {
+			if term.negated {
+				continue
+			}
+			for _, part := range strings.Fields(term.text) { // Each word of a phrase
+				if strings.Contains(lower, part) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// attributeText joins the string and number values of a product's attributes, like
+// jsonb_to_tsvector(..., '["string", "numeric"]').
+func attributeText(attributes *json.RawMessage) string {
+	if attributes == nil {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(*attributes, &v); err != nil {
+		return ""
+	}
+	var values []string
+	var walk func(interface{})
+	walk = func(v interface{}) {
+		switch t := v.(type) {
+		case string:
+			values = append(values, t)
+		case float64:
+			values = append(values, strconv.FormatFloat(t, 'f', -1, 64))
+		case map[string]interface{}:
+			for _, item := range t {
+				walk(item)
+			}
+		case []interface{}:
+			for _, item := range t {
+				walk(item)
+			}
+		}
+	}
+	walk(v)
+	return strings.Join(values, " ")
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,1258 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedMemoryProducts creates a category and a few products used by the listing tests.
+func seedMemoryProducts(t *testing.T, s *MemoryStore) (catID int64) {
+	t.Helper()
+	ctx := context.Background()
+
+	cat, err := s.CreateCategory(ctx, &domain.Category{Name: "Phones"})
+	require.NoError(t, err)
+
+	products := []domain.Product{
+		{Name: "Alpha Phone", SKU: "A-1", Price: usd(30000), StockQuantity: 5, CategoryID: &cat.ID, IsActive: true, Description: PtrTo("A great phone")},
+		{Name: "Beta Tablet", SKU: "B-1", Price: usd(50000), StockQuantity: 0, IsActive: true},
+		{Name: "Gamma Phone", SKU: "G-1", Price: usd(10000), StockQuantity: 2, CategoryID: &cat.ID, IsActive: false},
+		{Name: "Delta Case", SKU: "D-1", Price: usd(2000), StockQuantity: 50, IsActive: true, Description: PtrTo("Fits every phone")},
+	}
+	for i := range products {
+		_, err := s.CreateProduct(ctx, &products[i])
+		require.NoError(t, err)
+	}
+	return cat.ID
+}
+
+func TestMemoryStore_CreateProduct_DuplicateSKU(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	_, err := s.CreateProduct(ctx, &domain.Product{Name: "One", SKU: "SKU-1", Price: usd(100)})
+	require.NoError(t, err)
+
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "Two", SKU: "SKU-1", Price: usd(200)})
+	assert.True(t, errors.Is(err, ErrProductSKUExists))
+}
+
+func TestMemoryStore_CreateProduct_UnknownCategory(t *testing.T) {
+	s := NewMemoryStore()
+
+	_, err := s.CreateProduct(context.Background(), &domain.Product{Name: "One", SKU: "SKU-1", CategoryID: PtrTo(int64(42))})
+
+	assert.True(t, errors.Is(err, ErrCategoryNotFound))
+}
+
+func TestMemoryStore_CreateProduct_NegativePriceOrStock(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	_, err := s.CreateProduct(ctx, &domain.Product{Name: "One", SKU: "SKU-1", Price: usd(-100)})
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+	assert.NotErrorIs(t, err, ErrInsufficientStock, "a negative price is not a stock problem")
+
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "One", SKU: "SKU-1", Price: usd(100), StockQuantity: -1})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	// The price column is NUMERIC(15,3), so a trillion does not fit, in any currency.
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "One", SKU: "SKU-1", Price: usd(100_000_000_000_000)})
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "One", SKU: "SKU-1", Price: domain.Money{Amount: 1 << 62, Currency: "JPY"}})
+	assert.ErrorIs(t, err, ErrInvalidPrice, "an amount that overflows when scaled")
+}
+
+func TestMemoryStore_ListProducts_Filters(t *testing.T) {
+	s := NewMemoryStore()
+	catID := seedMemoryProducts(t, s)
+	ctx := context.Background()
+
+	products, total, err := s.ListProducts(ctx, ListProductsParams{SearchQuery: PtrTo("PHONE"), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total, "search matches name or description, case-insensitively")
+	assert.Len(t, products, 3)
+
+	products, total, err = s.ListProducts(ctx, ListProductsParams{CategoryID: &catID, IsActive: PtrTo(true), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "A-1", products[0].SKU)
+
+	products, total, err = s.ListProducts(ctx, ListProductsParams{MinPrice: PtrTo(usd(10000)), MaxPrice: PtrTo(usd(30000)), SortBy: "price", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []string{"G-1", "A-1"}, []string{products[0].SKU, products[1].SKU})
+}
+
+func TestMemoryStore_ListProducts_SortAndPagination(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s)
+	ctx := context.Background()
+
+	products, total, err := s.ListProducts(ctx, ListProductsParams{SortBy: "name", SortOrder: "desc", Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 4, total, "total ignores pagination")
+	require.Len(t, products, 2)
+	assert.Equal(t, "Delta Case", products[0].Name)
+	assert.Equal(t, "Beta Tablet", products[1].Name)
+
+	products, total, err = s.ListProducts(ctx, ListProductsParams{ProductIDs: []int64{1, 3}, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int64{1, 3}, []int64{products[0].ID, products[1].ID})
+
+	products, _, err = s.ListProducts(ctx, ListProductsParams{Limit: 10, Offset: 10})
+	require.NoError(t, err)
+	assert.Empty(t, products)
+}
+
+func TestMemoryStore_ListProducts_AfterCursor(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s)
+	ctx := context.Background()
+
+	params := ListProductsParams{SortBy: "price", SortOrder: "desc", Limit: 2}
+	page, total, err := s.ListProducts(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, []string{"B-1", "A-1"}, []string{page[0].SKU, page[1].SKU})
+
+	// A product inserted before the cursor position neither shifts nor repeats the next page.
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "Omega Laptop", SKU: "O-1", Price: usd(90000)})
+	require.NoError(t, err)
+
+	cursor := ProductCursor(params, &page[1])
+	params.After = &cursor
+	page, total, err = s.ListProducts(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total, "total ignores the cursor")
+	assert.Equal(t, []string{"G-1", "D-1"}, []string{page[0].SKU, page[1].SKU})
+
+	_, _, err = s.ListProducts(ctx, ListProductsParams{SortBy: "name", Limit: 2, After: &cursor})
+	assert.True(t, errors.Is(err, ErrInvalidCursor), "cursor was issued for another sort order")
+}
+
+func TestMemoryStore_ListProducts_Search(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s)
+	ctx := context.Background()
+	attrs := json.RawMessage(`{"color": "graphite", "ports": ["usb-c"]}`)
+	_, err := s.CreateProduct(ctx, &domain.Product{Name: "Charger", SKU: "PHONE-CHG", Price: usd(1500), IsActive: true, Attributes: &attrs})
+	require.NoError(t, err)
+
+	// Matches in the name rank above the SKU, which ranks above the description; matches add up.
+	products, total, err := s.ListProducts(ctx, ListProductsParams{SearchQuery: PtrTo("phone"), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []string{"A-1", "G-1", "PHONE-CHG", "D-1"}, []string{products[0].SKU, products[1].SKU, products[2].SKU, products[3].SKU})
+	assert.Equal(t, "A great <mark>phone</mark>", *products[0].SearchSnippet)
+	assert.Equal(t, "Fits every <mark>phone</mark>", *products[3].SearchSnippet)
+
+	products, _, err = s.ListProducts(ctx, ListProductsParams{SearchQuery: PtrTo(`phone -case "great phone" or graphite`), Limit: 10})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"A-1", "PHONE-CHG"}, []string{products[0].SKU, products[1].SKU}, "attribute values are searchable")
+
+	products, _, err = s.ListProducts(ctx, ListProductsParams{SearchQuery: PtrTo("phone"), SortBy: "price", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PHONE-CHG", "D-1"}, []string{products[0].SKU, products[1].SKU}, "an explicit sort_by overrides relevance")
+}
+
+func TestMemoryStore_ListProductFacets(t *testing.T) {
+	s := NewMemoryStore()
+	catID := seedMemoryProducts(t, s)
+	ctx := context.Background()
+	for i, attrs := range []string{`{"color": "red", "tags": ["usb-c", "wireless", "usb-c"]}`, `{"color": "red", "tags": "usb-c"}`, `{"color": {"nested": true}, "size": 16}`} {
+		raw := json.RawMessage(attrs)
+		_, err := s.CreateProduct(ctx, &domain.Product{Name: "Accessory", SKU: "ACC-" + strconv.Itoa(i), Price: usd(1000), IsActive: true, Attributes: &raw})
+		require.NoError(t, err)
+	}
+
+	req := FacetRequest{Categories: true, IsActive: true, PriceBoundaries: []domain.Money{usd(1000), usd(10000)}, AttributeKeys: []string{"color", "tags", "size", "missing"}}
+	facets, err := s.ListProductFacets(ctx, ListProductsParams{Limit: 1, Offset: 5}, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, []domain.CategoryFacet{{CategoryID: &catID, Count: 2}, {Count: 5}}, facets.Categories, "the uncategorized come last")
+	require.Len(t, facets.PriceRanges, 3)
+	assert.Equal(t, []int{0, 4, 3}, []int{facets.PriceRanges[0].Count, facets.PriceRanges[1].Count, facets.PriceRanges[2].Count}, "ranges include their lower bound")
+	assert.Nil(t, facets.PriceRanges[0].Min)
+	assert.Equal(t, usd(10000), *facets.PriceRanges[2].Min)
+	assert.Equal(t, []domain.IsActiveFacet{{Value: true, Count: 6}, {Value: false, Count: 1}}, facets.IsActive)
+	assert.Equal(t, []domain.AttributeValueFacet{{Value: "red", Count: 2}}, facets.Attributes["color"], "objects are not values")
+	assert.Equal(t, []domain.AttributeValueFacet{{Value: "usb-c", Count: 2}, {Value: "wireless", Count: 1}}, facets.Attributes["tags"], "array elements count once per product")
+	assert.Equal(t, []domain.AttributeValueFacet{{Value: "16", Count: 1}}, facets.Attributes["size"])
+	assert.Empty(t, facets.Attributes["missing"])
+
+	// Facets follow the listing's filters.
+	facets, err = s.ListProductFacets(ctx, ListProductsParams{SearchQuery: PtrTo("phone"), IsActive: PtrTo(true)}, FacetRequest{Categories: true})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CategoryFacet{{CategoryID: &catID, Count: 1}, {Count: 1}}, facets.Categories)
+	assert.Nil(t, facets.PriceRanges)
+	assert.Nil(t, facets.Attributes)
+
+	// Price ranges only count products priced in their currency.
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "Adapter", SKU: "ACC-EUR", Price: domain.Money{Amount: 1000, Currency: "EUR"}, IsActive: true})
+	require.NoError(t, err)
+	facets, err = s.ListProductFacets(ctx, ListProductsParams{}, FacetRequest{PriceBoundaries: DefaultPriceFacetBoundaries("EUR")})
+	require.NoError(t, err)
+	counts := make([]int, len(facets.PriceRanges))
+	for i, r := range facets.PriceRanges {
+		counts[i] = r.Count
+	}
+	assert.Equal(t, []int{0, 1, 0, 0, 0, 0, 0, 0}, counts)
+	assert.Equal(t, domain.Money{Amount: 1000, Currency: "EUR"}, *facets.PriceRanges[1].Min)
+}
+
+func TestMemoryStore_ListProducts_AttributeFilters(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	for i, attrs := range []string{
+		`{"color": "red", "size": "M", "weight": 1.5}`,
+		`{"color": "red", "size": ["S", "M"], "weight": "heavy"}`,
+		`{"color": "blue", "size": 16, "warranty": null}`,
+		`{"color": "Red"}`,
+	} {
+		raw := json.RawMessage(attrs)
+		_, err := s.CreateProduct(ctx, &domain.Product{Name: "Shirt", SKU: "SHIRT-" + strconv.Itoa(i), Price: usd(1000), Attributes: &raw})
+		require.NoError(t, err)
+	}
+	_, err := s.CreateProduct(ctx, &domain.Product{Name: "Plain", SKU: "PLAIN", Price: usd(1000)})
+	require.NoError(t, err)
+
+	skus := func(filters ...AttributeFilter) []string {
+		t.Helper()
+		products, _, err := s.ListProducts(ctx, ListProductsParams{Attributes: filters, Limit: 10})
+		require.NoError(t, err)
+		var skus []string
+		for _, p := range products {
+			skus = append(skus, p.SKU)
+		}
+		return skus
+	}
+
+	assert.ElementsMatch(t, []string{"SHIRT-0", "SHIRT-1"}, skus(
+		AttributeFilter{Key: "color", Op: AttributeEquals, Values: []string{"red"}},
+		AttributeFilter{Key: "size", Op: AttributeEquals, Values: []string{"M"}},
+	), "case-sensitive, and arrays match an element")
+	assert.ElementsMatch(t, []string{"SHIRT-1", "SHIRT-2"}, skus(AttributeFilter{Key: "size", Op: AttributeIn, Values: []string{"S", "16"}}), "numbers match their text")
+	assert.ElementsMatch(t, []string{"SHIRT-0"}, skus(AttributeFilter{Key: "weight", Op: AttributeRange, Min: PtrTo(1.0), Max: PtrTo(2.0)}), "strings are outside every range")
+	assert.ElementsMatch(t, []string{"SHIRT-2"}, skus(AttributeFilter{Key: "warranty", Op: AttributeExists}), "null values exist")
+
+	_, _, err = s.ListProducts(ctx, ListProductsParams{Attributes: []AttributeFilter{{Key: "weight", Op: AttributeRange}}})
+	assert.ErrorIs(t, err, ErrInvalidAttributeFilter)
+	_, err = s.ListProductFacets(ctx, ListProductsParams{Attributes: []AttributeFilter{{Key: "size", Op: AttributeEquals}}}, FacetRequest{})
+	assert.ErrorIs(t, err, ErrInvalidAttributeFilter)
+}
+
+func TestMemoryStore_ListCategories_AfterCursor(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	for _, name := range []string{"Books", "Audio", "Cameras"} {
+		_, err := s.CreateCategory(ctx, &domain.Category{Name: name})
+		require.NoError(t, err)
+	}
+
+	first, total, err := s.ListCategories(ctx, ListCategoriesParams{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, first, 2)
+
+	cursor := CategoryCursor(&first[1])
+	rest, _, err := s.ListCategories(ctx, ListCategoriesParams{Limit: 2, After: &cursor})
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "Cameras", rest[0].Name)
+}
+
+func TestMemoryStore_UpdateStock(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s)
+	ctx := context.Background()
+
+	updated, err := s.UpdateStock(ctx, 1, -5, StockMovementInfo{Reason: domain.StockReasonAdjustment})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), updated.StockQuantity)
+
+	_, err = s.UpdateStock(ctx, 1, -1, StockMovementInfo{Reason: domain.StockReasonAdjustment})
+	assert.True(t, errors.Is(err, ErrInsufficientStock))
+
+	_, err = s.UpdateStock(ctx, 999, 1, StockMovementInfo{Reason: domain.StockReasonAdjustment})
+	assert.True(t, errors.Is(err, ErrProductNotFound))
+}
+
+func TestMemoryStore_UpdateStock_KeepsReservedStock(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s) // Product 1 has 5 in stock
+	ctx := context.Background()
+	_, err := s.ReserveStock(ctx, "order-1", []ReservationItem{{ProductID: 1, Quantity: 3}}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	_, err = s.UpdateStock(ctx, 1, -3, StockMovementInfo{Reason: domain.StockReasonAdjustment})
+	assert.True(t, errors.Is(err, ErrInsufficientStock), "3 of the 5 units are reserved")
+	updated, err := s.UpdateStock(ctx, 1, -2, StockMovementInfo{Reason: domain.StockReasonAdjustment})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), updated.StockQuantity)
+
+	_, products, err := s.CommitReservation(ctx, "order-1")
+	require.NoError(t, err, "the reserved units are still there to commit")
+	assert.Equal(t, int32(0), products[0].StockQuantity)
+}
+
+func TestMemoryStore_UpdateStock_Concurrent(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	p, err := s.CreateProduct(ctx, &domain.Product{Name: "Widget", SKU: "W-1", StockQuantity: 50})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	failures := 0
+	for i := 0; i < 80; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.UpdateStock(ctx, p.ID, -1, StockMovementInfo{Reason: domain.StockReasonAdjustment}); err != nil {
+				mu.Lock()
+				failures++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := s.GetProductByID(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), got.StockQuantity)
+	assert.Equal(t, 30, failures)
+}
+
+func TestMemoryStore_DeleteCategory_DetachesProducts(t *testing.T) {
+	s := NewMemoryStore()
+	catID := seedMemoryProducts(t, s)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, s.DeleteCategory(ctx, catID, DeleteCategoryOptions{}), ErrCategoryNotEmpty, "restrict by default")
+	require.NoError(t, s.DeleteCategory(ctx, catID, DeleteCategoryOptions{Strategy: CategoryDeleteReparent}))
+
+	p, err := s.GetProductByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, p.CategoryID, "a root category has no parent to move products to")
+	assert.True(t, errors.Is(s.DeleteCategory(ctx, catID, DeleteCategoryOptions{}), ErrCategoryNotFound))
+}
+
+func TestMemoryStore_ReturnsCopies(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	created, err := s.CreateProduct(ctx, &domain.Product{Name: "Widget", SKU: "W-1", Description: PtrTo("original")})
+	require.NoError(t, err)
+
+	*created.Description = "mutated"
+
+	got, err := s.GetProductByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "original", *got.Description)
+}
+
+func TestMemoryStore_BatchUpdateStock_AtomicRollsBack(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s) // Product 1 has 5 in stock, product 2 has 0
+	ctx := context.Background()
+
+	results, err := s.BatchUpdateStock(ctx, []StockUpdate{
+		{ProductID: 1, QuantityChange: -2},
+		{ProductID: 2, QuantityChange: -1},
+		{ProductID: 999, QuantityChange: -1},
+	}, true, StockMovementInfo{Reason: domain.StockReasonStockUpdate})
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.True(t, errors.Is(results[0].Err, ErrStockBatchAborted))
+	assert.Nil(t, results[0].Product)
+	assert.True(t, errors.Is(results[1].Err, ErrInsufficientStock))
+	assert.True(t, errors.Is(results[2].Err, ErrProductNotFound))
+
+	p, err := s.GetProductByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), p.StockQuantity, "no item may be applied when the atomic batch fails")
+}
+
+func TestMemoryStore_BatchUpdateStock_BestEffort(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s)
+	ctx := context.Background()
+
+	results, err := s.BatchUpdateStock(ctx, []StockUpdate{
+		{ProductID: 1, QuantityChange: -2},
+		{ProductID: 2, QuantityChange: -1},
+		{ProductID: 1, QuantityChange: -3},
+	}, false, StockMovementInfo{Reason: domain.StockReasonStockUpdate})
+
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.True(t, errors.Is(results[1].Err, ErrInsufficientStock))
+	require.NoError(t, results[2].Err)
+	assert.Equal(t, int32(0), results[2].Product.StockQuantity, "repeated lines for a product accumulate")
+
+	p, err := s.GetProductByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), p.StockQuantity)
+}
+
+func TestMemoryStore_Reservations_Lifecycle(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s) // Product 1 has 5 in stock, product 4 has 50
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	held, err := s.ReserveStock(ctx, "order-1", []ReservationItem{
+		{ProductID: 4, Quantity: 10},
+		{ProductID: 1, Quantity: 2},
+		{ProductID: 1, Quantity: 1},
+	}, expiresAt)
+	require.NoError(t, err)
+	require.Len(t, held, 2, "repeated products are merged")
+	assert.Equal(t, int64(1), held[0].ProductID)
+	assert.Equal(t, int32(3), held[0].Quantity)
+
+	_, err = s.ReserveStock(ctx, "order-1", []ReservationItem{{ProductID: 4, Quantity: 1}}, expiresAt)
+	assert.True(t, errors.Is(err, ErrReservationExists))
+	_, err = s.ReserveStock(ctx, "order-2", []ReservationItem{{ProductID: 1, Quantity: 3}}, expiresAt)
+	assert.True(t, errors.Is(err, ErrInsufficientStock), "only 2 of 5 units are unreserved")
+
+	reserved, err := s.GetReservedQuantities(ctx, []int64{1, 4}, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int32{1: 3, 4: 10}, reserved)
+	reserved, err = s.GetReservedQuantities(ctx, []int64{1}, "order-1")
+	require.NoError(t, err)
+	assert.Empty(t, reserved, "the excluded reference's holds are not counted")
+
+	committed, products, err := s.CommitReservation(ctx, "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReservationCommitted, committed[0].Status)
+	assert.Equal(t, int32(2), products[0].StockQuantity)
+	assert.Equal(t, int32(40), products[1].StockQuantity)
+
+	_, _, err = s.CommitReservation(ctx, "order-1")
+	assert.True(t, errors.Is(err, ErrReservationNotFound), "a reservation commits once")
+}
+
+func TestMemoryStore_Reservations_ReleaseAndExpire(t *testing.T) {
+	s := NewMemoryStore()
+	seedMemoryProducts(t, s)
+	ctx := context.Background()
+
+	_, err := s.ReserveStock(ctx, "cart-1", []ReservationItem{{ProductID: 1, Quantity: 5}}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	released, err := s.ReleaseReservation(ctx, "cart-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReservationReleased, released[0].Status)
+
+	_, err = s.ReserveStock(ctx, "cart-2", []ReservationItem{{ProductID: 1, Quantity: 5}}, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	reserved, err := s.GetReservedQuantities(ctx, []int64{1}, "")
+	require.NoError(t, err)
+	assert.Zero(t, reserved[1], "overdue holds stop counting before they are swept")
+
+	_, _, err = s.CommitReservation(ctx, "cart-2")
+	assert.True(t, errors.Is(err, ErrReservationExpired))
+	expired, err := s.ExpireReservations(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, expired, "the failed commit already expired the hold")
+
+	p, err := s.GetProductByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), p.StockQuantity)
+}
+
+func TestMemoryStore_IdempotencyKeys(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	_, claimed, err := s.ClaimIdempotencyKey(ctx, "order-1", "update_stock", "h1", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	record, claimed, err := s.ClaimIdempotencyKey(ctx, "order-1", "update_stock", "h2", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.Equal(t, "h1", record.RequestHash)
+	assert.False(t, record.Completed())
+
+	_, claimed, err = s.ClaimIdempotencyKey(ctx, "order-1", "other_operation", "h1", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, claimed, "keys are scoped by operation")
+
+	require.NoError(t, s.CompleteIdempotencyKey(ctx, "order-1", "update_stock", []byte("response")))
+	assert.True(t, errors.Is(s.ReleaseIdempotencyKey(ctx, "order-1", "update_stock"), ErrIdempotencyKeyNotFound), "completed keys cannot be released")
+	record, _, err = s.ClaimIdempotencyKey(ctx, "order-1", "update_stock", "h1", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("response"), record.Response)
+
+	_, claimed, err = s.ClaimIdempotencyKey(ctx, "order-2", "update_stock", "h1", now.Add(-time.Second))
+	require.NoError(t, err)
+	require.True(t, claimed)
+	_, claimed, err = s.ClaimIdempotencyKey(ctx, "order-2", "update_stock", "h2", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, claimed, "expired keys may be reused")
+
+	deleted, err := s.DeleteExpiredIdempotencyKeys(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 3, deleted)
+}
+
+func TestMemoryStore_StockMovements(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := WithActor(context.Background(), "admin")
+	p, err := s.CreateProduct(ctx, &domain.Product{Name: "Widget", SKU: "W-1", StockQuantity: 5})
+	require.NoError(t, err)
+
+	p.StockQuantity = 8
+	_, err = s.UpdateProduct(ctx, p)
+	require.NoError(t, err)
+	p.Name = "Widget 2" // No stock change, no ledger entry
+	_, err = s.UpdateProduct(ctx, p)
+	require.NoError(t, err)
+	_, err = s.ReserveStock(ctx, "order-1", []ReservationItem{{ProductID: p.ID, Quantity: 3}}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, _, err = s.CommitReservation(ctx, "order-1")
+	require.NoError(t, err)
+
+	movements, total, err := s.ListStockMovements(ctx, ListStockMovementsParams{ProductID: p.ID, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	reasons := []domain.StockMovementReason{movements[0].Reason, movements[1].Reason, movements[2].Reason}
+	assert.Equal(t, []domain.StockMovementReason{domain.StockReasonReservationCommit, domain.StockReasonProductUpdate, domain.StockReasonInitialStock}, reasons)
+	assert.Equal(t, int32(5), movements[0].BalanceAfter)
+	assert.Equal(t, "order-1", *movements[0].OrderID)
+	assert.Equal(t, int32(3), movements[1].QuantityDelta)
+	assert.Equal(t, "admin", *movements[2].Actor)
+
+	after := StockMovementCursor(&movements[0])
+	page, total, err := s.ListStockMovements(ctx, ListStockMovementsParams{ProductID: p.ID, Limit: 10, After: &after})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total, "the count covers the whole history")
+	require.Len(t, page, 2)
+	assert.Equal(t, movements[1].ID, page[0].ID)
+	_, _, err = s.ListStockMovements(ctx, ListStockMovementsParams{ProductID: p.ID, Limit: 10, After: &Cursor{SortBy: ProductSortCreatedAt, Desc: true}})
+	assert.True(t, errors.Is(err, ErrInvalidCursor))
+
+	future := time.Now().Add(time.Minute)
+	_, total, err = s.ListStockMovements(ctx, ListStockMovementsParams{ProductID: p.ID, From: &future, Limit: 10})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestMemoryStore_LocationStock(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	p, err := s.CreateProduct(ctx, &domain.Product{Name: "Widget", SKU: "W-1", StockQuantity: 10})
+	require.NoError(t, err)
+	east, err := s.CreateLocation(ctx, &domain.Location{Code: "east", Name: "East", Priority: 5})
+	require.NoError(t, err)
+	_, err = s.CreateLocation(ctx, &domain.Location{Code: "east", Name: "East again"})
+	assert.True(t, errors.Is(err, ErrLocationCodeExists))
+
+	levels, err := s.TransferStock(ctx, p.ID, 1, east.ID, 4, StockMovementInfo{Reason: domain.StockReasonTransfer})
+	require.NoError(t, err)
+	require.Len(t, levels, 2)
+	assert.Equal(t, int32(6), levels[0].Quantity, "default location first: lower priority value")
+	assert.Equal(t, int32(4), levels[1].Quantity)
+	_, err = s.TransferStock(ctx, p.ID, 1, east.ID, 7, StockMovementInfo{Reason: domain.StockReasonTransfer})
+	assert.True(t, errors.Is(err, ErrInsufficientStock))
+
+	// Without a location, decreases drain locations in priority order.
+	results, err := s.BatchUpdateStock(ctx, []StockUpdate{{ProductID: p.ID, QuantityChange: -8}}, true, StockMovementInfo{Reason: domain.StockReasonStockUpdate})
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, []domain.StockAllocation{{LocationID: 1, Quantity: -6}, {LocationID: east.ID, Quantity: -2}}, results[0].Allocations)
+	assert.Equal(t, int32(2), results[0].Product.StockQuantity)
+
+	results, err = s.BatchUpdateStock(ctx, []StockUpdate{{ProductID: p.ID, QuantityChange: 1, LocationID: PtrTo(int64(99))}}, true, StockMovementInfo{Reason: domain.StockReasonStockUpdate})
+	require.NoError(t, err)
+	assert.True(t, errors.Is(results[0].Err, ErrLocationNotFound))
+
+	assert.True(t, errors.Is(s.DeleteLocation(ctx, east.ID), ErrLocationInUse))
+	_, err = s.TransferStock(ctx, p.ID, east.ID, 1, 2, StockMovementInfo{Reason: domain.StockReasonTransfer})
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteLocation(ctx, east.ID), "empty locations can be deleted")
+
+	movements, _, err := s.ListStockMovements(ctx, ListStockMovementsParams{ProductID: p.ID, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StockReasonTransfer, movements[0].Reason)
+	assert.Equal(t, int32(2), movements[0].BalanceAfter, "transfers leave the aggregate unchanged")
+	assert.Equal(t, int64(1), *movements[0].LocationID)
+}
+
+func TestMemoryStore_AttributeSchema(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	clothing, err := s.CreateCategory(ctx, &domain.Category{Name: "Clothing"})
+	require.NoError(t, err)
+	shirts, err := s.CreateCategory(ctx, &domain.Category{Name: "Shirts", ParentCategoryID: &clothing.ID})
+	require.NoError(t, err)
+
+	_, err = s.PutCategoryAttribute(ctx, &domain.CategoryAttribute{CategoryID: clothing.ID, Key: "color", Type: domain.AttributeTypeString, Required: true})
+	require.NoError(t, err)
+	_, err = s.PutCategoryAttribute(ctx, &domain.CategoryAttribute{CategoryID: clothing.ID, Key: "weight", Type: domain.AttributeTypeNumber, Unit: PtrTo("kg")})
+	require.NoError(t, err)
+	_, err = s.PutCategoryAttribute(ctx, &domain.CategoryAttribute{CategoryID: shirts.ID, Key: "color", Type: domain.AttributeTypeString, EnumValues: []string{"red", "blue"}, Required: true})
+	require.NoError(t, err)
+	_, err = s.PutCategoryAttribute(ctx, &domain.CategoryAttribute{CategoryID: shirts.ID, Key: "size", Type: domain.AttributeTypeBoolean, EnumValues: []string{"S"}})
+	assert.ErrorIs(t, err, ErrInvalidAttributeDefinition)
+	_, err = s.PutCategoryAttribute(ctx, &domain.CategoryAttribute{CategoryID: 99, Key: "size", Type: domain.AttributeTypeString})
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+
+	schema, err := s.GetCategoryAttributeSchema(ctx, shirts.ID)
+	require.NoError(t, err)
+	require.Len(t, schema, 2)
+	assert.Equal(t, shirts.ID, schema[0].CategoryID, "the subcategory's definition of color wins")
+	assert.Equal(t, []string{"red", "blue"}, schema[0].EnumValues)
+	assert.Equal(t, clothing.ID, schema[1].CategoryID, "weight is inherited")
+
+	raw := json.RawMessage(`{"Color": "green", "weight": "heavy"}`)
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "Shirt", SKU: "S-1", CategoryID: &shirts.ID, Attributes: &raw})
+	var schemaErr *AttributeSchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, []AttributeViolation{
+		{Field: "attributes.Color", Message: `is not defined for this category; did you mean "color"?`},
+		{Field: "attributes.color", Message: "is required"},
+		{Field: "attributes.weight", Message: "must be a number"},
+	}, schemaErr.Violations)
+
+	raw = json.RawMessage(`{"color": "red", "weight": 0.2}`)
+	p, err := s.CreateProduct(ctx, &domain.Product{Name: "Shirt", SKU: "S-1", CategoryID: &shirts.ID, Attributes: &raw})
+	require.NoError(t, err)
+	raw = json.RawMessage(`{"color": "green"}`)
+	p.Attributes = &raw
+	_, err = s.UpdateProduct(ctx, p)
+	assert.ErrorIs(t, err, ErrAttributeSchemaViolation)
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "Loose", SKU: "L-1", Attributes: &raw})
+	assert.NoError(t, err, "products without a category accept any attributes")
+
+	require.NoError(t, s.DeleteCategoryAttribute(ctx, shirts.ID, "color"))
+	assert.ErrorIs(t, s.DeleteCategoryAttribute(ctx, shirts.ID, "color"), ErrCategoryAttributeNotFound)
+	schema, err = s.GetCategoryAttributeSchema(ctx, shirts.ID)
+	require.NoError(t, err)
+	assert.Equal(t, clothing.ID, schema[0].CategoryID, "the parent's color applies again")
+}
+
+func TestMemoryStore_CategoryHierarchy(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	create := func(name string, parent *domain.Category) *domain.Category {
+		c := &domain.Category{Name: name}
+		if parent != nil {
+			c.ParentCategoryID = &parent.ID
+		}
+		created, err := s.CreateCategory(ctx, c)
+		require.NoError(t, err)
+		return created
+	}
+	electronics := create("Electronics", nil)
+	books := create("Books", nil)
+	phones := create("Phones", electronics)
+	laptops := create("Laptops", electronics)
+	android := create("Android", phones)
+
+	tree, err := s.GetCategoryTree(ctx, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, tree, 2)
+	assert.Equal(t, books.ID, tree[0].ID, "siblings are ordered by name")
+	assert.Empty(t, tree[0].Children)
+	require.Len(t, tree[1].Children, 2)
+	assert.Equal(t, laptops.ID, tree[1].Children[0].ID)
+	assert.Equal(t, android.ID, tree[1].Children[1].Children[0].ID)
+
+	tree, err = s.GetCategoryTree(ctx, &electronics.ID, 2)
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	phonesNode := tree[0].Children[1]
+	assert.Empty(t, phonesNode.Children, "below the depth limit")
+	assert.True(t, phonesNode.HasChildren)
+	_, err = s.GetCategoryTree(ctx, PtrTo(int64(99)), 0)
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+
+	ancestors, err := s.GetCategoryAncestors(ctx, android.ID)
+	require.NoError(t, err)
+	require.Len(t, ancestors, 2)
+	assert.Equal(t, []int64{electronics.ID, phones.ID}, []int64{ancestors[0].ID, ancestors[1].ID})
+	ancestors, err = s.GetCategoryAncestors(ctx, electronics.ID)
+	require.NoError(t, err)
+	assert.Empty(t, ancestors)
+
+	descendants, err := s.GetCategoryDescendants(ctx, electronics.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, descendants, 3)
+	assert.Equal(t, []int64{laptops.ID, phones.ID, android.ID}, []int64{descendants[0].ID, descendants[1].ID, descendants[2].ID})
+	descendants, err = s.GetCategoryDescendants(ctx, electronics.ID, 1)
+	require.NoError(t, err)
+	assert.Len(t, descendants, 2)
+	_, err = s.GetCategoryDescendants(ctx, electronics.ID, -1)
+	assert.ErrorIs(t, err, ErrInvalidMaxDepth)
+}
+
+func TestMemoryStore_CategoryHierarchyChanges(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	root, err := s.CreateCategory(ctx, &domain.Category{Name: "Electronics"})
+	require.NoError(t, err)
+	phones, err := s.CreateCategory(ctx, &domain.Category{Name: "Phones", ParentCategoryID: &root.ID})
+	require.NoError(t, err)
+	android, err := s.CreateCategory(ctx, &domain.Category{Name: "Android", ParentCategoryID: &phones.ID})
+	require.NoError(t, err)
+	other, err := s.CreateCategory(ctx, &domain.Category{Name: "Other"})
+	require.NoError(t, err)
+	phone, err := s.CreateProduct(ctx, &domain.Product{Name: "Phone", SKU: "P-1", CategoryID: &phones.ID})
+	require.NoError(t, err)
+	pixel, err := s.CreateProduct(ctx, &domain.Product{Name: "Pixel", SKU: "A-1", CategoryID: &android.ID})
+	require.NoError(t, err)
+
+	root.ParentCategoryID = &android.ID
+	_, err = s.UpdateCategory(ctx, root)
+	assert.ErrorIs(t, err, ErrCategoryCycle, "a category cannot move under its descendant")
+	root.ParentCategoryID = &root.ID
+	_, err = s.UpdateCategory(ctx, root)
+	assert.ErrorIs(t, err, ErrCategoryCycle)
+
+	err = s.DeleteCategory(ctx, phones.ID, DeleteCategoryOptions{Strategy: CategoryDeleteMoveProducts})
+	assert.ErrorIs(t, err, ErrInvalidDeleteOptions, "move_products needs a target")
+	err = s.DeleteCategory(ctx, phones.ID, DeleteCategoryOptions{Strategy: CategoryDeleteMoveProducts, TargetCategoryID: PtrTo(int64(99))})
+	assert.ErrorIs(t, err, ErrInvalidDeleteOptions)
+	err = s.DeleteCategory(ctx, phones.ID, DeleteCategoryOptions{Strategy: "shred"})
+	assert.ErrorIs(t, err, ErrInvalidDeleteOptions)
+
+	require.NoError(t, s.DeleteCategory(ctx, phones.ID, DeleteCategoryOptions{Strategy: CategoryDeleteMoveProducts, TargetCategoryID: &other.ID}))
+	got, err := s.GetCategoryByID(ctx, android.ID)
+	require.NoError(t, err)
+	assert.Equal(t, root.ID, *got.ParentCategoryID, "subcategories move to the grandparent")
+	moved, err := s.GetProductByID(ctx, phone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, *moved.CategoryID)
+
+	require.NoError(t, s.DeleteCategory(ctx, root.ID, DeleteCategoryOptions{Strategy: CategoryDeleteCascade}))
+	_, err = s.GetCategoryByID(ctx, android.ID)
+	assert.ErrorIs(t, err, ErrCategoryNotFound, "descendants are deleted too")
+	uncategorized, err := s.GetProductByID(ctx, pixel.ID)
+	require.NoError(t, err)
+	assert.Nil(t, uncategorized.CategoryID, "products are kept")
+}
+
+func TestMemoryStore_DeleteCategory_CascadeKeepsTrashedProducts(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	shoes, err := s.CreateCategory(ctx, &domain.Category{Name: "Shoes"})
+	require.NoError(t, err)
+	boots, err := s.CreateCategory(ctx, &domain.Category{Name: "Boots", ParentCategoryID: &shoes.ID})
+	require.NoError(t, err)
+	live, err := s.CreateProduct(ctx, &domain.Product{Name: "Boot", SKU: "BOOT-1", Price: usd(8000), CategoryID: &boots.ID})
+	require.NoError(t, err)
+	trashed, err := s.CreateProduct(ctx, &domain.Product{Name: "Boot", SKU: "BOOT-2", Price: usd(8000), CategoryID: &boots.ID})
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteProduct(ctx, trashed.ID))
+
+	require.NoError(t, s.DeleteCategory(ctx, shoes.ID, DeleteCategoryOptions{Strategy: CategoryDeleteCascade}))
+	got, err := s.GetProductByID(ctx, live.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.CategoryID, "live products are uncategorized")
+	_, err = s.RestoreProduct(ctx, trashed.ID)
+	assert.ErrorIs(t, err, ErrRestoreBlocked, "the product is still in the deleted category")
+
+	_, err = s.RestoreCategory(ctx, shoes.ID)
+	require.NoError(t, err)
+	got, err = s.RestoreProduct(ctx, trashed.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.CategoryID)
+	assert.Equal(t, boots.ID, *got.CategoryID, "restoring the categories gives trashed products theirs back")
+}
+
+func TestMemoryStore_CategoryFilters(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	electronics, err := s.CreateCategory(ctx, &domain.Category{Name: "Electronics"})
+	require.NoError(t, err)
+	phones, err := s.CreateCategory(ctx, &domain.Category{Name: "Phones", ParentCategoryID: &electronics.ID})
+	require.NoError(t, err)
+	android, err := s.CreateCategory(ctx, &domain.Category{Name: "Android", ParentCategoryID: &phones.ID})
+	require.NoError(t, err)
+	for i, categoryID := range []int64{electronics.ID, android.ID} {
+		_, err := s.CreateProduct(ctx, &domain.Product{Name: "Gadget", SKU: "GADGET-" + strconv.Itoa(i), Price: usd(1000), CategoryID: PtrTo(categoryID)})
+		require.NoError(t, err)
+	}
+
+	_, total, err := s.ListProducts(ctx, ListProductsParams{Limit: 10, CategoryID: &phones.ID})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	products, total, err := s.ListProducts(ctx, ListProductsParams{Limit: 10, CategoryID: &phones.ID, IncludeDescendants: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "GADGET-1", products[0].SKU)
+	_, total, err = s.ListProducts(ctx, ListProductsParams{Limit: 10, CategoryID: &electronics.ID, IncludeDescendants: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	_, total, err = s.ListProducts(ctx, ListProductsParams{Limit: 10, CategoryID: PtrTo(int64(99)), IncludeDescendants: true})
+	require.NoError(t, err)
+	assert.Zero(t, total, "an unknown category matches nothing")
+
+	categories, total, err := s.ListCategories(ctx, ListCategoriesParams{Limit: 10, ParentCategoryID: &electronics.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total, "only direct subcategories")
+	assert.Equal(t, phones.ID, categories[0].ID)
+}
+
+func TestMemoryStore_Prices(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	for _, p := range []domain.Product{
+		{Name: "Cheap", SKU: "USD-1", Price: usd(1999)},
+		{Name: "Dear", SKU: "USD-2", Price: usd(2000)},
+		{Name: "Euro", SKU: "EUR-1", Price: domain.Money{Amount: 1999, Currency: "EUR"}},
+		{Name: "Dinar", SKU: "KWD-1", Price: domain.Money{Amount: 19995, Currency: "KWD"}},
+		{Name: "Unpriced", SKU: "NONE"},
+	} {
+		_, err := s.CreateProduct(ctx, &p)
+		require.NoError(t, err)
+	}
+
+	products, total, err := s.ListProducts(ctx, ListProductsParams{MinPrice: PtrTo(usd(1999)), MaxPrice: PtrTo(usd(1999)), Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, total, "bounds are exact and only match their currency")
+	assert.Equal(t, "USD-1", products[0].SKU)
+
+	products, _, err = s.ListProducts(ctx, ListProductsParams{SortBy: "price", Limit: 10})
+	require.NoError(t, err)
+	var skus []string
+	for _, p := range products {
+		skus = append(skus, p.SKU)
+	}
+	assert.Equal(t, []string{"NONE", "USD-1", "EUR-1", "KWD-1", "USD-2"}, skus, "sorted by decimal value, like the price column")
+	assert.Equal(t, domain.Money{Currency: domain.DefaultCurrency}, products[0].Price)
+
+	params := ListProductsParams{SortBy: "price", Limit: 10}
+	after := ProductCursor(params, &products[3])
+	params.After = &after
+	products, _, err = s.ListProducts(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "USD-2", products[0].SKU)
+
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "Bad", SKU: "BAD", Price: domain.Money{Amount: 1, Currency: "usd"}})
+	assert.Error(t, err)
+}
+
+func TestMemoryStore_PriceLists(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	eur := func(cents int64) domain.Money { return domain.Money{Amount: cents, Currency: "EUR"} }
+	scarf, err := s.CreateProduct(ctx, &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999)})
+	require.NoError(t, err)
+	hat, err := s.CreateProduct(ctx, &domain.Product{Name: "Hat", SKU: "HAT", Price: usd(999)})
+	require.NoError(t, err)
+
+	retail, err := s.CreatePriceList(ctx, &domain.PriceList{Name: "EU retail", Currency: "EUR", IsDefault: true})
+	require.NoError(t, err)
+	_, err = s.CreatePriceList(ctx, &domain.PriceList{Name: "EU other", Currency: "EUR", IsDefault: true})
+	assert.ErrorIs(t, err, ErrDefaultPriceListExists)
+	wholesale, err := s.CreatePriceList(ctx, &domain.PriceList{Name: "EU wholesale", Currency: "EUR", CustomerGroup: PtrTo("wholesale")})
+	require.NoError(t, err)
+
+	_, err = s.SetPriceListEntries(ctx, retail.ID, []domain.PriceListEntry{
+		{ProductID: scarf.ID, Price: eur(1799)}, {ProductID: hat.ID, Price: eur(899)},
+	})
+	require.NoError(t, err)
+	_, err = s.SetPriceListEntries(ctx, wholesale.ID, []domain.PriceListEntry{{ProductID: scarf.ID, Price: eur(1299)}})
+	require.NoError(t, err)
+
+	// Bulk writes are all or nothing.
+	_, err = s.SetPriceListEntries(ctx, wholesale.ID, []domain.PriceListEntry{{ProductID: hat.ID, Price: eur(1)}, {ProductID: 99, Price: eur(1)}})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	_, err = s.SetPriceListEntries(ctx, wholesale.ID, []domain.PriceListEntry{{ProductID: hat.ID, Price: usd(1)}})
+	assert.ErrorIs(t, err, ErrInvalidPriceListEntry)
+	entries, total, err := s.ListPriceListEntries(ctx, ListPriceListEntriesParams{PriceListID: wholesale.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, eur(1299), entries[0].Price)
+
+	resolve := func(sel PriceSelection) []domain.Product {
+		products := []domain.Product{*scarf, *hat}
+		require.NoError(t, s.ResolvePrices(ctx, sel, products))
+		return products
+	}
+	products := resolve(PriceSelection{PriceListID: &wholesale.ID})
+	assert.Equal(t, eur(1299), products[0].Price)
+	assert.Equal(t, eur(899), products[1].Price, "falls back to the default list of the currency")
+	assert.Equal(t, retail.ID, *products[1].PriceListID)
+	products = resolve(PriceSelection{Currency: "EUR"})
+	assert.Equal(t, eur(1799), products[0].Price)
+	products = resolve(PriceSelection{Currency: "GBP"})
+	assert.Equal(t, usd(1999), products[0].Price, "the base price is kept without a list price")
+	assert.Nil(t, products[0].PriceListID)
+	assert.ErrorIs(t, s.ResolvePrices(ctx, PriceSelection{Currency: "USD", PriceListID: &wholesale.ID}, []domain.Product{*scarf}), ErrInvalidPriceSelection)
+
+	_, err = s.UpdatePriceList(ctx, &domain.PriceList{ID: wholesale.ID, Name: "EU wholesale", Currency: "GBP"})
+	assert.ErrorIs(t, err, ErrInvalidPriceList, "a list with entries keeps its currency")
+	deleted, err := s.DeletePriceListEntries(ctx, retail.ID, []int64{hat.ID, 99})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	require.NoError(t, s.DeleteProduct(ctx, scarf.ID))
+	_, err = s.PurgeDeletedProducts(ctx, time.Now())
+	require.NoError(t, err)
+	_, total, err = s.ListPriceListEntries(ctx, ListPriceListEntriesParams{PriceListID: retail.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 0, total, "entries are purged with their product")
+}
+
+func TestMemoryStore_ScheduledPrices(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	scarf, err := s.CreateProduct(ctx, &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), IsActive: true})
+	require.NoError(t, err)
+	hat, err := s.CreateProduct(ctx, &domain.Product{Name: "Hat", SKU: "HAT", Price: usd(1499), IsActive: true})
+	require.NoError(t, err)
+
+	_, err = s.CreateScheduledPrice(ctx, &domain.ScheduledPrice{ProductID: scarf.ID, Price: domain.Money{Amount: 999, Currency: "EUR"}, ValidFrom: now})
+	assert.ErrorIs(t, err, ErrInvalidScheduledPrice, "must be in the product's currency")
+	_, err = s.CreateScheduledPrice(ctx, &domain.ScheduledPrice{ProductID: scarf.ID, Price: usd(999), ValidFrom: now, ValidUntil: PtrTo(now)})
+	assert.ErrorIs(t, err, ErrInvalidScheduledPrice, "the window must not be empty")
+	_, err = s.CreateScheduledPrice(ctx, &domain.ScheduledPrice{ProductID: 99, Price: usd(999), ValidFrom: now})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+
+	// A weekend sale with a later flash sale inside it; the one that started last wins.
+	sale, err := s.CreateScheduledPrice(ctx, &domain.ScheduledPrice{ProductID: scarf.ID, Price: usd(1499), ValidFrom: now.Add(-time.Hour), ValidUntil: PtrTo(now.Add(time.Hour))})
+	require.NoError(t, err)
+	flash, err := s.CreateScheduledPrice(ctx, &domain.ScheduledPrice{ProductID: scarf.ID, Price: usd(999), ValidFrom: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	_, err = s.CreateScheduledPrice(ctx, &domain.ScheduledPrice{ProductID: scarf.ID, Price: usd(1), ValidFrom: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	got, err := s.GetProductByID(ctx, scarf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, usd(999), got.Price)
+	require.NotNil(t, got.CompareAtPrice)
+	assert.Equal(t, usd(1999), *got.CompareAtPrice)
+
+	// Filters and sorting see the effective price.
+	products, total, err := s.ListProducts(ctx, ListProductsParams{MaxPrice: PtrTo(usd(1000)), Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, scarf.ID, products[0].ID)
+	products, _, err = s.ListProducts(ctx, ListProductsParams{SortBy: "price", SortOrder: "asc", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{scarf.ID, hat.ID}, []int64{products[0].ID, products[1].ID})
+	assert.Nil(t, products[1].CompareAtPrice)
+
+	prices, err := s.ListScheduledPrices(ctx, scarf.ID)
+	require.NoError(t, err)
+	require.Len(t, prices, 3)
+	assert.Equal(t, sale.ID, prices[0].ID, "ordered by start time")
+
+	require.NoError(t, s.DeleteScheduledPrice(ctx, scarf.ID, flash.ID))
+	assert.ErrorIs(t, s.DeleteScheduledPrice(ctx, hat.ID, sale.ID), ErrScheduledPriceNotFound, "belongs to another product")
+	got, err = s.GetProductByID(ctx, scarf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, usd(1499), got.Price)
+
+	require.NoError(t, s.DeleteProduct(ctx, scarf.ID))
+	_, err = s.ListScheduledPrices(ctx, scarf.ID)
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestMemoryStore_PriceHistory(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := WithActor(context.Background(), "pricing-team")
+	beforeCreate := time.Now().UTC()
+	scarf, err := s.CreateProduct(ctx, &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), IsActive: true})
+	require.NoError(t, err)
+	beforeUpdate := time.Now().UTC()
+
+	scarf.Price = usd(2499)
+	_, err = s.UpdateProduct(ctx, scarf)
+	require.NoError(t, err)
+	scarf.Name = "Wool Scarf"
+	_, err = s.UpdateProduct(ctx, scarf)
+	require.NoError(t, err)
+	beforeSale := time.Now().UTC()
+	// Started an hour ago, but it only takes effect now.
+	sale, err := s.CreateScheduledPrice(ctx, &domain.ScheduledPrice{ProductID: scarf.ID, Price: usd(999), ValidFrom: beforeCreate.Add(-time.Hour)})
+	require.NoError(t, err)
+
+	changes, total, err := s.ListPriceChanges(ctx, ListPriceChangesParams{ProductID: scarf.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 3, total, "a name change is not a price change")
+	assert.Equal(t, domain.PriceReasonScheduledPrice, changes[0].Reason)
+	assert.Equal(t, sale.ID, *changes[0].ScheduledPriceID)
+	assert.Equal(t, usd(2499), *changes[0].OldPrice)
+	assert.False(t, changes[0].EffectiveAt.Before(beforeSale))
+	assert.Equal(t, domain.PriceReasonProductUpdate, changes[1].Reason)
+	assert.Equal(t, usd(1999), *changes[1].OldPrice)
+	assert.Equal(t, usd(2499), changes[1].NewPrice)
+	assert.Equal(t, domain.PriceReasonInitialPrice, changes[2].Reason)
+	assert.Nil(t, changes[2].OldPrice)
+	require.NotNil(t, changes[2].Actor)
+	assert.Equal(t, "pricing-team", *changes[2].Actor)
+
+	_, total, err = s.ListPriceChanges(ctx, ListPriceChangesParams{ProductID: scarf.ID, From: &beforeUpdate, To: &beforeSale, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	prices, err := s.GetPricesAt(ctx, []int64{scarf.ID, 99}, beforeCreate)
+	require.NoError(t, err)
+	assert.Empty(t, prices, "no price before the product existed")
+	prices, err = s.GetPricesAt(ctx, []int64{scarf.ID}, beforeUpdate)
+	require.NoError(t, err)
+	assert.Equal(t, usd(1999), prices[scarf.ID])
+	prices, err = s.GetPricesAt(ctx, []int64{scarf.ID}, beforeSale)
+	require.NoError(t, err)
+	assert.Equal(t, usd(2499), prices[scarf.ID], "the sale did not apply before it was created")
+	prices, err = s.GetPricesAt(ctx, []int64{scarf.ID}, time.Now().UTC())
+	require.NoError(t, err)
+	assert.Equal(t, usd(999), prices[scarf.ID])
+
+	// Cancelling the running sale ends it, keeping what was charged meanwhile.
+	beforeCancel := time.Now().UTC()
+	require.NoError(t, s.DeleteScheduledPrice(ctx, scarf.ID, sale.ID))
+	assert.ErrorIs(t, s.DeleteScheduledPrice(ctx, scarf.ID, sale.ID), ErrScheduledPriceEnded)
+	changes, _, err = s.ListPriceChanges(ctx, ListPriceChangesParams{ProductID: scarf.ID, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, domain.PriceReasonScheduledPriceCancelled, changes[0].Reason)
+	assert.Equal(t, usd(2499), changes[0].NewPrice)
+	prices, err = s.GetPricesAt(ctx, []int64{scarf.ID}, beforeCancel)
+	require.NoError(t, err)
+	assert.Equal(t, usd(999), prices[scarf.ID])
+	prices, err = s.GetPricesAt(ctx, []int64{scarf.ID}, time.Now().UTC())
+	require.NoError(t, err)
+	assert.Equal(t, usd(2499), prices[scarf.ID])
+}
+
+func TestMemoryStore_Variants(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	tee, err := s.CreateProduct(ctx, &domain.Product{Name: "Tee", SKU: "TEE", Price: usd(1500), IsActive: true, Options: []string{"color", "size"}})
+	require.NoError(t, err)
+	red, err := s.CreateProduct(ctx, &domain.Product{
+		Name: "Tee red M", SKU: "TEE-R-M", ParentID: &tee.ID, OptionValues: map[string]string{"color": "red", "size": "M"},
+		InheritsPrice: true, StockQuantity: 5, IsActive: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, usd(1500), red.Price, "inherited from the parent")
+	blue, err := s.CreateProduct(ctx, &domain.Product{
+		Name: "Tee blue M", SKU: "TEE-B-M", ParentID: &tee.ID, OptionValues: map[string]string{"color": "blue", "size": "M"},
+		Price: usd(1700), IsActive: true,
+	})
+	require.NoError(t, err)
+
+	for name, variant := range map[string]*domain.Product{
+		"missing option value":  {Name: "X", SKU: "X-1", ParentID: &tee.ID, OptionValues: map[string]string{"color": "red"}, InheritsPrice: true},
+		"unknown parent":        {Name: "X", SKU: "X-2", ParentID: PtrTo(int64(99)), OptionValues: map[string]string{"color": "red"}, InheritsPrice: true},
+		"nested variant":        {Name: "X", SKU: "X-3", ParentID: &red.ID, OptionValues: map[string]string{"color": "red"}, InheritsPrice: true},
+		"other currency":        {Name: "X", SKU: "X-4", ParentID: &tee.ID, OptionValues: map[string]string{"color": "red", "size": "L"}, Price: domain.Money{Amount: 1500, Currency: "EUR"}},
+		"values without parent": {Name: "X", SKU: "X-5", OptionValues: map[string]string{"color": "red"}, Price: usd(100)},
+	} {
+		_, err := s.CreateProduct(ctx, variant)
+		assert.ErrorIs(t, err, ErrInvalidVariant, name)
+	}
+	_, err = s.CreateProduct(ctx, &domain.Product{Name: "X", SKU: "X-6", ParentID: &tee.ID, OptionValues: map[string]string{"color": "red", "size": "M"}, InheritsPrice: true})
+	assert.ErrorIs(t, err, ErrVariantExists)
+
+	tee.Options = []string{"color"}
+	_, err = s.UpdateProduct(ctx, tee)
+	assert.ErrorIs(t, err, ErrInvalidVariant, "options cannot change while the product has variants")
+
+	// A new parent price reaches the variants that inherit it, with a price history entry.
+	tee.Options = []string{"color", "size"}
+	tee.Price = usd(1900)
+	_, err = s.UpdateProduct(ctx, tee)
+	require.NoError(t, err)
+	variants, err := s.ListVariants(ctx, []int64{tee.ID})
+	require.NoError(t, err)
+	require.Len(t, variants[tee.ID], 2)
+	assert.Equal(t, []int64{red.ID, blue.ID}, []int64{variants[tee.ID][0].ID, variants[tee.ID][1].ID})
+	assert.Equal(t, usd(1900), variants[tee.ID][0].Price)
+	assert.Equal(t, usd(1700), variants[tee.ID][1].Price, "a price override is kept")
+	changes, _, err := s.ListPriceChanges(ctx, ListPriceChangesParams{ProductID: red.ID, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, usd(1900), changes[0].NewPrice)
+
+	_, total, err := s.ListProducts(ctx, ListProductsParams{ExcludeVariants: true, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	products, _, err := s.ListProducts(ctx, ListProductsParams{ParentID: &tee.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	assert.Equal(t, map[string]string{"color": "red", "size": "M"}, products[0].OptionValues)
+
+	// Variants are deleted with their parent, and purged with it, stock and all.
+	require.NoError(t, s.DeleteProduct(ctx, tee.ID))
+	_, err = s.GetProductByID(ctx, red.ID)
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	purged, err := s.PurgeDeletedProducts(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 3, purged)
+	levels, err := s.ListLocationStock(ctx, []int64{red.ID})
+	require.NoError(t, err)
+	assert.Empty(t, levels)
+}
+
+func TestMemoryStore_ProductMedia(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	legacyURL := "https://cdn.example.com/legacy.jpg"
+	scarf, err := s.CreateProduct(ctx, &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), ImageURL: &legacyURL, IsActive: true})
+	require.NoError(t, err)
+
+	front, err := s.AddProductMedia(ctx, &domain.ProductMedia{ProductID: scarf.ID, URL: "https://cdn.example.com/front.jpg", Role: domain.MediaRoleGallery})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), front.Position)
+	back, err := s.AddProductMedia(ctx, &domain.ProductMedia{ProductID: scarf.ID, URL: "https://cdn.example.com/back.jpg", Role: domain.MediaRolePrimary, AltText: "Back"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), back.Position)
+	_, err = s.AddProductMedia(ctx, &domain.ProductMedia{ProductID: scarf.ID, URL: "https://cdn.example.com/thumb.jpg", Role: domain.MediaRoleThumbnail, Position: 1})
+	require.NoError(t, err)
+
+	product, err := s.GetProductByID(ctx, scarf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/back.jpg", *product.ImageURL, "the primary image")
+
+	// A product update keeps the derived image URL.
+	otherURL := "https://cdn.example.com/other.jpg"
+	product.ImageURL = &otherURL
+	product, err = s.UpdateProduct(ctx, product)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/back.jpg", *product.ImageURL)
+
+	// A new primary image moves the previous one to the gallery.
+	front.Role = domain.MediaRolePrimary
+	front.Position = 0
+	front, err = s.UpdateProductMedia(ctx, front)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), front.Position, "kept")
+	media, err := s.ListProductMedia(ctx, scarf.ID)
+	require.NoError(t, err)
+	require.Len(t, media, 3)
+	assert.Equal(t, []domain.MediaRole{domain.MediaRolePrimary, domain.MediaRoleThumbnail, domain.MediaRoleGallery},
+		[]domain.MediaRole{media[0].Role, media[1].Role, media[2].Role}, "by position, then ID")
+
+	// Without a primary image the first gallery image is the image URL; without either there is none.
+	require.NoError(t, s.DeleteProductMedia(ctx, scarf.ID, front.ID))
+	product, err = s.GetProductByID(ctx, scarf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/back.jpg", *product.ImageURL)
+	require.NoError(t, s.DeleteProductMedia(ctx, scarf.ID, back.ID))
+	product, err = s.GetProductByID(ctx, scarf.ID)
+	require.NoError(t, err)
+	assert.Nil(t, product.ImageURL, "thumbnails are not the product image")
+
+	assert.ErrorIs(t, s.DeleteProductMedia(ctx, scarf.ID, back.ID), ErrMediaNotFound)
+	_, err = s.AddProductMedia(ctx, &domain.ProductMedia{ProductID: scarf.ID, URL: "https://cdn.example.com/x.jpg", Role: "banner"})
+	assert.ErrorIs(t, err, ErrInvalidMedia)
+	_, err = s.AddProductMedia(ctx, &domain.ProductMedia{ProductID: 99, URL: "https://cdn.example.com/x.jpg", Role: domain.MediaRoleGallery})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+
+	byProduct, err := s.ListMedia(ctx, []int64{scarf.ID, 99})
+	require.NoError(t, err)
+	assert.Len(t, byProduct, 1)
+	require.NoError(t, s.DeleteProduct(ctx, scarf.ID))
+	_, err = s.PurgeDeletedProducts(ctx, time.Now())
+	require.NoError(t, err)
+	byProduct, err = s.ListMedia(ctx, []int64{scarf.ID})
+	require.NoError(t, err)
+	assert.Empty(t, byProduct, "purged with the product")
+}
+
+func TestMemoryStore_ImportProducts(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	scarf, err := s.CreateProduct(ctx, &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(1999), StockQuantity: 5, IsActive: true})
+	require.NoError(t, err)
+	missingCategory := int64(42)
+	products := []*domain.Product{
+		{Name: "Red Scarf", SKU: "SCARF", Price: usd(2499), StockQuantity: 7, IsActive: true},
+		{Name: "Hat", SKU: "HAT", Price: usd(999), IsActive: true},
+		{Name: "Gloves", SKU: "GLOVES", Price: usd(1499), CategoryID: &missingCategory},
+	}
+
+	// A dry run reports without writing.
+	results, err := s.ImportProducts(ctx, products, true)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, ProductImportResult{ProductID: scarf.ID}, results[0])
+	assert.Equal(t, ProductImportResult{Created: true}, results[1])
+	assert.ErrorIs(t, results[2].Err, ErrCategoryNotFound)
+	stored, err := s.GetProductByID(ctx, scarf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Scarf", stored.Name)
+	_, total, err := s.ListProducts(ctx, ListProductsParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	results, err = s.ImportProducts(ctx, products, false)
+	require.NoError(t, err)
+	assert.Equal(t, ProductImportResult{ProductID: scarf.ID}, results[0])
+	assert.True(t, results[1].Created)
+	assert.NotZero(t, results[1].ProductID)
+	assert.ErrorIs(t, results[2].Err, ErrCategoryNotFound)
+	stored, err = s.GetProductByID(ctx, scarf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Red Scarf", stored.Name)
+	assert.Equal(t, int32(7), stored.StockQuantity)
+	movements, _, err := s.ListStockMovements(ctx, ListStockMovementsParams{ProductID: scarf.ID, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, movements, 2, "stock changes are recorded like UpdateProduct's")
+	hat, err := s.GetProductByID(ctx, results[1].ProductID)
+	require.NoError(t, err)
+	assert.Equal(t, "HAT", hat.SKU)
+	_, total, err = s.ListProducts(ctx, ListProductsParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total, "the failed product is skipped")
+}
+
+func TestMemoryStore_ExportProducts(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	for i := 0; i < ExportBatchSize+2; i++ {
+		_, err := s.CreateProduct(ctx, &domain.Product{Name: "Sock", SKU: "SOCK-" + strconv.Itoa(i), Price: usd(499), IsActive: i%2 == 0})
+		require.NoError(t, err)
+	}
+
+	var batches []int
+	var lastID int64
+	isActive := true
+	err := s.ExportProducts(ctx, ListProductsParams{IsActive: &isActive, Limit: 1}, func(batch []domain.Product) error {
+		batches = append(batches, len(batch))
+		for _, p := range batch {
+			assert.Greater(t, p.ID, lastID, "products come in ID order")
+			assert.True(t, p.IsActive)
+			lastID = p.ID
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ExportBatchSize/2 + 1}, batches, "the limit is ignored")
+
+	batches = nil
+	err = s.ExportProducts(ctx, ListProductsParams{}, func(batch []domain.Product) error {
+		batches = append(batches, len(batch))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{ExportBatchSize, 2}, batches)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = s.ExportProducts(ctx, ListProductsParams{}, func([]domain.Product) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
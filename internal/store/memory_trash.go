@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// withDeleted returns the rows f selects among the live and the deleted ones, unordered.
+func withDeleted[T any](f DeletedFilter, live, deleted map[int64]*T) []*T {
+	var rows []*T
+	if f != DeletedOnly {
+		for _, row := range live {
+			rows = append(rows, row)
+		}
+	}
+	if f != DeletedExcluded {
+		for _, row := range deleted {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// trashProductLocked moves a product to the trash. Callers must hold s.mu for writing.
+func (s *MemoryStore) trashProductLocked(id int64, now time.Time) {
+	p := s.products[id]
+	p.DeletedAt = cloneTime(&now)
+	delete(s.products, id)
+	s.deletedProducts[id] = p
+}
+
+// trashCategoryLocked moves a category to the trash, keeping its attribute definitions. Callers
+// must hold s.mu for writing.
+func (s *MemoryStore) trashCategoryLocked(id int64, now time.Time) {
+	c := s.categories[id]
+	c.DeletedAt = cloneTime(&now)
+	delete(s.categories, id)
+	s.deletedCategories[id] = c
+}
+
+// --- Trash part of the ProductStorer Implementation ---
+
+// RestoreProduct mirrors the checks PostgresStore leaves to its unique indexes.
+func (s *MemoryStore) RestoreProduct(ctx context.Context, id int64) (*domain.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	product, ok := s.deletedProducts[id]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	if product.ParentID != nil {
+		if _, ok := s.products[*product.ParentID]; !ok {
+			return nil, restoreBlocked("parent product %d is in the trash", *product.ParentID)
+		}
+	}
+	restored := []*domain.Product{product}
+	for _, p := range s.deletedProducts {
+		if p.ParentID != nil && *p.ParentID == id && p.DeletedAt.Equal(*product.DeletedAt) {
+			restored = append(restored, p)
+		}
+	}
+	for _, r := range restored {
+		for _, p := range s.products {
+			if p.SKU == r.SKU {
+				return nil, ErrProductSKUExists
+			}
+			if r.ParentID != nil && p.ParentID != nil && *p.ParentID == *r.ParentID && maps.Equal(p.OptionValues, r.OptionValues) {
+				return nil, ErrVariantExists
+			}
+		}
+	}
+	for _, r := range restored {
+		if r.CategoryID != nil {
+			if _, ok := s.categories[*r.CategoryID]; !ok {
+				return nil, restoreBlocked("category %d is in the trash", *r.CategoryID)
+			}
+		}
+	}
+
+	for _, r := range restored {
+		r.DeletedAt = nil
+		delete(s.deletedProducts, r.ID)
+		s.products[r.ID] = r
+	}
+	return s.effectiveProductLocked(product, time.Now()), nil
+}
+
+func (s *MemoryStore) PurgeDeletedProducts(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for id, p := range s.deletedProducts {
+		if !p.DeletedAt.After(before) {
+			s.deleteProductLocked(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// --- Trash part of the CategoryStorer Implementation ---
+
+func (s *MemoryStore) RestoreCategory(ctx context.Context, id int64) (*domain.Category, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	category, ok := s.deletedCategories[id]
+	if !ok {
+		return nil, ErrCategoryNotFound
+	}
+	if category.ParentCategoryID != nil {
+		if _, ok := s.categories[*category.ParentCategoryID]; !ok {
+			return nil, restoreBlocked("parent category %d is in the trash", *category.ParentCategoryID)
+		}
+	}
+	// The descendants deleted together with the category, walked down through the trash.
+	restored := []*domain.Category{category}
+	for i := 0; i < len(restored); i++ {
+		for _, c := range s.deletedCategories {
+			if c.ParentCategoryID != nil && *c.ParentCategoryID == restored[i].ID && c.DeletedAt.Equal(*category.DeletedAt) {
+				restored = append(restored, c)
+			}
+		}
+	}
+	for _, r := range restored {
+		if s.categoryNameTaken(r.Name, 0) {
+			return nil, ErrCategoryNameExists
+		}
+	}
+
+	for _, r := range restored {
+		r.DeletedAt = nil
+		delete(s.deletedCategories, r.ID)
+		s.categories[r.ID] = r
+	}
+	return cloneCategory(category), nil
+}
+
+// PurgeDeletedCategories mirrors ON DELETE SET NULL on the references to the purged categories
+// and ON DELETE CASCADE on their attribute definitions.
+func (s *MemoryStore) PurgeDeletedCategories(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for id, c := range s.deletedCategories {
+		if c.DeletedAt.After(before) {
+			continue
+		}
+		for _, child := range withDeleted(DeletedIncluded, s.categories, s.deletedCategories) {
+			if child.ParentCategoryID != nil && *child.ParentCategoryID == id {
+				child.ParentCategoryID = nil
+			}
+		}
+		for _, p := range withDeleted(DeletedIncluded, s.products, s.deletedProducts) {
+			if p.CategoryID != nil && *p.CategoryID == id {
+				p.CategoryID = nil
+			}
+		}
+		delete(s.deletedCategories, id)
+		delete(s.categoryAttrs, id)
+		purged++
+	}
+	return purged, nil
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Variant part of the ProductStorer Implementation ---
+
+func (s *MemoryStore) ListVariants(ctx context.Context, parentIDs []int64) (map[int64][]domain.Product, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[int64]bool, len(parentIDs))
+	for _, id := range parentIDs {
+		wanted[id] = true
+	}
+	now := time.Now()
+	variants := make(map[int64][]domain.Product)
+	for _, p := range s.products {
+		if p.ParentID != nil && wanted[*p.ParentID] {
+			variants[*p.ParentID] = append(variants[*p.ParentID], *s.effectiveProductLocked(p, now))
+		}
+	}
+	for _, list := range variants {
+		sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	}
+	return variants, nil
+}
+
+// checkVariantLocked runs checkVariant for product against the stored state. It mirrors the
+// constraints of migration 0013, including the unique option values per parent. Callers must hold
+// s.mu.
+func (s *MemoryStore) checkVariantLocked(product *domain.Product) error {
+	var parent *domain.Product
+	if product.ParentID != nil {
+		parent = s.products[*product.ParentID]
+	}
+	var storedOptions []string
+	if stored, ok := s.products[product.ID]; ok {
+		storedOptions = stored.Options
+	}
+	if err := checkVariant(product, parent, s.hasVariantsLocked(product.ID), storedOptions); err != nil {
+		return err
+	}
+	if product.ParentID == nil {
+		return nil
+	}
+	for _, p := range s.products {
+		if p.ID != product.ID && p.ParentID != nil && *p.ParentID == *product.ParentID && maps.Equal(p.OptionValues, product.OptionValues) {
+			return ErrVariantExists
+		}
+	}
+	return nil
+}
+
+// hasVariantsLocked reports whether id has variants, including those in the trash. Callers must hold
+// s.mu.
+func (s *MemoryStore) hasVariantsLocked(id int64) bool {
+	for _, p := range withDeleted(DeletedIncluded, s.products, s.deletedProducts) {
+		if p.ParentID != nil && *p.ParentID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// passOnParentPriceLocked gives the variants of parent that inherit its price its new base price,
+// recording the changes in the price history. Variants in the trash get it too. Callers must hold
+// s.mu for writing.
+func (s *MemoryStore) passOnParentPriceLocked(ctx context.Context, parent *domain.Product, now time.Time) {
+	var inheriting []*domain.Product
+	for _, p := range withDeleted(DeletedIncluded, s.products, s.deletedProducts) {
+		if p.ParentID != nil && *p.ParentID == parent.ID && p.InheritsPrice && p.Price != parent.Price {
+			inheriting = append(inheriting, p)
+		}
+	}
+	sort.Slice(inheriting, func(i, j int) bool { return inheriting[i].ID < inheriting[j].ID }) // History in ID order
+	for _, p := range inheriting {
+		oldPrice := p.Price
+		p.Price = parent.Price
+		p.UpdatedAt = now
+		s.appendPriceChangeLocked(ctx, &domain.PriceChange{
+			ProductID: p.ID, OldPrice: &oldPrice, NewPrice: p.Price, EffectiveAt: now, Reason: domain.PriceReasonProductUpdate,
+		}, now)
+	}
+}
@@ -21,10 +21,13 @@ var (
 	ErrProductNotFound    = errors.New("store: product not found")
 	ErrProductSKUExists   = errors.New("store: product SKU already exists")
 	ErrInsufficientStock  = errors.New("store: insufficient stock or update constraint violation")
+	ErrInvalidPrice       = errors.New("store: price must not be negative or exceed 999999999999.999")
 	ErrUpdateFailed       = errors.New("store: update failed, 0 rows affected")
+	ErrStockBatchAborted  = errors.New("store: stock update not applied because another item in the batch failed")
 )
 
-// PostgresStore implements the CategoryStorer and ProductStorer interfaces using PostgreSQL.
+// PostgresStore implements the CategoryStorer, ProductStorer, LocationStorer, ReservationStorer and
+// IdempotencyStorer interfaces using PostgreSQL.
 type PostgresStore struct {
 	db *sql.DB
 }
@@ -36,10 +39,13 @@ func NewPostgresStore(db *sql.DB) *PostgresStore {
 
 // --- CategoryStorer Implementation ---
 
+// CreateCategory locks the parent, if any, against being deleted (FOR SHARE) and inserts nothing,
+// failing with ErrCategoryNotFound, if it does not exist or is in the trash.
 func (s *PostgresStore) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
 	query := `
 		INSERT INTO products.categories (name, description, parent_category_id)
-		VALUES ($1, $2, $3)
+		SELECT $1::text, $2::text, $3::bigint
+		WHERE $3::bigint IS NULL OR EXISTS (SELECT 1 FROM products.categories WHERE id = $3 AND deleted_at IS NULL FOR SHARE)
 		RETURNING id, name, description, parent_category_id, created_at, updated_at;
 	`
 	row := s.db.QueryRowContext(ctx, query, category.Name, category.Description, category.ParentCategoryID)
@@ -54,6 +60,9 @@ func (s *PostgresStore) CreateCategory(ctx context.Context, category *domain.Cat
 		&createdCategory.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCategoryNotFound // The parent does not exist
+		}
 		var pqErr *pq.Error
 		if errors.As(err, &pqErr) && pqErr.Code == "23505" { // Unique violation
 			// Assuming the unique constraint is on 'name' for categories
@@ -66,13 +75,34 @@ func (s *PostgresStore) CreateCategory(ctx context.Context, category *domain.Cat
 	return &createdCategory, nil
 }
 
-// ListCategories retrieves a paginated list of categories.
-// Note: Filtering capabilities (e.g., by parent_category_id) would require dynamic query building
-// similar to ListProducts if ListCategoriesParams is extended.
+// ListCategories retrieves a paginated list of categories, ordered by name and then ID, optionally
+// only the subcategories of params.ParentCategoryID.
 func (s *PostgresStore) ListCategories(ctx context.Context, params ListCategoriesParams) ([]domain.Category, int, error) {
-	countQuery := `SELECT COUNT(*) FROM products.categories;` // Simple count, no filters yet in ListCategoriesParams
+	if params.After != nil {
+		if err := params.After.checkCategoryCursor(); err != nil {
+			return nil, 0, err
+		}
+	}
+	// The count has the filters of the query, with the parent as its only argument.
+	queryArgs := []interface{}{params.Limit, params.Offset}
+	var countArgs []interface{}
+	var conditions, countConditions []string
+	if params.ParentCategoryID != nil {
+		queryArgs = append(queryArgs, *params.ParentCategoryID)
+		conditions = append(conditions, fmt.Sprintf("parent_category_id = $%d", len(queryArgs)))
+		countArgs = append(countArgs, *params.ParentCategoryID)
+		countConditions = append(countConditions, "parent_category_id = $1")
+	}
+	if deleted := deletedCondition(params.Deleted); deleted != "" {
+		conditions = append(conditions, deleted)
+		countConditions = append(countConditions, deleted)
+	}
+	countQuery := `SELECT COUNT(*) FROM products.categories;`
+	if len(countConditions) > 0 {
+		countQuery = `SELECT COUNT(*) FROM products.categories WHERE ` + strings.Join(countConditions, " AND ") + `;`
+	}
 	var totalCount int
-	if err := s.db.QueryRowContext(ctx, countQuery).Scan(&totalCount); err != nil {
+	if err := s.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount); err != nil {
 		return nil, 0, fmt.Errorf("store: ListCategories failed to count categories: %w", err)
 	}
 
@@ -80,13 +110,22 @@ func (s *PostgresStore) ListCategories(ctx context.Context, params ListCategorie
 		return []domain.Category{}, 0, nil
 	}
 
+	if params.After != nil { // Keyset: continue right after the cursor's (name, id)
+		queryArgs = append(queryArgs, params.After.Key, params.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(name, id) > ($%d, $%d)", len(queryArgs)-1, len(queryArgs)))
+	}
+	var where string
+	if len(conditions) > 0 {
+		where = `
+		WHERE ` + strings.Join(conditions, " AND ")
+	}
 	query := `
-		SELECT id, name, description, parent_category_id, created_at, updated_at
-		FROM products.categories
-		ORDER BY name ASC -- Default sort order
+		SELECT id, name, description, parent_category_id, created_at, updated_at, deleted_at
+		FROM products.categories` + where + `
+		ORDER BY name ASC, id ASC -- Default sort order
 		LIMIT $1 OFFSET $2;
 	`
-	rows, err := s.db.QueryContext(ctx, query, params.Limit, params.Offset)
+	rows, err := s.db.QueryContext(ctx, query, queryArgs...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("store: ListCategories failed to query categories: %w", err)
 	}
@@ -95,7 +134,7 @@ func (s *PostgresStore) ListCategories(ctx context.Context, params ListCategorie
 	categories := make([]domain.Category, 0, params.Limit)
 	for rows.Next() {
 		var c domain.Category
-		if err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.ParentCategoryID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.ParentCategoryID, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt); err != nil {
 			return nil, 0, fmt.Errorf("store: ListCategories failed to scan category row: %w", err)
 		}
 		categories = append(categories, c)
@@ -111,7 +150,7 @@ func (s *PostgresStore) GetCategoryByID(ctx context.Context, id int64) (*domain.
 	query := `
 		SELECT id, name, description, parent_category_id, created_at, updated_at
 		FROM products.categories
-		WHERE id = $1;
+		WHERE id = $1 AND deleted_at IS NULL;
 	`
 	var category domain.Category
 	err := s.db.QueryRowContext(ctx, query, id).Scan(
@@ -131,15 +170,48 @@ func (s *PostgresStore) GetCategoryByID(ctx context.Context, id int64) (*domain.
 	return &category, nil
 }
 
+// UpdateCategory rejects a parent that is the category itself or one of its descendants with
+// ErrCategoryCycle. Moves are serialized by lockCategoryHierarchy, so two concurrent moves cannot
+// form a cycle together.
 func (s *PostgresStore) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
 	query := `
 		UPDATE products.categories
 		SET name = $1, description = $2, parent_category_id = $3, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $4
+		WHERE id = $4 AND deleted_at IS NULL
 		RETURNING id, name, description, parent_category_id, created_at, updated_at;
 	`
+	if category.ParentCategoryID != nil && *category.ParentCategoryID == category.ID {
+		return nil, ErrCategoryCycle
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: UpdateCategory failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if category.ParentCategoryID != nil {
+		if err := lockCategoryHierarchy(ctx, tx); err != nil {
+			return nil, fmt.Errorf("store: UpdateCategory: %w", err)
+		}
+		var parentExists bool
+		if err := tx.QueryRowContext(ctx, liveCategoryQuery, *category.ParentCategoryID).Scan(&parentExists); err != nil {
+			return nil, fmt.Errorf("store: UpdateCategory failed to check parent category: %w", err)
+		}
+		if !parentExists {
+			return nil, ErrCategoryNotFound // The parent does not exist
+		}
+		var cycle bool
+		cycleQuery := categoryAncestryCTE + ` SELECT EXISTS (SELECT 1 FROM ancestry WHERE id = $2);`
+		if err := tx.QueryRowContext(ctx, cycleQuery, *category.ParentCategoryID, category.ID).Scan(&cycle); err != nil {
+			return nil, fmt.Errorf("store: UpdateCategory failed to check for a cycle: %w", err)
+		}
+		if cycle {
+			return nil, ErrCategoryCycle
+		}
+	}
+
 	var updatedCategory domain.Category
-	err := s.db.QueryRowContext(ctx, query, category.Name, category.Description, category.ParentCategoryID, category.ID).Scan(
+	err = tx.QueryRowContext(ctx, query, category.Name, category.Description, category.ParentCategoryID, category.ID).Scan(
 		&updatedCategory.ID,
 		&updatedCategory.Name,
 		&updatedCategory.Description,
@@ -157,36 +229,153 @@ func (s *PostgresStore) UpdateCategory(ctx context.Context, category *domain.Cat
 				return nil, ErrCategoryNameExists
 			}
 		}
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "categories_parent_category_id_fkey" {
+			return nil, ErrCategoryNotFound // The parent does not exist
+		}
 		return nil, fmt.Errorf("store: UpdateCategory failed to scan row: %w", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: UpdateCategory failed to commit transaction: %w", err)
+	}
 	return &updatedCategory, nil
 }
 
-func (s *PostgresStore) DeleteCategory(ctx context.Context, id int64) error {
-	query := `DELETE FROM products.categories WHERE id = $1;`
-	result, err := s.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("store: DeleteCategory failed to execute delete: %w", err)
+// DeleteCategory moves a category to the trash, treating its subcategories and products as opts
+// selects. The category is marked first, so that subcategories and products added concurrently
+// (which lock their category, see CreateCategory and checkProductAttributes) are either seen by the
+// strategy or rejected. Products in the trash move with the others, except that a cascade leaves
+// them in the deleted categories, which restoring the categories gives back to them.
+func (s *PostgresStore) DeleteCategory(ctx context.Context, id int64, opts DeleteCategoryOptions) error {
+	if err := opts.validate(id); err != nil {
+		return err
 	}
-	rowsAffected, err := result.RowsAffected()
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		// This error is less common for RowsAffected after a successful Exec, but good to check
-		return fmt.Errorf("store: DeleteCategory failed to get rows affected: %w", err)
+		return fmt.Errorf("store: DeleteCategory failed to begin transaction: %w", err)
 	}
-	if rowsAffected == 0 {
+	defer tx.Rollback() // No-op once committed
+
+	if err := lockCategoryHierarchy(ctx, tx); err != nil {
+		return fmt.Errorf("store: DeleteCategory: %w", err)
+	}
+	var parentID *int64
+	err = tx.QueryRowContext(ctx, `
+		UPDATE products.categories SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING parent_category_id;`, id).Scan(&parentID)
+	if errors.Is(err, sql.ErrNoRows) {
 		return ErrCategoryNotFound
 	}
+	if err != nil {
+		return fmt.Errorf("store: DeleteCategory failed to execute delete: %w", err)
+	}
+
+	switch opts.Strategy {
+	case CategoryDeleteReparent, CategoryDeleteMoveProducts:
+		productsTo := parentID
+		if opts.Strategy == CategoryDeleteMoveProducts {
+			var exists bool
+			if err := tx.QueryRowContext(ctx, liveCategoryQuery, *opts.TargetCategoryID).Scan(&exists); err != nil {
+				return fmt.Errorf("store: DeleteCategory failed to check target category: %w", err)
+			}
+			if !exists {
+				return missingDeleteTarget(*opts.TargetCategoryID)
+			}
+			productsTo = opts.TargetCategoryID
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE products.categories SET parent_category_id = $2, updated_at = CURRENT_TIMESTAMP
+			WHERE parent_category_id = $1;`, id, parentID); err != nil {
+			return fmt.Errorf("store: DeleteCategory failed to move subcategories: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE products.products SET category_id = $2, updated_at = CURRENT_TIMESTAMP
+			WHERE category_id = $1;`, id, productsTo); err != nil {
+			return fmt.Errorf("store: DeleteCategory failed to move products: %w", err)
+		}
+	case CategoryDeleteCascade:
+		// The descendants get the category's deletion time, by which RestoreCategory finds them.
+		walk, err := walkCategories(ctx, tx, "DeleteCategory", categorySubtreeQuery(`id = $2`), 0, id)
+		if err != nil {
+			return err
+		}
+		deleteIDs := make([]int64, 0, len(walk))
+		for _, row := range walk {
+			deleteIDs = append(deleteIDs, row.category.ID)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE products.categories SET deleted_at = CURRENT_TIMESTAMP
+			WHERE id = ANY($1) AND deleted_at IS NULL;`, pq.Array(deleteIDs)); err != nil {
+			return fmt.Errorf("store: DeleteCategory failed to delete subcategories: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE products.products SET category_id = NULL, updated_at = CURRENT_TIMESTAMP
+			WHERE category_id = ANY($1) AND deleted_at IS NULL;`, pq.Array(deleteIDs)); err != nil {
+			return fmt.Errorf("store: DeleteCategory failed to uncategorize products: %w", err)
+		}
+	default: // CategoryDeleteRestrict; subcategories and products in the trash keep pointing to the category
+		var inUse bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM products.categories WHERE parent_category_id = $1 AND deleted_at IS NULL)
+				OR EXISTS (SELECT 1 FROM products.products WHERE category_id = $1 AND deleted_at IS NULL);`, id).Scan(&inUse); err != nil {
+			return fmt.Errorf("store: DeleteCategory failed to check for contents: %w", err)
+		}
+		if inUse {
+			return ErrCategoryNotEmpty
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: DeleteCategory failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
 // --- ProductStorer Implementation ---
 
+// productCheckError maps the violations of the named CHECK constraints of migration 0001 on insert
+// or update, and an overflow of the NUMERIC(15,3) price column, and returns nil for other errors.
+func productCheckError(err error) error {
+	var pqErr *pq.Error
+	switch {
+	case !errors.As(err, &pqErr):
+		return nil
+	case pqErr.Code == "22003" && strings.HasPrefix(pqErr.Message, "numeric"): // Numeric field overflow
+		return ErrInvalidPrice
+	case pqErr.Code != "23514":
+		return nil
+	case pqErr.Constraint == "products_price_check":
+		return ErrInvalidPrice
+	case pqErr.Constraint == "products_stock_quantity_check":
+		return ErrInsufficientStock
+	}
+	return nil
+}
+
 func (s *PostgresStore) CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: CreateProduct failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	createdProduct, err := createProduct(ctx, tx, product)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: CreateProduct failed to commit: %w", err)
+	}
+	return createdProduct, nil
+}
+
+// createProduct inserts product within tx, as CreateProduct and ImportProducts do.
+func createProduct(ctx context.Context, tx *sql.Tx, product *domain.Product) (*domain.Product, error) {
 	query := `
 		INSERT INTO products.products 
-			(name, description, sku, price, stock_quantity, category_id, image_url, is_active, attributes)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, name, description, sku, price, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at;
+			(name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, parent_id, options, option_values, inherits_price)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at;
 	`
 	var attributesJSON []byte // For handling nullable JSONB
 	if product.Attributes != nil && len(*product.Attributes) > 0 {
@@ -195,18 +384,38 @@ func (s *PostgresStore) CreateProduct(ctx context.Context, product *domain.Produ
         attributesJSON = []byte("null") // Or []byte("{}") if you prefer empty object over SQL NULL
     }
 
+	if err := checkProductAttributes(ctx, tx, product); err != nil {
+		return nil, schemaCheckError("CreateProduct", err)
+	}
+	checked := *product // checkVariant sets the price of a variant that inherits it
+	product = &checked
+	var parent *domain.Product
+	if product.ParentID != nil {
+		var err error
+		if parent, err = lockVariantParent(ctx, tx, *product.ParentID); err != nil {
+			return nil, fmt.Errorf("store: CreateProduct: %w", err)
+		}
+	}
+	if err := checkVariant(product, parent, false, nil); err != nil {
+		return nil, err
+	}
+	options, optionValues, err := variantArgs(product)
+	if err != nil {
+		return nil, fmt.Errorf("store: CreateProduct: %w", err)
+	}
 
-	row := s.db.QueryRowContext(ctx, query,
-		product.Name, product.Description, product.SKU, product.Price, product.StockQuantity,
-		product.CategoryID, product.ImageURL, product.IsActive, attributesJSON,
+	row := tx.QueryRowContext(ctx, query,
+		product.Name, product.Description, product.SKU, storedPrice(product.Price).Decimal(), storedPrice(product.Price).Currency, product.StockQuantity,
+		product.CategoryID, product.ImageURL, product.IsActive, attributesJSON, product.ParentID, options, optionValues, product.InheritsPrice,
 	)
 
 	var createdProduct domain.Product
 	var scannedAttributes sql.NullString // Use sql.NullString for attributes to handle SQL NULL properly
+	var scannedPrice string
 
-	err := row.Scan(
+	err = row.Scan(
 		&createdProduct.ID, &createdProduct.Name, &createdProduct.Description, &createdProduct.SKU,
-		&createdProduct.Price, &createdProduct.StockQuantity, &createdProduct.CategoryID, &createdProduct.ImageURL,
+		&scannedPrice, &createdProduct.Price.Currency, &createdProduct.StockQuantity, &createdProduct.CategoryID, &createdProduct.ImageURL,
 		&createdProduct.IsActive, &scannedAttributes,
 		&createdProduct.CreatedAt, &createdProduct.UpdatedAt,
 	)
@@ -218,8 +427,37 @@ func (s *PostgresStore) CreateProduct(ctx context.Context, product *domain.Produ
 				return nil, ErrProductSKUExists
 			}
 		}
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "products_category_id_fkey" { // FK violation
+			return nil, ErrCategoryNotFound
+		}
+		if variantErr := variantWriteError(err); variantErr != nil {
+			return nil, variantErr
+		}
+		if checkErr := productCheckError(err); checkErr != nil {
+			return nil, checkErr
+		}
 		return nil, fmt.Errorf("store: CreateProduct failed to scan row: %w", err)
 	}
+	if err := setScannedPrice(&createdProduct, scannedPrice); err != nil {
+		return nil, fmt.Errorf("store: CreateProduct: %w", err)
+	}
+	setVariantFields(&createdProduct, product)
+	initialPrice := &domain.PriceChange{
+		ProductID: createdProduct.ID, NewPrice: createdProduct.Price, EffectiveAt: createdProduct.CreatedAt, Reason: domain.PriceReasonInitialPrice,
+	}
+	if err := insertPriceChange(ctx, tx, initialPrice); err != nil {
+		return nil, fmt.Errorf("store: CreateProduct: %w", err)
+	}
+	if createdProduct.StockQuantity != 0 {
+		locations, err := listLocations(ctx, tx)
+		if err != nil {
+			return nil, fmt.Errorf("store: CreateProduct: %w", err)
+		}
+		levels := newStockLevels(locations, map[int64]int32{createdProduct.ID: 0}, nil)
+		if err := recordStockEdit(ctx, tx, levels, createdProduct.ID, createdProduct.StockQuantity, domain.StockReasonInitialStock); err != nil {
+			return nil, fmt.Errorf("store: CreateProduct: %w", err)
+		}
+	}
 
 	if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
 		rawMsg := json.RawMessage(scannedAttributes.String)
@@ -229,54 +467,136 @@ func (s *PostgresStore) CreateProduct(ctx context.Context, product *domain.Produ
 	return &createdProduct, nil
 }
 
-func (s *PostgresStore) ListProducts(ctx context.Context, params ListProductsParams) ([]domain.Product, int, error) {
-	var queryArgs []interface{}
-	var whereClauses []string
-	argID := 1
-
-	if params.SearchQuery != nil && *params.SearchQuery != "" {
-		// Search in name OR description
-		whereClauses = append(whereClauses, fmt.Sprintf("(name ILIKE $%d OR description ILIKE $%d)", argID, argID+1))
-		searchTerm := "%" + *params.SearchQuery + "%"
-		queryArgs = append(queryArgs, searchTerm, searchTerm)
-		argID += 2
-	}
-	if params.CategoryID != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("category_id = $%d", argID))
-		queryArgs = append(queryArgs, *params.CategoryID)
-		argID++
+// Expressions available in ListProducts queries that join the search subquery.
+const (
+	searchRankExpr    = `ts_rank(search_vector, search.query)`
+	searchSnippetExpr = `ts_headline(search.language, coalesce(nullif(description, ''), name), search.query, 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15')`
+)
+
+// productFilter is the FROM and WHERE part of a product listing query, shared by ListProducts and
+// ListProductFacets, together with its arguments.
+type productFilter struct {
+	from      string   // effectiveProducts, joined with the search query when searching
+	where     []string // Conditions, joined with AND
+	args      []interface{}
+	searching bool
+}
+
+// newProductFilter translates the filters of params (not the sorting or pagination); its attribute
+// filters must have been validated. Every value, including attribute keys, is passed as an argument.
+func newProductFilter(params ListProductsParams) *productFilter {
+	f := &productFilter{from: " FROM " + effectiveProducts}
+	switch params.Deleted {
+	case DeletedIncluded:
+		f.from = " FROM " + allEffectiveProducts
+	case DeletedOnly:
+		f.from = " FROM " + allEffectiveProducts
+		f.where = append(f.where, "deleted_at IS NOT NULL")
+	}
+	if params.SearchQuery != nil && strings.TrimSpace(*params.SearchQuery) != "" {
+		// Full-text search on the weighted search_vector (see migration 0006), in the configured language.
+		f.searching = true
+		f.from += fmt.Sprintf(" CROSS JOIN (SELECT language, websearch_to_tsquery(language, %s) AS query FROM products.search_config) search", f.arg(*params.SearchQuery))
+		f.where = append(f.where, "search_vector @@ search.query")
 	}
+	if params.CategoryID != nil && params.IncludeDescendants {
+		f.where = append(f.where, "category_id IN ("+categorySubtreeIDsQuery(f.arg(*params.CategoryID))+")")
+	} else if params.CategoryID != nil {
+		f.where = append(f.where, "category_id = "+f.arg(*params.CategoryID))
+	}
+	// Price bounds are exact decimals and only match effective prices in their own currency.
 	if params.MinPrice != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("price >= $%d", argID))
-		queryArgs = append(queryArgs, *params.MinPrice)
-		argID++
+		f.where = append(f.where, "currency = "+f.arg(params.MinPrice.Currency), "price >= "+f.arg(params.MinPrice.Decimal())+"::numeric")
 	}
 	if params.MaxPrice != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("price <= $%d", argID))
-		queryArgs = append(queryArgs, *params.MaxPrice)
-		argID++
+		f.where = append(f.where, "currency = "+f.arg(params.MaxPrice.Currency), "price <= "+f.arg(params.MaxPrice.Decimal())+"::numeric")
 	}
 	if params.IsActive != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("is_active = $%d", argID))
-		queryArgs = append(queryArgs, *params.IsActive)
-		argID++
+		f.where = append(f.where, "is_active = "+f.arg(*params.IsActive))
 	}
 	if len(params.ProductIDs) > 0 {
 		placeholders := make([]string, len(params.ProductIDs))
 		for i, pid := range params.ProductIDs {
-			placeholders[i] = fmt.Sprintf("$%d", argID+i)
-			queryArgs = append(queryArgs, pid)
+			placeholders[i] = f.arg(pid)
 		}
-		whereClauses = append(whereClauses, fmt.Sprintf("id IN (%s)", strings.Join(placeholders, ",")))
-		argID += len(params.ProductIDs)
+		f.where = append(f.where, fmt.Sprintf("id IN (%s)", strings.Join(placeholders, ",")))
+	}
+	if params.ParentID != nil {
+		f.where = append(f.where, "parent_id = "+f.arg(*params.ParentID))
 	}
+	if params.ExcludeVariants {
+		f.where = append(f.where, "parent_id IS NULL")
+	}
+	for _, attr := range params.Attributes {
+		f.where = append(f.where, f.attributeCondition(attr))
+	}
+	return f
+}
 
-	whereCondition := ""
-	if len(whereClauses) > 0 {
-		whereCondition = " WHERE " + strings.Join(whereClauses, " AND ")
+// attributeCondition translates a validated attribute filter. Equality and existence are phrased as
+// containment (@>) and key existence (?) so that the GIN index on attributes can serve them; ranges
+// are narrowed by key existence first.
+func (f *productFilter) attributeCondition(attr AttributeFilter) string {
+	switch attr.Op {
+	case AttributeExists:
+		return "attributes ? " + f.arg(attr.Key)
+	case AttributeRange:
+		key := f.arg(attr.Key)
+		// The CASE keeps the cast away from non-numeric values.
+		number := fmt.Sprintf("(CASE WHEN jsonb_typeof(attributes -> %[1]s) = 'number' THEN (attributes ->> %[1]s)::numeric END)", key)
+		conditions := []string{"attributes ? " + key}
+		if attr.Min != nil {
+			conditions = append(conditions, number+" >= "+f.arg(*attr.Min))
+		}
+		if attr.Max != nil {
+			conditions = append(conditions, number+" <= "+f.arg(*attr.Max))
+		}
+		return "(" + strings.Join(conditions, " AND ") + ")"
+	default: // AttributeEquals, AttributeIn
+		var alternatives []string
+		for _, doc := range attributeContainmentDocs(attr.Key, attr.Values) {
+			alternatives = append(alternatives, "attributes @> "+f.arg(doc)+"::jsonb")
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")"
 	}
+}
 
-	countQuery := "SELECT COUNT(*) FROM products.products" + whereCondition
+// arg adds a query argument and returns its placeholder.
+func (f *productFilter) arg(v interface{}) string {
+	f.args = append(f.args, v)
+	return fmt.Sprintf("$%d", len(f.args))
+}
+
+func (f *productFilter) whereCondition() string {
+	if len(f.where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(f.where, " AND ")
+}
+
+func (s *PostgresStore) ListProducts(ctx context.Context, params ListProductsParams) ([]domain.Product, int, error) {
+	sortField, desc := productSort(params)
+	var afterValue interface{}
+	if params.After != nil {
+		var err error
+		if afterValue, err = params.After.productSortValue(sortField, desc); err != nil {
+			return nil, 0, err
+		}
+	}
+	// Every other allowed sort field is also the name of its column.
+	sortColumn := sortField
+	if sortField == ProductSortRelevance {
+		sortColumn = searchRankExpr
+	}
+
+	if err := validateAttributeFilters(params.Attributes); err != nil {
+		return nil, 0, err
+	}
+	filter := newProductFilter(params)
+	fromClause, whereCondition := filter.from, filter.whereCondition()
+	queryArgs := filter.args
+
+	countQuery := "SELECT COUNT(*)" + fromClause + whereCondition
 	var totalCount int
 	if err := s.db.QueryRowContext(ctx, countQuery, queryArgs...).Scan(&totalCount); err != nil {
 		return nil, 0, fmt.Errorf("store: ListProducts failed to count products: %w", err)
@@ -286,32 +606,29 @@ func (s *PostgresStore) ListProducts(ctx context.Context, params ListProductsPar
 		return []domain.Product{}, 0, nil
 	}
 	
-	sortColumn := "created_at" // Default sort
-	allowedSortColumns := map[string]string{
-		"name":       "name",
-		"price":      "price",
-		"created_at": "created_at",
-		"updated_at": "updated_at",
-	}
-	if col, ok := allowedSortColumns[strings.ToLower(params.SortBy)]; ok {
-		sortColumn = col
+	sortOrder, keysetOp := "ASC", ">" // Default order
+	if desc {
+		sortOrder, keysetOp = "DESC", "<"
 	}
-
-	sortOrder := "ASC" // Default order
-	if strings.ToUpper(params.SortOrder) == "DESC" {
-		sortOrder = "DESC"
+	// The cursor only narrows the page, not the total count. Ties on the sort column are broken by ID
+	// so that the (sort key, id) pair identifies a position.
+	if params.After != nil {
+		filter.where = append(filter.where, fmt.Sprintf("(%s, id) %s (%s, %s)", sortColumn, keysetOp, filter.arg(afterValue), filter.arg(params.After.ID)))
+		whereCondition = filter.whereCondition()
 	}
 
 	dataQueryPreamble := `
-		SELECT id, name, description, sku, price, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at
-		FROM products.products
-	`
-	dataQuery := fmt.Sprintf("%s%s ORDER BY %s %s LIMIT $%d OFFSET $%d",
-		dataQueryPreamble, whereCondition, sortColumn, sortOrder, argID, argID+1)
-	
-	finalQueryArgs := append(queryArgs, params.Limit, params.Offset)
+		SELECT id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at, compare_at_price, ` + variantColumns
+	if filter.searching {
+		dataQueryPreamble += ", " + searchRankExpr + ", " + searchSnippetExpr
+	}
+	if params.Deleted != DeletedExcluded {
+		dataQueryPreamble += ", deleted_at"
+	}
+	dataQuery := fmt.Sprintf("%s%s%s ORDER BY %s %s, id %s LIMIT %s OFFSET %s",
+		dataQueryPreamble, fromClause, whereCondition, sortColumn, sortOrder, sortOrder, filter.arg(params.Limit), filter.arg(params.Offset))
 
-	rows, err := s.db.QueryContext(ctx, dataQuery, finalQueryArgs...)
+	rows, err := s.db.QueryContext(ctx, dataQuery, filter.args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("store: ListProducts failed to query products: %w", err)
 	}
@@ -320,14 +637,33 @@ func (s *PostgresStore) ListProducts(ctx context.Context, params ListProductsPar
 	products := make([]domain.Product, 0, params.Limit)
 	for rows.Next() {
 		var p domain.Product
-		var scannedAttributes sql.NullString
-		if err := rows.Scan(
-			&p.ID, &p.Name, &p.Description, &p.SKU, &p.Price, &p.StockQuantity,
+		var scannedAttributes, scannedCompareAt sql.NullString
+		var scannedPrice string
+		var scannedOptionValues []byte
+		dest := []interface{}{
+			&p.ID, &p.Name, &p.Description, &p.SKU, &scannedPrice, &p.Price.Currency, &p.StockQuantity,
 			&p.CategoryID, &p.ImageURL, &p.IsActive, &scannedAttributes,
-			&p.CreatedAt, &p.UpdatedAt,
-		); err != nil {
+			&p.CreatedAt, &p.UpdatedAt, &scannedCompareAt,
+			&p.ParentID, pq.Array(&p.Options), &scannedOptionValues, &p.InheritsPrice,
+		}
+		if filter.searching {
+			dest = append(dest, &p.SearchRank, &p.SearchSnippet)
+		}
+		if params.Deleted != DeletedExcluded {
+			dest = append(dest, &p.DeletedAt)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, 0, fmt.Errorf("store: ListProducts failed to scan product row: %w", err)
 		}
+		if err := setScannedPrice(&p, scannedPrice); err != nil {
+			return nil, 0, fmt.Errorf("store: ListProducts: %w", err)
+		}
+		if err := setScannedCompareAtPrice(&p, scannedCompareAt); err != nil {
+			return nil, 0, fmt.Errorf("store: ListProducts: %w", err)
+		}
+		if err := setScannedOptionValues(&p, scannedOptionValues); err != nil {
+			return nil, 0, fmt.Errorf("store: ListProducts: %w", err)
+		}
 		if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
 			rawMsg := json.RawMessage(scannedAttributes.String)
 			p.Attributes = &rawMsg
@@ -343,16 +679,19 @@ func (s *PostgresStore) ListProducts(ctx context.Context, params ListProductsPar
 
 func (s *PostgresStore) GetProductByID(ctx context.Context, id int64) (*domain.Product, error) {
 	query := `
-		SELECT id, name, description, sku, price, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at
-		FROM products.products
+		SELECT id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at, compare_at_price, ` + variantColumns + `
+		FROM ` + effectiveProducts + `
 		WHERE id = $1;
 	`
 	var product domain.Product
-	var scannedAttributes sql.NullString
+	var scannedAttributes, scannedCompareAt sql.NullString
+	var scannedPrice string
+	var scannedOptionValues []byte
 	err := s.db.QueryRowContext(ctx, query, id).Scan(
-		&product.ID, &product.Name, &product.Description, &product.SKU, &product.Price, &product.StockQuantity,
+		&product.ID, &product.Name, &product.Description, &product.SKU, &scannedPrice, &product.Price.Currency, &product.StockQuantity,
 		&product.CategoryID, &product.ImageURL, &product.IsActive, &scannedAttributes,
-		&product.CreatedAt, &product.UpdatedAt,
+		&product.CreatedAt, &product.UpdatedAt, &scannedCompareAt,
+		&product.ParentID, pq.Array(&product.Options), &scannedOptionValues, &product.InheritsPrice,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -360,6 +699,15 @@ func (s *PostgresStore) GetProductByID(ctx context.Context, id int64) (*domain.P
 		}
 		return nil, fmt.Errorf("store: GetProductByID failed to scan row: %w", err)
 	}
+	if err := setScannedPrice(&product, scannedPrice); err != nil {
+		return nil, fmt.Errorf("store: GetProductByID: %w", err)
+	}
+	if err := setScannedCompareAtPrice(&product, scannedCompareAt); err != nil {
+		return nil, fmt.Errorf("store: GetProductByID: %w", err)
+	}
+	if err := setScannedOptionValues(&product, scannedOptionValues); err != nil {
+		return nil, fmt.Errorf("store: GetProductByID: %w", err)
+	}
 
 	if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
 		rawMsg := json.RawMessage(scannedAttributes.String)
@@ -369,12 +717,33 @@ func (s *PostgresStore) GetProductByID(ctx context.Context, id int64) (*domain.P
 }
 
 func (s *PostgresStore) UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: UpdateProduct failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	updatedProduct, err := updateProduct(ctx, tx, product)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: UpdateProduct failed to commit: %w", err)
+	}
+	return updatedProduct, nil
+}
+
+// updateProduct updates product within tx, as UpdateProduct and ImportProducts do.
+func updateProduct(ctx context.Context, tx *sql.Tx, product *domain.Product) (*domain.Product, error) {
 	query := `
 		UPDATE products.products
-		SET name = $1, description = $2, sku = $3, price = $4, stock_quantity = $5, 
-			category_id = $6, image_url = $7, is_active = $8, attributes = $9, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $10
-		RETURNING id, name, description, sku, price, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at;
+		SET name = $1, description = $2, sku = $3, price = $4, currency = $5, stock_quantity = $6,
+			category_id = $7, is_active = $9, attributes = $10, updated_at = CURRENT_TIMESTAMP,
+			parent_id = $12, options = $13, option_values = $14, inherits_price = $15,
+			-- The media writes keep the image URL of a product with images.
+			image_url = CASE WHEN EXISTS (SELECT 1 FROM products.product_media WHERE product_id = $11) THEN image_url ELSE $8 END
+		WHERE id = $11
+		RETURNING id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at;
 	`
 	var attributesJSON []byte
 	if product.Attributes != nil && len(*product.Attributes) > 0 {
@@ -383,15 +752,58 @@ func (s *PostgresStore) UpdateProduct(ctx context.Context, product *domain.Produ
         attributesJSON = []byte("null") // Or []byte("{}")
     }
 
+	// A variant's parent is locked first, as UpdateProduct of the parent locks the parent before its variants.
+	var parent *domain.Product
+	if product.ParentID != nil {
+		var err error
+		if parent, err = lockVariantParent(ctx, tx, *product.ParentID); err != nil {
+			return nil, fmt.Errorf("store: UpdateProduct: %w", err)
+		}
+	}
+	// The previous stock is needed for the ledger and the location rows; locking the row keeps the delta exact.
+	levels, err := loadStockLevels(ctx, tx, []int64{product.ID})
+	if err != nil {
+		return nil, fmt.Errorf("store: UpdateProduct failed to lock product: %w", err)
+	}
+	previousStock, ok := levels.totals[product.ID]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	if err := checkProductAttributes(ctx, tx, product); err != nil {
+		return nil, schemaCheckError("UpdateProduct", err)
+	}
+	// The row is locked, so the previous price recorded in the history is exact too.
+	var previousAmount, previousCurrency string
+	var previousOptions []string
+	var hasVariants bool
+	previousQuery := `SELECT price, currency, options, EXISTS (SELECT 1 FROM products.products WHERE parent_id = $1) FROM products.products WHERE id = $1;`
+	if err := tx.QueryRowContext(ctx, previousQuery, product.ID).Scan(&previousAmount, &previousCurrency, pq.Array(&previousOptions), &hasVariants); err != nil {
+		return nil, fmt.Errorf("store: UpdateProduct failed to read previous price: %w", err)
+	}
+	previousPrice, err := domain.ParseMoney(previousAmount, previousCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("store: UpdateProduct failed to parse previous price: %w", err)
+	}
+	checked := *product // checkVariant sets the price of a variant that inherits it
+	product = &checked
+	if err := checkVariant(product, parent, hasVariants, previousOptions); err != nil {
+		return nil, err
+	}
+	options, optionValues, err := variantArgs(product)
+	if err != nil {
+		return nil, fmt.Errorf("store: UpdateProduct: %w", err)
+	}
 
 	var updatedProduct domain.Product
 	var scannedAttributes sql.NullString
-	err := s.db.QueryRowContext(ctx, query,
-		product.Name, product.Description, product.SKU, product.Price, product.StockQuantity,
+	var scannedPrice string
+	err = tx.QueryRowContext(ctx, query,
+		product.Name, product.Description, product.SKU, storedPrice(product.Price).Decimal(), storedPrice(product.Price).Currency, product.StockQuantity,
 		product.CategoryID, product.ImageURL, product.IsActive, attributesJSON, product.ID,
+		product.ParentID, options, optionValues, product.InheritsPrice,
 	).Scan(
 		&updatedProduct.ID, &updatedProduct.Name, &updatedProduct.Description, &updatedProduct.SKU,
-		&updatedProduct.Price, &updatedProduct.StockQuantity, &updatedProduct.CategoryID, &updatedProduct.ImageURL,
+		&scannedPrice, &updatedProduct.Price.Currency, &updatedProduct.StockQuantity, &updatedProduct.CategoryID, &updatedProduct.ImageURL,
 		&updatedProduct.IsActive, &scannedAttributes,
 		&updatedProduct.CreatedAt, &updatedProduct.UpdatedAt,
 	)
@@ -406,8 +818,40 @@ func (s *PostgresStore) UpdateProduct(ctx context.Context, product *domain.Produ
 				return nil, ErrProductSKUExists
 			}
 		}
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "products_category_id_fkey" {
+			return nil, ErrCategoryNotFound
+		}
+		if variantErr := variantWriteError(err); variantErr != nil {
+			return nil, variantErr
+		}
+		if checkErr := productCheckError(err); checkErr != nil {
+			return nil, checkErr
+		}
 		return nil, fmt.Errorf("store: UpdateProduct failed to scan row: %w", err)
 	}
+	if err := setScannedPrice(&updatedProduct, scannedPrice); err != nil {
+		return nil, fmt.Errorf("store: UpdateProduct: %w", err)
+	}
+	setVariantFields(&updatedProduct, product)
+	if delta := updatedProduct.StockQuantity - previousStock; delta != 0 {
+		if err := recordStockEdit(ctx, tx, levels, updatedProduct.ID, delta, domain.StockReasonProductUpdate); err != nil {
+			return nil, fmt.Errorf("store: UpdateProduct: %w", err)
+		}
+	}
+	if updatedProduct.Price != previousPrice {
+		change := &domain.PriceChange{
+			ProductID: updatedProduct.ID, OldPrice: &previousPrice, NewPrice: updatedProduct.Price,
+			EffectiveAt: updatedProduct.UpdatedAt, Reason: domain.PriceReasonProductUpdate,
+		}
+		if err := insertPriceChange(ctx, tx, change); err != nil {
+			return nil, fmt.Errorf("store: UpdateProduct: %w", err)
+		}
+		if hasVariants {
+			if err := passOnParentPrice(ctx, tx, updatedProduct.ID, previousPrice, updatedProduct.Price); err != nil {
+				return nil, fmt.Errorf("store: UpdateProduct: %w", err)
+			}
+		}
+	}
 
 	if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
 		rawMsg := json.RawMessage(scannedAttributes.String)
@@ -416,9 +860,18 @@ func (s *PostgresStore) UpdateProduct(ctx context.Context, product *domain.Produ
 	return &updatedProduct, nil
 }
 
+// DeleteProduct marks the product first and its variants in a second statement, so that a variant
+// created concurrently (which locks its parent, see lockVariantParent) is either seen or rejected.
+// Both get the same deletion time, by which RestoreProduct finds the variants.
 func (s *PostgresStore) DeleteProduct(ctx context.Context, id int64) error {
-	query := `DELETE FROM products.products WHERE id = $1;`
-	result, err := s.db.ExecContext(ctx, query, id)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("store: DeleteProduct failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	query := `UPDATE products.products SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;`
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("store: DeleteProduct failed to execute delete: %w", err)
 	}
@@ -429,50 +882,52 @@ func (s *PostgresStore) DeleteProduct(ctx context.Context, id int64) error {
 	if rowsAffected == 0 {
 		return ErrProductNotFound
 	}
+	variantsQuery := `UPDATE products.products SET deleted_at = CURRENT_TIMESTAMP WHERE parent_id = $1 AND deleted_at IS NULL;`
+	if _, err := tx.ExecContext(ctx, variantsQuery, id); err != nil {
+		return fmt.Errorf("store: DeleteProduct failed to delete variants: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: DeleteProduct failed to commit: %w", err)
+	}
 	return nil
 }
 
-func (s *PostgresStore) UpdateStock(ctx context.Context, productID int64, quantityChange int32) (*domain.Product, error) {
-	// This query attempts to update stock and ensures it doesn't go below zero.
-	// The "AND stock_quantity + $1 >= 0" clause acts as a precondition.
-	// If it fails (e.g. product not found, or stock would become negative), ErrNoRows is returned by QueryRowContext.
-	query := `
-		UPDATE products.products
-		SET stock_quantity = stock_quantity + $1, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $2 AND stock_quantity + $1 >= 0 
-		RETURNING id, name, description, sku, price, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at;
-	`
-	var updatedProduct domain.Product
-	var scannedAttributes sql.NullString
+// UpdateStock applies a single stock change as a one-item atomic batch, so that it is locked and
+// recorded in the ledger exactly like BatchUpdateStock.
+func (s *PostgresStore) UpdateStock(ctx context.Context, productID int64, quantityChange int32, info StockMovementInfo) (*domain.Product, error) {
+	results, err := s.BatchUpdateStock(ctx, []StockUpdate{{ProductID: productID, QuantityChange: quantityChange}}, true, info)
+	if err != nil {
+		return nil, err
+	}
+	return results[0].Product, results[0].Err
+}
 
-	err := s.db.QueryRowContext(ctx, query, quantityChange, productID).Scan(
-		&updatedProduct.ID, &updatedProduct.Name, &updatedProduct.Description, &updatedProduct.SKU,
-		&updatedProduct.Price, &updatedProduct.StockQuantity, &updatedProduct.CategoryID, &updatedProduct.ImageURL,
-		&updatedProduct.IsActive, &scannedAttributes,
-		&updatedProduct.CreatedAt, &updatedProduct.UpdatedAt,
-	)
+// BatchUpdateStock applies all stock changes inside one transaction. The affected rows are locked
+// with SELECT ... FOR UPDATE in ascending ID order, so concurrent batches touching the same products
+// queue up instead of deadlocking. Changes are validated and allocated to locations in Go against the
+// locked rows before anything is written, which lets every item report its own outcome.
+func (s *PostgresStore) BatchUpdateStock(ctx context.Context, updates []StockUpdate, atomic bool, info StockMovementInfo) ([]StockUpdateResult, error) {
+	if len(updates) == 0 {
+		return []StockUpdateResult{}, nil
+	}
 
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			// This error means either the product was not found, or the stock update would violate a constraint (e.g., go negative).
-			// We might need to check if the product exists separately to return a more specific error.
-			// For now, we'll check existence first to provide a clearer error.
-			var exists bool
-			checkExistenceQuery := "SELECT EXISTS(SELECT 1 FROM products.products WHERE id = $1)"
-			s.db.QueryRowContext(ctx, checkExistenceQuery, productID).Scan(&exists)
-			if !exists {
-				return nil, ErrProductNotFound
-			}
-			return nil, ErrInsufficientStock // Product exists, so stock update condition failed
-		}
-		return nil, fmt.Errorf("store: UpdateStock failed to scan row: %w", err)
+		return nil, fmt.Errorf("store: BatchUpdateStock failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback() // No-op once committed
 
-	if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
-		rawMsg := json.RawMessage(scannedAttributes.String)
-		updatedProduct.Attributes = &rawMsg
+	results, err := changeStock(ctx, tx, updates, atomic, true, info)
+	if err != nil {
+		return nil, fmt.Errorf("store: BatchUpdateStock: %w", err)
 	}
-	return &updatedProduct, nil
+	if firstStockError(results) != nil && atomic {
+		return results, nil // Deferred Rollback releases the locks
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: BatchUpdateStock failed to commit: %w", err)
+	}
+	return results, nil
 }
 
 func (s *PostgresStore) GetRecentProducts(ctx context.Context, limit int) ([]domain.Product, error) {
@@ -480,8 +935,8 @@ func (s *PostgresStore) GetRecentProducts(ctx context.Context, limit int) ([]dom
 		return []domain.Product{}, nil
 	}
 	query := `
-		SELECT id, name, description, sku, price, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at
-		FROM products.products
+		SELECT id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at, compare_at_price, ` + variantColumns + `
+		FROM ` + effectiveProducts + `
 		WHERE is_active = TRUE
 		ORDER BY created_at DESC
 		LIMIT $1;
@@ -496,14 +951,26 @@ func (s *PostgresStore) GetRecentProducts(ctx context.Context, limit int) ([]dom
 	products := make([]domain.Product, 0, limit) 
 	for rows.Next() {
 		var p domain.Product
-		var scannedAttributes sql.NullString
+		var scannedAttributes, scannedCompareAt sql.NullString
+		var scannedPrice string
+		var scannedOptionValues []byte
 		if err := rows.Scan(
-			&p.ID, &p.Name, &p.Description, &p.SKU, &p.Price, &p.StockQuantity,
+			&p.ID, &p.Name, &p.Description, &p.SKU, &scannedPrice, &p.Price.Currency, &p.StockQuantity,
 			&p.CategoryID, &p.ImageURL, &p.IsActive, &scannedAttributes,
-			&p.CreatedAt, &p.UpdatedAt,
+			&p.CreatedAt, &p.UpdatedAt, &scannedCompareAt,
+			&p.ParentID, pq.Array(&p.Options), &scannedOptionValues, &p.InheritsPrice,
 		); err != nil {
 			return nil, fmt.Errorf("store: GetRecentProducts failed to scan product row: %w", err)
 		}
+		if err := setScannedPrice(&p, scannedPrice); err != nil {
+			return nil, fmt.Errorf("store: GetRecentProducts: %w", err)
+		}
+		if err := setScannedCompareAtPrice(&p, scannedCompareAt); err != nil {
+			return nil, fmt.Errorf("store: GetRecentProducts: %w", err)
+		}
+		if err := setScannedOptionValues(&p, scannedOptionValues); err != nil {
+			return nil, fmt.Errorf("store: GetRecentProducts: %w", err)
+		}
 		if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
 			rawMsg := json.RawMessage(scannedAttributes.String)
 			p.Attributes = &rawMsg
@@ -528,4 +995,101 @@ func (s *PostgresStore) Close() error {
 		return nil
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// execer is implemented by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// queryer is implemented by *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// lockProductStock locks the given product rows (FOR UPDATE, in ID order) and returns their stock.
+// Products that do not exist or are in the trash are absent from the result.
+func lockProductStock(ctx context.Context, tx *sql.Tx, ids []int64) (map[int64]int32, error) {
+	query := `
+		SELECT id, stock_quantity
+		FROM products.products
+		WHERE id = ANY($1) AND deleted_at IS NULL
+		ORDER BY id
+		FOR UPDATE;
+	`
+	rows, err := tx.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock products: %w", err)
+	}
+	defer rows.Close()
+
+	stock := make(map[int64]int32, len(ids))
+	for rows.Next() {
+		var id int64
+		var qty int32
+		if err := rows.Scan(&id, &qty); err != nil {
+			return nil, fmt.Errorf("failed to scan locked product: %w", err)
+		}
+		stock[id] = qty
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("locked products iteration error: %w", err)
+	}
+	return stock, nil
+}
+
+// recordStockEdit distributes a change of the aggregate stock_quantity made by CreateProduct or
+// UpdateProduct over the product's locations and records it in the ledger. levels must hold the
+// product's stock from before the change. Fails with ErrNoLocations if stock is added while no
+// location exists.
+func recordStockEdit(ctx context.Context, tx *sql.Tx, levels *stockLevels, productID int64, delta int32, reason domain.StockMovementReason) error {
+	results := make([]StockUpdateResult, 1)
+	_, entries, failed := levels.applyUpdates([]StockUpdate{{ProductID: productID, QuantityChange: delta}}, results)
+	if failed {
+		return results[0].Err
+	}
+	if err := writeLocationStock(ctx, tx, levels.changedRows()); err != nil {
+		return err
+	}
+	return insertStockMovements(ctx, tx, StockMovementInfo{Reason: reason}, entries)
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanProduct scans a row selected with the standard product column list:
+// id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at.
+// Writes return products this way, at their base price.
+func scanProduct(row rowScanner) (*domain.Product, error) {
+	var p domain.Product
+	var scannedAttributes sql.NullString
+	var scannedPrice string
+	if err := row.Scan(
+		&p.ID, &p.Name, &p.Description, &p.SKU, &scannedPrice, &p.Price.Currency, &p.StockQuantity,
+		&p.CategoryID, &p.ImageURL, &p.IsActive, &scannedAttributes,
+		&p.CreatedAt, &p.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := setScannedPrice(&p, scannedPrice); err != nil {
+		return nil, err
+	}
+	if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
+		rawMsg := json.RawMessage(scannedAttributes.String)
+		p.Attributes = &rawMsg
+	}
+	return &p, nil
+}
+
+// setScannedPrice combines the price column, scanned as a decimal string, with the currency column
+// already scanned into p.Price.Currency.
+func setScannedPrice(p *domain.Product, price string) error {
+	money, err := domain.ParseMoney(price, p.Price.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to parse price: %w", err)
+	}
+	p.Price = money
+	return nil
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+const categoryAttributeColumns = `category_id, key, type, enum_values, required, unit, created_at, updated_at`
+
+// effectiveAttributeSchemaQuery selects the nearest definition of every key among the category ($1)
+// and its ancestors.
+const effectiveAttributeSchemaQuery = categoryAncestryCTE + `
+	SELECT DISTINCT ON (ca.key) ca.category_id, ca.key, ca.type, ca.enum_values, ca.required, ca.unit, ca.created_at, ca.updated_at
+	FROM ancestry a
+	JOIN products.category_attributes ca ON ca.category_id = a.id
+	ORDER BY ca.key, a.depth;
+`
+
+// --- Attribute schema part of the CategoryStorer Implementation ---
+
+func (s *PostgresStore) GetCategoryAttributeSchema(ctx context.Context, categoryID int64) ([]domain.CategoryAttribute, error) {
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, liveCategoryQuery, categoryID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("store: GetCategoryAttributeSchema failed to check category: %w", err)
+	}
+	if !exists {
+		return nil, ErrCategoryNotFound
+	}
+	schema, err := loadAttributeSchema(ctx, s.db, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("store: GetCategoryAttributeSchema: %w", err)
+	}
+	return schema, nil
+}
+
+func (s *PostgresStore) PutCategoryAttribute(ctx context.Context, attr *domain.CategoryAttribute) (*domain.CategoryAttribute, error) {
+	if err := validateAttributeDefinition(attr); err != nil {
+		return nil, err
+	}
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, liveCategoryQuery, attr.CategoryID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("store: PutCategoryAttribute failed to check category: %w", err)
+	}
+	if !exists {
+		return nil, ErrCategoryNotFound
+	}
+	query := `
+		INSERT INTO products.category_attributes (category_id, key, type, enum_values, required, unit)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ON CONSTRAINT category_attributes_pkey DO UPDATE
+		SET type = EXCLUDED.type, enum_values = EXCLUDED.enum_values, required = EXCLUDED.required,
+			unit = EXCLUDED.unit, updated_at = CURRENT_TIMESTAMP
+		RETURNING ` + categoryAttributeColumns + `;`
+	var enumValues interface{} // SQL NULL rather than an empty array
+	if len(attr.EnumValues) > 0 {
+		enumValues = pq.Array(attr.EnumValues)
+	}
+	stored, err := scanCategoryAttribute(s.db.QueryRowContext(ctx, query,
+		attr.CategoryID, attr.Key, string(attr.Type), enumValues, attr.Required, attr.Unit))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "category_attributes_category_id_fkey" {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("store: PutCategoryAttribute failed to scan row: %w", err)
+	}
+	return stored, nil
+}
+
+func (s *PostgresStore) DeleteCategoryAttribute(ctx context.Context, categoryID int64, key string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM products.category_attributes WHERE category_id = $1 AND key = $2;`, categoryID, key)
+	if err != nil {
+		return fmt.Errorf("store: DeleteCategoryAttribute failed to execute delete: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("store: DeleteCategoryAttribute failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrCategoryAttributeNotFound
+	}
+	return nil
+}
+
+// loadAttributeSchema returns the effective attribute schema of a category, ordered by key.
+func loadAttributeSchema(ctx context.Context, q queryer, categoryID int64) ([]domain.CategoryAttribute, error) {
+	rows, err := q.QueryContext(ctx, effectiveAttributeSchemaQuery, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attribute schema: %w", err)
+	}
+	defer rows.Close()
+
+	schema := []domain.CategoryAttribute{}
+	for rows.Next() {
+		attr, err := scanCategoryAttribute(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category attribute: %w", err)
+		}
+		schema = append(schema, *attr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("attribute schema iteration error: %w", err)
+	}
+	return schema, nil
+}
+
+// checkProductAttributes validates a product's attributes against the schema of its category, if
+// it has one, within the transaction writing the product. It locks the category against being
+// deleted (FOR SHARE) and fails with ErrCategoryNotFound if it is in the trash.
+func checkProductAttributes(ctx context.Context, tx *sql.Tx, product *domain.Product) error {
+	if product.CategoryID == nil {
+		return nil
+	}
+	var inTrash bool
+	err := tx.QueryRowContext(ctx, `SELECT deleted_at IS NOT NULL FROM products.categories WHERE id = $1 FOR SHARE;`, *product.CategoryID).Scan(&inTrash)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil // The foreign key rejects the product
+	case err != nil:
+		return fmt.Errorf("failed to lock category: %w", err)
+	case inTrash:
+		return ErrCategoryNotFound
+	}
+	schema, err := loadAttributeSchema(ctx, tx, *product.CategoryID)
+	if err != nil {
+		return err
+	}
+	return validateProductAttributes(schema, product.Attributes)
+}
+
+// schemaCheckError passes an *AttributeSchemaError and ErrCategoryNotFound through and wraps
+// anything else.
+func schemaCheckError(operation string, err error) error {
+	if errors.Is(err, ErrAttributeSchemaViolation) || errors.Is(err, ErrCategoryNotFound) {
+		return err
+	}
+	return fmt.Errorf("store: %s failed to check attributes: %w", operation, err)
+}
+
+func scanCategoryAttribute(row rowScanner) (*domain.CategoryAttribute, error) {
+	var attr domain.CategoryAttribute
+	var attrType string
+	var enumValues []string
+	if err := row.Scan(&attr.CategoryID, &attr.Key, &attrType, (*pq.StringArray)(&enumValues), &attr.Required, &attr.Unit,
+		&attr.CreatedAt, &attr.UpdatedAt); err != nil {
+		return nil, err
+	}
+	attr.Type = domain.AttributeType(attrType)
+	if len(enumValues) > 0 {
+		attr.EnumValues = enumValues
+	}
+	return &attr, nil
+}
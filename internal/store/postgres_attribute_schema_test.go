@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var categoryAttributeColumnNames = []string{"category_id", "key", "type", "enum_values", "required", "unit", "created_at", "updated_at"}
+
+func TestPostgresStore_PutCategoryAttribute(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(liveCategoryQuery)).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT ON CONSTRAINT category_attributes_pkey DO UPDATE`)).
+		WithArgs(int64(3), "color", "string", pq.Array([]string{"red", "blue"}), true, nil).
+		WillReturnRows(sqlmock.NewRows(categoryAttributeColumnNames).AddRow(int64(3), "color", "string", "{red,blue}", true, nil, now, now))
+
+	stored, err := store.PutCategoryAttribute(context.Background(), &domain.CategoryAttribute{
+		CategoryID: 3, Key: "color", Type: domain.AttributeTypeString, EnumValues: []string{"red", "blue"}, Required: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"red", "blue"}, stored.EnumValues)
+	assert.Nil(t, stored.Unit)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_PutCategoryAttribute_CategoryNotFound(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(liveCategoryQuery)).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	// The category is purged between the check and the insert.
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products.category_attributes`)).
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "category_attributes_category_id_fkey"})
+
+	_, err := store.PutCategoryAttribute(context.Background(), &domain.CategoryAttribute{CategoryID: 3, Key: "weight", Type: domain.AttributeTypeNumber})
+
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CreateProduct_RejectsSchemaViolation(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT deleted_at IS NOT NULL FROM products.categories WHERE id = $1 FOR SHARE;`)).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"in_trash"}).AddRow(false))
+	mock.ExpectQuery(regexp.QuoteMeta(`WITH RECURSIVE ancestry AS`)).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows(categoryAttributeColumnNames).
+			AddRow(int64(1), "color", "string", "{red,blue}", true, nil, now, now))
+	mock.ExpectRollback()
+
+	raw := json.RawMessage(`{"color": "green"}`)
+	_, err := store.CreateProduct(context.Background(), &domain.Product{Name: "Shirt", SKU: "S-1", CategoryID: PtrTo(int64(3)), Attributes: &raw})
+
+	var schemaErr *AttributeSchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, []AttributeViolation{{Field: "attributes.color", Message: "must be one of red, blue"}}, schemaErr.Violations)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CreateProduct_CategoryInTrash(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT deleted_at IS NOT NULL FROM products.categories WHERE id = $1 FOR SHARE;`)).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"in_trash"}).AddRow(true))
+	mock.ExpectRollback()
+
+	_, err := store.CreateProduct(context.Background(), &domain.Product{Name: "Shirt", SKU: "S-1", CategoryID: PtrTo(int64(3))})
+
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -35,6 +35,11 @@ func PtrTo[T any](v T) *T {
 	return &v
 }
 
+// usd returns an amount of US dollars given in cents.
+func usd(cents int64) domain.Money {
+	return domain.Money{Amount: cents, Currency: "USD"}
+}
+
 func TestPostgresStore_CreateCategory(t *testing.T) {
 	db, mock, store := newMockDBAndStore(t)
 	defer db.Close()
@@ -51,7 +56,8 @@ func TestPostgresStore_CreateCategory(t *testing.T) {
 	// Query from store.CreateCategory
 	query := regexp.QuoteMeta(`
 		INSERT INTO products.categories (name, description, parent_category_id)
-		VALUES ($1, $2, $3)
+		SELECT $1::text, $2::text, $3::bigint
+		WHERE $3::bigint IS NULL OR EXISTS (SELECT 1 FROM products.categories WHERE id = $3 AND deleted_at IS NULL FOR SHARE)
 		RETURNING id, name, description, parent_category_id, created_at, updated_at;
 	`)
 
@@ -88,7 +94,8 @@ func TestPostgresStore_CreateCategory_NameExists(t *testing.T) {
 
 	query := regexp.QuoteMeta(`
 		INSERT INTO products.categories (name, description, parent_category_id)
-		VALUES ($1, $2, $3)
+		SELECT $1::text, $2::text, $3::bigint
+		WHERE $3::bigint IS NULL OR EXISTS (SELECT 1 FROM products.categories WHERE id = $3 AND deleted_at IS NULL FOR SHARE)
 		RETURNING id, name, description, parent_category_id, created_at, updated_at;
 	`)
 
@@ -125,7 +132,7 @@ func TestPostgresStore_GetCategoryByID_Found(t *testing.T) {
 	query := regexp.QuoteMeta(`
 		SELECT id, name, description, parent_category_id, created_at, updated_at
 		FROM products.categories
-		WHERE id = $1;
+		WHERE id = $1 AND deleted_at IS NULL;
 	`)
 
 	rows := sqlmock.NewRows([]string{"id", "name", "description", "parent_category_id", "created_at", "updated_at"}).
@@ -157,7 +164,7 @@ func TestPostgresStore_GetCategoryByID_NotFound(t *testing.T) {
 	query := regexp.QuoteMeta(`
 		SELECT id, name, description, parent_category_id, created_at, updated_at
 		FROM products.categories
-		WHERE id = $1;
+		WHERE id = $1 AND deleted_at IS NULL;
 	`)
 
 	mock.ExpectQuery(query).WithArgs(categoryID).WillReturnError(sql.ErrNoRows)
@@ -183,18 +190,19 @@ func TestPostgresStore_ListCategories(t *testing.T) {
 	// This is the query string from your store.ListCategories function, including the comment.
 	// Ensure it exactly matches the one in store/postgres.go
 	listQuerySQL := `
-		SELECT id, name, description, parent_category_id, created_at, updated_at
+		SELECT id, name, description, parent_category_id, created_at, updated_at, deleted_at
 		FROM products.categories
-		ORDER BY name ASC -- Default sort order
+		WHERE deleted_at IS NULL
+		ORDER BY name ASC, id ASC -- Default sort order
 		LIMIT $1 OFFSET $2;
 	`
 	listQuery := regexp.QuoteMeta(listQuerySQL) // Apply QuoteMeta to the exact SQL
 
-	countQuery := regexp.QuoteMeta(`SELECT COUNT(*) FROM products.categories;`)
+	countQuery := regexp.QuoteMeta(`SELECT COUNT(*) FROM products.categories WHERE deleted_at IS NULL;`)
 
-	listRows := sqlmock.NewRows([]string{"id", "name", "description", "parent_category_id", "created_at", "updated_at"}).
-		AddRow(int64(1), "Alpha Category", PtrTo("Desc A"), nil, now, now).
-		AddRow(int64(2), "Beta Category", PtrTo("Desc B"), PtrTo(int64(1)), now, now)
+	listRows := sqlmock.NewRows([]string{"id", "name", "description", "parent_category_id", "created_at", "updated_at", "deleted_at"}).
+		AddRow(int64(1), "Alpha Category", PtrTo("Desc A"), nil, now, now, nil).
+		AddRow(int64(2), "Beta Category", PtrTo("Desc B"), PtrTo(int64(1)), now, now, nil)
 
 	countRows := sqlmock.NewRows([]string{"count"}).AddRow(expectedTotalCount)
 
@@ -229,7 +237,7 @@ func TestPostgresStore_UpdateCategory(t *testing.T) {
 	query := regexp.QuoteMeta(`
 		UPDATE products.categories
 		SET name = $1, description = $2, parent_category_id = $3, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $4
+		WHERE id = $4 AND deleted_at IS NULL
 		RETURNING id, name, description, parent_category_id, created_at, updated_at;
 	`)
 
@@ -237,9 +245,17 @@ func TestPostgresStore_UpdateCategory(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "name", "description", "parent_category_id", "created_at", "updated_at"}).
 		AddRow(categoryToUpdate.ID, categoryToUpdate.Name, categoryToUpdate.Description, categoryToUpdate.ParentCategoryID, originalCreatedAt, now)
 
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(liveCategoryQuery)).WithArgs(*categoryToUpdate.ParentCategoryID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM ancestry WHERE id = $2);`)).
+		WithArgs(*categoryToUpdate.ParentCategoryID, categoryToUpdate.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 	mock.ExpectQuery(query).
 		WithArgs(categoryToUpdate.Name, categoryToUpdate.Description, categoryToUpdate.ParentCategoryID, categoryToUpdate.ID).
 		WillReturnRows(rows)
+	mock.ExpectCommit()
 
 	updatedCategory, err := store.UpdateCategory(context.Background(), categoryToUpdate)
 
@@ -269,12 +285,14 @@ func TestPostgresStore_UpdateCategory_NotFound(t *testing.T) {
 	query := regexp.QuoteMeta(`
 		UPDATE products.categories
 		SET name = $1, description = $2, parent_category_id = $3, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $4
+		WHERE id = $4 AND deleted_at IS NULL
 		RETURNING id, name, description, parent_category_id, created_at, updated_at;
 	`)
+	mock.ExpectBegin() // No parent, so no lock or cycle check
 	mock.ExpectQuery(query).
 		WithArgs(categoryToUpdate.Name, categoryToUpdate.Description, categoryToUpdate.ParentCategoryID, categoryToUpdate.ID).
 		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
 
 	_, err := store.UpdateCategory(context.Background(), categoryToUpdate)
 	require.Error(t, err)
@@ -289,11 +307,16 @@ func TestPostgresStore_DeleteCategory_Success(t *testing.T) {
 	defer db.Close()
 
 	categoryID := int64(1)
-	query := regexp.QuoteMeta(`DELETE FROM products.categories WHERE id = $1;`)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products.categories SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`)).
+		WithArgs(categoryID).WillReturnRows(sqlmock.NewRows([]string{"parent_category_id"}).AddRow(nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`OR EXISTS (SELECT 1 FROM products.products WHERE category_id = $1 AND deleted_at IS NULL)`)).
+		WithArgs(categoryID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectCommit()
 
-	mock.ExpectExec(query).WithArgs(categoryID).WillReturnResult(sqlmock.NewResult(0, 1))
-
-	err := store.DeleteCategory(context.Background(), categoryID)
+	err := store.DeleteCategory(context.Background(), categoryID, DeleteCategoryOptions{})
 
 	require.NoError(t, err, "DeleteCategory should not return an error on success")
 
@@ -306,17 +329,223 @@ func TestPostgresStore_DeleteCategory_NotFound(t *testing.T) {
 	defer db.Close()
 
 	categoryID := int64(99)
-	query := regexp.QuoteMeta(`DELETE FROM products.categories WHERE id = $1;`)
-
-	mock.ExpectExec(query).WithArgs(categoryID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products.categories SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`)).
+		WithArgs(categoryID).WillReturnRows(sqlmock.NewRows([]string{"parent_category_id"}))
+	mock.ExpectRollback()
 
-	err := store.DeleteCategory(context.Background(), categoryID)
+	err := store.DeleteCategory(context.Background(), categoryID, DeleteCategoryOptions{})
 
-	require.Error(t, err, "DeleteCategory should return an error if no rows were affected")
+	require.Error(t, err, "DeleteCategory should return an error if the category does not exist")
 	assert.True(t, errors.Is(err, ErrCategoryNotFound), "Error should be ErrCategoryNotFound")
 
 	err = mock.ExpectationsWereMet()
 	require.NoError(t, err)
 }
 
+func TestPostgresStore_DeleteCategory_Restricted(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products.categories SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`)).
+		WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows([]string{"parent_category_id"}).AddRow(int64(1)))
+	mock.ExpectQuery(regexp.QuoteMeta(`OR EXISTS (SELECT 1 FROM products.products WHERE category_id = $1 AND deleted_at IS NULL)`)).
+		WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	err := store.DeleteCategory(context.Background(), 2, DeleteCategoryOptions{Strategy: CategoryDeleteRestrict})
+
+	assert.ErrorIs(t, err, ErrCategoryNotEmpty)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_DeleteCategory_Reparent(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products.categories SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`)).
+		WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows([]string{"parent_category_id"}).AddRow(int64(1)))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE products.categories SET parent_category_id = $2`)).
+		WithArgs(int64(2), int64(1)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE products.products SET category_id = $2`)).
+		WithArgs(int64(2), int64(1)).WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectCommit()
+
+	err := store.DeleteCategory(context.Background(), 2, DeleteCategoryOptions{Strategy: CategoryDeleteReparent})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_DeleteCategory_Cascade(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products.categories SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`)).
+		WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows([]string{"parent_category_id"}).AddRow(nil))
+	mock.ExpectQuery(`WITH RECURSIVE subtree AS`).
+		WithArgs(0, int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "parent_category_id", "created_at", "updated_at", "depth", "has_children"}).
+			AddRow(int64(2), "Shoes", nil, nil, now, now, 1, true).
+			AddRow(int64(3), "Boots", nil, int64(2), now, now, 2, false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE products.categories SET deleted_at = CURRENT_TIMESTAMP
+			WHERE id = ANY($1) AND deleted_at IS NULL;`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// Products in the trash keep their categories, to get them back when the categories are restored.
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE products.products SET category_id = NULL, updated_at = CURRENT_TIMESTAMP
+			WHERE category_id = ANY($1) AND deleted_at IS NULL;`)).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	err := store.DeleteCategory(context.Background(), 2, DeleteCategoryOptions{Strategy: CategoryDeleteCascade})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_UpdateCategory_RejectsCycle(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1);`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(liveCategoryQuery)).WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM ancestry WHERE id = $2);`)).
+		WithArgs(int64(5), int64(1)). // Is category 1 among the ancestors of its new parent 5?
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	_, err := store.UpdateCategory(context.Background(), &domain.Category{ID: 1, Name: "Electronics", ParentCategoryID: PtrTo(int64(5))})
+
+	assert.ErrorIs(t, err, ErrCategoryCycle)
+	_, err = store.UpdateCategory(context.Background(), &domain.Category{ID: 1, Name: "Electronics", ParentCategoryID: PtrTo(int64(1))})
+	assert.ErrorIs(t, err, ErrCategoryCycle)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // --- End of store tests ---
+
+func TestPostgresStore_ListCategories_AfterCursor(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	now := time.Now().Truncate(time.Millisecond)
+	after := CategoryCursor(&domain.Category{ID: 2, Name: "Beta Category"})
+	params := ListCategoriesParams{Limit: 2, After: &after}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM products.categories WHERE deleted_at IS NULL;`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE deleted_at IS NULL AND (name, id) > ($3, $4)
+		ORDER BY name ASC, id ASC -- Default sort order
+		LIMIT $1 OFFSET $2;`)).
+		WithArgs(2, 0, "Beta Category", int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "parent_category_id", "created_at", "updated_at", "deleted_at"}).
+			AddRow(int64(3), "Gamma Category", nil, nil, now, now, nil))
+
+	categories, totalCount, err := store.ListCategories(context.Background(), params)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, totalCount)
+	require.Len(t, categories, 1)
+	assert.Equal(t, "Gamma Category", categories[0].Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+var categoryTreeColumnNames = []string{"id", "name", "description", "parent_category_id", "created_at", "updated_at", "depth", "has_children"}
+
+func TestPostgresStore_GetCategoryTree(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE parent_category_id IS NULL`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(categoryTreeColumnNames).
+			AddRow(int64(1), "Electronics", nil, nil, now, now, 1, true).
+			AddRow(int64(4), "Garden", nil, nil, now, now, 1, false).
+			AddRow(int64(2), "Phones", nil, int64(1), now, now, 2, true))
+
+	tree, err := store.GetCategoryTree(context.Background(), nil, 2)
+
+	require.NoError(t, err)
+	require.Len(t, tree, 2)
+	require.Len(t, tree[0].Children, 1)
+	assert.Equal(t, int64(2), tree[0].Children[0].ID)
+	assert.True(t, tree[0].Children[0].HasChildren)
+	assert.Empty(t, tree[1].Children)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_GetCategoryDescendants_NotFound(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE id = $2`)).
+		WithArgs(3, int64(9)). // The walk starts at the category itself, one level up
+		WillReturnRows(sqlmock.NewRows(categoryTreeColumnNames))
+
+	_, err := store.GetCategoryDescendants(context.Background(), 9, 2)
+
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_GetCategoryAncestors(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY a.depth DESC`)).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "parent_category_id", "created_at", "updated_at"}).
+			AddRow(int64(1), "Electronics", nil, nil, now, now).
+			AddRow(int64(2), "Phones", nil, int64(1), now, now).
+			AddRow(int64(3), "Android", nil, int64(2), now, now))
+
+	ancestors, err := store.GetCategoryAncestors(context.Background(), 3)
+
+	require.NoError(t, err)
+	require.Len(t, ancestors, 2, "the category itself is not its own ancestor")
+	assert.Equal(t, "Electronics", ancestors[0].Name)
+	assert.Equal(t, "Phones", ancestors[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_ListCategories_ParentCategory(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	now := time.Now().Truncate(time.Millisecond)
+	after := CategoryCursor(&domain.Category{ID: 2, Name: "Beta Category"})
+	params := ListCategoriesParams{Limit: 2, After: &after, ParentCategoryID: PtrTo(int64(1))}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM products.categories WHERE parent_category_id = $1 AND deleted_at IS NULL;`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE parent_category_id = $3 AND deleted_at IS NULL AND (name, id) > ($4, $5)
+		ORDER BY name ASC, id ASC`)).
+		WithArgs(2, 0, int64(1), "Beta Category", int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "parent_category_id", "created_at", "updated_at", "deleted_at"}).
+			AddRow(int64(3), "Gamma Category", nil, int64(1), now, now, nil))
+
+	categories, totalCount, err := store.ListCategories(context.Background(), params)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, totalCount)
+	require.Len(t, categories, 1)
+	assert.Equal(t, int64(1), *categories[0].ParentCategoryID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
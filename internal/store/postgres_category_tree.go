@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"product-catalog-service/internal/domain"
+)
+
+// categoryAncestryCTE walks up from the category $1 (depth 0) to its root; it is empty if $1 is in
+// the trash, whereas the ancestors of other categories never are. The path guards against a cycle in
+// the hierarchy.
+const categoryAncestryCTE = `
+	WITH RECURSIVE ancestry AS (
+		SELECT id, parent_category_id, 0 AS depth, ARRAY[id] AS path
+		FROM products.categories
+		WHERE id = $1 AND deleted_at IS NULL
+		UNION ALL
+		SELECT c.id, c.parent_category_id, a.depth + 1, a.path || c.id
+		FROM products.categories c
+		JOIN ancestry a ON c.id = a.parent_category_id
+		WHERE NOT c.id = ANY(a.path)
+	)`
+
+// categorySubtreeQuery walks down from the categories matching start (depth 1), at most $1 levels
+// deep (0 for no limit), in the order of sortTreeCategories. Categories in the trash are only
+// walked from if start matches them.
+func categorySubtreeQuery(start string) string {
+	return `
+		WITH RECURSIVE subtree AS (
+			SELECT id, 1 AS depth, ARRAY[id] AS path
+			FROM products.categories
+			WHERE ` + start + `
+			UNION ALL
+			SELECT c.id, s.depth + 1, s.path || c.id
+			FROM products.categories c
+			JOIN subtree s ON c.parent_category_id = s.id
+			WHERE NOT c.id = ANY(s.path) AND c.deleted_at IS NULL AND ($1 = 0 OR s.depth < $1)
+		)
+		SELECT c.id, c.name, c.description, c.parent_category_id, c.created_at, c.updated_at, s.depth,
+			EXISTS (SELECT 1 FROM products.categories child WHERE child.parent_category_id = c.id AND child.deleted_at IS NULL) AS has_children
+		FROM subtree s
+		JOIN products.categories c ON c.id = s.id
+		ORDER BY s.depth, c.name, c.id;
+	`
+}
+
+// categorySubtreeIDsQuery selects the ID of the category root (a placeholder) and of all its
+// descendants not in the trash, for use as a subquery.
+func categorySubtreeIDsQuery(root string) string {
+	return `WITH RECURSIVE subtree AS (` +
+		`SELECT id, ARRAY[id] AS path FROM products.categories WHERE id = ` + root +
+		` UNION ALL SELECT c.id, s.path || c.id FROM products.categories c JOIN subtree s ON c.parent_category_id = s.id` +
+		` WHERE NOT c.id = ANY(s.path) AND c.deleted_at IS NULL) SELECT id FROM subtree`
+}
+
+// categoryHierarchyLockKey identifies the transaction-level advisory lock held by every change that
+// moves or removes categories, so that a concurrent change cannot invalidate its cycle check.
+const categoryHierarchyLockKey int64 = 0x63617465676f7279 // "category"
+
+// --- Category hierarchy part of the CategoryStorer Implementation ---
+
+func (s *PostgresStore) GetCategoryTree(ctx context.Context, rootID *int64, maxDepth int) ([]domain.CategoryNode, error) {
+	if maxDepth < 0 {
+		return nil, ErrInvalidMaxDepth
+	}
+	query, args := categorySubtreeQuery(`parent_category_id IS NULL AND deleted_at IS NULL`), []interface{}{maxDepth}
+	if rootID != nil {
+		query, args = categorySubtreeQuery(`id = $2 AND deleted_at IS NULL`), append(args, *rootID)
+	}
+	rows, err := walkCategories(ctx, s.db, "GetCategoryTree", query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if rootID != nil && len(rows) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+	return buildCategoryTree(rows), nil
+}
+
+func (s *PostgresStore) GetCategoryAncestors(ctx context.Context, id int64) ([]domain.Category, error) {
+	query := categoryAncestryCTE + `
+		SELECT c.id, c.name, c.description, c.parent_category_id, c.created_at, c.updated_at
+		FROM ancestry a
+		JOIN products.categories c ON c.id = a.id
+		ORDER BY a.depth DESC;
+	`
+	rows, err := s.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("store: GetCategoryAncestors failed to query categories: %w", err)
+	}
+	defer rows.Close()
+
+	var ancestry []domain.Category // Root first, ending with the category itself
+	for rows.Next() {
+		var c domain.Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.ParentCategoryID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("store: GetCategoryAncestors failed to scan category row: %w", err)
+		}
+		ancestry = append(ancestry, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: GetCategoryAncestors iteration error: %w", err)
+	}
+	if len(ancestry) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+	return ancestry[:len(ancestry)-1], nil
+}
+
+func (s *PostgresStore) GetCategoryDescendants(ctx context.Context, id int64, maxDepth int) ([]domain.Category, error) {
+	if maxDepth < 0 {
+		return nil, ErrInvalidMaxDepth
+	}
+	if maxDepth > 0 {
+		maxDepth++ // The walk counts the category itself as depth 1
+	}
+	rows, err := walkCategories(ctx, s.db, "GetCategoryDescendants", categorySubtreeQuery(`id = $2 AND deleted_at IS NULL`), maxDepth, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+	descendants := make([]domain.Category, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		descendants = append(descendants, row.category)
+	}
+	return descendants, nil
+}
+
+// walkCategories runs a categorySubtreeQuery.
+func walkCategories(ctx context.Context, q queryer, operation, query string, args ...interface{}) ([]treeCategory, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: %s failed to query categories: %w", operation, err)
+	}
+	defer rows.Close()
+
+	var walk []treeCategory
+	for rows.Next() {
+		var row treeCategory
+		c := &row.category
+		if err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.ParentCategoryID, &c.CreatedAt, &c.UpdatedAt, &row.depth, &row.hasChildren); err != nil {
+			return nil, fmt.Errorf("store: %s failed to scan category row: %w", operation, err)
+		}
+		walk = append(walk, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: %s iteration error: %w", operation, err)
+	}
+	return walk, nil
+}
+
+// lockCategoryHierarchy takes the categoryHierarchyLockKey lock until tx ends.
+func lockCategoryHierarchy(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1);`, categoryHierarchyLockKey); err != nil {
+		return fmt.Errorf("failed to lock the category hierarchy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+// ExportBatchSize is the largest batch ExportProducts passes on, and the number of rows PostgresStore
+// fetches from its cursor at a time.
+const ExportBatchSize = 500
+
+// --- Export part of the ProductStorer Implementation ---
+
+// ExportProducts reads through a server-side cursor in a read-only REPEATABLE READ transaction,
+// fetching the next batch only once fn has returned.
+func (s *PostgresStore) ExportProducts(ctx context.Context, params ListProductsParams, fn func([]domain.Product) error) error {
+	if err := validateAttributeFilters(params.Attributes); err != nil {
+		return err
+	}
+	filter := newProductFilter(params)
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("store: ExportProducts failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // Closes the cursor; nothing is written
+
+	columns := variantColumns
+	withDeleted := params.Deleted != DeletedExcluded
+	if withDeleted {
+		columns += ", deleted_at"
+	}
+	declare := `DECLARE product_export NO SCROLL CURSOR FOR
+		SELECT id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at, compare_at_price, ` + columns +
+		filter.from + filter.whereCondition() + ` ORDER BY id;`
+	if _, err := tx.ExecContext(ctx, declare, filter.args...); err != nil {
+		return fmt.Errorf("store: ExportProducts failed to declare cursor: %w", err)
+	}
+	fetch := fmt.Sprintf(`FETCH %d FROM product_export;`, ExportBatchSize)
+	for {
+		batch, err := fetchExportBatch(ctx, tx, fetch, withDeleted)
+		if err != nil {
+			return err
+		}
+		if len(batch) > 0 {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+		if len(batch) < ExportBatchSize {
+			return nil
+		}
+	}
+}
+
+// fetchExportBatch runs fetch and scans the products it returns, with deleted_at after the variant
+// columns if withDeleted.
+func fetchExportBatch(ctx context.Context, tx *sql.Tx, fetch string, withDeleted bool) ([]domain.Product, error) {
+	rows, err := tx.QueryContext(ctx, fetch)
+	if err != nil {
+		return nil, fmt.Errorf("store: ExportProducts failed to fetch products: %w", err)
+	}
+	defer rows.Close()
+
+	products := make([]domain.Product, 0, ExportBatchSize)
+	for rows.Next() {
+		var p domain.Product
+		var scannedAttributes, scannedCompareAt sql.NullString
+		var scannedPrice string
+		var scannedOptionValues []byte
+		dest := []interface{}{
+			&p.ID, &p.Name, &p.Description, &p.SKU, &scannedPrice, &p.Price.Currency, &p.StockQuantity,
+			&p.CategoryID, &p.ImageURL, &p.IsActive, &scannedAttributes, &p.CreatedAt, &p.UpdatedAt, &scannedCompareAt,
+			&p.ParentID, pq.Array(&p.Options), &scannedOptionValues, &p.InheritsPrice,
+		}
+		if withDeleted {
+			dest = append(dest, &p.DeletedAt)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("store: ExportProducts failed to scan product: %w", err)
+		}
+		if err := setScannedPrice(&p, scannedPrice); err != nil {
+			return nil, fmt.Errorf("store: ExportProducts: %w", err)
+		}
+		if err := setScannedCompareAtPrice(&p, scannedCompareAt); err != nil {
+			return nil, fmt.Errorf("store: ExportProducts: %w", err)
+		}
+		if err := setScannedOptionValues(&p, scannedOptionValues); err != nil {
+			return nil, fmt.Errorf("store: ExportProducts: %w", err)
+		}
+		if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
+			rawMsg := json.RawMessage(scannedAttributes.String)
+			p.Attributes = &rawMsg
+		}
+		products = append(products, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: ExportProducts iteration error: %w", err)
+	}
+	return products, nil
+}
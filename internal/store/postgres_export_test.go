@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore_ExportProducts(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DECLARE product_export NO SCROLL CURSOR FOR`) + `(?s).*` + regexp.QuoteMeta(`WHERE is_active = $1 ORDER BY id;`)).
+		WithArgs(true).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`FETCH 500 FROM product_export;`)).
+		WillReturnRows(sqlmock.NewRows(effectiveProductColumns).
+			AddRow(int64(3), "Scarf", nil, "SCARF", "19.990", "USD", int32(5), nil, nil, true, `{"color":"red"}`, now, now, "24.990", nil, "{}", nil, false).
+			AddRow(int64(8), "Hat", nil, "HAT", "9.990", "USD", int32(0), nil, nil, true, nil, now, now, nil, nil, "{}", nil, false))
+	mock.ExpectRollback()
+
+	var exported []domain.Product
+	isActive := true
+	err := store.ExportProducts(context.Background(), ListProductsParams{IsActive: &isActive}, func(batch []domain.Product) error {
+		exported = append(exported, batch...)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, exported, 2)
+	assert.Equal(t, int64(3), exported[0].ID)
+	assert.Equal(t, usd(1999), exported[0].Price)
+	require.NotNil(t, exported[0].CompareAtPrice)
+	assert.Equal(t, usd(2499), *exported[0].CompareAtPrice)
+	assert.JSONEq(t, `{"color":"red"}`, string(*exported[0].Attributes))
+	assert.Equal(t, int64(8), exported[1].ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_ExportProducts_StopsOnCallbackError(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	rows := sqlmock.NewRows(effectiveProductColumns)
+	for id := int64(1); id <= ExportBatchSize; id++ {
+		rows.AddRow(id, "Sock", nil, "SOCK", "4.990", "USD", int32(1), nil, nil, true, nil, now, now, nil, nil, "{}", nil, false)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DECLARE product_export NO SCROLL CURSOR FOR`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`FETCH 500 FROM product_export;`)).WillReturnRows(rows)
+	// A full batch would be followed by another FETCH, but the client went away.
+	mock.ExpectRollback()
+
+	gone := errors.New("client went away")
+	err := store.ExportProducts(context.Background(), ListProductsParams{}, func(batch []domain.Product) error {
+		assert.Len(t, batch, ExportBatchSize)
+		return gone
+	})
+
+	assert.ErrorIs(t, err, gone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
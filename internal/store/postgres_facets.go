@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+// attributeFacetValuesJoin expands the attribute whose key is the placeholder %[1]s into one row per
+// distinct scalar value (the value itself, or the elements of an array) as text.
+const attributeFacetValuesJoin = ` CROSS JOIN LATERAL (
+		SELECT DISTINCT element #>> '{}' AS value
+		FROM jsonb_array_elements(CASE WHEN jsonb_typeof(attributes -> %[1]s) = 'array'
+			THEN attributes -> %[1]s ELSE jsonb_build_array(attributes -> %[1]s) END) element
+		WHERE jsonb_typeof(element) IN ('string', 'number', 'boolean')
+	) facet`
+
+// ListProductFacets runs one grouped query per requested facet over the rows ListProducts would
+// match. The queries are independent, so counts may be slightly out of step under concurrent writes.
+func (s *PostgresStore) ListProductFacets(ctx context.Context, params ListProductsParams, req FacetRequest) (*domain.ProductFacets, error) {
+	if err := validateAttributeFilters(params.Attributes); err != nil {
+		return nil, err
+	}
+	facets := &domain.ProductFacets{}
+
+	if req.Categories {
+		f := newProductFilter(params)
+		query := "SELECT category_id, COUNT(*)" + f.from + f.whereCondition() +
+			" GROUP BY category_id ORDER BY COUNT(*) DESC, category_id NULLS LAST;"
+		facets.Categories = make([]domain.CategoryFacet, 0)
+		err := s.queryFacet(ctx, query, f.args, func(rows *sql.Rows) error {
+			var c domain.CategoryFacet
+			var categoryID sql.NullInt64
+			if err := rows.Scan(&categoryID, &c.Count); err != nil {
+				return err
+			}
+			if categoryID.Valid {
+				c.CategoryID = &categoryID.Int64
+			}
+			facets.Categories = append(facets.Categories, c)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("store: ListProductFacets failed to count categories: %w", err)
+		}
+	}
+
+	if len(req.PriceBoundaries) > 0 {
+		f := newProductFilter(params)
+		boundaries := make([]string, len(req.PriceBoundaries))
+		for i, b := range req.PriceBoundaries {
+			boundaries[i] = b.Decimal()
+		}
+		// width_bucket returns the number of boundaries at or below the price, i.e. the range index.
+		query := fmt.Sprintf("SELECT width_bucket(price, %s::numeric[]) AS bucket, COUNT(*)", f.arg(pq.Array(boundaries)))
+		f.where = append(f.where, "currency = "+f.arg(req.PriceBoundaries[0].Currency))
+		query += f.from + f.whereCondition() + " GROUP BY bucket;"
+		facets.PriceRanges = newPriceRangeFacets(req.PriceBoundaries)
+		err := s.queryFacet(ctx, query, f.args, func(rows *sql.Rows) error {
+			var bucket, count int
+			if err := rows.Scan(&bucket, &count); err != nil {
+				return err
+			}
+			if bucket < 0 || bucket >= len(facets.PriceRanges) {
+				return fmt.Errorf("unexpected price bucket %d", bucket)
+			}
+			facets.PriceRanges[bucket].Count = count
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("store: ListProductFacets failed to count price ranges: %w", err)
+		}
+	}
+
+	if req.IsActive {
+		f := newProductFilter(params)
+		query := "SELECT is_active, COUNT(*)" + f.from + f.whereCondition() + " GROUP BY is_active;"
+		var active, inactive int
+		err := s.queryFacet(ctx, query, f.args, func(rows *sql.Rows) error {
+			var value bool
+			var count int
+			if err := rows.Scan(&value, &count); err != nil {
+				return err
+			}
+			if value {
+				active = count
+			} else {
+				inactive = count
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("store: ListProductFacets failed to count is_active values: %w", err)
+		}
+		facets.IsActive = isActiveFacets(active, inactive)
+	}
+
+	if len(req.AttributeKeys) > 0 {
+		facets.Attributes = make(map[string][]domain.AttributeValueFacet, len(req.AttributeKeys))
+	}
+	for _, key := range req.AttributeKeys {
+		f := newProductFilter(params)
+		query := "SELECT facet.value, COUNT(*)" + f.from + fmt.Sprintf(attributeFacetValuesJoin, f.arg(key)) + f.whereCondition() +
+			fmt.Sprintf(" GROUP BY facet.value ORDER BY COUNT(*) DESC, facet.value LIMIT %d;", MaxAttributeFacetValues)
+		values := make([]domain.AttributeValueFacet, 0)
+		err := s.queryFacet(ctx, query, f.args, func(rows *sql.Rows) error {
+			var v domain.AttributeValueFacet
+			if err := rows.Scan(&v.Value, &v.Count); err != nil {
+				return err
+			}
+			values = append(values, v)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("store: ListProductFacets failed to count values of attribute %q: %w", key, err)
+		}
+		facets.Attributes[key] = values
+	}
+	return facets, nil
+}
+
+// queryFacet runs query and calls scan for each row.
+func (s *PostgresStore) queryFacet(ctx context.Context, query string, args []interface{}, scan func(*sql.Rows) error) error {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
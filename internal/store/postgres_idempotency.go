@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// ErrIdempotencyKeyNotFound is returned when completing or releasing a key that is not claimed.
+var ErrIdempotencyKeyNotFound = errors.New("store: idempotency key not found")
+
+// --- IdempotencyStorer Implementation ---
+
+// ClaimIdempotencyKey relies on the primary key for mutual exclusion: of two concurrent claims for
+// the same key, exactly one INSERT succeeds and the other observes the winner's record.
+func (s *PostgresStore) ClaimIdempotencyKey(ctx context.Context, key, operation, requestHash string, expiresAt time.Time) (*domain.IdempotencyRecord, bool, error) {
+	claimQuery := `
+		INSERT INTO products.idempotency_keys (idempotency_key, operation, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (idempotency_key, operation) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response = NULL, created_at = CURRENT_TIMESTAMP,
+			completed_at = NULL, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+		RETURNING idempotency_key, operation, request_hash, response, created_at, completed_at, expires_at;
+	`
+	record, err := scanIdempotencyRecord(s.db.QueryRowContext(ctx, claimQuery, key, operation, requestHash, expiresAt))
+	if err == nil {
+		return record, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, fmt.Errorf("store: ClaimIdempotencyKey failed to insert key: %w", err)
+	}
+
+	// A live record exists (the conditional DO UPDATE did not fire).
+	selectQuery := `
+		SELECT idempotency_key, operation, request_hash, response, created_at, completed_at, expires_at
+		FROM products.idempotency_keys
+		WHERE idempotency_key = $1 AND operation = $2;
+	`
+	record, err = scanIdempotencyRecord(s.db.QueryRowContext(ctx, selectQuery, key, operation))
+	if err != nil {
+		return nil, false, fmt.Errorf("store: ClaimIdempotencyKey failed to fetch existing key: %w", err)
+	}
+	return record, false, nil
+}
+
+func (s *PostgresStore) CompleteIdempotencyKey(ctx context.Context, key, operation string, response []byte) error {
+	query := `
+		UPDATE products.idempotency_keys
+		SET response = $3, completed_at = CURRENT_TIMESTAMP
+		WHERE idempotency_key = $1 AND operation = $2 AND completed_at IS NULL;
+	`
+	result, err := s.db.ExecContext(ctx, query, key, operation, response)
+	if err != nil {
+		return fmt.Errorf("store: CompleteIdempotencyKey failed to execute update: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("store: CompleteIdempotencyKey failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrIdempotencyKeyNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStore) ReleaseIdempotencyKey(ctx context.Context, key, operation string) error {
+	query := `DELETE FROM products.idempotency_keys WHERE idempotency_key = $1 AND operation = $2 AND completed_at IS NULL;`
+	result, err := s.db.ExecContext(ctx, query, key, operation)
+	if err != nil {
+		return fmt.Errorf("store: ReleaseIdempotencyKey failed to execute delete: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("store: ReleaseIdempotencyKey failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrIdempotencyKeyNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStore) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	query := `DELETE FROM products.idempotency_keys WHERE expires_at <= $1;`
+	result, err := s.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("store: DeleteExpiredIdempotencyKeys failed to execute delete: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("store: DeleteExpiredIdempotencyKeys failed to get rows affected: %w", err)
+	}
+	return int(rowsAffected), nil
+}
+
+// scanIdempotencyRecord scans a row of the idempotency_keys columns in table order.
+func scanIdempotencyRecord(row rowScanner) (*domain.IdempotencyRecord, error) {
+	var r domain.IdempotencyRecord
+	if err := row.Scan(&r.Key, &r.Operation, &r.RequestHash, &r.Response, &r.CreatedAt, &r.CompletedAt, &r.ExpiresAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
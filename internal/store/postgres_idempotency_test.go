@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idempotencyColumns = []string{"idempotency_key", "operation", "request_hash", "response", "created_at", "completed_at", "expires_at"}
+
+func TestPostgresStore_ClaimIdempotencyKey_ReturnsExistingRecord(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT (idempotency_key, operation) DO UPDATE`)).
+		WithArgs("order-1", "update_stock", "h2", expiresAt).
+		WillReturnRows(sqlmock.NewRows(idempotencyColumns)) // Live record: the conditional update does not fire
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM products.idempotency_keys`)).
+		WithArgs("order-1", "update_stock").
+		WillReturnRows(sqlmock.NewRows(idempotencyColumns).AddRow("order-1", "update_stock", "h1", []byte("resp"), now, now, expiresAt))
+
+	record, claimed, err := store.ClaimIdempotencyKey(context.Background(), "order-1", "update_stock", "h2", expiresAt)
+
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.Equal(t, "h1", record.RequestHash)
+	assert.True(t, record.Completed())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CompleteIdempotencyKey_NotClaimed(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`SET response = $3, completed_at = CURRENT_TIMESTAMP`)).
+		WithArgs("order-1", "update_stock", []byte("resp")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := store.CompleteIdempotencyKey(context.Background(), "order-1", "update_stock", []byte("resp"))
+
+	assert.ErrorIs(t, err, ErrIdempotencyKeyNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product-catalog-service/internal/domain"
+)
+
+// --- Import part of the ProductStorer Implementation ---
+
+// ImportProducts writes each product under a savepoint, so that one that fails is rolled back on
+// its own. A dry run rolls every product back, and the transaction with it.
+func (s *PostgresStore) ImportProducts(ctx context.Context, products []*domain.Product, dryRun bool) ([]ProductImportResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: ImportProducts failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	results := make([]ProductImportResult, len(products))
+	for i, product := range products {
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT import_product;`); err != nil {
+			return nil, fmt.Errorf("store: ImportProducts failed to set savepoint: %w", err)
+		}
+		results[i], err = importProduct(ctx, tx, product)
+		if err != nil {
+			return nil, err
+		}
+		if dryRun && results[i].Created {
+			results[i].ProductID = 0 // Rolled back
+		}
+		release := `RELEASE SAVEPOINT import_product;`
+		if dryRun || results[i].Err != nil {
+			release = `ROLLBACK TO SAVEPOINT import_product; RELEASE SAVEPOINT import_product;`
+		}
+		if _, err := tx.ExecContext(ctx, release); err != nil {
+			return nil, fmt.Errorf("store: ImportProducts failed to release savepoint: %w", err)
+		}
+	}
+	if dryRun {
+		return results, nil // Deferred Rollback discards the transaction
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: ImportProducts failed to commit: %w", err)
+	}
+	return results, nil
+}
+
+// importProduct creates or updates one product by SKU within tx. Write failures go into the result;
+// the error is only non-nil if the SKU cannot be looked up.
+func importProduct(ctx context.Context, tx *sql.Tx, product *domain.Product) (ProductImportResult, error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM products.products WHERE sku = $1 AND deleted_at IS NULL FOR UPDATE;`, product.SKU).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		p := *product
+		p.ID = 0
+		created, err := createProduct(ctx, tx, &p)
+		if err != nil {
+			return ProductImportResult{Created: true, Err: err}, nil
+		}
+		return ProductImportResult{ProductID: created.ID, Created: true}, nil
+	}
+	if err != nil {
+		return ProductImportResult{}, fmt.Errorf("store: ImportProducts failed to look up SKU: %w", err)
+	}
+	p := *product
+	p.ID = id
+	_, err = updateProduct(ctx, tx, &p)
+	return ProductImportResult{ProductID: id, Err: err}, nil
+}
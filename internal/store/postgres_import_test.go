@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore_ImportProducts_FailedProductIsRolledBack(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SAVEPOINT import_product;`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM products.products WHERE sku = $1 AND deleted_at IS NULL FOR UPDATE;`)).
+		WithArgs("SCARF").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	// Another transaction created the SKU meanwhile.
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products.products`)).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "products_sku_key"})
+	mock.ExpectExec(regexp.QuoteMeta(`ROLLBACK TO SAVEPOINT import_product; RELEASE SAVEPOINT import_product;`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	results, err := store.ImportProducts(context.Background(), []*domain.Product{{Name: "Scarf", SKU: "SCARF", Price: usd(1999)}}, false)
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Created)
+	assert.ErrorIs(t, results[0].Err, ErrProductSKUExists)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_ImportProducts_LookupFailureWritesNothing(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SAVEPOINT import_product;`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM products.products WHERE sku = $1 AND deleted_at IS NULL FOR UPDATE;`)).
+		WithArgs("SCARF").
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	_, err := store.ImportProducts(context.Background(), []*domain.Product{{Name: "Scarf", SKU: "SCARF", Price: usd(1999)}}, false)
+
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
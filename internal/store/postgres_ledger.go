@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+func (s *PostgresStore) ListStockMovements(ctx context.Context, params ListStockMovementsParams) ([]domain.StockMovement, int, error) {
+	var afterTime time.Time
+	if params.After != nil {
+		var err error
+		if afterTime, err = params.After.stockMovementTime(); err != nil {
+			return nil, 0, err
+		}
+	}
+	where := `WHERE product_id = $1 AND ($2::TIMESTAMPTZ IS NULL OR created_at >= $2) AND ($3::TIMESTAMPTZ IS NULL OR created_at < $3)`
+
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM products.stock_movements ` + where + `;`
+	if err := s.db.QueryRowContext(ctx, countQuery, params.ProductID, params.From, params.To).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("store: ListStockMovements failed to count movements: %w", err)
+	}
+	if totalCount == 0 {
+		return []domain.StockMovement{}, 0, nil
+	}
+
+	queryArgs := []interface{}{params.ProductID, params.From, params.To, params.Limit, params.Offset}
+	if params.After != nil { // Keyset: continue right after the cursor's (created_at, id)
+		queryArgs = append(queryArgs, afterTime, params.After.ID)
+		where += ` AND (created_at, id) < ($6, $7)`
+	}
+	query := `
+		SELECT id, product_id, location_id, quantity_delta, balance_after, reason, order_id, actor, note, created_at
+		FROM products.stock_movements
+		` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5;
+	`
+	rows, err := s.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("store: ListStockMovements failed to query movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := make([]domain.StockMovement, 0, params.Limit)
+	for rows.Next() {
+		var m domain.StockMovement
+		if err := rows.Scan(&m.ID, &m.ProductID, &m.LocationID, &m.QuantityDelta, &m.BalanceAfter, &m.Reason, &m.OrderID, &m.Actor, &m.Note, &m.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("store: ListStockMovements failed to scan movement: %w", err)
+		}
+		movements = append(movements, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("store: ListStockMovements iteration error: %w", err)
+	}
+	return movements, totalCount, nil
+}
+
+// insertStockMovements appends entries to the ledger in one statement, in input order. It must run in
+// the transaction that changed the stock, so the ledger and the balances cannot drift apart.
+func insertStockMovements(ctx context.Context, ex execer, info StockMovementInfo, entries []ledgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	productIDs := make([]int64, len(entries))
+	locationIDs := make([]sql.NullInt64, len(entries))
+	deltas := make([]int64, len(entries))
+	balances := make([]int64, len(entries))
+	for i, e := range entries {
+		productIDs[i] = e.productID
+		if e.locationID != nil {
+			locationIDs[i] = sql.NullInt64{Int64: *e.locationID, Valid: true}
+		}
+		deltas[i] = int64(e.delta)
+		balances[i] = int64(e.balanceAfter)
+	}
+	query := `
+		INSERT INTO products.stock_movements (product_id, location_id, quantity_delta, balance_after, reason, order_id, actor, note)
+		SELECT e.product_id, e.location_id, e.delta, e.balance_after, $5, $6, $7, $8
+		FROM unnest($1::BIGINT[], $2::BIGINT[], $3::INTEGER[], $4::INTEGER[]) WITH ORDINALITY AS e(product_id, location_id, delta, balance_after, ord)
+		ORDER BY e.ord;
+	`
+	_, err := ex.ExecContext(ctx, query, pq.Array(productIDs), pq.Array(locationIDs), pq.Array(deltas), pq.Array(balances),
+		string(info.Reason), info.OrderID, ActorFromContext(ctx), info.Note)
+	if err != nil {
+		return fmt.Errorf("failed to record stock movements: %w", err)
+	}
+	return nil
+}
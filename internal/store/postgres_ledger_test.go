@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore_UpdateProduct_RecordsStockDelta(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	expectStockLevels(mock, []int64{4},
+		sqlmock.NewRows([]string{"id", "stock_quantity"}).AddRow(int64(4), int32(10)),
+		sqlmock.NewRows(locationColumnNames).AddRow(int64(1), "default", "Default", int32(0), now, now),
+		sqlmock.NewRows(locationStockColumnNames).AddRow(int64(4), int64(1), int32(10), now))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT price, currency, options, EXISTS`)).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"price", "currency", "options", "exists"}).AddRow("1.500", "USD", "{}", false)) // Unchanged: no price history entry
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products.products`)).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(int64(4), "P4", nil, "SKU-4", "1.500", "USD", int32(7), nil, nil, true, nil, now, now))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.location_stock`)).
+		WithArgs(pq.Array([]int64{4}), pq.Array([]int64{1}), pq.Array([]int64{7})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.stock_movements`)).
+		WithArgs(pq.Array([]int64{4}), pq.Array([]sql.NullInt64{{Int64: 1, Valid: true}}), pq.Array([]int64{-3}), pq.Array([]int64{7}),
+			"product_update", nil, "admin", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	ctx := WithActor(context.Background(), "admin")
+	updated, err := store.UpdateProduct(ctx, &domain.Product{ID: 4, Name: "P4", SKU: "SKU-4", Price: usd(150), StockQuantity: 7, IsActive: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), updated.StockQuantity)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_UpdateProduct_NotFound(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	expectStockLevels(mock, []int64{4},
+		sqlmock.NewRows([]string{"id", "stock_quantity"}),
+		sqlmock.NewRows(locationColumnNames),
+		sqlmock.NewRows(locationStockColumnNames))
+	mock.ExpectRollback()
+
+	_, err := store.UpdateProduct(context.Background(), &domain.Product{ID: 4, Name: "P4", SKU: "SKU-4"})
+
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,324 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+const locationColumns = `id, code, name, priority, created_at, updated_at`
+
+// --- LocationStorer Implementation ---
+
+func (s *PostgresStore) CreateLocation(ctx context.Context, location *domain.Location) (*domain.Location, error) {
+	query := `
+		INSERT INTO products.locations (code, name, priority)
+		VALUES ($1, $2, $3)
+		RETURNING ` + locationColumns + `;`
+	created, err := scanLocation(s.db.QueryRowContext(ctx, query, location.Code, location.Name, location.Priority))
+	if err != nil {
+		if isLocationCodeViolation(err) {
+			return nil, ErrLocationCodeExists
+		}
+		return nil, fmt.Errorf("store: CreateLocation failed to scan row: %w", err)
+	}
+	return created, nil
+}
+
+func (s *PostgresStore) GetLocationByID(ctx context.Context, id int64) (*domain.Location, error) {
+	query := `SELECT ` + locationColumns + ` FROM products.locations WHERE id = $1;`
+	location, err := scanLocation(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("store: GetLocationByID failed to scan row: %w", err)
+	}
+	return location, nil
+}
+
+func (s *PostgresStore) ListLocations(ctx context.Context) ([]domain.Location, error) {
+	locations, err := listLocations(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("store: ListLocations: %w", err)
+	}
+	return locations, nil
+}
+
+func (s *PostgresStore) UpdateLocation(ctx context.Context, location *domain.Location) (*domain.Location, error) {
+	query := `
+		UPDATE products.locations
+		SET code = $1, name = $2, priority = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING ` + locationColumns + `;`
+	updated, err := scanLocation(s.db.QueryRowContext(ctx, query, location.Code, location.Name, location.Priority, location.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLocationNotFound
+		}
+		if isLocationCodeViolation(err) {
+			return nil, ErrLocationCodeExists
+		}
+		return nil, fmt.Errorf("store: UpdateLocation failed to scan row: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteLocation removes the location together with its empty stock rows. Rows that still hold
+// stock keep the location_stock_location_id_fkey reference and make the delete fail.
+func (s *PostgresStore) DeleteLocation(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("store: DeleteLocation failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM products.location_stock WHERE location_id = $1 AND quantity = 0;`, id); err != nil {
+		return fmt.Errorf("store: DeleteLocation failed to delete empty stock rows: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM products.locations WHERE id = $1;`, id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "location_stock_location_id_fkey" {
+			return ErrLocationInUse
+		}
+		return fmt.Errorf("store: DeleteLocation failed to execute delete: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("store: DeleteLocation failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrLocationNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: DeleteLocation failed to commit: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) ListLocationStock(ctx context.Context, productIDs []int64) ([]domain.LocationStock, error) {
+	if len(productIDs) == 0 {
+		return []domain.LocationStock{}, nil
+	}
+	rows, err := listLocationStock(ctx, s.db, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("store: ListLocationStock: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *PostgresStore) TransferStock(ctx context.Context, productID, fromLocationID, toLocationID int64, quantity int32, info StockMovementInfo) ([]domain.LocationStock, error) {
+	if fromLocationID == toLocationID {
+		return nil, ErrSameLocation
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: TransferStock failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	results, err := changeStock(ctx, tx, transferUpdates(productID, fromLocationID, toLocationID, quantity), true, false, info)
+	if err != nil {
+		return nil, fmt.Errorf("store: TransferStock: %w", err)
+	}
+	if err := firstStockError(results); err != nil {
+		return nil, err
+	}
+	levels, err := listLocationStock(ctx, tx, []int64{productID})
+	if err != nil {
+		return nil, fmt.Errorf("store: TransferStock: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: TransferStock failed to commit: %w", err)
+	}
+	return levels, nil
+}
+
+// --- Helpers ---
+
+// changeStock applies updates inside tx: it locks the products, validates every item against their
+// location rows and, unless atomic is set and an item failed, writes the new location rows, the
+// aggregate stock_quantity and the ledger entries. Results are returned in input order, with Product
+// set for applied items. With keepReserved set, decreases must also leave the active reservations of
+// each product covered. Shared by every Postgres method that changes stock.
+func changeStock(ctx context.Context, tx *sql.Tx, updates []StockUpdate, atomic, keepReserved bool, info StockMovementInfo) ([]StockUpdateResult, error) {
+	results := make([]StockUpdateResult, len(updates))
+	ids := make([]int64, 0, len(updates))
+	for _, u := range updates {
+		ids = append(ids, u.ProductID)
+	}
+	levels, err := loadStockLevels(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if keepReserved {
+		// New holds lock the products first, so the sum cannot grow until the transaction ends.
+		if levels.held, err = reservedQuantities(ctx, tx, ids, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	changed, entries, failed := levels.applyUpdates(updates, results)
+	if failed && atomic {
+		abortStockResults(results)
+		return results, nil // The caller's rollback releases the locks
+	}
+
+	if err := writeLocationStock(ctx, tx, levels.changedRows()); err != nil {
+		return nil, err
+	}
+	// Write the new aggregate of every changed product, in ID order like the locks.
+	updateQuery := `
+		UPDATE products.products
+		SET stock_quantity = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+		RETURNING id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at;
+	`
+	updated := make(map[int64]*domain.Product, len(changed))
+	for _, id := range changed {
+		p, err := scanProduct(tx.QueryRowContext(ctx, updateQuery, levels.totals[id], id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to update product %d: %w", id, err)
+		}
+		updated[id] = p
+	}
+	if err := insertStockMovements(ctx, tx, info, entries); err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Product = updated[results[i].ProductID]
+		}
+	}
+	return results, nil
+}
+
+// loadStockLevels locks the given products (see lockProductStock) and loads their location rows
+// together with the locations in allocation order. Rows of locked products cannot change
+// concurrently, as every stock change locks the product first.
+func loadStockLevels(ctx context.Context, tx *sql.Tx, productIDs []int64) (*stockLevels, error) {
+	totals, err := lockProductStock(ctx, tx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := listLocations(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT product_id, location_id, quantity, updated_at
+		FROM products.location_stock
+		WHERE product_id = ANY($1);
+	`
+	rows, err := scanLocationStockRows(tx.QueryContext(ctx, query, pq.Array(productIDs)))
+	if err != nil {
+		return nil, err
+	}
+	return newStockLevels(locations, totals, rows), nil
+}
+
+// writeLocationStock upserts the given location rows in one statement.
+func writeLocationStock(ctx context.Context, ex execer, rows []domain.LocationStock) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	productIDs := make([]int64, len(rows))
+	locationIDs := make([]int64, len(rows))
+	quantities := make([]int64, len(rows))
+	for i, r := range rows {
+		productIDs[i] = r.ProductID
+		locationIDs[i] = r.LocationID
+		quantities[i] = int64(r.Quantity)
+	}
+	query := `
+		INSERT INTO products.location_stock (product_id, location_id, quantity)
+		SELECT * FROM unnest($1::BIGINT[], $2::BIGINT[], $3::INTEGER[])
+		ON CONFLICT (product_id, location_id) DO UPDATE
+		SET quantity = EXCLUDED.quantity, updated_at = CURRENT_TIMESTAMP;
+	`
+	if _, err := ex.ExecContext(ctx, query, pq.Array(productIDs), pq.Array(locationIDs), pq.Array(quantities)); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "location_stock_location_id_fkey" {
+			return ErrLocationNotFound // Deleted after the stock levels were loaded
+		}
+		return fmt.Errorf("failed to write location stock: %w", err)
+	}
+	return nil
+}
+
+// listLocations returns all locations in allocation order.
+func listLocations(ctx context.Context, q queryer) ([]domain.Location, error) {
+	query := `SELECT ` + locationColumns + ` FROM products.locations ORDER BY priority, id;`
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := []domain.Location{}
+	for rows.Next() {
+		l, err := scanLocation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan location: %w", err)
+		}
+		locations = append(locations, *l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("locations iteration error: %w", err)
+	}
+	return locations, nil
+}
+
+// listLocationStock returns the location rows of the given products, by product and allocation order.
+func listLocationStock(ctx context.Context, q queryer, productIDs []int64) ([]domain.LocationStock, error) {
+	query := `
+		SELECT ls.product_id, ls.location_id, ls.quantity, ls.updated_at
+		FROM products.location_stock ls
+		JOIN products.locations l ON l.id = ls.location_id
+		WHERE ls.product_id = ANY($1)
+		ORDER BY ls.product_id, l.priority, l.id;
+	`
+	return scanLocationStockRows(q.QueryContext(ctx, query, pq.Array(productIDs)))
+}
+
+// scanLocationStockRows scans the result of a query selecting product_id, location_id, quantity, updated_at.
+func scanLocationStockRows(rows *sql.Rows, err error) ([]domain.LocationStock, error) {
+	if err != nil {
+		return nil, fmt.Errorf("failed to query location stock: %w", err)
+	}
+	defer rows.Close()
+
+	stock := []domain.LocationStock{}
+	for rows.Next() {
+		var ls domain.LocationStock
+		if err := rows.Scan(&ls.ProductID, &ls.LocationID, &ls.Quantity, &ls.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan location stock: %w", err)
+		}
+		stock = append(stock, ls)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("location stock iteration error: %w", err)
+	}
+	return stock, nil
+}
+
+// scanLocation scans a row selected with locationColumns.
+func scanLocation(row rowScanner) (*domain.Location, error) {
+	var l domain.Location
+	if err := row.Scan(&l.ID, &l.Code, &l.Name, &l.Priority, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func isLocationCodeViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "locations_code_key"
+}
@@ -0,0 +1,211 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+const mediaColumns = `id, product_id, url, alt_text, role, position, width, height, created_at, updated_at`
+
+// --- Media part of the ProductStorer Implementation ---
+
+func (s *PostgresStore) ListProductMedia(ctx context.Context, productID int64) ([]domain.ProductMedia, error) {
+	media, err := s.ListMedia(ctx, []int64{productID})
+	if err != nil {
+		return nil, err
+	}
+	if len(media[productID]) == 0 {
+		if err := s.checkProductExists(ctx, productID); err != nil {
+			return nil, err
+		}
+		return []domain.ProductMedia{}, nil
+	}
+	return media[productID], nil
+}
+
+func (s *PostgresStore) ListMedia(ctx context.Context, productIDs []int64) (map[int64][]domain.ProductMedia, error) {
+	query := `SELECT ` + mediaColumns + ` FROM products.product_media WHERE product_id = ANY($1) ORDER BY product_id, position, id;`
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("store: ListMedia failed to query media: %w", err)
+	}
+	defer rows.Close()
+
+	media := make(map[int64][]domain.ProductMedia)
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("store: ListMedia failed to scan media: %w", err)
+		}
+		media[m.ProductID] = append(media[m.ProductID], *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: ListMedia iteration error: %w", err)
+	}
+	return media, nil
+}
+
+func (s *PostgresStore) AddProductMedia(ctx context.Context, m *domain.ProductMedia) (*domain.ProductMedia, error) {
+	if err := validateMedia(m); err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: AddProductMedia failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := lockMediaProduct(ctx, tx, m.ProductID); err != nil {
+		return nil, err
+	}
+	if err := demotePrimaryImage(ctx, tx, m); err != nil {
+		return nil, fmt.Errorf("store: AddProductMedia: %w", err)
+	}
+	query := `
+		INSERT INTO products.product_media (product_id, url, alt_text, role, position, width, height)
+		VALUES ($1, $2, $3, $4,
+			COALESCE(NULLIF($5::INT, 0), (SELECT COALESCE(MAX(position), 0) + 1 FROM products.product_media WHERE product_id = $1)),
+			$6, $7)
+		RETURNING ` + mediaColumns + `;`
+	created, err := scanMedia(tx.QueryRowContext(ctx, query, m.ProductID, m.URL, m.AltText, string(m.Role), m.Position, m.Width, m.Height))
+	if err != nil {
+		return nil, fmt.Errorf("store: AddProductMedia failed to scan row: %w", err)
+	}
+	if err := syncPrimaryImage(ctx, tx, m.ProductID); err != nil {
+		return nil, fmt.Errorf("store: AddProductMedia: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: AddProductMedia failed to commit: %w", err)
+	}
+	return created, nil
+}
+
+func (s *PostgresStore) UpdateProductMedia(ctx context.Context, m *domain.ProductMedia) (*domain.ProductMedia, error) {
+	if err := validateMedia(m); err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: UpdateProductMedia failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := lockMediaProduct(ctx, tx, m.ProductID); err != nil {
+		return nil, err
+	}
+	if err := demotePrimaryImage(ctx, tx, m); err != nil {
+		return nil, fmt.Errorf("store: UpdateProductMedia: %w", err)
+	}
+	query := `
+		UPDATE products.product_media
+		SET url = $3, alt_text = $4, role = $5, position = COALESCE(NULLIF($6::INT, 0), position),
+			width = $7, height = $8, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND product_id = $2
+		RETURNING ` + mediaColumns + `;`
+	updated, err := scanMedia(tx.QueryRowContext(ctx, query, m.ID, m.ProductID, m.URL, m.AltText, string(m.Role), m.Position, m.Width, m.Height))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("store: UpdateProductMedia failed to scan row: %w", err)
+	}
+	if err := syncPrimaryImage(ctx, tx, m.ProductID); err != nil {
+		return nil, fmt.Errorf("store: UpdateProductMedia: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: UpdateProductMedia failed to commit: %w", err)
+	}
+	return updated, nil
+}
+
+func (s *PostgresStore) DeleteProductMedia(ctx context.Context, productID, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("store: DeleteProductMedia failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := lockMediaProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM products.product_media WHERE id = $1 AND product_id = $2;`, id, productID)
+	if err != nil {
+		return fmt.Errorf("store: DeleteProductMedia failed to delete media: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("store: DeleteProductMedia failed to get rows affected: %w", err)
+	} else if n == 0 {
+		return ErrMediaNotFound
+	}
+	if err := syncPrimaryImage(ctx, tx, productID); err != nil {
+		return fmt.Errorf("store: DeleteProductMedia: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: DeleteProductMedia failed to commit: %w", err)
+	}
+	return nil
+}
+
+// lockMediaProduct locks a product row (FOR UPDATE) for a change of its media, which also updates
+// its image URL, or returns ErrProductNotFound.
+func lockMediaProduct(ctx context.Context, tx *sql.Tx, productID int64) error {
+	var id int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM products.products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`, productID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		return fmt.Errorf("store: failed to lock product: %w", err)
+	}
+	return nil
+}
+
+// demotePrimaryImage moves the product's primary image other than m to the gallery if m is to be
+// the primary image, so that the unique index on primary images holds.
+func demotePrimaryImage(ctx context.Context, tx *sql.Tx, m *domain.ProductMedia) error {
+	if m.Role != domain.MediaRolePrimary {
+		return nil
+	}
+	query := `
+		UPDATE products.product_media SET role = 'gallery', updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = $1 AND role = 'primary' AND id <> $2;
+	`
+	if _, err := tx.ExecContext(ctx, query, m.ProductID, m.ID); err != nil {
+		return fmt.Errorf("failed to demote primary image: %w", err)
+	}
+	return nil
+}
+
+// syncPrimaryImage sets the image URL of a product to the one derived from its media (see
+// primaryImageURL), touching the product only if it changes.
+func syncPrimaryImage(ctx context.Context, tx *sql.Tx, productID int64) error {
+	query := `
+		UPDATE products.products p SET image_url = derived.url, updated_at = CURRENT_TIMESTAMP
+		FROM (SELECT (
+			SELECT url FROM products.product_media
+			WHERE product_id = $1 AND role IN ('primary', 'gallery')
+			ORDER BY role = 'primary' DESC, position, id LIMIT 1
+		) AS url) derived
+		WHERE p.id = $1 AND p.image_url IS DISTINCT FROM derived.url;
+	`
+	if _, err := tx.ExecContext(ctx, query, productID); err != nil {
+		return fmt.Errorf("failed to update image URL: %w", err)
+	}
+	return nil
+}
+
+func scanMedia(row rowScanner) (*domain.ProductMedia, error) {
+	var m domain.ProductMedia
+	var role string
+	if err := row.Scan(&m.ID, &m.ProductID, &m.URL, &m.AltText, &role, &m.Position, &m.Width, &m.Height, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	m.Role = domain.MediaRole(role)
+	return &m, nil
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore_AddProductMedia_Primary(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM products.products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	// The previous primary image moves to the gallery before the new one is inserted.
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE products.product_media SET role = 'gallery'`)).
+		WithArgs(int64(1), int64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products.product_media`)).
+		WithArgs(int64(1), "https://cdn.example.com/scarf.jpg", "A red scarf", "primary", int32(0), nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "url", "alt_text", "role", "position", "width", "height", "created_at", "updated_at"}).
+			AddRow(int64(7), int64(1), "https://cdn.example.com/scarf.jpg", "A red scarf", "primary", int32(3), nil, nil, now, now))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE products.products p SET image_url = derived.url`)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	created, err := store.AddProductMedia(context.Background(), &domain.ProductMedia{
+		ProductID: 1, URL: "https://cdn.example.com/scarf.jpg", AltText: "A red scarf", Role: domain.MediaRolePrimary,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), created.ID)
+	assert.Equal(t, int32(3), created.Position, "appended after the other images")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_DeleteProductMedia_NotFound(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM products.products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM products.product_media WHERE id = $1 AND product_id = $2;`)).
+		WithArgs(int64(9), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := store.DeleteProductMedia(context.Background(), 1, 9)
+
+	assert.ErrorIs(t, err, ErrMediaNotFound)
+	require.NoError(t, mock.ExpectationsWereMet(), "the image URL is left alone")
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+const priceChangeColumns = `id, product_id, old_price, old_currency, new_price, new_currency, effective_at, effective_until, reason, scheduled_price_id, actor, created_at`
+
+// --- Price history part of the ProductStorer Implementation ---
+
+func (s *PostgresStore) ListPriceChanges(ctx context.Context, params ListPriceChangesParams) ([]domain.PriceChange, int, error) {
+	where := `WHERE product_id = $1 AND ($2::TIMESTAMPTZ IS NULL OR effective_at >= $2) AND ($3::TIMESTAMPTZ IS NULL OR effective_at < $3)`
+
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM products.price_changes ` + where + `;`
+	if err := s.db.QueryRowContext(ctx, countQuery, params.ProductID, params.From, params.To).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("store: ListPriceChanges failed to count price changes: %w", err)
+	}
+	if totalCount == 0 {
+		return []domain.PriceChange{}, 0, nil
+	}
+
+	query := `
+		SELECT ` + priceChangeColumns + `
+		FROM products.price_changes
+		` + where + `
+		ORDER BY effective_at DESC, id DESC
+		LIMIT $4 OFFSET $5;
+	`
+	rows, err := s.db.QueryContext(ctx, query, params.ProductID, params.From, params.To, params.Limit, params.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("store: ListPriceChanges failed to query price changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]domain.PriceChange, 0, params.Limit)
+	for rows.Next() {
+		c, err := scanPriceChange(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("store: ListPriceChanges failed to scan price change: %w", err)
+		}
+		changes = append(changes, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("store: ListPriceChanges iteration error: %w", err)
+	}
+	return changes, totalCount, nil
+}
+
+// GetPricesAt takes the base price from the latest base change effective at the time and lets a
+// scheduled price replace it as effectiveProducts does. Products that no longer exist are absent
+// because their scheduled prices are gone with them; those in the trash are still there.
+func (s *PostgresStore) GetPricesAt(ctx context.Context, productIDs []int64, at time.Time) (map[int64]domain.Money, error) {
+	query := `
+		SELECT p.id, COALESCE(sp.price, b.new_price), b.new_currency
+		FROM products.products p
+		CROSS JOIN LATERAL (
+			SELECT new_price, new_currency FROM products.price_changes
+			WHERE product_id = p.id AND reason IN ('initial_price', 'product_update') AND effective_at <= $2
+			ORDER BY effective_at DESC, id DESC LIMIT 1
+		) b
+		LEFT JOIN LATERAL (
+			SELECT price FROM products.scheduled_prices
+			WHERE product_id = p.id AND currency = b.new_currency AND valid_from <= $2
+				AND (valid_until IS NULL OR valid_until > $2) AND created_at <= $2
+			ORDER BY valid_from DESC, id DESC LIMIT 1
+		) sp ON TRUE
+		WHERE p.id = ANY($1);
+	`
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(productIDs), at)
+	if err != nil {
+		return nil, fmt.Errorf("store: GetPricesAt failed to query prices: %w", err)
+	}
+	defer rows.Close()
+
+	prices := make(map[int64]domain.Money, len(productIDs))
+	for rows.Next() {
+		var id int64
+		var amount, currency string
+		if err := rows.Scan(&id, &amount, &currency); err != nil {
+			return nil, fmt.Errorf("store: GetPricesAt failed to scan price: %w", err)
+		}
+		price, err := domain.ParseMoney(amount, currency)
+		if err != nil {
+			return nil, fmt.Errorf("store: GetPricesAt failed to parse price: %w", err)
+		}
+		prices[id] = price
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: GetPricesAt iteration error: %w", err)
+	}
+	return prices, nil
+}
+
+// insertPriceChange appends c to the price history with the actor of ctx. A zero EffectiveAt means
+// the time of the transaction. It must run in the transaction that changed the price.
+func insertPriceChange(ctx context.Context, ex execer, c *domain.PriceChange) error {
+	var oldPrice, oldCurrency, effectiveAt interface{}
+	if c.OldPrice != nil {
+		oldPrice, oldCurrency = c.OldPrice.Decimal(), c.OldPrice.Currency
+	}
+	if !c.EffectiveAt.IsZero() {
+		effectiveAt = c.EffectiveAt
+	}
+	query := `
+		INSERT INTO products.price_changes
+			(product_id, old_price, old_currency, new_price, new_currency, effective_at, effective_until, reason, scheduled_price_id, actor)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::TIMESTAMPTZ, CURRENT_TIMESTAMP), $7, $8, $9, $10);
+	`
+	_, err := ex.ExecContext(ctx, query, c.ProductID, oldPrice, oldCurrency, c.NewPrice.Decimal(), c.NewPrice.Currency,
+		effectiveAt, c.EffectiveUntil, string(c.Reason), c.ScheduledPriceID, ActorFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to record price change: %w", err)
+	}
+	return nil
+}
+
+func scanPriceChange(row rowScanner) (*domain.PriceChange, error) {
+	var c domain.PriceChange
+	var oldPrice, oldCurrency sql.NullString
+	var newPrice string
+	if err := row.Scan(&c.ID, &c.ProductID, &oldPrice, &oldCurrency, &newPrice, &c.NewPrice.Currency, &c.EffectiveAt,
+		&c.EffectiveUntil, &c.Reason, &c.ScheduledPriceID, &c.Actor, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+	price, err := domain.ParseMoney(newPrice, c.NewPrice.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse new price: %w", err)
+	}
+	c.NewPrice = price
+	if oldPrice.Valid {
+		old, err := domain.ParseMoney(oldPrice.String, oldCurrency.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse old price: %w", err)
+		}
+		c.OldPrice = &old
+	}
+	return &c, nil
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore_ListPriceChanges(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM products.price_changes WHERE product_id = $1`)).
+		WithArgs(int64(1), nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY effective_at DESC, id DESC`)).
+		WithArgs(int64(1), nil, nil, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "old_price", "old_currency", "new_price", "new_currency", "effective_at", "effective_until", "reason", "scheduled_price_id", "actor", "created_at"}).
+			AddRow(int64(2), int64(1), "19.990", "USD", "24.990", "USD", now, nil, "product_update", nil, "pricing-team", now).
+			AddRow(int64(1), int64(1), nil, nil, "19.990", "USD", now, nil, "initial_price", nil, nil, now))
+
+	changes, total, err := store.ListPriceChanges(context.Background(), ListPriceChangesParams{ProductID: 1, Limit: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, changes, 2)
+	assert.Equal(t, usd(1999), *changes[0].OldPrice)
+	assert.Equal(t, usd(2499), changes[0].NewPrice)
+	assert.Equal(t, "pricing-team", *changes[0].Actor)
+	assert.Nil(t, changes[1].OldPrice)
+	assert.Equal(t, domain.PriceReasonInitialPrice, changes[1].Reason)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_DeleteScheduledPrice_EndsStartedPrice(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	ctx := WithActor(context.Background(), "pricing-team")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT price, currency FROM products.products WHERE id = $1 AND deleted_at IS NULL FOR SHARE;`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"price", "currency"}).AddRow("19.990", "USD"))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM products.scheduled_prices`)).
+		WithArgs(int64(7), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"price", "currency", "started", "ended"}).AddRow("9.990", "USD", true, false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE products.scheduled_prices SET valid_until = CURRENT_TIMESTAMP WHERE id = $1;`)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.price_changes`)).
+		WithArgs(int64(1), "9.99", "USD", "19.99", "USD", nil, nil, "scheduled_price_cancelled", int64(7), "pricing-team").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, store.DeleteScheduledPrice(ctx, 1, 7))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,344 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+const priceListColumns = `id, name, currency, market, customer_group, is_default, created_at, updated_at`
+
+// priceListEntryColumns are read from price_list_entries e joined with price_lists pl, which
+// supplies the currency of the price.
+const priceListEntryColumns = `e.price_list_id, e.product_id, e.price, pl.currency, e.updated_at`
+
+// --- PriceListStorer Implementation ---
+
+func (s *PostgresStore) CreatePriceList(ctx context.Context, list *domain.PriceList) (*domain.PriceList, error) {
+	if err := validatePriceList(list); err != nil {
+		return nil, err
+	}
+	query := `
+		INSERT INTO products.price_lists (name, currency, market, customer_group, is_default)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + priceListColumns + `;`
+	created, err := scanPriceList(s.db.QueryRowContext(ctx, query, list.Name, list.Currency, list.Market, list.CustomerGroup, list.IsDefault))
+	if err != nil {
+		if uniqueErr := priceListUniqueViolation(err); uniqueErr != nil {
+			return nil, uniqueErr
+		}
+		return nil, fmt.Errorf("store: CreatePriceList failed to scan row: %w", err)
+	}
+	return created, nil
+}
+
+func (s *PostgresStore) GetPriceListByID(ctx context.Context, id int64) (*domain.PriceList, error) {
+	query := `SELECT ` + priceListColumns + ` FROM products.price_lists WHERE id = $1;`
+	list, err := scanPriceList(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPriceListNotFound
+		}
+		return nil, fmt.Errorf("store: GetPriceListByID failed to scan row: %w", err)
+	}
+	return list, nil
+}
+
+func (s *PostgresStore) ListPriceLists(ctx context.Context) ([]domain.PriceList, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+priceListColumns+` FROM products.price_lists ORDER BY name;`)
+	if err != nil {
+		return nil, fmt.Errorf("store: ListPriceLists failed to query price lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []domain.PriceList{}
+	for rows.Next() {
+		list, err := scanPriceList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("store: ListPriceLists failed to scan price list: %w", err)
+		}
+		lists = append(lists, *list)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: ListPriceLists iteration error: %w", err)
+	}
+	return lists, nil
+}
+
+// UpdatePriceList only changes the currency of a list without entries, whose prices would
+// otherwise silently change currency.
+func (s *PostgresStore) UpdatePriceList(ctx context.Context, list *domain.PriceList) (*domain.PriceList, error) {
+	if err := validatePriceList(list); err != nil {
+		return nil, err
+	}
+	query := `
+		UPDATE products.price_lists
+		SET name = $1, currency = $2, market = $3, customer_group = $4, is_default = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6 AND (currency = $2 OR NOT EXISTS (SELECT 1 FROM products.price_list_entries WHERE price_list_id = $6))
+		RETURNING ` + priceListColumns + `;`
+	updated, err := scanPriceList(s.db.QueryRowContext(ctx, query, list.Name, list.Currency, list.Market, list.CustomerGroup, list.IsDefault, list.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if _, getErr := s.GetPriceListByID(ctx, list.ID); getErr != nil {
+				return nil, getErr
+			}
+			return nil, fmt.Errorf("%w: the currency of a list with entries cannot change", ErrInvalidPriceList)
+		}
+		if uniqueErr := priceListUniqueViolation(err); uniqueErr != nil {
+			return nil, uniqueErr
+		}
+		return nil, fmt.Errorf("store: UpdatePriceList failed to scan row: %w", err)
+	}
+	return updated, nil
+}
+
+func (s *PostgresStore) DeletePriceList(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM products.price_lists WHERE id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("store: DeletePriceList failed to execute delete: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("store: DeletePriceList failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrPriceListNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStore) ListPriceListEntries(ctx context.Context, params ListPriceListEntriesParams) ([]domain.PriceListEntry, int, error) {
+	if _, err := s.GetPriceListByID(ctx, params.PriceListID); err != nil {
+		return nil, 0, err
+	}
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM products.price_list_entries WHERE price_list_id = $1;`
+	if err := s.db.QueryRowContext(ctx, countQuery, params.PriceListID).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("store: ListPriceListEntries failed to count entries: %w", err)
+	}
+	if totalCount == 0 {
+		return []domain.PriceListEntry{}, 0, nil
+	}
+
+	query := `
+		SELECT ` + priceListEntryColumns + `
+		FROM products.price_list_entries e
+		JOIN products.price_lists pl ON pl.id = e.price_list_id
+		WHERE e.price_list_id = $1
+		ORDER BY e.product_id
+		LIMIT $2 OFFSET $3;
+	`
+	entries, err := queryPriceListEntries(ctx, s.db, query, params.PriceListID, params.Limit, params.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("store: ListPriceListEntries: %w", err)
+	}
+	return entries, totalCount, nil
+}
+
+// SetPriceListEntries writes all entries in one statement after checking, with the list and the
+// products locked against deletion, that every product exists.
+func (s *PostgresStore) SetPriceListEntries(ctx context.Context, priceListID int64, entries []domain.PriceListEntry) ([]domain.PriceListEntry, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: SetPriceListEntries failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	list, err := scanPriceList(tx.QueryRowContext(ctx, `SELECT `+priceListColumns+` FROM products.price_lists WHERE id = $1 FOR SHARE;`, priceListID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPriceListNotFound
+		}
+		return nil, fmt.Errorf("store: SetPriceListEntries failed to lock price list: %w", err)
+	}
+	if err := validatePriceListEntries(list, entries); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return []domain.PriceListEntry{}, nil
+	}
+
+	productIDs := make([]int64, len(entries))
+	prices := make([]string, len(entries))
+	for i, e := range entries {
+		productIDs[i] = e.ProductID
+		prices[i] = e.Price.Decimal()
+	}
+	existing := make(map[int64]bool, len(entries))
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM products.products WHERE id = ANY($1) AND deleted_at IS NULL FOR KEY SHARE;`, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("store: SetPriceListEntries failed to lock products: %w", err)
+	}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: SetPriceListEntries failed to scan product ID: %w", err)
+		}
+		existing[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: SetPriceListEntries product iteration error: %w", err)
+	}
+	for _, id := range productIDs {
+		if !existing[id] {
+			return nil, fmt.Errorf("%w (product ID %d)", ErrProductNotFound, id)
+		}
+	}
+
+	query := `
+		INSERT INTO products.price_list_entries (price_list_id, product_id, price)
+		SELECT $1, e.product_id, e.price
+		FROM unnest($2::BIGINT[], $3::NUMERIC[]) AS e(product_id, price)
+		ON CONFLICT ON CONSTRAINT price_list_entries_pkey DO UPDATE
+		SET price = EXCLUDED.price, updated_at = CURRENT_TIMESTAMP
+		RETURNING product_id, updated_at;
+	`
+	rows, err = tx.QueryContext(ctx, query, priceListID, pq.Array(productIDs), pq.Array(prices))
+	if err != nil {
+		return nil, fmt.Errorf("store: SetPriceListEntries failed to write entries: %w", err)
+	}
+	updatedAt := make(map[int64]time.Time, len(entries))
+	for rows.Next() {
+		var id int64
+		var t time.Time
+		if err := rows.Scan(&id, &t); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: SetPriceListEntries failed to scan entry: %w", err)
+		}
+		updatedAt[id] = t
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: SetPriceListEntries entry iteration error: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: SetPriceListEntries failed to commit: %w", err)
+	}
+
+	stored := make([]domain.PriceListEntry, len(entries))
+	for i, e := range entries {
+		stored[i] = domain.PriceListEntry{PriceListID: priceListID, ProductID: e.ProductID, Price: e.Price, UpdatedAt: updatedAt[e.ProductID]}
+	}
+	return stored, nil
+}
+
+func (s *PostgresStore) DeletePriceListEntries(ctx context.Context, priceListID int64, productIDs []int64) (int, error) {
+	if _, err := s.GetPriceListByID(ctx, priceListID); err != nil {
+		return 0, err
+	}
+	result, err := s.db.ExecContext(ctx, `DELETE FROM products.price_list_entries WHERE price_list_id = $1 AND product_id = ANY($2);`,
+		priceListID, pq.Array(productIDs))
+	if err != nil {
+		return 0, fmt.Errorf("store: DeletePriceListEntries failed to execute delete: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("store: DeletePriceListEntries failed to get rows affected: %w", err)
+	}
+	return int(rowsAffected), nil
+}
+
+// ResolvePrices reads the entries of the selected list and of the currency's default list in one
+// query; an entry in the selected list takes precedence.
+func (s *PostgresStore) ResolvePrices(ctx context.Context, sel PriceSelection, products []domain.Product) error {
+	if sel.IsZero() || len(products) == 0 {
+		return nil
+	}
+	var selected *domain.PriceList
+	if sel.PriceListID != nil {
+		list, err := s.GetPriceListByID(ctx, *sel.PriceListID)
+		if err != nil {
+			return err
+		}
+		selected = list
+	}
+	currency, err := selectionCurrency(sel, selected)
+	if err != nil {
+		return err
+	}
+	var selectedID int64 // 0 matches no list
+	if selected != nil {
+		selectedID = selected.ID
+	}
+	productIDs := make([]int64, len(products))
+	for i, p := range products {
+		productIDs[i] = p.ID
+	}
+
+	query := `
+		SELECT ` + priceListEntryColumns + `
+		FROM products.price_list_entries e
+		JOIN products.price_lists pl ON pl.id = e.price_list_id
+		WHERE e.product_id = ANY($1) AND (e.price_list_id = $2 OR (pl.is_default AND pl.currency = $3));
+	`
+	entries, err := queryPriceListEntries(ctx, s.db, query, pq.Array(productIDs), selectedID, currency)
+	if err != nil {
+		return fmt.Errorf("store: ResolvePrices: %w", err)
+	}
+	prices := make(map[int64]domain.PriceListEntry, len(entries))
+	for _, e := range entries {
+		if _, found := prices[e.ProductID]; !found || e.PriceListID == selectedID {
+			prices[e.ProductID] = e
+		}
+	}
+	applyListPrices(products, prices)
+	return nil
+}
+
+// queryPriceListEntries runs a query selecting priceListEntryColumns.
+func queryPriceListEntries(ctx context.Context, q queryer, query string, args ...interface{}) ([]domain.PriceListEntry, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price list entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.PriceListEntry{}
+	for rows.Next() {
+		var e domain.PriceListEntry
+		var price string
+		if err := rows.Scan(&e.PriceListID, &e.ProductID, &price, &e.Price.Currency, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan price list entry: %w", err)
+		}
+		if e.Price, err = domain.ParseMoney(price, e.Price.Currency); err != nil {
+			return nil, fmt.Errorf("failed to parse price: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("price list entry iteration error: %w", err)
+	}
+	return entries, nil
+}
+
+func scanPriceList(row rowScanner) (*domain.PriceList, error) {
+	var l domain.PriceList
+	if err := row.Scan(&l.ID, &l.Name, &l.Currency, &l.Market, &l.CustomerGroup, &l.IsDefault, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// priceListUniqueViolation maps a violation of the name or default-per-currency constraint to its
+// error, and returns nil for any other error.
+func priceListUniqueViolation(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "23505" {
+		return nil
+	}
+	switch pqErr.Constraint {
+	case "price_lists_name_key":
+		return ErrPriceListNameExists
+	case "price_lists_default_currency_idx":
+		return ErrDefaultPriceListExists
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	priceListColumnNames      = []string{"id", "name", "currency", "market", "customer_group", "is_default", "created_at", "updated_at"}
+	priceListEntryColumnNames = []string{"price_list_id", "product_id", "price", "currency", "updated_at"}
+)
+
+func TestPostgresStore_CreatePriceList_DefaultExists(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products.price_lists`)).
+		WithArgs("EU retail", "EUR", nil, nil, true).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "price_lists_default_currency_idx"})
+
+	_, err := store.CreatePriceList(context.Background(), &domain.PriceList{Name: "EU retail", Currency: "EUR", IsDefault: true})
+
+	assert.ErrorIs(t, err, ErrDefaultPriceListExists)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_ResolvePrices(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, currency, market, customer_group, is_default, created_at, updated_at FROM products.price_lists WHERE id = $1;`)).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows(priceListColumnNames).AddRow(int64(2), "EU wholesale", "EUR", nil, "wholesale", false, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE e.product_id = ANY($1) AND (e.price_list_id = $2 OR (pl.is_default AND pl.currency = $3));`)).
+		WithArgs(pq.Array([]int64{1, 2, 3}), int64(2), "EUR").
+		WillReturnRows(sqlmock.NewRows(priceListEntryColumnNames).
+			AddRow(int64(2), int64(1), "12.990", "EUR", now).
+			AddRow(int64(1), int64(1), "17.990", "EUR", now). // The default list loses to the selected one
+			AddRow(int64(1), int64(2), "8.990", "EUR", now))
+
+	products := []domain.Product{{ID: 1, Price: usd(1999)}, {ID: 2, Price: usd(999)}, {ID: 3, Price: usd(500)}}
+	listID := int64(2)
+	err := store.ResolvePrices(context.Background(), PriceSelection{PriceListID: &listID}, products)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.Money{Amount: 1299, Currency: "EUR"}, products[0].Price)
+	assert.Equal(t, int64(2), *products[0].PriceListID)
+	assert.Equal(t, domain.Money{Amount: 899, Currency: "EUR"}, products[1].Price)
+	assert.Equal(t, int64(1), *products[1].PriceListID)
+	assert.Equal(t, usd(500), products[2].Price)
+	assert.Nil(t, products[2].PriceListID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_SetPriceListEntries_ProductNotFound(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM products.price_lists WHERE id = $1 FOR SHARE;`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(priceListColumnNames).AddRow(int64(1), "EU retail", "EUR", nil, nil, true, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM products.products WHERE id = ANY($1) AND deleted_at IS NULL FOR KEY SHARE;`)).
+		WithArgs(pq.Array([]int64{1, 7})).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectRollback()
+
+	_, err := store.SetPriceListEntries(context.Background(), 1, []domain.PriceListEntry{
+		{ProductID: 1, Price: domain.Money{Amount: 1799, Currency: "EUR"}},
+		{ProductID: 7, Price: domain.Money{Amount: 899, Currency: "EUR"}},
+	})
+
+	assert.ErrorIs(t, err, ErrProductNotFound)
+	assert.Contains(t, err.Error(), "product ID 7")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,250 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// effectiveProductColumns are the columns of product reads, which select from effectiveProducts.
+var effectiveProductColumns = append(productColumns[:len(productColumns):len(productColumns)], "compare_at_price", "parent_id", "options", "option_values", "inherits_price")
+
+func TestPostgresStore_ListProducts_AfterCursor(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	params := ListProductsParams{SortBy: "created_at", SortOrder: "desc", IsActive: PtrTo(true), Limit: 2}
+	after := ProductCursor(params, &domain.Product{ID: 7, CreatedAt: now})
+	params.After = &after
+
+	// The count ignores the cursor; the data query continues after (created_at, id) in the same order.
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM ` + effectiveProducts + ` WHERE is_active = $1`)).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE is_active = $1 AND (created_at, id) < ($2, $3) ORDER BY created_at DESC, id DESC LIMIT $4 OFFSET $5`)).
+		WithArgs(true, now, int64(7), 2, 0).
+		WillReturnRows(sqlmock.NewRows(effectiveProductColumns).
+			AddRow(int64(6), "Older", nil, "SKU-6", "10.000", "USD", int32(1), nil, nil, true, nil, now.Add(-time.Hour), now, nil, nil, "{}", nil, false))
+
+	products, total, err := store.ListProducts(context.Background(), params)
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, total)
+	require.Len(t, products, 1)
+	assert.Equal(t, int64(6), products[0].ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_ListProducts_MismatchedCursor(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	after := ProductCursor(ListProductsParams{SortBy: "price"}, &domain.Product{ID: 1, Price: usd(999)})
+
+	_, _, err := store.ListProducts(context.Background(), ListProductsParams{SortBy: "name", Limit: 10, After: &after})
+
+	assert.True(t, errors.Is(err, ErrInvalidCursor))
+	require.NoError(t, mock.ExpectationsWereMet(), "no query is issued for an invalid cursor")
+}
+
+func TestPostgresStore_ListProducts_FullTextSearch(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	params := ListProductsParams{SearchQuery: PtrTo(`"noise cancelling" -wired`), Limit: 10}
+	searchJoin := ` FROM ` + effectiveProducts + ` CROSS JOIN (SELECT language, websearch_to_tsquery(language, $1) AS query FROM products.search_config) search WHERE search_vector @@ search.query`
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*)` + searchJoin)).
+		WithArgs(*params.SearchQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	// A search sorts by relevance (best first) unless another sort_by is given.
+	mock.ExpectQuery(regexp.QuoteMeta(`created_at, updated_at, compare_at_price, parent_id, options, option_values, inherits_price, ts_rank(search_vector, search.query), ts_headline(search.language,`)+
+		`.*`+regexp.QuoteMeta(searchJoin+` ORDER BY ts_rank(search_vector, search.query) DESC, id DESC LIMIT $2 OFFSET $3`)).
+		WithArgs(*params.SearchQuery, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "sku", "price", "currency", "stock_quantity", "category_id", "image_url", "is_active", "attributes", "created_at", "updated_at", "compare_at_price", "parent_id", "options", "option_values", "inherits_price", "ts_rank", "ts_headline"}).
+			AddRow(int64(3), "Headphones", "Wireless noise cancelling", "HP-3", "99.000", "USD", int32(1), nil, nil, true, nil, now, now, nil, nil, "{}", nil, false, float32(0.6079271), "Wireless <mark>noise</mark> <mark>cancelling</mark>"))
+
+	products, total, err := store.ListProducts(context.Background(), params)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, products, 1)
+	require.NotNil(t, products[0].SearchRank)
+	assert.Equal(t, float32(0.6079271), *products[0].SearchRank)
+	assert.Equal(t, "Wireless <mark>noise</mark> <mark>cancelling</mark>", *products[0].SearchSnippet)
+	assert.Equal(t, "0.6079271", ProductCursor(params, &products[0]).Key, "relevance cursors round-trip the float4 rank")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_SetSearchLanguage(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	// Unchanged: nothing is reindexed.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT language::text FROM products.search_config FOR UPDATE;`)).
+		WillReturnRows(sqlmock.NewRows([]string{"language"}).AddRow("english"))
+	mock.ExpectRollback()
+
+	reindexed, err := store.SetSearchLanguage(context.Background(), "english")
+	require.NoError(t, err)
+	assert.False(t, reindexed)
+
+	// Changed: the configuration and every search vector are updated together.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT language::text FROM products.search_config FOR UPDATE;`)).
+		WillReturnRows(sqlmock.NewRows([]string{"language"}).AddRow("english"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE products.search_config SET language = $1::regconfig;`)).
+		WithArgs("german").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`SET search_vector = products.product_search_vector($1::regconfig, name, sku, description, attributes);`)).
+		WithArgs("german").WillReturnResult(sqlmock.NewResult(0, 42))
+	mock.ExpectCommit()
+
+	reindexed, err = store.SetSearchLanguage(context.Background(), "german")
+	require.NoError(t, err)
+	assert.True(t, reindexed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_ListProductFacets(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	params := ListProductsParams{CategoryID: PtrTo(int64(3)), Limit: 10}
+	req := FacetRequest{Categories: true, PriceBoundaries: []domain.Money{usd(1000), usd(10000)}, IsActive: true, AttributeKeys: []string{"color"}}
+
+	// Every facet query applies the listing's filters and nothing of its pagination.
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT category_id, COUNT(*) FROM ` + effectiveProducts + ` WHERE category_id = $1 GROUP BY category_id`)).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "count"}).AddRow(int64(3), 4))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT width_bucket(price, $2::numeric[]) AS bucket, COUNT(*) FROM `+effectiveProducts+` WHERE category_id = $1 AND currency = $3 GROUP BY bucket`)).
+		WithArgs(int64(3), sqlmock.AnyArg(), "USD").
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "count"}).AddRow(0, 1).AddRow(2, 3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT is_active, COUNT(*) FROM ` + effectiveProducts + ` WHERE category_id = $1 GROUP BY is_active`)).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"is_active", "count"}).AddRow(true, 4))
+	mock.ExpectQuery(`SELECT facet.value, COUNT\(\*\) FROM `+regexp.QuoteMeta(effectiveProducts)+` CROSS JOIN LATERAL \(.+attributes -> \$2.+\) facet WHERE category_id = \$1 GROUP BY facet.value ORDER BY COUNT\(\*\) DESC, facet.value LIMIT 50`).
+		WithArgs(int64(3), "color").
+		WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).AddRow("red", 3).AddRow("blue", 1))
+
+	facets, err := store.ListProductFacets(context.Background(), params, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CategoryFacet{{CategoryID: PtrTo(int64(3)), Count: 4}}, facets.Categories)
+	require.Len(t, facets.PriceRanges, 3)
+	assert.Equal(t, []int{1, 0, 3}, []int{facets.PriceRanges[0].Count, facets.PriceRanges[1].Count, facets.PriceRanges[2].Count}, "empty ranges are kept")
+	assert.Equal(t, []domain.IsActiveFacet{{Value: true, Count: 4}, {Value: false, Count: 0}}, facets.IsActive)
+	assert.Equal(t, []domain.AttributeValueFacet{{Value: "red", Count: 3}, {Value: "blue", Count: 1}}, facets.Attributes["color"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_ListProducts_AttributeFilters(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	params := ListProductsParams{Limit: 10, Attributes: []AttributeFilter{
+		{Key: "size", Op: AttributeIn, Values: []string{"M", "16"}},
+		{Key: "weight", Op: AttributeRange, Min: PtrTo(1.5)},
+		{Key: "color'; DROP TABLE products.products; --", Op: AttributeExists},
+	}}
+
+	// Keys and values only ever travel as arguments. Equality becomes containment so the GIN index applies.
+	where := `(attributes @> $1::jsonb OR attributes @> $2::jsonb OR attributes @> $3::jsonb OR attributes @> $4::jsonb OR attributes @> $5::jsonb OR attributes @> $6::jsonb)` +
+		` AND (attributes ? $7 AND (CASE WHEN jsonb_typeof(attributes -> $7) = 'number' THEN (attributes ->> $7)::numeric END) >= $8)` +
+		` AND attributes ? $9`
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM `+effectiveProducts+` WHERE `+where)).
+		WithArgs(`{"size":"M"}`, `{"size":["M"]}`, `{"size":"16"}`, `{"size":["16"]}`, `{"size":16}`, `{"size":[16]}`, "weight", 1.5, "color'; DROP TABLE products.products; --").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0)) // No data query follows
+
+	products, total, err := store.ListProducts(context.Background(), params)
+
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, _, err = store.ListProducts(context.Background(), ListProductsParams{Attributes: []AttributeFilter{{Key: "size", Op: AttributeIn}}})
+	assert.ErrorIs(t, err, ErrInvalidAttributeFilter, "rejected before querying")
+}
+
+func TestPostgresStore_ListProducts_IncludeDescendants(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM ` + effectiveProducts + ` WHERE category_id IN (WITH RECURSIVE subtree AS (` +
+		`SELECT id, ARRAY[id] AS path FROM products.categories WHERE id = $1 UNION ALL`)).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	_, total, err := store.ListProducts(context.Background(), ListProductsParams{Limit: 10, CategoryID: PtrTo(int64(4)), IncludeDescendants: true})
+
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_ListProducts_ExactPrices(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	params := ListProductsParams{MinPrice: &domain.Money{Amount: 1999, Currency: "EUR"}, MaxPrice: &domain.Money{Amount: 2000, Currency: "EUR"}, Limit: 10}
+
+	// Bounds travel as decimal strings, never as floats, and only match their own currency.
+	where := `WHERE currency = $1 AND price >= $2::numeric AND currency = $3 AND price <= $4::numeric`
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM `+effectiveProducts+` `+where)).
+		WithArgs("EUR", "19.99", "EUR", "20.00").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(where)).
+		WithArgs("EUR", "19.99", "EUR", "20.00", 10, 0).
+		WillReturnRows(sqlmock.NewRows(effectiveProductColumns).
+			AddRow(int64(1), "Scarf", nil, "SCARF-1", "19.990", "EUR", int32(1), nil, nil, true, nil, now, now, nil, nil, "{}", nil, false))
+
+	products, _, err := store.ListProducts(context.Background(), params)
+
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, domain.Money{Amount: 1999, Currency: "EUR"}, products[0].Price)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CreateProduct_NegativePrice(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products.products`)).
+		WillReturnError(&pq.Error{Code: "23514", Constraint: "products_price_check"})
+	mock.ExpectRollback()
+
+	_, err := store.CreateProduct(context.Background(), &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(-1999)})
+
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CreateProduct_PriceOverflow(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products.products`)).
+		WillReturnError(&pq.Error{Code: "22003", Message: "numeric field overflow"})
+	mock.ExpectRollback()
+
+	_, err := store.CreateProduct(context.Background(), &domain.Product{Name: "Scarf", SKU: "SCARF", Price: usd(100_000_000_000_000)})
+
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
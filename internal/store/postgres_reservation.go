@@ -0,0 +1,271 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+// Predefined errors for reservation operations
+var (
+	ErrReservationNotFound = errors.New("store: no active reservation for reference")
+	ErrReservationExists   = errors.New("store: reference already holds an active reservation")
+	ErrReservationExpired  = errors.New("store: reservation has expired")
+)
+
+const reservationColumns = `id, reference_id, product_id, quantity, status, expires_at, created_at, updated_at`
+
+// --- ReservationStorer Implementation ---
+
+// ReserveStock locks the requested product rows in ID order, so that concurrent reservations and
+// stock updates for the same products are serialized, and checks each item against
+// stock_quantity minus the quantities already held by other active reservations.
+func (s *PostgresStore) ReserveStock(ctx context.Context, referenceID string, items []ReservationItem, expiresAt time.Time) ([]domain.StockReservation, error) {
+	items = mergeReservationItems(items)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: ReserveStock failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	// Holds of this reference that expired but were not swept yet must not block a new reservation.
+	expireStale := `
+		UPDATE products.stock_reservations
+		SET status = 'expired', updated_at = CURRENT_TIMESTAMP
+		WHERE reference_id = $1 AND status = 'active' AND expires_at <= CURRENT_TIMESTAMP;
+	`
+	if _, err := tx.ExecContext(ctx, expireStale, referenceID); err != nil {
+		return nil, fmt.Errorf("store: ReserveStock failed to expire stale holds: %w", err)
+	}
+	var exists bool
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM products.stock_reservations WHERE reference_id = $1 AND status = 'active');`
+	if err := tx.QueryRowContext(ctx, existsQuery, referenceID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("store: ReserveStock failed to check existing reservation: %w", err)
+	}
+	if exists {
+		return nil, ErrReservationExists
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ProductID
+	}
+	stock, err := lockProductStock(ctx, tx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("store: ReserveStock: %w", err)
+	}
+	reserved, err := reservedQuantities(ctx, tx, ids, "")
+	if err != nil {
+		return nil, fmt.Errorf("store: ReserveStock: %w", err)
+	}
+	if err := checkReservationItems(items, stock, reserved); err != nil {
+		return nil, err
+	}
+
+	insertQuery := `
+		INSERT INTO products.stock_reservations (reference_id, product_id, quantity, status, expires_at)
+		VALUES ($1, $2, $3, 'active', $4)
+		RETURNING ` + reservationColumns + `;`
+	reservations := make([]domain.StockReservation, 0, len(items))
+	for _, item := range items {
+		r, err := scanReservation(tx.QueryRowContext(ctx, insertQuery, referenceID, item.ProductID, item.Quantity, expiresAt))
+		if err != nil {
+			return nil, fmt.Errorf("store: ReserveStock failed to insert reservation: %w", err)
+		}
+		reservations = append(reservations, *r)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: ReserveStock failed to commit: %w", err)
+	}
+	return reservations, nil
+}
+
+func (s *PostgresStore) CommitReservation(ctx context.Context, referenceID string) ([]domain.StockReservation, []domain.Product, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("store: CommitReservation failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	selectQuery := `
+		SELECT ` + reservationColumns + `, expires_at <= CURRENT_TIMESTAMP AS expired
+		FROM products.stock_reservations
+		WHERE reference_id = $1 AND status = 'active'
+		ORDER BY product_id
+		FOR UPDATE;
+	`
+	rows, err := tx.QueryContext(ctx, selectQuery, referenceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("store: CommitReservation failed to query reservations: %w", err)
+	}
+	var held []domain.StockReservation
+	anyExpired := false
+	for rows.Next() {
+		var r domain.StockReservation
+		var expired bool
+		if err := rows.Scan(&r.ID, &r.ReferenceID, &r.ProductID, &r.Quantity, &r.Status, &r.ExpiresAt, &r.CreatedAt, &r.UpdatedAt, &expired); err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("store: CommitReservation failed to scan reservation: %w", err)
+		}
+		anyExpired = anyExpired || expired
+		held = append(held, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("store: CommitReservation iteration error: %w", err)
+	}
+	if len(held) == 0 {
+		return nil, nil, ErrReservationNotFound
+	}
+	if anyExpired {
+		// Expire the whole hold now rather than waiting for the sweeper.
+		if _, err := tx.ExecContext(ctx, `UPDATE products.stock_reservations SET status = 'expired', updated_at = CURRENT_TIMESTAMP WHERE reference_id = $1 AND status = 'active';`, referenceID); err != nil {
+			return nil, nil, fmt.Errorf("store: CommitReservation failed to expire reservation: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, nil, fmt.Errorf("store: CommitReservation failed to commit expiry: %w", err)
+		}
+		return nil, nil, ErrReservationExpired
+	}
+
+	// Each held quantity is taken from the product's locations in allocation order.
+	updates := make([]StockUpdate, 0, len(held))
+	for _, r := range held {
+		updates = append(updates, StockUpdate{ProductID: r.ProductID, QuantityChange: -r.Quantity})
+	}
+	info := StockMovementInfo{Reason: domain.StockReasonReservationCommit, OrderID: &referenceID}
+	results, err := changeStock(ctx, tx, updates, true, false, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("store: CommitReservation: %w", err)
+	}
+	if err := firstStockError(results); err != nil {
+		// Stock was decremented outside the reservation since it was taken.
+		return nil, nil, err
+	}
+	products := make([]domain.Product, 0, len(results))
+	for _, res := range results {
+		products = append(products, *res.Product)
+	}
+
+	committed, err := setReservationStatus(ctx, tx, referenceID, domain.ReservationCommitted)
+	if err != nil {
+		return nil, nil, fmt.Errorf("store: CommitReservation: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("store: CommitReservation failed to commit: %w", err)
+	}
+	return committed, products, nil
+}
+
+func (s *PostgresStore) ReleaseReservation(ctx context.Context, referenceID string) ([]domain.StockReservation, error) {
+	released, err := setReservationStatus(ctx, s.db, referenceID, domain.ReservationReleased)
+	if err != nil {
+		return nil, fmt.Errorf("store: ReleaseReservation: %w", err)
+	}
+	if len(released) == 0 {
+		return nil, ErrReservationNotFound
+	}
+	return released, nil
+}
+
+func (s *PostgresStore) ExpireReservations(ctx context.Context, now time.Time) (int, error) {
+	query := `
+		UPDATE products.stock_reservations
+		SET status = 'expired', updated_at = CURRENT_TIMESTAMP
+		WHERE status = 'active' AND expires_at <= $1;
+	`
+	result, err := s.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("store: ExpireReservations failed to execute update: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("store: ExpireReservations failed to get rows affected: %w", err)
+	}
+	return int(rowsAffected), nil
+}
+
+func (s *PostgresStore) GetReservedQuantities(ctx context.Context, productIDs []int64, excludeReferenceID string) (map[int64]int32, error) {
+	if len(productIDs) == 0 {
+		return map[int64]int32{}, nil
+	}
+	reserved, err := reservedQuantities(ctx, s.db, productIDs, excludeReferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("store: GetReservedQuantities: %w", err)
+	}
+	return reserved, nil
+}
+
+// --- Helpers ---
+
+// reservedQuantities sums the active, unexpired holds per product.
+func reservedQuantities(ctx context.Context, q queryer, productIDs []int64, excludeReferenceID string) (map[int64]int32, error) {
+	query := `
+		SELECT product_id, COALESCE(SUM(quantity), 0)
+		FROM products.stock_reservations
+		WHERE product_id = ANY($1) AND status = 'active' AND expires_at > CURRENT_TIMESTAMP AND reference_id <> $2
+		GROUP BY product_id;
+	`
+	rows, err := q.QueryContext(ctx, query, pq.Array(productIDs), excludeReferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum reserved quantities: %w", err)
+	}
+	defer rows.Close()
+
+	reserved := make(map[int64]int32, len(productIDs))
+	for rows.Next() {
+		var id int64
+		var qty int32
+		if err := rows.Scan(&id, &qty); err != nil {
+			return nil, fmt.Errorf("failed to scan reserved quantity: %w", err)
+		}
+		reserved[id] = qty
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reserved quantities iteration error: %w", err)
+	}
+	return reserved, nil
+}
+
+// setReservationStatus moves every active reservation of referenceID to status and returns them.
+func setReservationStatus(ctx context.Context, q queryer, referenceID string, status domain.ReservationStatus) ([]domain.StockReservation, error) {
+	query := `
+		UPDATE products.stock_reservations
+		SET status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE reference_id = $1 AND status = 'active'
+		RETURNING ` + reservationColumns + `;`
+	rows, err := q.QueryContext(ctx, query, referenceID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reservation status: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []domain.StockReservation
+	for rows.Next() {
+		r, err := scanReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		reservations = append(reservations, *r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reservation iteration error: %w", err)
+	}
+	return reservations, nil
+}
+
+// scanReservation scans a row selected with reservationColumns.
+func scanReservation(row rowScanner) (*domain.StockReservation, error) {
+	var r domain.StockReservation
+	if err := row.Scan(&r.ID, &r.ReferenceID, &r.ProductID, &r.Quantity, &r.Status, &r.ExpiresAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var reservationColumnNames = []string{"id", "reference_id", "product_id", "quantity", "status", "expires_at", "created_at", "updated_at"}
+
+func TestPostgresStore_ReserveStock_SubtractsOtherHolds(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	expiresAt := time.Now().Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'expired'`)).WithArgs("order-1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(`)).WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(`FOR UPDATE`).WithArgs(pq.Array([]int64{2, 5})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "stock_quantity"}).AddRow(int64(2), int32(10)).AddRow(int64(5), int32(3)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SUM(quantity)`)).WithArgs(pq.Array([]int64{2, 5}), "").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "sum"}).AddRow(int64(5), int32(2)))
+	mock.ExpectRollback()
+
+	_, err := store.ReserveStock(context.Background(), "order-1", []ReservationItem{
+		{ProductID: 5, Quantity: 2},
+		{ProductID: 2, Quantity: 4},
+	}, expiresAt)
+
+	assert.True(t, errors.Is(err, ErrInsufficientStock), "product 5 has 3 in stock but 2 are held elsewhere")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CommitReservation_DecrementsInProductOrder(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY product_id`)).WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows(append(reservationColumnNames, "expired")).
+			AddRow(int64(11), "order-1", int64(2), int32(4), "active", later, now, now, false).
+			AddRow(int64(10), "order-1", int64(5), int32(1), "active", later, now, now, false))
+	expectStockLevels(mock, []int64{2, 5},
+		sqlmock.NewRows([]string{"id", "stock_quantity"}).AddRow(int64(2), int32(10)).AddRow(int64(5), int32(3)),
+		sqlmock.NewRows(locationColumnNames).AddRow(int64(1), "default", "Default", int32(0), now, now),
+		sqlmock.NewRows(locationStockColumnNames).AddRow(int64(2), int64(1), int32(10), now).AddRow(int64(5), int64(1), int32(3), now))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.location_stock`)).
+		WithArgs(pq.Array([]int64{2, 5}), pq.Array([]int64{1, 1}), pq.Array([]int64{6, 2})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	updateQuery := regexp.QuoteMeta(`SET stock_quantity = $1, updated_at = CURRENT_TIMESTAMP`)
+	mock.ExpectQuery(updateQuery).WithArgs(int32(6), int64(2)).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(int64(2), "P2", nil, "SKU-2", "1.500", "USD", int32(6), nil, nil, true, nil, now, now))
+	mock.ExpectQuery(updateQuery).WithArgs(int32(2), int64(5)).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(int64(5), "P5", nil, "SKU-5", "2.500", "USD", int32(2), nil, nil, true, nil, now, now))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.stock_movements`)).
+		WithArgs(pq.Array([]int64{2, 5}), pq.Array([]sql.NullInt64{{Int64: 1, Valid: true}, {Int64: 1, Valid: true}}),
+			pq.Array([]int64{-4, -1}), pq.Array([]int64{6, 2}), "reservation_commit", "order-1", nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SET status = $2`)).WithArgs("order-1", "committed").
+		WillReturnRows(sqlmock.NewRows(reservationColumnNames).
+			AddRow(int64(10), "order-1", int64(5), int32(1), "committed", later, now, now).
+			AddRow(int64(11), "order-1", int64(2), int32(4), "committed", later, now, now))
+	mock.ExpectCommit()
+
+	reservations, products, err := store.CommitReservation(context.Background(), "order-1")
+
+	require.NoError(t, err)
+	assert.Len(t, reservations, 2)
+	require.Len(t, products, 2)
+	assert.Equal(t, int32(6), products[0].StockQuantity)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CommitReservation_Expired(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY product_id`)).WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows(append(reservationColumnNames, "expired")).
+			AddRow(int64(10), "order-1", int64(5), int32(1), "active", now.Add(-time.Minute), now, now, true))
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'expired'`)).WithArgs("order-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, _, err := store.CommitReservation(context.Background(), "order-1")
+
+	assert.True(t, errors.Is(err, ErrReservationExpired))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
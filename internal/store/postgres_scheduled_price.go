@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"product-catalog-service/internal/domain"
+)
+
+const scheduledPriceColumns = `id, product_id, price, currency, valid_from, valid_until, created_at`
+
+// effectiveProducts is products.products with the effective price in the price column and, while a
+// scheduled price replaces it, the base price in compare_at_price. Product reads select from it so
+// that price filters, sorting, cursors and facets all see the effective price. Scheduled prices in
+// another currency than the product's (left behind by a currency change) never apply. Products in
+// the trash are left out; allEffectiveProducts keeps them, for listings that ask for them.
+const (
+	effectiveProducts    = effectiveProductsSelect + ` WHERE p.deleted_at IS NULL) catalog`
+	allEffectiveProducts = effectiveProductsSelect + `) catalog`
+)
+
+const effectiveProductsSelect = `(SELECT p.id, p.name, p.description, p.sku, COALESCE(sp.price, p.price) AS price, p.currency,` +
+	` p.stock_quantity, p.category_id, p.image_url, p.is_active, p.attributes, p.created_at, p.updated_at, p.search_vector,` +
+	` CASE WHEN sp.price IS NOT NULL THEN p.price END AS compare_at_price, p.parent_id, p.options, p.option_values, p.inherits_price,` +
+	` p.deleted_at FROM products.products p LEFT JOIN LATERAL (SELECT price FROM products.scheduled_prices` +
+	` WHERE product_id = p.id AND currency = p.currency AND valid_from <= CURRENT_TIMESTAMP` +
+	` AND (valid_until IS NULL OR valid_until > CURRENT_TIMESTAMP) AND created_at <= CURRENT_TIMESTAMP` +
+	` ORDER BY valid_from DESC, id DESC LIMIT 1) sp ON TRUE`
+
+// --- Scheduled price part of the ProductStorer Implementation ---
+
+// CreateScheduledPrice checks the currency against the product's current one. Should the product's
+// currency change later, the price is kept but no longer applies.
+func (s *PostgresStore) CreateScheduledPrice(ctx context.Context, sp *domain.ScheduledPrice) (*domain.ScheduledPrice, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: CreateScheduledPrice failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	// FOR SHARE keeps the base price recorded in the history from changing until the commit.
+	base, err := lockBasePrice(ctx, tx, sp.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateScheduledPrice(sp, base.Currency); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO products.scheduled_prices (product_id, price, currency, valid_from, valid_until)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + scheduledPriceColumns + `;`
+	created, err := scanScheduledPrice(tx.QueryRowContext(ctx, query, sp.ProductID, sp.Price.Decimal(), sp.Price.Currency, sp.ValidFrom, sp.ValidUntil))
+	if err != nil {
+		return nil, fmt.Errorf("store: CreateScheduledPrice failed to scan row: %w", err)
+	}
+	if err := insertPriceChange(ctx, tx, scheduledPriceChange(created, base)); err != nil {
+		return nil, fmt.Errorf("store: CreateScheduledPrice: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: CreateScheduledPrice failed to commit: %w", err)
+	}
+	return created, nil
+}
+
+func (s *PostgresStore) ListScheduledPrices(ctx context.Context, productID int64) ([]domain.ScheduledPrice, error) {
+	query := `SELECT ` + scheduledPriceColumns + ` FROM products.scheduled_prices WHERE product_id = $1 ORDER BY valid_from, id;`
+	rows, err := s.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("store: ListScheduledPrices failed to query scheduled prices: %w", err)
+	}
+	defer rows.Close()
+
+	prices := []domain.ScheduledPrice{}
+	for rows.Next() {
+		sp, err := scanScheduledPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("store: ListScheduledPrices failed to scan scheduled price: %w", err)
+		}
+		prices = append(prices, *sp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: ListScheduledPrices iteration error: %w", err)
+	}
+	if len(prices) == 0 {
+		if err := s.checkProductExists(ctx, productID); err != nil {
+			return nil, err
+		}
+	}
+	return prices, nil
+}
+
+func (s *PostgresStore) DeleteScheduledPrice(ctx context.Context, productID, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("store: DeleteScheduledPrice failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	base, err := lockBasePrice(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+	query := `
+		SELECT price, currency, valid_from < CURRENT_TIMESTAMP, valid_until IS NOT NULL AND valid_until <= CURRENT_TIMESTAMP
+		FROM products.scheduled_prices
+		WHERE id = $1 AND product_id = $2
+		FOR UPDATE;
+	`
+	var amount, currency string
+	var started, ended bool
+	if err := tx.QueryRowContext(ctx, query, id, productID).Scan(&amount, &currency, &started, &ended); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrScheduledPriceNotFound
+		}
+		return fmt.Errorf("store: DeleteScheduledPrice failed to read scheduled price: %w", err)
+	}
+	scheduled, err := domain.ParseMoney(amount, currency)
+	if err != nil {
+		return fmt.Errorf("store: DeleteScheduledPrice failed to parse price: %w", err)
+	}
+	switch {
+	case ended:
+		return ErrScheduledPriceEnded
+	case started: // End it now, so that the past stays as it was
+		_, err = tx.ExecContext(ctx, `UPDATE products.scheduled_prices SET valid_until = CURRENT_TIMESTAMP WHERE id = $1;`, id)
+	default:
+		_, err = tx.ExecContext(ctx, `DELETE FROM products.scheduled_prices WHERE id = $1;`, id)
+	}
+	if err != nil {
+		return fmt.Errorf("store: DeleteScheduledPrice failed to cancel scheduled price: %w", err)
+	}
+	change := &domain.PriceChange{
+		ProductID: productID, OldPrice: &scheduled, NewPrice: base,
+		Reason: domain.PriceReasonScheduledPriceCancelled, ScheduledPriceID: &id,
+	}
+	if err := insertPriceChange(ctx, tx, change); err != nil {
+		return fmt.Errorf("store: DeleteScheduledPrice: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: DeleteScheduledPrice failed to commit: %w", err)
+	}
+	return nil
+}
+
+// lockBasePrice locks a product row against changes (FOR SHARE) and returns its base price, or
+// ErrProductNotFound if it does not exist or is in the trash.
+func lockBasePrice(ctx context.Context, tx *sql.Tx, productID int64) (domain.Money, error) {
+	var amount, currency string
+	err := tx.QueryRowContext(ctx, `SELECT price, currency FROM products.products WHERE id = $1 AND deleted_at IS NULL FOR SHARE;`, productID).Scan(&amount, &currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Money{}, ErrProductNotFound
+		}
+		return domain.Money{}, fmt.Errorf("store: failed to lock product: %w", err)
+	}
+	price, err := domain.ParseMoney(amount, currency)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("store: failed to parse product price: %w", err)
+	}
+	return price, nil
+}
+
+// checkProductExists returns ErrProductNotFound if the product does not exist or is in the trash.
+func (s *PostgresStore) checkProductExists(ctx context.Context, productID int64) error {
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM products.products WHERE id = $1 AND deleted_at IS NULL);`, productID).Scan(&exists); err != nil {
+		return fmt.Errorf("store: failed to check product existence: %w", err)
+	}
+	if !exists {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+func scanScheduledPrice(row rowScanner) (*domain.ScheduledPrice, error) {
+	var sp domain.ScheduledPrice
+	var price string
+	if err := row.Scan(&sp.ID, &sp.ProductID, &price, &sp.Price.Currency, &sp.ValidFrom, &sp.ValidUntil, &sp.CreatedAt); err != nil {
+		return nil, err
+	}
+	money, err := domain.ParseMoney(price, sp.Price.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse price: %w", err)
+	}
+	sp.Price = money
+	return &sp, nil
+}
+
+// setScannedCompareAtPrice sets the compare-at price of p, read from effectiveProducts, in the
+// currency of its price.
+func setScannedCompareAtPrice(p *domain.Product, compareAt sql.NullString) error {
+	if !compareAt.Valid {
+		return nil
+	}
+	money, err := domain.ParseMoney(compareAt.String, p.Price.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to parse compare-at price: %w", err)
+	}
+	p.CompareAtPrice = &money
+	return nil
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore_GetProductByID_ScheduledPrice(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	// The effective price replaces the base price in the price column.
+	mock.ExpectQuery(regexp.QuoteMeta(`updated_at, compare_at_price, ` + variantColumns + ` FROM ` + effectiveProducts + ` WHERE id = $1;`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(effectiveProductColumns).AddRow(int64(1), "Scarf", nil, "SCARF", "14.990", "USD", int32(3), nil, nil, true, nil, now, now, "19.990", nil, "{}", nil, false))
+
+	product, err := store.GetProductByID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, usd(1499), product.Price)
+	require.NotNil(t, product.CompareAtPrice)
+	assert.Equal(t, usd(1999), *product.CompareAtPrice)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CreateScheduledPrice_WrongCurrency(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT price, currency FROM products.products WHERE id = $1 AND deleted_at IS NULL FOR SHARE;`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"price", "currency"}).AddRow("19.990", "USD"))
+	mock.ExpectRollback()
+
+	_, err := store.CreateScheduledPrice(context.Background(), &domain.ScheduledPrice{ProductID: 1, Price: domain.Money{Amount: 999, Currency: "EUR"}, ValidFrom: time.Now()})
+
+	assert.ErrorIs(t, err, ErrInvalidScheduledPrice)
+	require.NoError(t, mock.ExpectationsWereMet(), "nothing is inserted")
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetSearchLanguage makes language (a Postgres text search configuration such as "english" or
+// "simple") the one products are indexed and searched with. If it differs from the current one, the
+// search vector of every product is rebuilt in the same transaction; it returns whether that happened.
+// The memory backend has no stemming, so it has no counterpart.
+func (s *PostgresStore) SetSearchLanguage(ctx context.Context, language string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("store: SetSearchLanguage failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	var current string
+	if err := tx.QueryRowContext(ctx, `SELECT language::text FROM products.search_config FOR UPDATE;`).Scan(&current); err != nil {
+		return false, fmt.Errorf("store: SetSearchLanguage failed to read the current language: %w", err)
+	}
+	if current == language {
+		return false, nil
+	}
+
+	// The cast fails for unknown configurations, leaving everything unchanged.
+	if _, err := tx.ExecContext(ctx, `UPDATE products.search_config SET language = $1::regconfig;`, language); err != nil {
+		return false, fmt.Errorf("store: SetSearchLanguage failed to set language %q: %w", language, err)
+	}
+	reindexQuery := `
+		UPDATE products.products
+		SET search_vector = products.product_search_vector($1::regconfig, name, sku, description, attributes);
+	`
+	if _, err := tx.ExecContext(ctx, reindexQuery, language); err != nil {
+		return false, fmt.Errorf("store: SetSearchLanguage failed to reindex products: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("store: SetSearchLanguage failed to commit: %w", err)
+	}
+	return true, nil
+}
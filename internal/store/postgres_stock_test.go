@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var productColumns = []string{"id", "name", "description", "sku", "price", "currency", "stock_quantity", "category_id", "image_url", "is_active", "attributes", "created_at", "updated_at"}
+
+var (
+	locationColumnNames      = []string{"id", "code", "name", "priority", "created_at", "updated_at"}
+	locationStockColumnNames = []string{"product_id", "location_id", "quantity", "updated_at"}
+)
+
+// expectStockLevels expects the queries of loadStockLevels: the product lock returning stock, the
+// location list returning locations and the location rows returning levels.
+func expectStockLevels(mock sqlmock.Sqlmock, ids []int64, stock, locations, levels *sqlmock.Rows) {
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY id
+		FOR UPDATE;`)).WithArgs(pq.Array(ids)).WillReturnRows(stock)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM products.locations ORDER BY priority, id;`)).WillReturnRows(locations)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM products.location_stock
+		WHERE product_id = ANY($1);`)).WithArgs(pq.Array(ids)).WillReturnRows(levels)
+}
+
+// expectReservedQuantities expects the sum of the active reservations that BatchUpdateStock keeps
+// in stock, returning reserved.
+func expectReservedQuantities(mock sqlmock.Sqlmock, ids []int64, reserved map[int64]int32) {
+	rows := sqlmock.NewRows([]string{"product_id", "sum"})
+	for id, qty := range reserved {
+		rows.AddRow(id, qty)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SUM(quantity)`)).WithArgs(pq.Array(ids), "").WillReturnRows(rows)
+}
+
+func TestPostgresStore_BatchUpdateStock_LocksInIDOrderAndCommits(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	expectStockLevels(mock, []int64{7, 3},
+		sqlmock.NewRows([]string{"id", "stock_quantity"}).AddRow(int64(3), int32(10)).AddRow(int64(7), int32(4)),
+		sqlmock.NewRows(locationColumnNames).AddRow(int64(1), "default", "Default", int32(0), now, now),
+		sqlmock.NewRows(locationStockColumnNames).AddRow(int64(3), int64(1), int32(10), now).AddRow(int64(7), int64(1), int32(4), now))
+	expectReservedQuantities(mock, []int64{7, 3}, nil)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.location_stock`)).
+		WithArgs(pq.Array([]int64{3, 7}), pq.Array([]int64{1, 1}), pq.Array([]int64{9, 0})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	updateQuery := regexp.QuoteMeta(`SET stock_quantity = $1, updated_at = CURRENT_TIMESTAMP`)
+	mock.ExpectQuery(updateQuery).WithArgs(int32(9), int64(3)).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(int64(3), "P3", nil, "SKU-3", "1.500", "USD", int32(9), nil, nil, true, nil, now, now))
+	mock.ExpectQuery(updateQuery).WithArgs(int32(0), int64(7)).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(int64(7), "P7", nil, "SKU-7", "2.500", "USD", int32(0), nil, nil, true, nil, now, now))
+	// Ledger entries follow the request order, with the balance right after each line.
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.stock_movements`)).
+		WithArgs(pq.Array([]int64{7, 3}), pq.Array([]sql.NullInt64{{Int64: 1, Valid: true}, {Int64: 1, Valid: true}}),
+			pq.Array([]int64{-4, -1}), pq.Array([]int64{0, 9}), "stock_update", nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	results, err := store.BatchUpdateStock(context.Background(), []StockUpdate{
+		{ProductID: 7, QuantityChange: -4},
+		{ProductID: 3, QuantityChange: -1},
+	}, true, StockMovementInfo{Reason: domain.StockReasonStockUpdate})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, int32(0), results[0].Product.StockQuantity)
+	assert.Equal(t, []domain.StockAllocation{{LocationID: 1, Quantity: -4}}, results[0].Allocations)
+	require.NoError(t, results[1].Err)
+	assert.Equal(t, int32(9), results[1].Product.StockQuantity)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_BatchUpdateStock_AllocatesAcrossLocationsByPriority(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	// Location 2 has the lower priority value, so it is drained first.
+	expectStockLevels(mock, []int64{5},
+		sqlmock.NewRows([]string{"id", "stock_quantity"}).AddRow(int64(5), int32(8)),
+		sqlmock.NewRows(locationColumnNames).
+			AddRow(int64(2), "east", "East", int32(0), now, now).
+			AddRow(int64(1), "west", "West", int32(10), now, now),
+		sqlmock.NewRows(locationStockColumnNames).AddRow(int64(5), int64(1), int32(5), now).AddRow(int64(5), int64(2), int32(3), now))
+	expectReservedQuantities(mock, []int64{5}, nil)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.location_stock`)).
+		WithArgs(pq.Array([]int64{5, 5}), pq.Array([]int64{1, 2}), pq.Array([]int64{4, 0})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SET stock_quantity = $1`)).WithArgs(int32(4), int64(5)).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(int64(5), "P5", nil, "SKU-5", "1.500", "USD", int32(4), nil, nil, true, nil, now, now))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.stock_movements`)).
+		WithArgs(pq.Array([]int64{5, 5}), pq.Array([]sql.NullInt64{{Int64: 2, Valid: true}, {Int64: 1, Valid: true}}),
+			pq.Array([]int64{-3, -1}), pq.Array([]int64{5, 4}), "stock_update", nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	results, err := store.BatchUpdateStock(context.Background(), []StockUpdate{{ProductID: 5, QuantityChange: -4}}, true,
+		StockMovementInfo{Reason: domain.StockReasonStockUpdate})
+
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, []domain.StockAllocation{{LocationID: 2, Quantity: -3}, {LocationID: 1, Quantity: -1}}, results[0].Allocations)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_BatchUpdateStock_AtomicFailureRollsBack(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	expectStockLevels(mock, []int64{1, 2},
+		sqlmock.NewRows([]string{"id", "stock_quantity"}).AddRow(int64(1), int32(1)),
+		sqlmock.NewRows(locationColumnNames).AddRow(int64(1), "default", "Default", int32(0), now, now),
+		sqlmock.NewRows(locationStockColumnNames).AddRow(int64(1), int64(1), int32(1), now))
+	expectReservedQuantities(mock, []int64{1, 2}, nil)
+	mock.ExpectRollback()
+
+	results, err := store.BatchUpdateStock(context.Background(), []StockUpdate{
+		{ProductID: 1, QuantityChange: -1},
+		{ProductID: 2, QuantityChange: -1},
+	}, true, StockMovementInfo{Reason: domain.StockReasonStockUpdate})
+
+	require.NoError(t, err)
+	assert.True(t, errors.Is(results[0].Err, ErrStockBatchAborted))
+	assert.True(t, errors.Is(results[1].Err, ErrProductNotFound))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_UpdateStock_KeepsReservedStock(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	expectStockLevels(mock, []int64{4},
+		sqlmock.NewRows([]string{"id", "stock_quantity"}).AddRow(int64(4), int32(10)),
+		sqlmock.NewRows(locationColumnNames).AddRow(int64(1), "default", "Default", int32(0), now, now),
+		sqlmock.NewRows(locationStockColumnNames).AddRow(int64(4), int64(1), int32(10), now))
+	expectReservedQuantities(mock, []int64{4}, map[int64]int32{4: 7})
+	mock.ExpectRollback()
+
+	_, err := store.UpdateStock(context.Background(), 4, -4, StockMovementInfo{Reason: domain.StockReasonAdjustment})
+
+	assert.True(t, errors.Is(err, ErrInsufficientStock), "7 of the 10 units are reserved")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
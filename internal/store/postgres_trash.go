@@ -0,0 +1,252 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+// liveCategoryQuery checks that the category $1 exists and is not in the trash.
+const liveCategoryQuery = `SELECT EXISTS (SELECT 1 FROM products.categories WHERE id = $1 AND deleted_at IS NULL);`
+
+// deletedCondition translates a DeletedFilter into a condition on deleted_at, or "" for none.
+func deletedCondition(f DeletedFilter) string {
+	switch f {
+	case DeletedIncluded:
+		return ""
+	case DeletedOnly:
+		return "deleted_at IS NOT NULL"
+	default:
+		return "deleted_at IS NULL"
+	}
+}
+
+// --- Trash part of the ProductStorer Implementation ---
+
+// RestoreProduct restores the variants that DeleteProduct marked together with the product, i.e.
+// those with its deletion time; variants deleted on their own stay in the trash.
+func (s *PostgresStore) RestoreProduct(ctx context.Context, id int64) (*domain.Product, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: RestoreProduct failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	var parentID *int64
+	var deletedAt time.Time
+	err = tx.QueryRowContext(ctx, `SELECT parent_id, deleted_at FROM products.products WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE;`, id).
+		Scan(&parentID, &deletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: RestoreProduct failed to lock product: %w", err)
+	}
+	if parentID != nil {
+		parent, err := lockVariantParent(ctx, tx, *parentID)
+		if err != nil {
+			return nil, fmt.Errorf("store: RestoreProduct: %w", err)
+		}
+		if parent == nil {
+			return nil, restoreBlocked("parent product %d is in the trash", *parentID)
+		}
+	}
+
+	query := `
+		UPDATE products.products SET deleted_at = NULL
+		WHERE id = $1 OR (parent_id = $1 AND deleted_at = $2)
+		RETURNING category_id;
+	`
+	rows, err := tx.QueryContext(ctx, query, id, deletedAt)
+	if err != nil {
+		return nil, restoreProductError(err)
+	}
+	var categoryIDs []int64
+	for rows.Next() {
+		var categoryID *int64
+		if err := rows.Scan(&categoryID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: RestoreProduct failed to scan category ID: %w", err)
+		}
+		if categoryID != nil {
+			categoryIDs = append(categoryIDs, *categoryID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, restoreProductError(err)
+	}
+	rows.Close()
+	if err := lockRestoredCategories(ctx, tx, categoryIDs); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: RestoreProduct failed to commit: %w", err)
+	}
+	return s.GetProductByID(ctx, id)
+}
+
+// restoreProductError maps the unique violations of products taking the place of restored ones.
+func restoreProductError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && strings.Contains(pqErr.Constraint, "products_sku_key") {
+		return ErrProductSKUExists
+	}
+	if variantErr := variantWriteError(err); variantErr != nil {
+		return variantErr
+	}
+	return fmt.Errorf("store: RestoreProduct failed to execute restore: %w", err)
+}
+
+// lockRestoredCategories locks the categories of restored products against being deleted (FOR
+// SHARE), or fails with ErrRestoreBlocked if one is in the trash.
+func lockRestoredCategories(ctx context.Context, tx *sql.Tx, categoryIDs []int64) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM products.categories WHERE id = ANY($1) AND deleted_at IS NULL FOR SHARE;`, pq.Array(categoryIDs))
+	if err != nil {
+		return fmt.Errorf("store: RestoreProduct failed to lock categories: %w", err)
+	}
+	defer rows.Close()
+	live := make(map[int64]bool, len(categoryIDs))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("store: RestoreProduct failed to scan category ID: %w", err)
+		}
+		live[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("store: RestoreProduct categories iteration error: %w", err)
+	}
+	for _, id := range categoryIDs {
+		if !live[id] {
+			return restoreBlocked("category %d is in the trash", id)
+		}
+	}
+	return nil
+}
+
+// PurgeDeletedProducts relies on the foreign keys to delete the stock, prices and media of the
+// purged products.
+func (s *PostgresStore) PurgeDeletedProducts(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM products.products WHERE deleted_at <= $1;`, before)
+	if err != nil {
+		return 0, fmt.Errorf("store: PurgeDeletedProducts failed to execute delete: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("store: PurgeDeletedProducts failed to get rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// --- Trash part of the CategoryStorer Implementation ---
+
+// restoreCategoriesQuery restores the category $1 and the descendants deleted together with it, at
+// $2, returning them.
+const restoreCategoriesQuery = `
+	WITH RECURSIVE restored AS (
+		SELECT id, ARRAY[id] AS path
+		FROM products.categories
+		WHERE id = $1
+		UNION ALL
+		SELECT c.id, r.path || c.id
+		FROM products.categories c
+		JOIN restored r ON c.parent_category_id = r.id
+		WHERE NOT c.id = ANY(r.path) AND c.deleted_at = $2
+	)
+	UPDATE products.categories SET deleted_at = NULL
+	WHERE id IN (SELECT id FROM restored)
+	RETURNING id, name, description, parent_category_id, created_at, updated_at;
+`
+
+// RestoreCategory holds the lockCategoryHierarchy lock, so that the parent cannot be deleted in the
+// meantime.
+func (s *PostgresStore) RestoreCategory(ctx context.Context, id int64) (*domain.Category, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: RestoreCategory failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := lockCategoryHierarchy(ctx, tx); err != nil {
+		return nil, fmt.Errorf("store: RestoreCategory: %w", err)
+	}
+	var parentID *int64
+	var deletedAt time.Time
+	err = tx.QueryRowContext(ctx, `SELECT parent_category_id, deleted_at FROM products.categories WHERE id = $1 AND deleted_at IS NOT NULL;`, id).
+		Scan(&parentID, &deletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: RestoreCategory failed to load category: %w", err)
+	}
+	if parentID != nil {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, liveCategoryQuery, *parentID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("store: RestoreCategory failed to check parent category: %w", err)
+		}
+		if !exists {
+			return nil, restoreBlocked("parent category %d is in the trash", *parentID)
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, restoreCategoriesQuery, id, deletedAt)
+	if err != nil {
+		return nil, restoreCategoryError(err)
+	}
+	var restored *domain.Category
+	for rows.Next() {
+		var c domain.Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.ParentCategoryID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: RestoreCategory failed to scan category row: %w", err)
+		}
+		if c.ID == id {
+			restored = &c
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, restoreCategoryError(err)
+	}
+	rows.Close()
+	if restored == nil {
+		return nil, ErrCategoryNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: RestoreCategory failed to commit transaction: %w", err)
+	}
+	return restored, nil
+}
+
+// restoreCategoryError maps the unique violation of a category that took the name of a restored one.
+func restoreCategoryError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && strings.Contains(pqErr.Constraint, "categories_name_key") {
+		return ErrCategoryNameExists
+	}
+	return fmt.Errorf("store: RestoreCategory failed to execute restore: %w", err)
+}
+
+// PurgeDeletedCategories leaves it to the foreign keys to detach the subcategories and products
+// still pointing to the purged categories, which are in the trash themselves.
+func (s *PostgresStore) PurgeDeletedCategories(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM products.categories WHERE deleted_at <= $1;`, before)
+	if err != nil {
+		return 0, fmt.Errorf("store: PurgeDeletedCategories failed to execute delete: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("store: PurgeDeletedCategories failed to get rows affected: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lib/pq"
+
+	"product-catalog-service/internal/domain"
+)
+
+// variantColumns are the variant columns of products.products (and effectiveProducts) that product
+// reads select after compare_at_price.
+const variantColumns = `parent_id, options, option_values, inherits_price`
+
+// --- Variant part of the ProductStorer Implementation ---
+
+func (s *PostgresStore) ListVariants(ctx context.Context, parentIDs []int64) (map[int64][]domain.Product, error) {
+	query := `
+		SELECT id, name, description, sku, price, currency, stock_quantity, category_id, image_url, is_active, attributes, created_at, updated_at, compare_at_price, ` + variantColumns + `
+		FROM ` + effectiveProducts + `
+		WHERE parent_id = ANY($1)
+		ORDER BY id;
+	`
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(parentIDs))
+	if err != nil {
+		return nil, fmt.Errorf("store: ListVariants failed to query variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants := make(map[int64][]domain.Product)
+	for rows.Next() {
+		var p domain.Product
+		var scannedAttributes, scannedCompareAt sql.NullString
+		var scannedPrice string
+		var scannedOptionValues []byte
+		if err := rows.Scan(
+			&p.ID, &p.Name, &p.Description, &p.SKU, &scannedPrice, &p.Price.Currency, &p.StockQuantity,
+			&p.CategoryID, &p.ImageURL, &p.IsActive, &scannedAttributes, &p.CreatedAt, &p.UpdatedAt, &scannedCompareAt,
+			&p.ParentID, pq.Array(&p.Options), &scannedOptionValues, &p.InheritsPrice,
+		); err != nil {
+			return nil, fmt.Errorf("store: ListVariants failed to scan variant: %w", err)
+		}
+		if err := setScannedPrice(&p, scannedPrice); err != nil {
+			return nil, fmt.Errorf("store: ListVariants: %w", err)
+		}
+		if err := setScannedCompareAtPrice(&p, scannedCompareAt); err != nil {
+			return nil, fmt.Errorf("store: ListVariants: %w", err)
+		}
+		if err := setScannedOptionValues(&p, scannedOptionValues); err != nil {
+			return nil, fmt.Errorf("store: ListVariants: %w", err)
+		}
+		if scannedAttributes.Valid && scannedAttributes.String != "" && scannedAttributes.String != "null" {
+			rawMsg := json.RawMessage(scannedAttributes.String)
+			p.Attributes = &rawMsg
+		}
+		variants[*p.ParentID] = append(variants[*p.ParentID], p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: ListVariants iteration error: %w", err)
+	}
+	return variants, nil
+}
+
+// lockVariantParent locks the parent product of a variant against changes (FOR SHARE) and returns
+// it at its base price with the fields checkVariant needs, or nil if it does not exist or is in the
+// trash.
+func lockVariantParent(ctx context.Context, tx *sql.Tx, parentID int64) (*domain.Product, error) {
+	query := `SELECT id, parent_id, options, price, currency FROM products.products WHERE id = $1 AND deleted_at IS NULL FOR SHARE;`
+	var parent domain.Product
+	var price string
+	err := tx.QueryRowContext(ctx, query, parentID).Scan(&parent.ID, &parent.ParentID, pq.Array(&parent.Options), &price, &parent.Price.Currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock parent product: %w", err)
+	}
+	if err := setScannedPrice(&parent, price); err != nil {
+		return nil, err
+	}
+	return &parent, nil
+}
+
+// variantArgs returns the query arguments for the options and option_values columns of p.
+func variantArgs(p *domain.Product) (options, optionValues interface{}, err error) {
+	options = pq.Array(append([]string{}, p.Options...)) // Never NULL
+	if p.ParentID != nil {
+		if optionValues, err = json.Marshal(p.OptionValues); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode option values: %w", err)
+		}
+	}
+	return options, optionValues, nil
+}
+
+// setVariantFields copies the variant fields written for product to p, a product returned by a
+// write, whose RETURNING list only has the standard product columns.
+func setVariantFields(p, product *domain.Product) {
+	p.ParentID = product.ParentID
+	p.Options = product.Options
+	p.OptionValues = product.OptionValues
+	p.InheritsPrice = product.InheritsPrice
+}
+
+// variantWriteError maps the constraint violations of the variant columns on insert or update, and
+// returns nil for other errors.
+func variantWriteError(err error) error {
+	var pqErr *pq.Error
+	switch {
+	case !errors.As(err, &pqErr):
+		return nil
+	case pqErr.Code == "23505" && pqErr.Constraint == "products_parent_id_option_values_key":
+		return ErrVariantExists
+	case pqErr.Code == "23503" && pqErr.Constraint == "products_parent_id_fkey":
+		return fmt.Errorf("%w: parent product not found", ErrInvalidVariant)
+	}
+	return nil
+}
+
+// passOnParentPrice gives the variants of a parent that inherit its price the parent's new base
+// price, recording the changes in the price history. Inheriting variants always had the parent's
+// old price.
+func passOnParentPrice(ctx context.Context, tx *sql.Tx, parentID int64, oldPrice, newPrice domain.Money) error {
+	query := `
+		UPDATE products.products SET price = $1, currency = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE parent_id = $3 AND inherits_price
+		RETURNING id;
+	`
+	rows, err := tx.QueryContext(ctx, query, newPrice.Decimal(), newPrice.Currency, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to update inheriting variants: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan variant ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("inheriting variants iteration error: %w", err)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] }) // History in ID order
+	for _, id := range ids {
+		change := &domain.PriceChange{ProductID: id, OldPrice: &oldPrice, NewPrice: newPrice, Reason: domain.PriceReasonProductUpdate}
+		if err := insertPriceChange(ctx, tx, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setScannedOptionValues decodes the option_values column of a variant.
+func setScannedOptionValues(p *domain.Product, optionValues []byte) error {
+	if len(optionValues) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(optionValues, &p.OptionValues); err != nil {
+		return fmt.Errorf("failed to parse option values: %w", err)
+	}
+	return nil
+}
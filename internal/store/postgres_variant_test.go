@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"product-catalog-service/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore_UpdateProduct_PassesOnParentPrice(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	expectStockLevels(mock, []int64{4},
+		sqlmock.NewRows([]string{"id", "stock_quantity"}).AddRow(int64(4), int32(0)),
+		sqlmock.NewRows(locationColumnNames).AddRow(int64(1), "default", "Default", int32(0), now, now),
+		sqlmock.NewRows(locationStockColumnNames))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT price, currency, options, EXISTS`)).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"price", "currency", "options", "exists"}).AddRow("15.000", "USD", "{color,size}", true))
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products.products`)).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(int64(4), "Tee", nil, "TEE", "19.000", "USD", int32(0), nil, nil, true, nil, now, now))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.price_changes`)).
+		WithArgs(int64(4), "15.00", "USD", "19.00", "USD", now, nil, "product_update", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// Variants 7 and 5 inherit the price; the history records them in ID order.
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE parent_id = $3 AND inherits_price`)).
+		WithArgs("19.00", "USD", int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(7)).AddRow(int64(5)))
+	for _, id := range []int64{5, 7} {
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO products.price_changes`)).
+			WithArgs(id, "15.00", "USD", "19.00", "USD", nil, nil, "product_update", nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	updated, err := store.UpdateProduct(context.Background(), &domain.Product{ID: 4, Name: "Tee", SKU: "TEE", Price: usd(1900), IsActive: true, Options: []string{"color", "size"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"color", "size"}, updated.Options)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_CreateProduct_VariantMissingOptionValue(t *testing.T) {
+	db, mock, store := newMockDBAndStore(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, parent_id, options, price, currency FROM products.products WHERE id = $1 AND deleted_at IS NULL FOR SHARE;`)).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "options", "price", "currency"}).AddRow(int64(4), nil, "{color,size}", "15.000", "USD"))
+	mock.ExpectRollback()
+
+	// A variant without a value for every option of its parent is rejected before anything is written.
+	_, err := store.CreateProduct(context.Background(), &domain.Product{
+		Name: "Tee red", SKU: "TEE-R", ParentID: PtrTo(int64(4)), OptionValues: map[string]string{"color": "red"}, InheritsPrice: true,
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidVariant)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
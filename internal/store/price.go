@@ -0,0 +1,12 @@
+package store
+
+import "product-catalog-service/internal/domain"
+
+// storedPrice returns the price as it is stored: a price without a currency, such as the zero
+// Money, is in domain.DefaultCurrency like the column default.
+func storedPrice(price domain.Money) domain.Money {
+	if price.Currency == "" {
+		price.Currency = domain.DefaultCurrency
+	}
+	return price
+}
@@ -0,0 +1,22 @@
+package store
+
+import (
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// ListPriceChangesParams holds parameters for listing a product's price history.
+type ListPriceChangesParams struct {
+	ProductID int64
+	From      *time.Time // Inclusive lower bound on effective_at
+	To        *time.Time // Exclusive upper bound on effective_at
+	Limit     int
+	Offset    int
+}
+
+// isBasePriceChange reports whether entries with reason change the base price, as opposed to
+// recording a scheduled price.
+func isBasePriceChange(reason domain.PriceChangeReason) bool {
+	return reason == domain.PriceReasonInitialPrice || reason == domain.PriceReasonProductUpdate
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"product-catalog-service/internal/domain"
+)
+
+// Predefined errors for price lists
+var (
+	ErrPriceListNotFound      = errors.New("store: price list not found")
+	ErrPriceListNameExists    = errors.New("store: price list with this name already exists")
+	ErrDefaultPriceListExists = errors.New("store: the currency already has a default price list")
+	// ErrInvalidPriceList wraps what is wrong with a PriceList.
+	ErrInvalidPriceList = errors.New("store: invalid price list")
+	// ErrInvalidPriceListEntry wraps what is wrong with an entry, annotated with its product ID.
+	ErrInvalidPriceListEntry = errors.New("store: invalid price list entry")
+	// ErrInvalidPriceSelection is returned when a PriceSelection names a list in another currency.
+	ErrInvalidPriceSelection = errors.New("store: price list is not in the requested currency")
+)
+
+// Maximum lengths from the price_lists schema.
+const (
+	maxPriceListNameLength  = 255
+	maxPriceListLabelLength = 64 // market and customer_group
+)
+
+// PriceSelection chooses the price product reads return (see PriceListStorer.ResolvePrices). The
+// zero value selects the base price.
+type PriceSelection struct {
+	Currency    string // Optional: ISO 4217 code; the default list of this currency is used
+	PriceListID *int64 // Optional: if Currency is also set, the list must be in it
+}
+
+// IsZero reports whether the selection leaves base prices unchanged.
+func (sel PriceSelection) IsZero() bool {
+	return sel.Currency == "" && sel.PriceListID == nil
+}
+
+// ListPriceListEntriesParams holds parameters for listing the entries of a price list.
+type ListPriceListEntriesParams struct {
+	PriceListID int64
+	Limit       int
+	Offset      int
+}
+
+// validatePriceList checks a price list before it is stored.
+func validatePriceList(list *domain.PriceList) error {
+	if list.Name == "" || len(list.Name) > maxPriceListNameLength {
+		return fmt.Errorf("%w: name must be 1 to %d characters long", ErrInvalidPriceList, maxPriceListNameLength)
+	}
+	if !domain.ValidCurrency(list.Currency) {
+		return fmt.Errorf("%w: currency %q is not an ISO 4217 code", ErrInvalidPriceList, list.Currency)
+	}
+	if list.Market != nil && (*list.Market == "" || len(*list.Market) > maxPriceListLabelLength) {
+		return fmt.Errorf("%w: market must be 1 to %d characters long", ErrInvalidPriceList, maxPriceListLabelLength)
+	}
+	if list.CustomerGroup != nil && (*list.CustomerGroup == "" || len(*list.CustomerGroup) > maxPriceListLabelLength) {
+		return fmt.Errorf("%w: customer_group must be 1 to %d characters long", ErrInvalidPriceList, maxPriceListLabelLength)
+	}
+	return nil
+}
+
+// validatePriceListEntries checks entries before they are written to a list: each product at most
+// once, with a non-negative price in the list's currency.
+func validatePriceListEntries(list *domain.PriceList, entries []domain.PriceListEntry) error {
+	seen := make(map[int64]bool, len(entries))
+	for _, e := range entries {
+		switch {
+		case e.ProductID <= 0:
+			return fmt.Errorf("%w: product ID must be positive (product ID %d)", ErrInvalidPriceListEntry, e.ProductID)
+		case seen[e.ProductID]:
+			return fmt.Errorf("%w: duplicate product (product ID %d)", ErrInvalidPriceListEntry, e.ProductID)
+		case e.Price.Currency != list.Currency:
+			return fmt.Errorf("%w: price must be in %s, the currency of the list (product ID %d)", ErrInvalidPriceListEntry, list.Currency, e.ProductID)
+		case e.Price.Amount < 0:
+			return fmt.Errorf("%w: price must not be negative (product ID %d)", ErrInvalidPriceListEntry, e.ProductID)
+		case !e.Price.InRange():
+			return fmt.Errorf("%w: price must be less than 1000000000000 (product ID %d)", ErrInvalidPriceListEntry, e.ProductID)
+		}
+		seen[e.ProductID] = true
+	}
+	return nil
+}
+
+// selectionCurrency returns the currency a selection resolves prices in, given the list it names
+// (nil if none).
+func selectionCurrency(sel PriceSelection, list *domain.PriceList) (string, error) {
+	if list == nil {
+		return sel.Currency, nil
+	}
+	if sel.Currency != "" && sel.Currency != list.Currency {
+		return "", fmt.Errorf("%w: price list %d is in %s, not %s", ErrInvalidPriceSelection, list.ID, list.Currency, sel.Currency)
+	}
+	return list.Currency, nil
+}
+
+// applyListPrices replaces the price of every product that has an entry in prices, keyed by
+// product ID. Other products keep their price. A list price also replaces a scheduled price, so
+// the compare-at price, which is in the base currency, is dropped.
+func applyListPrices(products []domain.Product, prices map[int64]domain.PriceListEntry) {
+	for i := range products {
+		if entry, ok := prices[products[i].ID]; ok {
+			listID := entry.PriceListID
+			products[i].Price = entry.Price
+			products[i].PriceListID = &listID
+			products[i].CompareAtPrice = nil
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/domain"
+)
+
+// Predefined errors for scheduled prices
+var (
+	ErrScheduledPriceNotFound = errors.New("store: scheduled price not found")
+	// ErrScheduledPriceEnded is returned when deleting a scheduled price whose window has passed; it
+	// stays as a record of the prices charged.
+	ErrScheduledPriceEnded = errors.New("store: scheduled price has already ended")
+	// ErrInvalidScheduledPrice wraps what is wrong with a ScheduledPrice.
+	ErrInvalidScheduledPrice = errors.New("store: invalid scheduled price")
+)
+
+// validateScheduledPrice checks a scheduled price before it is stored for a product whose base
+// price is in currency.
+func validateScheduledPrice(sp *domain.ScheduledPrice, currency string) error {
+	switch {
+	case sp.Price.Currency != currency:
+		return fmt.Errorf("%w: price must be in %s, the currency of the product", ErrInvalidScheduledPrice, currency)
+	case sp.Price.Amount < 0:
+		return fmt.Errorf("%w: price must not be negative", ErrInvalidScheduledPrice)
+	case !sp.Price.InRange():
+		return fmt.Errorf("%w: price must be less than 1000000000000", ErrInvalidScheduledPrice)
+	case sp.ValidFrom.IsZero():
+		return fmt.Errorf("%w: valid_from is required", ErrInvalidScheduledPrice)
+	case sp.ValidUntil != nil && !sp.ValidUntil.After(sp.ValidFrom):
+		return fmt.Errorf("%w: valid_until must be after valid_from", ErrInvalidScheduledPrice)
+	}
+	return nil
+}
+
+// activeScheduledPrice returns the price among prices that replaces a base price in currency at t:
+// the active one that started last, the later created one on a tie. A price only applies once it
+// has been created, even if its window started earlier. It returns nil if none is active. Mirrors
+// the lateral joins of effectiveProducts and GetPricesAt.
+func activeScheduledPrice(prices []*domain.ScheduledPrice, currency string, t time.Time) *domain.ScheduledPrice {
+	var active *domain.ScheduledPrice
+	for _, sp := range prices {
+		if sp.Price.Currency != currency || !sp.ActiveAt(t) || sp.CreatedAt.After(t) {
+			continue
+		}
+		if active == nil || sp.ValidFrom.After(active.ValidFrom) || (sp.ValidFrom.Equal(active.ValidFrom) && sp.ID > active.ID) {
+			active = sp
+		}
+	}
+	return active
+}
+
+// applyScheduledPrice makes sp the price of p, keeping the base price as its compare-at price.
+func applyScheduledPrice(p *domain.Product, sp *domain.ScheduledPrice) {
+	base := p.Price
+	p.Price = sp.Price
+	p.CompareAtPrice = &base
+}
+
+// scheduledPriceChange returns the price history entry for creating sp while the product's base
+// price is base. A price scheduled to start in the past takes effect when it is created.
+func scheduledPriceChange(sp *domain.ScheduledPrice, base domain.Money) *domain.PriceChange {
+	effectiveAt := sp.ValidFrom
+	if sp.CreatedAt.After(effectiveAt) {
+		effectiveAt = sp.CreatedAt
+	}
+	id := sp.ID
+	return &domain.PriceChange{
+		ProductID:        sp.ProductID,
+		OldPrice:         &base,
+		NewPrice:         sp.Price,
+		EffectiveAt:      effectiveAt,
+		EffectiveUntil:   sp.ValidUntil,
+		Reason:           domain.PriceReasonScheduledPrice,
+		ScheduledPriceID: &id,
+	}
+}
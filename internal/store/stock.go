@@ -0,0 +1,49 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+)
+
+// abortStockResults marks every successful result as rolled back, used when an atomic batch fails.
+func abortStockResults(results []StockUpdateResult) {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = ErrStockBatchAborted
+			results[i].Product = nil
+		}
+	}
+}
+
+// mergeReservationItems sums the quantities of repeated products and returns one item per product,
+// in ascending product ID order.
+func mergeReservationItems(items []ReservationItem) []ReservationItem {
+	totals := make(map[int64]int32, len(items))
+	merged := make([]ReservationItem, 0, len(items))
+	for _, item := range items {
+		if _, ok := totals[item.ProductID]; !ok {
+			merged = append(merged, ReservationItem{ProductID: item.ProductID})
+		}
+		totals[item.ProductID] += item.Quantity
+	}
+	for i := range merged {
+		merged[i].Quantity = totals[merged[i].ProductID]
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].ProductID < merged[j].ProductID })
+	return merged
+}
+
+// checkReservationItems verifies that every item fits into its product's stock (keyed by product ID)
+// minus the quantity already reserved by others. Products missing from stock do not exist.
+func checkReservationItems(items []ReservationItem, stock, reserved map[int64]int32) error {
+	for _, item := range items {
+		qty, ok := stock[item.ProductID]
+		if !ok {
+			return fmt.Errorf("%w (product ID %d)", ErrProductNotFound, item.ProductID)
+		}
+		if qty-reserved[item.ProductID] < item.Quantity {
+			return fmt.Errorf("%w (product ID %d)", ErrInsufficientStock, item.ProductID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRestoreBlocked wraps why a product or category in the trash cannot be restored yet, e.g.
+// because the parent it belongs to is in the trash itself.
+var ErrRestoreBlocked = errors.New("store: cannot restore")
+
+// DeletedFilter selects how listings treat products and categories in the trash: those deleted by
+// DeleteProduct and DeleteCategory and not yet purged.
+type DeletedFilter int
+
+const (
+	// DeletedExcluded leaves deleted rows out, as every read but the trash does.
+	DeletedExcluded DeletedFilter = iota
+	// DeletedIncluded lists deleted rows together with the others, e.g. to resolve the products
+	// of past orders.
+	DeletedIncluded
+	// DeletedOnly lists the trash.
+	DeletedOnly
+)
+
+// restoreBlocked is returned when a row in the trash cannot be restored because of the one it
+// belongs to, which has to be restored first.
+func restoreBlocked(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrRestoreBlocked, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"product-catalog-service/internal/domain"
+)
+
+// Predefined errors for product variants
+var (
+	// ErrInvalidVariant wraps what is wrong with the variant fields of a product.
+	ErrInvalidVariant = errors.New("store: invalid product variant")
+	ErrVariantExists  = errors.New("store: the parent product already has a variant with these option values")
+)
+
+// checkVariant validates the variant fields of product and gives a variant that inherits its price
+// the parent's base price. parent is the stored parent of product.ParentID, nil if it does not
+// exist; hasVariants and storedOptions describe the stored product on updates (false and nil for
+// new products).
+func checkVariant(product, parent *domain.Product, hasVariants bool, storedOptions []string) error {
+	seen := make(map[string]bool, len(product.Options))
+	for _, name := range product.Options {
+		if strings.TrimSpace(name) == "" || seen[name] {
+			return fmt.Errorf("%w: option names must be non-empty and unique", ErrInvalidVariant)
+		}
+		seen[name] = true
+	}
+	if product.ParentID == nil {
+		switch {
+		case len(product.OptionValues) > 0 || product.InheritsPrice:
+			return fmt.Errorf("%w: option_values and an inherited price require a parent_id", ErrInvalidVariant)
+		case hasVariants && !slices.Equal(product.Options, storedOptions):
+			return fmt.Errorf("%w: options cannot change while the product has variants", ErrInvalidVariant)
+		}
+		return nil
+	}
+
+	switch {
+	case parent == nil:
+		return fmt.Errorf("%w: parent product %d not found", ErrInvalidVariant, *product.ParentID)
+	case parent.ID == product.ID:
+		return fmt.Errorf("%w: a product cannot be its own variant", ErrInvalidVariant)
+	case parent.ParentID != nil:
+		return fmt.Errorf("%w: the parent product is a variant itself", ErrInvalidVariant)
+	case hasVariants:
+		return fmt.Errorf("%w: a product with variants cannot become a variant", ErrInvalidVariant)
+	case len(product.Options) > 0:
+		return fmt.Errorf("%w: a variant cannot have options of its own", ErrInvalidVariant)
+	case len(parent.Options) == 0:
+		return fmt.Errorf("%w: the parent product has no options", ErrInvalidVariant)
+	}
+	valuesErr := fmt.Errorf("%w: option_values must have a value for each option of the parent: %s",
+		ErrInvalidVariant, strings.Join(parent.Options, ", "))
+	if len(product.OptionValues) != len(parent.Options) {
+		return valuesErr
+	}
+	for _, name := range parent.Options {
+		if strings.TrimSpace(product.OptionValues[name]) == "" {
+			return valuesErr
+		}
+	}
+	if product.InheritsPrice {
+		product.Price = parent.Price
+	} else if currency := storedPrice(product.Price).Currency; currency != parent.Price.Currency {
+		return fmt.Errorf("%w: price must be in %s, the currency of the parent product", ErrInvalidVariant, parent.Price.Currency)
+	}
+	return nil
+}
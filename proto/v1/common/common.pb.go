@@ -77,9 +77,11 @@ func (x *ErrorResponse) GetMessage() string {
 
 // Request message for pagination
 type PageInfoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PageSize int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque next_page_token of the previous page; empty for the first page. A token is only valid
+	// for the listing that issued it.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -130,9 +132,12 @@ func (x *PageInfoRequest) GetPageToken() string {
 
 // Response message for pagination
 type PageInfoResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NextPageToken string                 `protobuf:"bytes,1,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalSize     int32                  `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Token for the next page, empty on the last one. Product and category listings issue signed
+	// keyset tokens: the next page continues right after the last item returned, so items inserted
+	// meanwhile are neither skipped nor repeated.
+	NextPageToken string `protobuf:"bytes,1,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalSize     int32  `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -181,6 +186,68 @@ func (x *PageInfoResponse) GetTotalSize() int32 {
 	return 0
 }
 
+// An exact amount of money. amount_minor counts the currency's minor unit, whose size follows
+// ISO 4217: cents for USD, yen for JPY (no decimals), fils for KWD (3 decimals).
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrencyCode  string                 `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"` // ISO 4217 code, e.g. "USD".
+	AmountMinor   int64                  `protobuf:"varint,2,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"`   // Amount in minor units, e.g. 1999 for 19.99 USD.
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`                                 // The same amount as a decimal string, e.g. "19.99".
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_proto_v1_common_common_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_common_common_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_proto_v1_common_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+func (x *Money) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
 // Represents an empty request or response.
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -190,7 +257,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_proto_v1_common_common_proto_msgTypes[3]
+	mi := &file_proto_v1_common_common_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -202,7 +269,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_common_common_proto_msgTypes[3]
+	mi := &file_proto_v1_common_common_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -215,7 +282,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_proto_v1_common_common_proto_rawDescGZIP(), []int{3}
+	return file_proto_v1_common_common_proto_rawDescGZIP(), []int{4}
 }
 
 // Represents a request with a single ID.
@@ -228,7 +295,7 @@ type IdRequest struct {
 
 func (x *IdRequest) Reset() {
 	*x = IdRequest{}
-	mi := &file_proto_v1_common_common_proto_msgTypes[4]
+	mi := &file_proto_v1_common_common_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -240,7 +307,7 @@ func (x *IdRequest) String() string {
 func (*IdRequest) ProtoMessage() {}
 
 func (x *IdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_common_common_proto_msgTypes[4]
+	mi := &file_proto_v1_common_common_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -253,7 +320,7 @@ func (x *IdRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IdRequest.ProtoReflect.Descriptor instead.
 func (*IdRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_common_common_proto_rawDescGZIP(), []int{4}
+	return file_proto_v1_common_common_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *IdRequest) GetId() int64 {
@@ -274,7 +341,7 @@ type SuccessResponse struct {
 
 func (x *SuccessResponse) Reset() {
 	*x = SuccessResponse{}
-	mi := &file_proto_v1_common_common_proto_msgTypes[5]
+	mi := &file_proto_v1_common_common_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -286,7 +353,7 @@ func (x *SuccessResponse) String() string {
 func (*SuccessResponse) ProtoMessage() {}
 
 func (x *SuccessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_common_common_proto_msgTypes[5]
+	mi := &file_proto_v1_common_common_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,7 +366,7 @@ func (x *SuccessResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SuccessResponse.ProtoReflect.Descriptor instead.
 func (*SuccessResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_common_common_proto_rawDescGZIP(), []int{5}
+	return file_proto_v1_common_common_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SuccessResponse) GetSuccess() bool {
@@ -331,7 +398,11 @@ const file_proto_v1_common_common_proto_rawDesc = "" +
 	"\x10PageInfoResponse\x12&\n" +
 	"\x0fnext_page_token\x18\x01 \x01(\tR\rnextPageToken\x12\x1d\n" +
 	"\n" +
-	"total_size\x18\x02 \x01(\x05R\ttotalSize\"\a\n" +
+	"total_size\x18\x02 \x01(\x05R\ttotalSize\"g\n" +
+	"\x05Money\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12!\n" +
+	"\famount_minor\x18\x02 \x01(\x03R\vamountMinor\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\"\a\n" +
 	"\x05Empty\"\x1b\n" +
 	"\tIdRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"V\n" +
@@ -353,14 +424,15 @@ func file_proto_v1_common_common_proto_rawDescGZIP() []byte {
 	return file_proto_v1_common_common_proto_rawDescData
 }
 
-var file_proto_v1_common_common_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_v1_common_common_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proto_v1_common_common_proto_goTypes = []any{
 	(*ErrorResponse)(nil),    // 0: common.v1.ErrorResponse
 	(*PageInfoRequest)(nil),  // 1: common.v1.PageInfoRequest
 	(*PageInfoResponse)(nil), // 2: common.v1.PageInfoResponse
-	(*Empty)(nil),            // 3: common.v1.Empty
-	(*IdRequest)(nil),        // 4: common.v1.IdRequest
-	(*SuccessResponse)(nil),  // 5: common.v1.SuccessResponse
+	(*Money)(nil),            // 3: common.v1.Money
+	(*Empty)(nil),            // 4: common.v1.Empty
+	(*IdRequest)(nil),        // 5: common.v1.IdRequest
+	(*SuccessResponse)(nil),  // 6: common.v1.SuccessResponse
 }
 var file_proto_v1_common_common_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -375,14 +447,14 @@ func file_proto_v1_common_common_proto_init() {
 	if File_proto_v1_common_common_proto != nil {
 		return
 	}
-	file_proto_v1_common_common_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_v1_common_common_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_common_common_proto_rawDesc), len(file_proto_v1_common_common_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
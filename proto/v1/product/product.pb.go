@@ -24,6 +24,409 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// How an AttributeFilter tests the attribute.
+type AttributeFilterOperator int32
+
+const (
+	AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED AttributeFilterOperator = 0 // Invalid.
+	AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_EQUALS      AttributeFilterOperator = 1 // The attribute is values[0], or an array containing it.
+	AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_IN          AttributeFilterOperator = 2 // The attribute is one of values, or an array containing one.
+	AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_RANGE       AttributeFilterOperator = 3 // The attribute is a number within [min, max].
+	AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_EXISTS      AttributeFilterOperator = 4 // The attribute is present.
+)
+
+// Enum value maps for AttributeFilterOperator.
+var (
+	AttributeFilterOperator_name = map[int32]string{
+		0: "ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED",
+		1: "ATTRIBUTE_FILTER_OPERATOR_EQUALS",
+		2: "ATTRIBUTE_FILTER_OPERATOR_IN",
+		3: "ATTRIBUTE_FILTER_OPERATOR_RANGE",
+		4: "ATTRIBUTE_FILTER_OPERATOR_EXISTS",
+	}
+	AttributeFilterOperator_value = map[string]int32{
+		"ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED": 0,
+		"ATTRIBUTE_FILTER_OPERATOR_EQUALS":      1,
+		"ATTRIBUTE_FILTER_OPERATOR_IN":          2,
+		"ATTRIBUTE_FILTER_OPERATOR_RANGE":       3,
+		"ATTRIBUTE_FILTER_OPERATOR_EXISTS":      4,
+	}
+)
+
+func (x AttributeFilterOperator) Enum() *AttributeFilterOperator {
+	p := new(AttributeFilterOperator)
+	*p = x
+	return p
+}
+
+func (x AttributeFilterOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttributeFilterOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_product_product_proto_enumTypes[0].Descriptor()
+}
+
+func (AttributeFilterOperator) Type() protoreflect.EnumType {
+	return &file_proto_v1_product_product_proto_enumTypes[0]
+}
+
+func (x AttributeFilterOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttributeFilterOperator.Descriptor instead.
+func (AttributeFilterOperator) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{0}
+}
+
+// How a batch of stock updates is applied.
+type StockUpdateMode int32
+
+const (
+	StockUpdateMode_STOCK_UPDATE_MODE_UNSPECIFIED StockUpdateMode = 0 // Treated as ATOMIC.
+	StockUpdateMode_STOCK_UPDATE_MODE_ATOMIC      StockUpdateMode = 1 // All items are applied in one transaction, or none are.
+	StockUpdateMode_STOCK_UPDATE_MODE_BEST_EFFORT StockUpdateMode = 2 // Valid items are applied; failed items are skipped.
+)
+
+// Enum value maps for StockUpdateMode.
+var (
+	StockUpdateMode_name = map[int32]string{
+		0: "STOCK_UPDATE_MODE_UNSPECIFIED",
+		1: "STOCK_UPDATE_MODE_ATOMIC",
+		2: "STOCK_UPDATE_MODE_BEST_EFFORT",
+	}
+	StockUpdateMode_value = map[string]int32{
+		"STOCK_UPDATE_MODE_UNSPECIFIED": 0,
+		"STOCK_UPDATE_MODE_ATOMIC":      1,
+		"STOCK_UPDATE_MODE_BEST_EFFORT": 2,
+	}
+)
+
+func (x StockUpdateMode) Enum() *StockUpdateMode {
+	p := new(StockUpdateMode)
+	*p = x
+	return p
+}
+
+func (x StockUpdateMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockUpdateMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_product_product_proto_enumTypes[1].Descriptor()
+}
+
+func (StockUpdateMode) Type() protoreflect.EnumType {
+	return &file_proto_v1_product_product_proto_enumTypes[1]
+}
+
+func (x StockUpdateMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockUpdateMode.Descriptor instead.
+func (StockUpdateMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{1}
+}
+
+// Outcome of a single item in an UpdateStock batch.
+type StockUpdateStatus int32
+
+const (
+	StockUpdateStatus_STOCK_UPDATE_STATUS_UNSPECIFIED        StockUpdateStatus = 0
+	StockUpdateStatus_STOCK_UPDATE_STATUS_APPLIED            StockUpdateStatus = 1
+	StockUpdateStatus_STOCK_UPDATE_STATUS_PRODUCT_NOT_FOUND  StockUpdateStatus = 2
+	StockUpdateStatus_STOCK_UPDATE_STATUS_INSUFFICIENT_STOCK StockUpdateStatus = 3 // Would leave less stock than zero or than active reservations hold.
+	StockUpdateStatus_STOCK_UPDATE_STATUS_INVALID_ITEM       StockUpdateStatus = 4 // e.g. non-positive product ID or zero quantity change.
+	StockUpdateStatus_STOCK_UPDATE_STATUS_ABORTED            StockUpdateStatus = 5 // Valid, but rolled back because another item failed (ATOMIC mode).
+	StockUpdateStatus_STOCK_UPDATE_STATUS_LOCATION_NOT_FOUND StockUpdateStatus = 6
+	StockUpdateStatus_STOCK_UPDATE_STATUS_NO_LOCATION        StockUpdateStatus = 7 // Stock was added without a location_id while no location exists.
+)
+
+// Enum value maps for StockUpdateStatus.
+var (
+	StockUpdateStatus_name = map[int32]string{
+		0: "STOCK_UPDATE_STATUS_UNSPECIFIED",
+		1: "STOCK_UPDATE_STATUS_APPLIED",
+		2: "STOCK_UPDATE_STATUS_PRODUCT_NOT_FOUND",
+		3: "STOCK_UPDATE_STATUS_INSUFFICIENT_STOCK",
+		4: "STOCK_UPDATE_STATUS_INVALID_ITEM",
+		5: "STOCK_UPDATE_STATUS_ABORTED",
+		6: "STOCK_UPDATE_STATUS_LOCATION_NOT_FOUND",
+		7: "STOCK_UPDATE_STATUS_NO_LOCATION",
+	}
+	StockUpdateStatus_value = map[string]int32{
+		"STOCK_UPDATE_STATUS_UNSPECIFIED":        0,
+		"STOCK_UPDATE_STATUS_APPLIED":            1,
+		"STOCK_UPDATE_STATUS_PRODUCT_NOT_FOUND":  2,
+		"STOCK_UPDATE_STATUS_INSUFFICIENT_STOCK": 3,
+		"STOCK_UPDATE_STATUS_INVALID_ITEM":       4,
+		"STOCK_UPDATE_STATUS_ABORTED":            5,
+		"STOCK_UPDATE_STATUS_LOCATION_NOT_FOUND": 6,
+		"STOCK_UPDATE_STATUS_NO_LOCATION":        7,
+	}
+)
+
+func (x StockUpdateStatus) Enum() *StockUpdateStatus {
+	p := new(StockUpdateStatus)
+	*p = x
+	return p
+}
+
+func (x StockUpdateStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockUpdateStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_product_product_proto_enumTypes[2].Descriptor()
+}
+
+func (StockUpdateStatus) Type() protoreflect.EnumType {
+	return &file_proto_v1_product_product_proto_enumTypes[2]
+}
+
+func (x StockUpdateStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockUpdateStatus.Descriptor instead.
+func (StockUpdateStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{2}
+}
+
+// What DeleteCategory does with the subcategories and products of the deleted category.
+// Products are never deleted.
+type CategoryDeleteStrategy int32
+
+const (
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_UNSPECIFIED CategoryDeleteStrategy = 0 // Treated as RESTRICT.
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_RESTRICT    CategoryDeleteStrategy = 1 // Fails with FAILED_PRECONDITION if the category has subcategories or products.
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_REPARENT    CategoryDeleteStrategy = 2 // Moves subcategories and products to the parent; roots and uncategorized
+	// products if the category is a root.
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_MOVE_PRODUCTS CategoryDeleteStrategy = 3 // Moves products to target_category_id and subcategories to the parent.
+	CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_CASCADE       CategoryDeleteStrategy = 4 // Deletes all descendants too; their products become uncategorized.
+)
+
+// Enum value maps for CategoryDeleteStrategy.
+var (
+	CategoryDeleteStrategy_name = map[int32]string{
+		0: "CATEGORY_DELETE_STRATEGY_UNSPECIFIED",
+		1: "CATEGORY_DELETE_STRATEGY_RESTRICT",
+		2: "CATEGORY_DELETE_STRATEGY_REPARENT",
+		3: "CATEGORY_DELETE_STRATEGY_MOVE_PRODUCTS",
+		4: "CATEGORY_DELETE_STRATEGY_CASCADE",
+	}
+	CategoryDeleteStrategy_value = map[string]int32{
+		"CATEGORY_DELETE_STRATEGY_UNSPECIFIED":   0,
+		"CATEGORY_DELETE_STRATEGY_RESTRICT":      1,
+		"CATEGORY_DELETE_STRATEGY_REPARENT":      2,
+		"CATEGORY_DELETE_STRATEGY_MOVE_PRODUCTS": 3,
+		"CATEGORY_DELETE_STRATEGY_CASCADE":       4,
+	}
+)
+
+func (x CategoryDeleteStrategy) Enum() *CategoryDeleteStrategy {
+	p := new(CategoryDeleteStrategy)
+	*p = x
+	return p
+}
+
+func (x CategoryDeleteStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CategoryDeleteStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_product_product_proto_enumTypes[3].Descriptor()
+}
+
+func (CategoryDeleteStrategy) Type() protoreflect.EnumType {
+	return &file_proto_v1_product_product_proto_enumTypes[3]
+}
+
+func (x CategoryDeleteStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CategoryDeleteStrategy.Descriptor instead.
+func (CategoryDeleteStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{3}
+}
+
+// The type a product attribute must have.
+type AttributeType int32
+
+const (
+	AttributeType_ATTRIBUTE_TYPE_UNSPECIFIED AttributeType = 0
+	AttributeType_ATTRIBUTE_TYPE_STRING      AttributeType = 1
+	AttributeType_ATTRIBUTE_TYPE_NUMBER      AttributeType = 2
+	AttributeType_ATTRIBUTE_TYPE_BOOLEAN     AttributeType = 3
+	AttributeType_ATTRIBUTE_TYPE_STRING_LIST AttributeType = 4 // An array of strings.
+)
+
+// Enum value maps for AttributeType.
+var (
+	AttributeType_name = map[int32]string{
+		0: "ATTRIBUTE_TYPE_UNSPECIFIED",
+		1: "ATTRIBUTE_TYPE_STRING",
+		2: "ATTRIBUTE_TYPE_NUMBER",
+		3: "ATTRIBUTE_TYPE_BOOLEAN",
+		4: "ATTRIBUTE_TYPE_STRING_LIST",
+	}
+	AttributeType_value = map[string]int32{
+		"ATTRIBUTE_TYPE_UNSPECIFIED": 0,
+		"ATTRIBUTE_TYPE_STRING":      1,
+		"ATTRIBUTE_TYPE_NUMBER":      2,
+		"ATTRIBUTE_TYPE_BOOLEAN":     3,
+		"ATTRIBUTE_TYPE_STRING_LIST": 4,
+	}
+)
+
+func (x AttributeType) Enum() *AttributeType {
+	p := new(AttributeType)
+	*p = x
+	return p
+}
+
+func (x AttributeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttributeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_product_product_proto_enumTypes[4].Descriptor()
+}
+
+func (AttributeType) Type() protoreflect.EnumType {
+	return &file_proto_v1_product_product_proto_enumTypes[4]
+}
+
+func (x AttributeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttributeType.Descriptor instead.
+func (AttributeType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{4}
+}
+
+// Lifecycle state of a stock reservation.
+type ReservationStatus int32
+
+const (
+	ReservationStatus_RESERVATION_STATUS_UNSPECIFIED ReservationStatus = 0
+	ReservationStatus_RESERVATION_STATUS_ACTIVE      ReservationStatus = 1 // Holding stock until expires_at.
+	ReservationStatus_RESERVATION_STATUS_COMMITTED   ReservationStatus = 2 // Converted into a stock decrement.
+	ReservationStatus_RESERVATION_STATUS_RELEASED    ReservationStatus = 3 // Returned by the caller.
+	ReservationStatus_RESERVATION_STATUS_EXPIRED     ReservationStatus = 4 // Returned after expires_at passed.
+)
+
+// Enum value maps for ReservationStatus.
+var (
+	ReservationStatus_name = map[int32]string{
+		0: "RESERVATION_STATUS_UNSPECIFIED",
+		1: "RESERVATION_STATUS_ACTIVE",
+		2: "RESERVATION_STATUS_COMMITTED",
+		3: "RESERVATION_STATUS_RELEASED",
+		4: "RESERVATION_STATUS_EXPIRED",
+	}
+	ReservationStatus_value = map[string]int32{
+		"RESERVATION_STATUS_UNSPECIFIED": 0,
+		"RESERVATION_STATUS_ACTIVE":      1,
+		"RESERVATION_STATUS_COMMITTED":   2,
+		"RESERVATION_STATUS_RELEASED":    3,
+		"RESERVATION_STATUS_EXPIRED":     4,
+	}
+)
+
+func (x ReservationStatus) Enum() *ReservationStatus {
+	p := new(ReservationStatus)
+	*p = x
+	return p
+}
+
+func (x ReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_product_product_proto_enumTypes[5].Descriptor()
+}
+
+func (ReservationStatus) Type() protoreflect.EnumType {
+	return &file_proto_v1_product_product_proto_enumTypes[5]
+}
+
+func (x ReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationStatus.Descriptor instead.
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{5}
+}
+
+// Why a product's stock changed.
+type StockMovementReason int32
+
+const (
+	StockMovementReason_STOCK_MOVEMENT_REASON_UNSPECIFIED        StockMovementReason = 0
+	StockMovementReason_STOCK_MOVEMENT_REASON_INITIAL_STOCK      StockMovementReason = 1 // Product created with stock.
+	StockMovementReason_STOCK_MOVEMENT_REASON_PRODUCT_UPDATE     StockMovementReason = 2 // stock_quantity edited through a product update.
+	StockMovementReason_STOCK_MOVEMENT_REASON_STOCK_UPDATE       StockMovementReason = 3 // UpdateStock.
+	StockMovementReason_STOCK_MOVEMENT_REASON_RESERVATION_COMMIT StockMovementReason = 4 // CommitReservation.
+	StockMovementReason_STOCK_MOVEMENT_REASON_ADJUSTMENT         StockMovementReason = 5 // Manual adjustment.
+	StockMovementReason_STOCK_MOVEMENT_REASON_TRANSFER           StockMovementReason = 6 // Moved between locations.
+)
+
+// Enum value maps for StockMovementReason.
+var (
+	StockMovementReason_name = map[int32]string{
+		0: "STOCK_MOVEMENT_REASON_UNSPECIFIED",
+		1: "STOCK_MOVEMENT_REASON_INITIAL_STOCK",
+		2: "STOCK_MOVEMENT_REASON_PRODUCT_UPDATE",
+		3: "STOCK_MOVEMENT_REASON_STOCK_UPDATE",
+		4: "STOCK_MOVEMENT_REASON_RESERVATION_COMMIT",
+		5: "STOCK_MOVEMENT_REASON_ADJUSTMENT",
+		6: "STOCK_MOVEMENT_REASON_TRANSFER",
+	}
+	StockMovementReason_value = map[string]int32{
+		"STOCK_MOVEMENT_REASON_UNSPECIFIED":        0,
+		"STOCK_MOVEMENT_REASON_INITIAL_STOCK":      1,
+		"STOCK_MOVEMENT_REASON_PRODUCT_UPDATE":     2,
+		"STOCK_MOVEMENT_REASON_STOCK_UPDATE":       3,
+		"STOCK_MOVEMENT_REASON_RESERVATION_COMMIT": 4,
+		"STOCK_MOVEMENT_REASON_ADJUSTMENT":         5,
+		"STOCK_MOVEMENT_REASON_TRANSFER":           6,
+	}
+)
+
+func (x StockMovementReason) Enum() *StockMovementReason {
+	p := new(StockMovementReason)
+	*p = x
+	return p
+}
+
+func (x StockMovementReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockMovementReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_product_product_proto_enumTypes[6].Descriptor()
+}
+
+func (StockMovementReason) Type() protoreflect.EnumType {
+	return &file_proto_v1_product_product_proto_enumTypes[6]
+}
+
+func (x StockMovementReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockMovementReason.Descriptor instead.
+func (StockMovementReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{6}
+}
+
 type Category struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -109,19 +512,34 @@ func (x *Category) GetUpdatedAt() *timestamppb.Timestamp {
 }
 
 type Product struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"` // Consider string for precise decimal handling in some languages, or custom decimal type
-	StockQuantity int32                  `protobuf:"varint,6,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
-	CategoryId    *int64                 `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
-	ImageUrl      *string                `protobuf:"bytes,8,opt,name=image_url,json=imageUrl,proto3,oneof" json:"image_url,omitempty"`
-	IsActive      bool                   `protobuf:"varint,9,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
-	Attributes    *structpb.Struct       `protobuf:"bytes,10,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"` // Flexible field for additional attributes
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Sku         string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+	Price          float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"` // Approximation of price_money, kept for older clients.
+	StockQuantity  int32                  `protobuf:"varint,6,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	CategoryId     *int64                 `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	ImageUrl       *string                `protobuf:"bytes,8,opt,name=image_url,json=imageUrl,proto3,oneof" json:"image_url,omitempty"`
+	IsActive       bool                   `protobuf:"varint,9,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Attributes     *structpb.Struct       `protobuf:"bytes,10,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"` // Flexible field for additional attributes
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PriceMoney     *common.Money          `protobuf:"bytes,13,opt,name=price_money,json=priceMoney,proto3" json:"price_money,omitempty"`                     // Exact price.
+	PriceListId    *int64                 `protobuf:"varint,14,opt,name=price_list_id,json=priceListId,proto3,oneof" json:"price_list_id,omitempty"`         // Set when price_money was taken from a price list rather than being the base price.
+	CompareAtPrice *common.Money          `protobuf:"bytes,15,opt,name=compare_at_price,json=compareAtPrice,proto3,oneof" json:"compare_at_price,omitempty"` // The base price while a scheduled price (e.g. a sale) replaces it in price_money.
+	// Variants: a parent product names its options; each variant is a product with its own SKU, price
+	// and stock that points to the parent and has a value for every option.
+	ParentId      *int64            `protobuf:"varint,16,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`                                                                                // Set for variants.
+	Options       []string          `protobuf:"bytes,17,rep,name=options,proto3" json:"options,omitempty"`                                                                                                         // Option names of a parent, in display order, e.g. ["color", "size"].
+	OptionValues  map[string]string `protobuf:"bytes,18,rep,name=option_values,json=optionValues,proto3" json:"option_values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // A variant's value for each option of its parent.
+	InheritsPrice bool              `protobuf:"varint,19,opt,name=inherits_price,json=inheritsPrice,proto3" json:"inherits_price,omitempty"`                                                                       // A variant without a price override: it has the parent's base price.
+	Variants      []*Product        `protobuf:"bytes,20,rep,name=variants,proto3" json:"variants,omitempty"`                                                                                                       // The variants of a parent, ordered by ID, where requested.
+	// The product's images in display order; image_url is derived from them once there are any.
+	Media []*ProductMedia `protobuf:"bytes,21,rep,name=media,proto3" json:"media,omitempty"`
+	// Set while the product is in the trash; such products are only returned where requested with include_deleted.
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=deleted_at,json=deletedAt,proto3,oneof" json:"deleted_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -184,6 +602,7 @@ func (x *Product) GetSku() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
 func (x *Product) GetPrice() float64 {
 	if x != nil {
 		return x.Price
@@ -240,119 +659,108 @@ func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type GetProductDetailsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetProductDetailsRequest) Reset() {
-	*x = GetProductDetailsRequest{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Product) GetPriceMoney() *common.Money {
+	if x != nil {
+		return x.PriceMoney
+	}
+	return nil
 }
 
-func (x *GetProductDetailsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Product) GetPriceListId() int64 {
+	if x != nil && x.PriceListId != nil {
+		return *x.PriceListId
+	}
+	return 0
 }
 
-func (*GetProductDetailsRequest) ProtoMessage() {}
-
-func (x *GetProductDetailsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[2]
+func (x *Product) GetCompareAtPrice() *common.Money {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CompareAtPrice
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetProductDetailsRequest.ProtoReflect.Descriptor instead.
-func (*GetProductDetailsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{2}
+	return nil
 }
 
-func (x *GetProductDetailsRequest) GetProductId() int64 {
-	if x != nil {
-		return x.ProductId
+func (x *Product) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
 	}
 	return 0
 }
 
-type GetProductDetailsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Product) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
 }
 
-func (x *GetProductDetailsResponse) Reset() {
-	*x = GetProductDetailsResponse{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Product) GetOptionValues() map[string]string {
+	if x != nil {
+		return x.OptionValues
+	}
+	return nil
 }
 
-func (x *GetProductDetailsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Product) GetInheritsPrice() bool {
+	if x != nil {
+		return x.InheritsPrice
+	}
+	return false
 }
 
-func (*GetProductDetailsResponse) ProtoMessage() {}
-
-func (x *GetProductDetailsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[3]
+func (x *Product) GetVariants() []*Product {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Variants
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetProductDetailsResponse.ProtoReflect.Descriptor instead.
-func (*GetProductDetailsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{3}
+func (x *Product) GetMedia() []*ProductMedia {
+	if x != nil {
+		return x.Media
+	}
+	return nil
 }
 
-func (x *GetProductDetailsResponse) GetProduct() *Product {
+func (x *Product) GetDeletedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Product
+		return x.DeletedAt
 	}
 	return nil
 }
 
-type ListProductsInternalRequest struct {
-	state           protoimpl.MessageState  `protogen:"open.v1"`
-	PageInfo        *common.PageInfoRequest `protobuf:"bytes,1,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
-	CategoryId      *int64                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`                // Optional: Filter products by category ID.
-	ProductIds      []int64                 `protobuf:"varint,3,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`               // Optional: Fetch specific products by their IDs.
-	IncludeInactive *bool                   `protobuf:"varint,4,opt,name=include_inactive,json=includeInactive,proto3,oneof" json:"include_inactive,omitempty"` // Optional: Flag to include inactive products.
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// ProductMedia is an image of a product.
+type ProductMedia struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	AltText       string                 `protobuf:"bytes,4,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`            // "primary" (at most one per product), "gallery" or "thumbnail".
+	Position      int32                  `protobuf:"varint,6,opt,name=position,proto3" json:"position,omitempty"`   // 1-based display order; positions may have gaps.
+	Width         *int32                 `protobuf:"varint,7,opt,name=width,proto3,oneof" json:"width,omitempty"`   // In pixels, if known.
+	Height        *int32                 `protobuf:"varint,8,opt,name=height,proto3,oneof" json:"height,omitempty"` // In pixels, if known.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsInternalRequest) Reset() {
-	*x = ListProductsInternalRequest{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[4]
+func (x *ProductMedia) Reset() {
+	*x = ProductMedia{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsInternalRequest) String() string {
+func (x *ProductMedia) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsInternalRequest) ProtoMessage() {}
+func (*ProductMedia) ProtoMessage() {}
 
-func (x *ListProductsInternalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[4]
+func (x *ProductMedia) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -363,62 +771,2838 @@ func (x *ListProductsInternalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsInternalRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsInternalRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ProductMedia.ProtoReflect.Descriptor instead.
+func (*ProductMedia) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ListProductsInternalRequest) GetPageInfo() *common.PageInfoRequest {
+func (x *ProductMedia) GetId() int64 {
 	if x != nil {
-		return x.PageInfo
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductMedia) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductMedia) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ProductMedia) GetWidth() int32 {
+	if x != nil && x.Width != nil {
+		return *x.Width
+	}
+	return 0
+}
+
+func (x *ProductMedia) GetHeight() int32 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
+func (x *ProductMedia) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProductMedia) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetProductDetailsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Currency       *string                `protobuf:"bytes,2,opt,name=currency,proto3,oneof" json:"currency,omitempty"`                                    // Optional: Return the price from the default price list of this ISO 4217 currency.
+	PriceListId    *int64                 `protobuf:"varint,3,opt,name=price_list_id,json=priceListId,proto3,oneof" json:"price_list_id,omitempty"`        // Optional: Return the price from this price list, falling back to the default list of its currency.
+	IncludeDeleted *bool                  `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3,oneof" json:"include_deleted,omitempty"` // Optional: Also return the product if it is in the trash, e.g. to show past orders.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetProductDetailsRequest) Reset() {
+	*x = GetProductDetailsRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductDetailsRequest) ProtoMessage() {}
+
+func (x *GetProductDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetProductDetailsRequest) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *GetProductDetailsRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *GetProductDetailsRequest) GetPriceListId() int64 {
+	if x != nil && x.PriceListId != nil {
+		return *x.PriceListId
+	}
+	return 0
+}
+
+func (x *GetProductDetailsRequest) GetIncludeDeleted() bool {
+	if x != nil && x.IncludeDeleted != nil {
+		return *x.IncludeDeleted
+	}
+	return false
+}
+
+type GetProductDetailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"` // For a parent, product.variants holds the variant matrix.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductDetailsResponse) Reset() {
+	*x = GetProductDetailsResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductDetailsResponse) ProtoMessage() {}
+
+func (x *GetProductDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProductDetailsResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type ListProductsInternalRequest struct {
+	state              protoimpl.MessageState  `protogen:"open.v1"`
+	PageInfo           *common.PageInfoRequest `protobuf:"bytes,1,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	CategoryId         *int64                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`                         // Optional: Filter products by category ID.
+	ProductIds         []int64                 `protobuf:"varint,3,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`                        // Optional: Fetch specific products by their IDs.
+	IncludeInactive    *bool                   `protobuf:"varint,4,opt,name=include_inactive,json=includeInactive,proto3,oneof" json:"include_inactive,omitempty"`          // Optional: Flag to include inactive products.
+	Facets             *ProductFacetsRequest   `protobuf:"bytes,5,opt,name=facets,proto3,oneof" json:"facets,omitempty"`                                                    // Optional: Facets to count over all matching products.
+	AttributeFilters   []*AttributeFilter      `protobuf:"bytes,6,rep,name=attribute_filters,json=attributeFilters,proto3" json:"attribute_filters,omitempty"`              // Optional: Conditions on product attributes; all must hold.
+	IncludeDescendants *bool                   `protobuf:"varint,7,opt,name=include_descendants,json=includeDescendants,proto3,oneof" json:"include_descendants,omitempty"` // Optional: With category_id, also match the products of its subcategories.
+	Currency           *string                 `protobuf:"bytes,8,opt,name=currency,proto3,oneof" json:"currency,omitempty"`                                                // Optional: As in GetProductDetailsRequest.
+	PriceListId        *int64                  `protobuf:"varint,9,opt,name=price_list_id,json=priceListId,proto3,oneof" json:"price_list_id,omitempty"`                    // Optional: As in GetProductDetailsRequest.
+	ParentId           *int64                  `protobuf:"varint,10,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`                              // Optional: List the variants of this product.
+	GroupByParent      *bool                   `protobuf:"varint,11,opt,name=group_by_parent,json=groupByParent,proto3,oneof" json:"group_by_parent,omitempty"`             // Optional: Leave variants out and return them in the variants of their parent.
+	IncludeDeleted     *bool                   `protobuf:"varint,12,opt,name=include_deleted,json=includeDeleted,proto3,oneof" json:"include_deleted,omitempty"`            // Optional: As in GetProductDetailsRequest.
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListProductsInternalRequest) Reset() {
+	*x = ListProductsInternalRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsInternalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsInternalRequest) ProtoMessage() {}
+
+func (x *ListProductsInternalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsInternalRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsInternalRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListProductsInternalRequest) GetPageInfo() *common.PageInfoRequest {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *ListProductsInternalRequest) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *ListProductsInternalRequest) GetProductIds() []int64 {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *ListProductsInternalRequest) GetIncludeInactive() bool {
+	if x != nil && x.IncludeInactive != nil {
+		return *x.IncludeInactive
+	}
+	return false
+}
+
+func (x *ListProductsInternalRequest) GetFacets() *ProductFacetsRequest {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *ListProductsInternalRequest) GetAttributeFilters() []*AttributeFilter {
+	if x != nil {
+		return x.AttributeFilters
+	}
+	return nil
+}
+
+func (x *ListProductsInternalRequest) GetIncludeDescendants() bool {
+	if x != nil && x.IncludeDescendants != nil {
+		return *x.IncludeDescendants
+	}
+	return false
+}
+
+func (x *ListProductsInternalRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *ListProductsInternalRequest) GetPriceListId() int64 {
+	if x != nil && x.PriceListId != nil {
+		return *x.PriceListId
+	}
+	return 0
+}
+
+func (x *ListProductsInternalRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *ListProductsInternalRequest) GetGroupByParent() bool {
+	if x != nil && x.GroupByParent != nil {
+		return *x.GroupByParent
+	}
+	return false
+}
+
+func (x *ListProductsInternalRequest) GetIncludeDeleted() bool {
+	if x != nil && x.IncludeDeleted != nil {
+		return *x.IncludeDeleted
+	}
+	return false
+}
+
+// The filters of ListProductsInternalRequest, without paging, facets or grouping.
+type ExportProductsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId         *int64                 `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`                         // Optional: Filter products by category ID.
+	ProductIds         []int64                `protobuf:"varint,2,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`                        // Optional: Export specific products by their IDs.
+	IncludeInactive    *bool                  `protobuf:"varint,3,opt,name=include_inactive,json=includeInactive,proto3,oneof" json:"include_inactive,omitempty"`          // Optional: Flag to include inactive products.
+	AttributeFilters   []*AttributeFilter     `protobuf:"bytes,4,rep,name=attribute_filters,json=attributeFilters,proto3" json:"attribute_filters,omitempty"`              // Optional: Conditions on product attributes; all must hold.
+	IncludeDescendants *bool                  `protobuf:"varint,5,opt,name=include_descendants,json=includeDescendants,proto3,oneof" json:"include_descendants,omitempty"` // Optional: With category_id, also match the products of its subcategories.
+	Currency           *string                `protobuf:"bytes,6,opt,name=currency,proto3,oneof" json:"currency,omitempty"`                                                // Optional: As in GetProductDetailsRequest.
+	PriceListId        *int64                 `protobuf:"varint,7,opt,name=price_list_id,json=priceListId,proto3,oneof" json:"price_list_id,omitempty"`                    // Optional: As in GetProductDetailsRequest.
+	ParentId           *int64                 `protobuf:"varint,8,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`                               // Optional: Export the variants of this product.
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExportProductsRequest) Reset() {
+	*x = ExportProductsRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsRequest) ProtoMessage() {}
+
+func (x *ExportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ExportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExportProductsRequest) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *ExportProductsRequest) GetProductIds() []int64 {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *ExportProductsRequest) GetIncludeInactive() bool {
+	if x != nil && x.IncludeInactive != nil {
+		return *x.IncludeInactive
+	}
+	return false
+}
+
+func (x *ExportProductsRequest) GetAttributeFilters() []*AttributeFilter {
+	if x != nil {
+		return x.AttributeFilters
+	}
+	return nil
+}
+
+func (x *ExportProductsRequest) GetIncludeDescendants() bool {
+	if x != nil && x.IncludeDescendants != nil {
+		return *x.IncludeDescendants
+	}
+	return false
+}
+
+func (x *ExportProductsRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *ExportProductsRequest) GetPriceListId() int64 {
+	if x != nil && x.PriceListId != nil {
+		return *x.PriceListId
+	}
+	return 0
+}
+
+func (x *ExportProductsRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+type ExportProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"` // The next batch of products, in ID order.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportProductsResponse) Reset() {
+	*x = ExportProductsResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsResponse) ProtoMessage() {}
+
+func (x *ExportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ExportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExportProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// A condition on the top-level key of a product's attributes. Values are compared as text, so "16"
+// matches both the string "16" and the number 16.
+type AttributeFilter struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Key           string                  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator      AttributeFilterOperator `protobuf:"varint,2,opt,name=operator,proto3,enum=product.v1.AttributeFilterOperator" json:"operator,omitempty"`
+	Values        []string                `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`   // EQUALS: exactly one; IN: at most 20.
+	Min           *float64                `protobuf:"fixed64,4,opt,name=min,proto3,oneof" json:"min,omitempty"` // RANGE: at least one of min and max.
+	Max           *float64                `protobuf:"fixed64,5,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeFilter) Reset() {
+	*x = AttributeFilter{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeFilter) ProtoMessage() {}
+
+func (x *AttributeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeFilter.ProtoReflect.Descriptor instead.
+func (*AttributeFilter) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AttributeFilter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AttributeFilter) GetOperator() AttributeFilterOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED
+}
+
+func (x *AttributeFilter) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *AttributeFilter) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *AttributeFilter) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+type ListProductsInternalResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Products      []*Product               `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	PageInfo      *common.PageInfoResponse `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	Facets        *ProductFacets           `protobuf:"bytes,3,opt,name=facets,proto3,oneof" json:"facets,omitempty"` // Set if the request asked for facets.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsInternalResponse) Reset() {
+	*x = ListProductsInternalResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsInternalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsInternalResponse) ProtoMessage() {}
+
+func (x *ListProductsInternalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsInternalResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsInternalResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListProductsInternalResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsInternalResponse) GetPageInfo() *common.PageInfoResponse {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *ListProductsInternalResponse) GetFacets() *ProductFacets {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+// Facets to compute for a product listing. They count every product matching the listing's filters,
+// not just the returned page.
+type ProductFacetsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Categories  bool                   `protobuf:"varint,1,opt,name=categories,proto3" json:"categories,omitempty"`
+	PriceRanges bool                   `protobuf:"varint,2,opt,name=price_ranges,json=priceRanges,proto3" json:"price_ranges,omitempty"`
+	// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+	PriceBoundaries []float64 `protobuf:"fixed64,3,rep,packed,name=price_boundaries,json=priceBoundaries,proto3" json:"price_boundaries,omitempty"` // Approximation of price_boundaries_money, kept for older clients.
+	IsActive        bool      `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	AttributeKeys   []string  `protobuf:"bytes,5,rep,name=attribute_keys,json=attributeKeys,proto3" json:"attribute_keys,omitempty"` // Keys of the JSON attributes whose values to count (at most 10).
+	// Ascending boundaries of the price ranges, in price_currency; only products priced in it are
+	// counted. Defaults to 10, 25, 50, 100, 250, 500, 1000.
+	PriceBoundariesMoney []*common.Money `protobuf:"bytes,6,rep,name=price_boundaries_money,json=priceBoundariesMoney,proto3" json:"price_boundaries_money,omitempty"`
+	// Currency of the price ranges; defaults to USD. Price ranges count base prices, so they cannot be
+	// combined with the request's currency or price_list_id.
+	PriceCurrency *string `protobuf:"bytes,7,opt,name=price_currency,json=priceCurrency,proto3,oneof" json:"price_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductFacetsRequest) Reset() {
+	*x = ProductFacetsRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductFacetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductFacetsRequest) ProtoMessage() {}
+
+func (x *ProductFacetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductFacetsRequest.ProtoReflect.Descriptor instead.
+func (*ProductFacetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProductFacetsRequest) GetCategories() bool {
+	if x != nil {
+		return x.Categories
+	}
+	return false
+}
+
+func (x *ProductFacetsRequest) GetPriceRanges() bool {
+	if x != nil {
+		return x.PriceRanges
+	}
+	return false
+}
+
+// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+func (x *ProductFacetsRequest) GetPriceBoundaries() []float64 {
+	if x != nil {
+		return x.PriceBoundaries
+	}
+	return nil
+}
+
+func (x *ProductFacetsRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *ProductFacetsRequest) GetAttributeKeys() []string {
+	if x != nil {
+		return x.AttributeKeys
+	}
+	return nil
+}
+
+func (x *ProductFacetsRequest) GetPriceBoundariesMoney() []*common.Money {
+	if x != nil {
+		return x.PriceBoundariesMoney
+	}
+	return nil
+}
+
+func (x *ProductFacetsRequest) GetPriceCurrency() string {
+	if x != nil && x.PriceCurrency != nil {
+		return *x.PriceCurrency
+	}
+	return ""
+}
+
+type ProductFacets struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*CategoryFacet       `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`                      // Most products first; the uncategorized (no category_id) last.
+	PriceRanges   []*PriceRangeFacet     `protobuf:"bytes,2,rep,name=price_ranges,json=priceRanges,proto3" json:"price_ranges,omitempty"` // Every range in ascending order, including empty ones.
+	IsActive      []*IsActiveFacet       `protobuf:"bytes,3,rep,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Attributes    []*AttributeFacet      `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty"` // In the order of the requested keys.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductFacets) Reset() {
+	*x = ProductFacets{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductFacets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductFacets) ProtoMessage() {}
+
+func (x *ProductFacets) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductFacets.ProtoReflect.Descriptor instead.
+func (*ProductFacets) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProductFacets) GetCategories() []*CategoryFacet {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ProductFacets) GetPriceRanges() []*PriceRangeFacet {
+	if x != nil {
+		return x.PriceRanges
+	}
+	return nil
+}
+
+func (x *ProductFacets) GetIsActive() []*IsActiveFacet {
+	if x != nil {
+		return x.IsActive
+	}
+	return nil
+}
+
+func (x *ProductFacets) GetAttributes() []*AttributeFacet {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type CategoryFacet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    *int64                 `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryFacet) Reset() {
+	*x = CategoryFacet{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryFacet) ProtoMessage() {}
+
+func (x *CategoryFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryFacet.ProtoReflect.Descriptor instead.
+func (*CategoryFacet) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CategoryFacet) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *CategoryFacet) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Products priced in [min, max). The lowest range has no min and the highest no max.
+type PriceRangeFacet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+	Min *float64 `protobuf:"fixed64,1,opt,name=min,proto3,oneof" json:"min,omitempty"` // Approximation of min_money, kept for older clients.
+	// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+	Max           *float64      `protobuf:"fixed64,2,opt,name=max,proto3,oneof" json:"max,omitempty"` // Approximation of max_money, kept for older clients.
+	Count         int32         `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	MinMoney      *common.Money `protobuf:"bytes,4,opt,name=min_money,json=minMoney,proto3,oneof" json:"min_money,omitempty"`
+	MaxMoney      *common.Money `protobuf:"bytes,5,opt,name=max_money,json=maxMoney,proto3,oneof" json:"max_money,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceRangeFacet) Reset() {
+	*x = PriceRangeFacet{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceRangeFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceRangeFacet) ProtoMessage() {}
+
+func (x *PriceRangeFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceRangeFacet.ProtoReflect.Descriptor instead.
+func (*PriceRangeFacet) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{13}
+}
+
+// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+func (x *PriceRangeFacet) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+func (x *PriceRangeFacet) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *PriceRangeFacet) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PriceRangeFacet) GetMinMoney() *common.Money {
+	if x != nil {
+		return x.MinMoney
+	}
+	return nil
+}
+
+func (x *PriceRangeFacet) GetMaxMoney() *common.Money {
+	if x != nil {
+		return x.MaxMoney
+	}
+	return nil
+}
+
+type IsActiveFacet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         bool                   `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsActiveFacet) Reset() {
+	*x = IsActiveFacet{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsActiveFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsActiveFacet) ProtoMessage() {}
+
+func (x *IsActiveFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsActiveFacet.ProtoReflect.Descriptor instead.
+func (*IsActiveFacet) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IsActiveFacet) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+func (x *IsActiveFacet) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AttributeFacet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Values        []*AttributeValueCount `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"` // Most frequent first, at most 50.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeFacet) Reset() {
+	*x = AttributeFacet{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeFacet) ProtoMessage() {}
+
+func (x *AttributeFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeFacet.ProtoReflect.Descriptor instead.
+func (*AttributeFacet) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AttributeFacet) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AttributeFacet) GetValues() []*AttributeValueCount {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Products whose attribute has the value, or contains it if the attribute is an array. Values are
+// the JSON scalars as text (e.g. "red", "16", "true").
+type AttributeValueCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeValueCount) Reset() {
+	*x = AttributeValueCount{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeValueCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeValueCount) ProtoMessage() {}
+
+func (x *AttributeValueCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeValueCount.ProtoReflect.Descriptor instead.
+func (*AttributeValueCount) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AttributeValueCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *AttributeValueCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type StockUpdateItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityChange int32                  `protobuf:"varint,2,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"` // Negative to decrease, positive to increase.
+	LocationId     *int64                 `protobuf:"varint,3,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"`       // Optional: Location to change. If unset, decreases are taken from locations
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StockUpdateItem) Reset() {
+	*x = StockUpdateItem{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockUpdateItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockUpdateItem) ProtoMessage() {}
+
+func (x *StockUpdateItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockUpdateItem.ProtoReflect.Descriptor instead.
+func (*StockUpdateItem) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StockUpdateItem) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockUpdateItem) GetQuantityChange() int32 {
+	if x != nil {
+		return x.QuantityChange
+	}
+	return 0
+}
+
+func (x *StockUpdateItem) GetLocationId() int64 {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return 0
+}
+
+type StockUpdateItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Status        StockUpdateStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=product.v1.StockUpdateStatus" json:"status,omitempty"`
+	Reason        *string                `protobuf:"bytes,3,opt,name=reason,proto3,oneof" json:"reason,omitempty"`     // Human-readable explanation when status is not APPLIED.
+	Product       *Product               `protobuf:"bytes,4,opt,name=product,proto3,oneof" json:"product,omitempty"`   // Product state after the batch when status is APPLIED.
+	Allocations   []*StockAllocation     `protobuf:"bytes,5,rep,name=allocations,proto3" json:"allocations,omitempty"` // Per-location parts of the change when status is APPLIED.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockUpdateItemResult) Reset() {
+	*x = StockUpdateItemResult{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockUpdateItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockUpdateItemResult) ProtoMessage() {}
+
+func (x *StockUpdateItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockUpdateItemResult.ProtoReflect.Descriptor instead.
+func (*StockUpdateItemResult) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StockUpdateItemResult) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockUpdateItemResult) GetStatus() StockUpdateStatus {
+	if x != nil {
+		return x.Status
+	}
+	return StockUpdateStatus_STOCK_UPDATE_STATUS_UNSPECIFIED
+}
+
+func (x *StockUpdateItemResult) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+func (x *StockUpdateItemResult) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *StockUpdateItemResult) GetAllocations() []*StockAllocation {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+type UpdateStockRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Items   []*StockUpdateItem     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                          // Allows batch stock updates
+	OrderId *string                `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3,oneof" json:"order_id,omitempty"` // Optional: Idempotency key. A retry with the same order_id and items returns the
+	// original response; reusing it with different items fails with ALREADY_EXISTS.
+	Mode          StockUpdateMode `protobuf:"varint,3,opt,name=mode,proto3,enum=product.v1.StockUpdateMode" json:"mode,omitempty"` // Defaults to ATOMIC.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStockRequest) Reset() {
+	*x = UpdateStockRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockRequest) ProtoMessage() {}
+
+func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateStockRequest) GetItems() []*StockUpdateItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *UpdateStockRequest) GetOrderId() string {
+	if x != nil && x.OrderId != nil {
+		return *x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetMode() StockUpdateMode {
+	if x != nil {
+		return x.Mode
+	}
+	return StockUpdateMode_STOCK_UPDATE_MODE_UNSPECIFIED
+}
+
+type UpdateStockResponse struct {
+	state           protoimpl.MessageState   `protogen:"open.v1"`
+	UpdatedProducts []*Product               `protobuf:"bytes,1,rep,name=updated_products,json=updatedProducts,proto3" json:"updated_products,omitempty"` // Returns details of all products whose stock was updated.
+	Results         []*StockUpdateItemResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`                                        // One entry per request item, in request order.
+	Mode            StockUpdateMode          `protobuf:"varint,3,opt,name=mode,proto3,enum=product.v1.StockUpdateMode" json:"mode,omitempty"`             // The mode that was applied (never UNSPECIFIED).
+	AllApplied      bool                     `protobuf:"varint,4,opt,name=all_applied,json=allApplied,proto3" json:"all_applied,omitempty"`               // True if every item has status APPLIED.
+	Replayed        bool                     `protobuf:"varint,5,opt,name=replayed,proto3" json:"replayed,omitempty"`                                     // True if this is the stored response of an earlier request with the same order_id.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateStockResponse) Reset() {
+	*x = UpdateStockResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockResponse) ProtoMessage() {}
+
+func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateStockResponse) GetUpdatedProducts() []*Product {
+	if x != nil {
+		return x.UpdatedProducts
+	}
+	return nil
+}
+
+func (x *UpdateStockResponse) GetResults() []*StockUpdateItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *UpdateStockResponse) GetMode() StockUpdateMode {
+	if x != nil {
+		return x.Mode
+	}
+	return StockUpdateMode_STOCK_UPDATE_MODE_UNSPECIFIED
+}
+
+func (x *UpdateStockResponse) GetAllApplied() bool {
+	if x != nil {
+		return x.AllApplied
+	}
+	return false
+}
+
+func (x *UpdateStockResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+type GetCategoryDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryDetailsRequest) Reset() {
+	*x = GetCategoryDetailsRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryDetailsRequest) ProtoMessage() {}
+
+func (x *GetCategoryDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetCategoryDetailsRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+type GetCategoryDetailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryDetailsResponse) Reset() {
+	*x = GetCategoryDetailsResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryDetailsResponse) ProtoMessage() {}
+
+func (x *GetCategoryDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetCategoryDetailsResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type ListCategoriesInternalRequest struct {
+	state            protoimpl.MessageState  `protogen:"open.v1"`
+	PageInfo         *common.PageInfoRequest `protobuf:"bytes,1,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	ParentCategoryId *int64                  `protobuf:"varint,2,opt,name=parent_category_id,json=parentCategoryId,proto3,oneof" json:"parent_category_id,omitempty"` // Optional: Only the direct subcategories of this category.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListCategoriesInternalRequest) Reset() {
+	*x = ListCategoriesInternalRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesInternalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesInternalRequest) ProtoMessage() {}
+
+func (x *ListCategoriesInternalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesInternalRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesInternalRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCategoriesInternalRequest) GetPageInfo() *common.PageInfoRequest {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+func (x *ListCategoriesInternalRequest) GetParentCategoryId() int64 {
+	if x != nil && x.ParentCategoryId != nil {
+		return *x.ParentCategoryId
+	}
+	return 0
+}
+
+type ListCategoriesInternalResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Categories    []*Category              `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	PageInfo      *common.PageInfoResponse `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesInternalResponse) Reset() {
+	*x = ListCategoriesInternalResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesInternalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesInternalResponse) ProtoMessage() {}
+
+func (x *ListCategoriesInternalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesInternalResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesInternalResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListCategoriesInternalResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ListCategoriesInternalResponse) GetPageInfo() *common.PageInfoResponse {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type DeleteCategoryRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId       int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Strategy         CategoryDeleteStrategy `protobuf:"varint,2,opt,name=strategy,proto3,enum=product.v1.CategoryDeleteStrategy" json:"strategy,omitempty"`
+	TargetCategoryId *int64                 `protobuf:"varint,3,opt,name=target_category_id,json=targetCategoryId,proto3,oneof" json:"target_category_id,omitempty"` // Required by, and only allowed with, MOVE_PRODUCTS.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryRequest) ProtoMessage() {}
+
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteCategoryRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *DeleteCategoryRequest) GetStrategy() CategoryDeleteStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return CategoryDeleteStrategy_CATEGORY_DELETE_STRATEGY_UNSPECIFIED
+}
+
+func (x *DeleteCategoryRequest) GetTargetCategoryId() int64 {
+	if x != nil && x.TargetCategoryId != nil {
+		return *x.TargetCategoryId
+	}
+	return 0
+}
+
+type DeleteCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryResponse) Reset() {
+	*x = DeleteCategoryResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryResponse) ProtoMessage() {}
+
+func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{26}
+}
+
+type CategoryTreeNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Children      []*CategoryTreeNode    `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`                           // Ordered by name; empty below max_depth.
+	HasChildren   bool                   `protobuf:"varint,3,opt,name=has_children,json=hasChildren,proto3" json:"has_children,omitempty"` // Set if the category has subcategories, even below max_depth.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryTreeNode) Reset() {
+	*x = CategoryTreeNode{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryTreeNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryTreeNode) ProtoMessage() {}
+
+func (x *CategoryTreeNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryTreeNode.ProtoReflect.Descriptor instead.
+func (*CategoryTreeNode) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CategoryTreeNode) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *CategoryTreeNode) GetChildren() []*CategoryTreeNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *CategoryTreeNode) GetHasChildren() bool {
+	if x != nil {
+		return x.HasChildren
+	}
+	return false
+}
+
+type GetCategoryTreeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RootCategoryId *int64                 `protobuf:"varint,1,opt,name=root_category_id,json=rootCategoryId,proto3,oneof" json:"root_category_id,omitempty"` // Optional: Only the tree under this category. Default: every root category.
+	MaxDepth       int32                  `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`                           // Levels to return; 1 returns just the roots. 0 for no limit.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetCategoryTreeRequest) Reset() {
+	*x = GetCategoryTreeRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryTreeRequest) ProtoMessage() {}
+
+func (x *GetCategoryTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryTreeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetCategoryTreeRequest) GetRootCategoryId() int64 {
+	if x != nil && x.RootCategoryId != nil {
+		return *x.RootCategoryId
+	}
+	return 0
+}
+
+func (x *GetCategoryTreeRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+type GetCategoryTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roots         []*CategoryTreeNode    `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"` // Ordered by name.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryTreeResponse) Reset() {
+	*x = GetCategoryTreeResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryTreeResponse) ProtoMessage() {}
+
+func (x *GetCategoryTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryTreeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetCategoryTreeResponse) GetRoots() []*CategoryTreeNode {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+type GetCategoryAncestorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryAncestorsRequest) Reset() {
+	*x = GetCategoryAncestorsRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryAncestorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryAncestorsRequest) ProtoMessage() {}
+
+func (x *GetCategoryAncestorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryAncestorsRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryAncestorsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetCategoryAncestorsRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+type GetCategoryAncestorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ancestors     []*Category            `protobuf:"bytes,1,rep,name=ancestors,proto3" json:"ancestors,omitempty"` // Root first, without the category itself.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryAncestorsResponse) Reset() {
+	*x = GetCategoryAncestorsResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryAncestorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryAncestorsResponse) ProtoMessage() {}
+
+func (x *GetCategoryAncestorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryAncestorsResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryAncestorsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetCategoryAncestorsResponse) GetAncestors() []*Category {
+	if x != nil {
+		return x.Ancestors
+	}
+	return nil
+}
+
+type GetCategoryDescendantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"` // Levels to return; 1 returns the direct subcategories. 0 for no limit.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryDescendantsRequest) Reset() {
+	*x = GetCategoryDescendantsRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryDescendantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryDescendantsRequest) ProtoMessage() {}
+
+func (x *GetCategoryDescendantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryDescendantsRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryDescendantsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetCategoryDescendantsRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *GetCategoryDescendantsRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+type GetCategoryDescendantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Descendants   []*Category            `protobuf:"bytes,1,rep,name=descendants,proto3" json:"descendants,omitempty"` // Ordered by depth, then name.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryDescendantsResponse) Reset() {
+	*x = GetCategoryDescendantsResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryDescendantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryDescendantsResponse) ProtoMessage() {}
+
+func (x *GetCategoryDescendantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryDescendantsResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryDescendantsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetCategoryDescendantsResponse) GetDescendants() []*Category {
+	if x != nil {
+		return x.Descendants
+	}
+	return nil
+}
+
+// The definition of a product attribute, applying to a category and all its subcategories.
+type CategoryAttribute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // The category that defines the attribute.
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Type          AttributeType          `protobuf:"varint,3,opt,name=type,proto3,enum=product.v1.AttributeType" json:"type,omitempty"`
+	EnumValues    []string               `protobuf:"bytes,4,rep,name=enum_values,json=enumValues,proto3" json:"enum_values,omitempty"` // Allowed values of STRING and STRING_LIST attributes; empty allows any.
+	Required      bool                   `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	Unit          *string                `protobuf:"bytes,6,opt,name=unit,proto3,oneof" json:"unit,omitempty"` // Unit of NUMBER attributes (e.g. "kg").
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryAttribute) Reset() {
+	*x = CategoryAttribute{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryAttribute) ProtoMessage() {}
+
+func (x *CategoryAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryAttribute.ProtoReflect.Descriptor instead.
+func (*CategoryAttribute) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CategoryAttribute) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CategoryAttribute) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CategoryAttribute) GetType() AttributeType {
+	if x != nil {
+		return x.Type
+	}
+	return AttributeType_ATTRIBUTE_TYPE_UNSPECIFIED
+}
+
+func (x *CategoryAttribute) GetEnumValues() []string {
+	if x != nil {
+		return x.EnumValues
+	}
+	return nil
+}
+
+func (x *CategoryAttribute) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *CategoryAttribute) GetUnit() string {
+	if x != nil && x.Unit != nil {
+		return *x.Unit
+	}
+	return ""
+}
+
+func (x *CategoryAttribute) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CategoryAttribute) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetCategoryAttributeSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryAttributeSchemaRequest) Reset() {
+	*x = GetCategoryAttributeSchemaRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryAttributeSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryAttributeSchemaRequest) ProtoMessage() {}
+
+func (x *GetCategoryAttributeSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryAttributeSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryAttributeSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetCategoryAttributeSchemaRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+type GetCategoryAttributeSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attributes    []*CategoryAttribute   `protobuf:"bytes,1,rep,name=attributes,proto3" json:"attributes,omitempty"` // The nearest definition of every key, ordered by key.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryAttributeSchemaResponse) Reset() {
+	*x = GetCategoryAttributeSchemaResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryAttributeSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryAttributeSchemaResponse) ProtoMessage() {}
+
+func (x *GetCategoryAttributeSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryAttributeSchemaResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryAttributeSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetCategoryAttributeSchemaResponse) GetAttributes() []*CategoryAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type ProductAvailabilityItemInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProductId        int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	RequiredQuantity int32                  `protobuf:"varint,2,opt,name=required_quantity,json=requiredQuantity,proto3" json:"required_quantity,omitempty"`
+	LocationId       *int64                 `protobuf:"varint,3,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"` // Optional: Only stock at this location counts.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProductAvailabilityItemInput) Reset() {
+	*x = ProductAvailabilityItemInput{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductAvailabilityItemInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductAvailabilityItemInput) ProtoMessage() {}
+
+func (x *ProductAvailabilityItemInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductAvailabilityItemInput.ProtoReflect.Descriptor instead.
+func (*ProductAvailabilityItemInput) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ProductAvailabilityItemInput) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductAvailabilityItemInput) GetRequiredQuantity() int32 {
+	if x != nil {
+		return x.RequiredQuantity
+	}
+	return 0
+}
+
+func (x *ProductAvailabilityItemInput) GetLocationId() int64 {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return 0
+}
+
+type CheckProductsAvailabilityRequest struct {
+	state                  protoimpl.MessageState          `protogen:"open.v1"`
+	Items                  []*ProductAvailabilityItemInput `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	ReservationReferenceId *string                         `protobuf:"bytes,2,opt,name=reservation_reference_id,json=reservationReferenceId,proto3,oneof" json:"reservation_reference_id,omitempty"` // Optional: Holds of this reference are not subtracted (e.g. the caller's own cart).
+	Currency               *string                         `protobuf:"bytes,3,opt,name=currency,proto3,oneof" json:"currency,omitempty"`                                                             // Optional: Price the items in this currency; items without a price in it are not available.
+	PriceListId            *int64                          `protobuf:"varint,4,opt,name=price_list_id,json=priceListId,proto3,oneof" json:"price_list_id,omitempty"`                                 // Optional: Price the items from this price list, as in GetProductDetailsRequest.
+	PriceAt                *timestamppb.Timestamp          `protobuf:"bytes,5,opt,name=price_at,json=priceAt,proto3,oneof" json:"price_at,omitempty"`                                                // Optional: Also return each product's price at this time, e.g. to verify
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CheckProductsAvailabilityRequest) Reset() {
+	*x = CheckProductsAvailabilityRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckProductsAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckProductsAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckProductsAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckProductsAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckProductsAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CheckProductsAvailabilityRequest) GetItems() []*ProductAvailabilityItemInput {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CheckProductsAvailabilityRequest) GetReservationReferenceId() string {
+	if x != nil && x.ReservationReferenceId != nil {
+		return *x.ReservationReferenceId
+	}
+	return ""
+}
+
+func (x *CheckProductsAvailabilityRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *CheckProductsAvailabilityRequest) GetPriceListId() int64 {
+	if x != nil && x.PriceListId != nil {
+		return *x.PriceListId
+	}
+	return 0
+}
+
+func (x *CheckProductsAvailabilityRequest) GetPriceAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PriceAt
+	}
+	return nil
+}
+
+type ProductAvailabilityStatus struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProductId         int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	IsAvailable       bool                   `protobuf:"varint,2,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`                   // Is enough stock available for the required quantity?
+	AvailableQuantity int32                  `protobuf:"varint,3,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"` // Stock quantity minus active reservations.
+	// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+	CurrentPrice       float64            `protobuf:"fixed64,4,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`                         // Approximation of current_price_money, kept for older clients.
+	Name               string             `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`                                                               // Product name for convenience in response.
+	ReasonNotAvailable *string            `protobuf:"bytes,6,opt,name=reason_not_available,json=reasonNotAvailable,proto3,oneof" json:"reason_not_available,omitempty"` // e.g., "Insufficient stock", "Product inactive", "Product not found"
+	ReservedQuantity   int32              `protobuf:"varint,7,opt,name=reserved_quantity,json=reservedQuantity,proto3" json:"reserved_quantity,omitempty"`              // Quantity held by active reservations (excluding reservation_reference_id).
+	Allocations        []*StockAllocation `protobuf:"bytes,8,rep,name=allocations,proto3" json:"allocations,omitempty"`                                                 // Where the required quantity would be taken from, in priority order.
+	// Empty if not available.
+	CurrentPriceMoney *common.Money `protobuf:"bytes,9,opt,name=current_price_money,json=currentPriceMoney,proto3" json:"current_price_money,omitempty"` // Exact current price of the product; use it for order totals.
+	PriceAtMoney      *common.Money `protobuf:"bytes,10,opt,name=price_at_money,json=priceAtMoney,proto3,oneof" json:"price_at_money,omitempty"`         // Price at the request's price_at; unset if the product had none then.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ProductAvailabilityStatus) Reset() {
+	*x = ProductAvailabilityStatus{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductAvailabilityStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductAvailabilityStatus) ProtoMessage() {}
+
+func (x *ProductAvailabilityStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductAvailabilityStatus.ProtoReflect.Descriptor instead.
+func (*ProductAvailabilityStatus) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ProductAvailabilityStatus) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductAvailabilityStatus) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *ProductAvailabilityStatus) GetAvailableQuantity() int32 {
+	if x != nil {
+		return x.AvailableQuantity
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/v1/product/product.proto.
+func (x *ProductAvailabilityStatus) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *ProductAvailabilityStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductAvailabilityStatus) GetReasonNotAvailable() string {
+	if x != nil && x.ReasonNotAvailable != nil {
+		return *x.ReasonNotAvailable
+	}
+	return ""
+}
+
+func (x *ProductAvailabilityStatus) GetReservedQuantity() int32 {
+	if x != nil {
+		return x.ReservedQuantity
+	}
+	return 0
+}
+
+func (x *ProductAvailabilityStatus) GetAllocations() []*StockAllocation {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *ProductAvailabilityStatus) GetCurrentPriceMoney() *common.Money {
+	if x != nil {
+		return x.CurrentPriceMoney
+	}
+	return nil
+}
+
+func (x *ProductAvailabilityStatus) GetPriceAtMoney() *common.Money {
+	if x != nil {
+		return x.PriceAtMoney
+	}
+	return nil
+}
+
+type CheckProductsAvailabilityResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Statuses      []*ProductAvailabilityStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckProductsAvailabilityResponse) Reset() {
+	*x = CheckProductsAvailabilityResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckProductsAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckProductsAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckProductsAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckProductsAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckProductsAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CheckProductsAvailabilityResponse) GetStatuses() []*ProductAvailabilityStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type StockReservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,2,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status        ReservationStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=product.v1.ReservationStatus" json:"status,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservation) Reset() {
+	*x = StockReservation{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservation) ProtoMessage() {}
+
+func (x *StockReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservation.ProtoReflect.Descriptor instead.
+func (*StockReservation) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StockReservation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockReservation) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *StockReservation) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockReservation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockReservation) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_RESERVATION_STATUS_UNSPECIFIED
+}
+
+func (x *StockReservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StockReservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StockReservation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ReservationItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // Must be positive. Repeated products are summed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationItem) Reset() {
+	*x = ReservationItem{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationItem) ProtoMessage() {}
+
+func (x *ReservationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationItem.ProtoReflect.Descriptor instead.
+func (*ReservationItem) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReservationItem) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ReservationItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"` // Order or cart ID the stock is held for. One active reservation per reference.
+	Items         []*ReservationItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	TtlSeconds    *int32                 `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3,oneof" json:"ttl_seconds,omitempty"` // Optional: How long the hold lasts. Defaults to 15 minutes, at most 24 hours.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReserveStockRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int32 {
+	if x != nil && x.TtlSeconds != nil {
+		return *x.TtlSeconds
+	}
+	return 0
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservations  []*StockReservation    `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"` // One entry per distinct product, ordered by product ID.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReserveStockResponse) GetReservations() []*StockReservation {
+	if x != nil {
+		return x.Reservations
 	}
 	return nil
 }
 
-func (x *ListProductsInternalRequest) GetCategoryId() int64 {
-	if x != nil && x.CategoryId != nil {
-		return *x.CategoryId
+func (x *ReserveStockResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CommitReservationRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+type CommitReservationResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Reservations    []*StockReservation    `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	UpdatedProducts []*Product             `protobuf:"bytes,2,rep,name=updated_products,json=updatedProducts,proto3" json:"updated_products,omitempty"` // Product state after the stock decrement.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ListProductsInternalRequest) GetProductIds() []int64 {
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CommitReservationResponse) GetReservations() []*StockReservation {
 	if x != nil {
-		return x.ProductIds
+		return x.Reservations
 	}
 	return nil
 }
 
-func (x *ListProductsInternalRequest) GetIncludeInactive() bool {
-	if x != nil && x.IncludeInactive != nil {
-		return *x.IncludeInactive
+func (x *CommitReservationResponse) GetUpdatedProducts() []*Product {
+	if x != nil {
+		return x.UpdatedProducts
 	}
-	return false
+	return nil
 }
 
-type ListProductsInternalResponse struct {
-	state         protoimpl.MessageState   `protogen:"open.v1"`
-	Products      []*Product               `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	PageInfo      *common.PageInfoResponse `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsInternalResponse) Reset() {
-	*x = ListProductsInternalResponse{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[5]
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsInternalResponse) String() string {
+func (x *ReleaseReservationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsInternalResponse) ProtoMessage() {}
+func (*ReleaseReservationRequest) ProtoMessage() {}
 
-func (x *ListProductsInternalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[5]
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,48 +3613,94 @@ func (x *ListProductsInternalResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsInternalResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsInternalResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *ListProductsInternalResponse) GetProducts() []*Product {
+func (x *ReleaseReservationRequest) GetReferenceId() string {
 	if x != nil {
-		return x.Products
+		return x.ReferenceId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListProductsInternalResponse) GetPageInfo() *common.PageInfoResponse {
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservations  []*StockReservation    `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[48]
 	if x != nil {
-		return x.PageInfo
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ReleaseReservationResponse) GetReservations() []*StockReservation {
+	if x != nil {
+		return x.Reservations
 	}
 	return nil
 }
 
-type StockUpdateItem struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ProductId      int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	QuantityChange int32                  `protobuf:"varint,2,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"` // Negative to decrease, positive to increase.
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// One entry of the append-only inventory ledger.
+type StockMovement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityDelta int32                  `protobuf:"varint,3,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"`
+	BalanceAfter  int32                  `protobuf:"varint,4,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"` // Stock quantity right after the change.
+	Reason        StockMovementReason    `protobuf:"varint,5,opt,name=reason,proto3,enum=product.v1.StockMovementReason" json:"reason,omitempty"`
+	OrderId       *string                `protobuf:"bytes,6,opt,name=order_id,json=orderId,proto3,oneof" json:"order_id,omitempty"` // Order or reservation reference that caused the change.
+	Actor         *string                `protobuf:"bytes,7,opt,name=actor,proto3,oneof" json:"actor,omitempty"`                    // Caller identity (x-actor metadata / X-Actor header), when known.
+	Note          *string                `protobuf:"bytes,8,opt,name=note,proto3,oneof" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LocationId    *int64                 `protobuf:"varint,10,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"` // Location whose stock changed; unset for entries from before locations.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StockUpdateItem) Reset() {
-	*x = StockUpdateItem{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[6]
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StockUpdateItem) String() string {
+func (x *StockMovement) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StockUpdateItem) ProtoMessage() {}
+func (*StockMovement) ProtoMessage() {}
 
-func (x *StockUpdateItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[6]
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,48 +3711,106 @@ func (x *StockUpdateItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StockUpdateItem.ProtoReflect.Descriptor instead.
-func (*StockUpdateItem) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *StockUpdateItem) GetProductId() int64 {
+func (x *StockMovement) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockMovement) GetProductId() int64 {
 	if x != nil {
 		return x.ProductId
 	}
 	return 0
 }
 
-func (x *StockUpdateItem) GetQuantityChange() int32 {
+func (x *StockMovement) GetQuantityDelta() int32 {
 	if x != nil {
-		return x.QuantityChange
+		return x.QuantityDelta
 	}
 	return 0
 }
 
-type UpdateStockRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*StockUpdateItem     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                          // Allows batch stock updates
-	OrderId       *string                `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3,oneof" json:"order_id,omitempty"` // Optional: For tracing back to an order that triggered the stock update
+func (x *StockMovement) GetBalanceAfter() int32 {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() StockMovementReason {
+	if x != nil {
+		return x.Reason
+	}
+	return StockMovementReason_STOCK_MOVEMENT_REASON_UNSPECIFIED
+}
+
+func (x *StockMovement) GetOrderId() string {
+	if x != nil && x.OrderId != nil {
+		return *x.OrderId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetActor() string {
+	if x != nil && x.Actor != nil {
+		return *x.Actor
+	}
+	return ""
+}
+
+func (x *StockMovement) GetNote() string {
+	if x != nil && x.Note != nil {
+		return *x.Note
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StockMovement) GetLocationId() int64 {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return 0
+}
+
+type GetStockHistoryRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	ProductId     int64                   `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	PageInfo      *common.PageInfoRequest `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	From          *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"` // Optional: Inclusive lower bound on created_at.
+	To            *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=to,proto3,oneof" json:"to,omitempty"`     // Optional: Exclusive upper bound on created_at.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateStockRequest) Reset() {
-	*x = UpdateStockRequest{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[7]
+func (x *GetStockHistoryRequest) Reset() {
+	*x = GetStockHistoryRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStockRequest) String() string {
+func (x *GetStockHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStockRequest) ProtoMessage() {}
+func (*GetStockHistoryRequest) ProtoMessage() {}
 
-func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[7]
+func (x *GetStockHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,47 +3821,62 @@ func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
-func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetStockHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetStockHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *UpdateStockRequest) GetItems() []*StockUpdateItem {
+func (x *GetStockHistoryRequest) GetProductId() int64 {
 	if x != nil {
-		return x.Items
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *GetStockHistoryRequest) GetPageInfo() *common.PageInfoRequest {
+	if x != nil {
+		return x.PageInfo
 	}
 	return nil
 }
 
-func (x *UpdateStockRequest) GetOrderId() string {
-	if x != nil && x.OrderId != nil {
-		return *x.OrderId
+func (x *GetStockHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
 	}
-	return ""
+	return nil
 }
 
-type UpdateStockResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	UpdatedProducts []*Product             `protobuf:"bytes,1,rep,name=updated_products,json=updatedProducts,proto3" json:"updated_products,omitempty"` // Returns details of all products whose stock was updated.
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *GetStockHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
 }
 
-func (x *UpdateStockResponse) Reset() {
-	*x = UpdateStockResponse{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[8]
+type GetStockHistoryResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Movements     []*StockMovement         `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	PageInfo      *common.PageInfoResponse `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockHistoryResponse) Reset() {
+	*x = GetStockHistoryResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStockResponse) String() string {
+func (x *GetStockHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStockResponse) ProtoMessage() {}
+func (*GetStockHistoryResponse) ProtoMessage() {}
 
-func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[8]
+func (x *GetStockHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -584,40 +3887,136 @@ func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
-func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetStockHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetStockHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetStockHistoryResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *GetStockHistoryResponse) GetPageInfo() *common.PageInfoResponse {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+// A warehouse or other place that holds stock.
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"` // Allocation order: lower ships first, ties broken by ID.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Location) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Location) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Location) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *UpdateStockResponse) GetUpdatedProducts() []*Product {
+func (x *Location) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdatedProducts
+		return x.UpdatedAt
 	}
 	return nil
 }
 
-type GetCategoryDetailsRequest struct {
+// Quantity of a product held at one location.
+type LocationStock struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LocationId    int64                  `protobuf:"varint,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCategoryDetailsRequest) Reset() {
-	*x = GetCategoryDetailsRequest{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[9]
+func (x *LocationStock) Reset() {
+	*x = LocationStock{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCategoryDetailsRequest) String() string {
+func (x *LocationStock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCategoryDetailsRequest) ProtoMessage() {}
+func (*LocationStock) ProtoMessage() {}
 
-func (x *GetCategoryDetailsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[9]
+func (x *LocationStock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,40 +4027,63 @@ func (x *GetCategoryDetailsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCategoryDetailsRequest.ProtoReflect.Descriptor instead.
-func (*GetCategoryDetailsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use LocationStock.ProtoReflect.Descriptor instead.
+func (*LocationStock) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *GetCategoryDetailsRequest) GetCategoryId() int64 {
+func (x *LocationStock) GetProductId() int64 {
 	if x != nil {
-		return x.CategoryId
+		return x.ProductId
 	}
 	return 0
 }
 
-type GetCategoryDetailsResponse struct {
+func (x *LocationStock) GetLocationId() int64 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *LocationStock) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *LocationStock) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Part of a stock change applied to (or planned for) one location.
+type StockAllocation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	LocationId    int64                  `protobuf:"varint,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // Signed like the change it belongs to; positive for availability plans.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCategoryDetailsResponse) Reset() {
-	*x = GetCategoryDetailsResponse{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[10]
+func (x *StockAllocation) Reset() {
+	*x = StockAllocation{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCategoryDetailsResponse) String() string {
+func (x *StockAllocation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCategoryDetailsResponse) ProtoMessage() {}
+func (*StockAllocation) ProtoMessage() {}
 
-func (x *GetCategoryDetailsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[10]
+func (x *StockAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -672,41 +4094,46 @@ func (x *GetCategoryDetailsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCategoryDetailsResponse.ProtoReflect.Descriptor instead.
-func (*GetCategoryDetailsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use StockAllocation.ProtoReflect.Descriptor instead.
+func (*StockAllocation) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *GetCategoryDetailsResponse) GetCategory() *Category {
+func (x *StockAllocation) GetLocationId() int64 {
 	if x != nil {
-		return x.Category
+		return x.LocationId
 	}
-	return nil
+	return 0
 }
 
-type ListCategoriesInternalRequest struct {
-	state            protoimpl.MessageState  `protogen:"open.v1"`
-	PageInfo         *common.PageInfoRequest `protobuf:"bytes,1,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
-	ParentCategoryId *int64                  `protobuf:"varint,2,opt,name=parent_category_id,json=parentCategoryId,proto3,oneof" json:"parent_category_id,omitempty"` // Optional: Filter by parent category ID
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *StockAllocation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
 }
 
-func (x *ListCategoriesInternalRequest) Reset() {
-	*x = ListCategoriesInternalRequest{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[11]
+type ListLocationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationsRequest) Reset() {
+	*x = ListLocationsRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCategoriesInternalRequest) String() string {
+func (x *ListLocationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCategoriesInternalRequest) ProtoMessage() {}
+func (*ListLocationsRequest) ProtoMessage() {}
 
-func (x *ListCategoriesInternalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[11]
+func (x *ListLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,48 +4144,33 @@ func (x *ListCategoriesInternalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCategoriesInternalRequest.ProtoReflect.Descriptor instead.
-func (*ListCategoriesInternalRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *ListCategoriesInternalRequest) GetPageInfo() *common.PageInfoRequest {
-	if x != nil {
-		return x.PageInfo
-	}
-	return nil
-}
-
-func (x *ListCategoriesInternalRequest) GetParentCategoryId() int64 {
-	if x != nil && x.ParentCategoryId != nil {
-		return *x.ParentCategoryId
-	}
-	return 0
+// Deprecated: Use ListLocationsRequest.ProtoReflect.Descriptor instead.
+func (*ListLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{55}
 }
 
-type ListCategoriesInternalResponse struct {
-	state         protoimpl.MessageState   `protogen:"open.v1"`
-	Categories    []*Category              `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
-	PageInfo      *common.PageInfoResponse `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+type ListLocationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locations     []*Location            `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCategoriesInternalResponse) Reset() {
-	*x = ListCategoriesInternalResponse{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[12]
+func (x *ListLocationsResponse) Reset() {
+	*x = ListLocationsResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCategoriesInternalResponse) String() string {
+func (x *ListLocationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCategoriesInternalResponse) ProtoMessage() {}
+func (*ListLocationsResponse) ProtoMessage() {}
 
-func (x *ListCategoriesInternalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[12]
+func (x *ListLocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -769,48 +4181,40 @@ func (x *ListCategoriesInternalResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCategoriesInternalResponse.ProtoReflect.Descriptor instead.
-func (*ListCategoriesInternalResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *ListCategoriesInternalResponse) GetCategories() []*Category {
-	if x != nil {
-		return x.Categories
-	}
-	return nil
+// Deprecated: Use ListLocationsResponse.ProtoReflect.Descriptor instead.
+func (*ListLocationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *ListCategoriesInternalResponse) GetPageInfo() *common.PageInfoResponse {
+func (x *ListLocationsResponse) GetLocations() []*Location {
 	if x != nil {
-		return x.PageInfo
+		return x.Locations
 	}
 	return nil
 }
 
-type ProductAvailabilityItemInput struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	ProductId        int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	RequiredQuantity int32                  `protobuf:"varint,2,opt,name=required_quantity,json=requiredQuantity,proto3" json:"required_quantity,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type GetStockLevelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []int64                `protobuf:"varint,1,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProductAvailabilityItemInput) Reset() {
-	*x = ProductAvailabilityItemInput{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[13]
+func (x *GetStockLevelsRequest) Reset() {
+	*x = GetStockLevelsRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProductAvailabilityItemInput) String() string {
+func (x *GetStockLevelsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProductAvailabilityItemInput) ProtoMessage() {}
+func (*GetStockLevelsRequest) ProtoMessage() {}
 
-func (x *ProductAvailabilityItemInput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[13]
+func (x *GetStockLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -821,47 +4225,40 @@ func (x *ProductAvailabilityItemInput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProductAvailabilityItemInput.ProtoReflect.Descriptor instead.
-func (*ProductAvailabilityItemInput) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *ProductAvailabilityItemInput) GetProductId() int64 {
-	if x != nil {
-		return x.ProductId
-	}
-	return 0
+// Deprecated: Use GetStockLevelsRequest.ProtoReflect.Descriptor instead.
+func (*GetStockLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ProductAvailabilityItemInput) GetRequiredQuantity() int32 {
+func (x *GetStockLevelsRequest) GetProductIds() []int64 {
 	if x != nil {
-		return x.RequiredQuantity
+		return x.ProductIds
 	}
-	return 0
+	return nil
 }
 
-type CheckProductsAvailabilityRequest struct {
-	state         protoimpl.MessageState          `protogen:"open.v1"`
-	Items         []*ProductAvailabilityItemInput `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+type GetStockLevelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Levels        []*LocationStock       `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty"` // Ordered by product ID, then by location priority.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckProductsAvailabilityRequest) Reset() {
-	*x = CheckProductsAvailabilityRequest{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[14]
+func (x *GetStockLevelsResponse) Reset() {
+	*x = GetStockLevelsResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckProductsAvailabilityRequest) String() string {
+func (x *GetStockLevelsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckProductsAvailabilityRequest) ProtoMessage() {}
+func (*GetStockLevelsResponse) ProtoMessage() {}
 
-func (x *CheckProductsAvailabilityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[14]
+func (x *GetStockLevelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -872,45 +4269,44 @@ func (x *CheckProductsAvailabilityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckProductsAvailabilityRequest.ProtoReflect.Descriptor instead.
-func (*CheckProductsAvailabilityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetStockLevelsResponse.ProtoReflect.Descriptor instead.
+func (*GetStockLevelsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *CheckProductsAvailabilityRequest) GetItems() []*ProductAvailabilityItemInput {
+func (x *GetStockLevelsResponse) GetLevels() []*LocationStock {
 	if x != nil {
-		return x.Items
+		return x.Levels
 	}
 	return nil
 }
 
-type ProductAvailabilityStatus struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	ProductId          int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	IsAvailable        bool                   `protobuf:"varint,2,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`                             // Is enough stock available for the required quantity?
-	AvailableQuantity  int32                  `protobuf:"varint,3,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`           // Current actual stock quantity.
-	CurrentPrice       float64                `protobuf:"fixed64,4,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`                         // Current price of the product.
-	Name               string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`                                                               // Product name for convenience in response.
-	ReasonNotAvailable *string                `protobuf:"bytes,6,opt,name=reason_not_available,json=reasonNotAvailable,proto3,oneof" json:"reason_not_available,omitempty"` // e.g., "Insufficient stock", "Product inactive", "Product not found"
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type TransferStockRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	FromLocationId int64                  `protobuf:"varint,2,opt,name=from_location_id,json=fromLocationId,proto3" json:"from_location_id,omitempty"`
+	ToLocationId   int64                  `protobuf:"varint,3,opt,name=to_location_id,json=toLocationId,proto3" json:"to_location_id,omitempty"`
+	Quantity       int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // Must be positive.
+	Note           *string                `protobuf:"bytes,5,opt,name=note,proto3,oneof" json:"note,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ProductAvailabilityStatus) Reset() {
-	*x = ProductAvailabilityStatus{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[15]
+func (x *TransferStockRequest) Reset() {
+	*x = TransferStockRequest{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProductAvailabilityStatus) String() string {
+func (x *TransferStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProductAvailabilityStatus) ProtoMessage() {}
+func (*TransferStockRequest) ProtoMessage() {}
 
-func (x *ProductAvailabilityStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[15]
+func (x *TransferStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -921,75 +4317,68 @@ func (x *ProductAvailabilityStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProductAvailabilityStatus.ProtoReflect.Descriptor instead.
-func (*ProductAvailabilityStatus) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use TransferStockRequest.ProtoReflect.Descriptor instead.
+func (*TransferStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ProductAvailabilityStatus) GetProductId() int64 {
+func (x *TransferStockRequest) GetProductId() int64 {
 	if x != nil {
 		return x.ProductId
 	}
 	return 0
 }
 
-func (x *ProductAvailabilityStatus) GetIsAvailable() bool {
-	if x != nil {
-		return x.IsAvailable
-	}
-	return false
-}
-
-func (x *ProductAvailabilityStatus) GetAvailableQuantity() int32 {
+func (x *TransferStockRequest) GetFromLocationId() int64 {
 	if x != nil {
-		return x.AvailableQuantity
+		return x.FromLocationId
 	}
 	return 0
 }
 
-func (x *ProductAvailabilityStatus) GetCurrentPrice() float64 {
+func (x *TransferStockRequest) GetToLocationId() int64 {
 	if x != nil {
-		return x.CurrentPrice
+		return x.ToLocationId
 	}
 	return 0
 }
 
-func (x *ProductAvailabilityStatus) GetName() string {
+func (x *TransferStockRequest) GetQuantity() int32 {
 	if x != nil {
-		return x.Name
+		return x.Quantity
 	}
-	return ""
+	return 0
 }
 
-func (x *ProductAvailabilityStatus) GetReasonNotAvailable() string {
-	if x != nil && x.ReasonNotAvailable != nil {
-		return *x.ReasonNotAvailable
+func (x *TransferStockRequest) GetNote() string {
+	if x != nil && x.Note != nil {
+		return *x.Note
 	}
 	return ""
 }
 
-type CheckProductsAvailabilityResponse struct {
-	state         protoimpl.MessageState       `protogen:"open.v1"`
-	Statuses      []*ProductAvailabilityStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+type TransferStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Levels        []*LocationStock       `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty"` // The product's stock per location after the transfer.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckProductsAvailabilityResponse) Reset() {
-	*x = CheckProductsAvailabilityResponse{}
-	mi := &file_proto_v1_product_product_proto_msgTypes[16]
+func (x *TransferStockResponse) Reset() {
+	*x = TransferStockResponse{}
+	mi := &file_proto_v1_product_product_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckProductsAvailabilityResponse) String() string {
+func (x *TransferStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckProductsAvailabilityResponse) ProtoMessage() {}
+func (*TransferStockResponse) ProtoMessage() {}
 
-func (x *CheckProductsAvailabilityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_product_product_proto_msgTypes[16]
+func (x *TransferStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_product_product_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1000,14 +4389,14 @@ func (x *CheckProductsAvailabilityResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckProductsAvailabilityResponse.ProtoReflect.Descriptor instead.
-func (*CheckProductsAvailabilityResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use TransferStockResponse.ProtoReflect.Descriptor instead.
+func (*TransferStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_product_product_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *CheckProductsAvailabilityResponse) GetStatuses() []*ProductAvailabilityStatus {
+func (x *TransferStockResponse) GetLevels() []*LocationStock {
 	if x != nil {
-		return x.Statuses
+		return x.Levels
 	}
 	return nil
 }
@@ -1028,13 +4417,13 @@ const file_proto_v1_product_product_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
 	"\f_descriptionB\x15\n" +
-	"\x13_parent_category_id\"\xf9\x03\n" +
+	"\x13_parent_category_id\"\xef\b\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
 	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x10\n" +
-	"\x03sku\x18\x04 \x01(\tR\x03sku\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12%\n" +
+	"\x03sku\x18\x04 \x01(\tR\x03sku\x12\x18\n" +
+	"\x05price\x18\x05 \x01(\x01B\x02\x18\x01R\x05price\x12%\n" +
 	"\x0estock_quantity\x18\x06 \x01(\x05R\rstockQuantity\x12$\n" +
 	"\vcategory_id\x18\a \x01(\x03H\x01R\n" +
 	"categoryId\x88\x01\x01\x12 \n" +
@@ -1047,39 +4436,194 @@ const file_proto_v1_product_product_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x121\n" +
+	"\vprice_money\x18\r \x01(\v2\x10.common.v1.MoneyR\n" +
+	"priceMoney\x12'\n" +
+	"\rprice_list_id\x18\x0e \x01(\x03H\x04R\vpriceListId\x88\x01\x01\x12?\n" +
+	"\x10compare_at_price\x18\x0f \x01(\v2\x10.common.v1.MoneyH\x05R\x0ecompareAtPrice\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\x10 \x01(\x03H\x06R\bparentId\x88\x01\x01\x12\x18\n" +
+	"\aoptions\x18\x11 \x03(\tR\aoptions\x12J\n" +
+	"\roption_values\x18\x12 \x03(\v2%.product.v1.Product.OptionValuesEntryR\foptionValues\x12%\n" +
+	"\x0einherits_price\x18\x13 \x01(\bR\rinheritsPrice\x12/\n" +
+	"\bvariants\x18\x14 \x03(\v2\x13.product.v1.ProductR\bvariants\x12.\n" +
+	"\x05media\x18\x15 \x03(\v2\x18.product.v1.ProductMediaR\x05media\x12>\n" +
+	"\n" +
+	"deleted_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampH\aR\tdeletedAt\x88\x01\x01\x1a?\n" +
+	"\x11OptionValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
 	"\f_descriptionB\x0e\n" +
 	"\f_category_idB\f\n" +
 	"\n" +
 	"_image_urlB\r\n" +
-	"\v_attributes\"9\n" +
+	"\v_attributesB\x10\n" +
+	"\x0e_price_list_idB\x13\n" +
+	"\x11_compare_at_priceB\f\n" +
+	"\n" +
+	"_parent_idB\r\n" +
+	"\v_deleted_at\"\xdd\x02\n" +
+	"\fProductMedia\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x03R\tproductId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x19\n" +
+	"\balt_text\x18\x04 \x01(\tR\aaltText\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1a\n" +
+	"\bposition\x18\x06 \x01(\x05R\bposition\x12\x19\n" +
+	"\x05width\x18\a \x01(\x05H\x00R\x05width\x88\x01\x01\x12\x1b\n" +
+	"\x06height\x18\b \x01(\x05H\x01R\x06height\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\b\n" +
+	"\x06_widthB\t\n" +
+	"\a_height\"\xe4\x01\n" +
 	"\x18GetProductDetailsRequest\x12\x1d\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\x03R\tproductId\"J\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1f\n" +
+	"\bcurrency\x18\x02 \x01(\tH\x00R\bcurrency\x88\x01\x01\x12'\n" +
+	"\rprice_list_id\x18\x03 \x01(\x03H\x01R\vpriceListId\x88\x01\x01\x12,\n" +
+	"\x0finclude_deleted\x18\x04 \x01(\bH\x02R\x0eincludeDeleted\x88\x01\x01B\v\n" +
+	"\t_currencyB\x10\n" +
+	"\x0e_price_list_idB\x12\n" +
+	"\x10_include_deleted\"J\n" +
 	"\x19GetProductDetailsResponse\x12-\n" +
-	"\aproduct\x18\x01 \x01(\v2\x13.product.v1.ProductR\aproduct\"\xf2\x01\n" +
+	"\aproduct\x18\x01 \x01(\v2\x13.product.v1.ProductR\aproduct\"\xf0\x05\n" +
 	"\x1bListProductsInternalRequest\x127\n" +
 	"\tpage_info\x18\x01 \x01(\v2\x1a.common.v1.PageInfoRequestR\bpageInfo\x12$\n" +
 	"\vcategory_id\x18\x02 \x01(\x03H\x00R\n" +
 	"categoryId\x88\x01\x01\x12\x1f\n" +
 	"\vproduct_ids\x18\x03 \x03(\x03R\n" +
 	"productIds\x12.\n" +
-	"\x10include_inactive\x18\x04 \x01(\bH\x01R\x0fincludeInactive\x88\x01\x01B\x0e\n" +
+	"\x10include_inactive\x18\x04 \x01(\bH\x01R\x0fincludeInactive\x88\x01\x01\x12=\n" +
+	"\x06facets\x18\x05 \x01(\v2 .product.v1.ProductFacetsRequestH\x02R\x06facets\x88\x01\x01\x12H\n" +
+	"\x11attribute_filters\x18\x06 \x03(\v2\x1b.product.v1.AttributeFilterR\x10attributeFilters\x124\n" +
+	"\x13include_descendants\x18\a \x01(\bH\x03R\x12includeDescendants\x88\x01\x01\x12\x1f\n" +
+	"\bcurrency\x18\b \x01(\tH\x04R\bcurrency\x88\x01\x01\x12'\n" +
+	"\rprice_list_id\x18\t \x01(\x03H\x05R\vpriceListId\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\n" +
+	" \x01(\x03H\x06R\bparentId\x88\x01\x01\x12+\n" +
+	"\x0fgroup_by_parent\x18\v \x01(\bH\aR\rgroupByParent\x88\x01\x01\x12,\n" +
+	"\x0finclude_deleted\x18\f \x01(\bH\bR\x0eincludeDeleted\x88\x01\x01B\x0e\n" +
+	"\f_category_idB\x13\n" +
+	"\x11_include_inactiveB\t\n" +
+	"\a_facetsB\x16\n" +
+	"\x14_include_descendantsB\v\n" +
+	"\t_currencyB\x10\n" +
+	"\x0e_price_list_idB\f\n" +
+	"\n" +
+	"_parent_idB\x12\n" +
+	"\x10_group_by_parentB\x12\n" +
+	"\x10_include_deleted\"\xe4\x03\n" +
+	"\x15ExportProductsRequest\x12$\n" +
+	"\vcategory_id\x18\x01 \x01(\x03H\x00R\n" +
+	"categoryId\x88\x01\x01\x12\x1f\n" +
+	"\vproduct_ids\x18\x02 \x03(\x03R\n" +
+	"productIds\x12.\n" +
+	"\x10include_inactive\x18\x03 \x01(\bH\x01R\x0fincludeInactive\x88\x01\x01\x12H\n" +
+	"\x11attribute_filters\x18\x04 \x03(\v2\x1b.product.v1.AttributeFilterR\x10attributeFilters\x124\n" +
+	"\x13include_descendants\x18\x05 \x01(\bH\x02R\x12includeDescendants\x88\x01\x01\x12\x1f\n" +
+	"\bcurrency\x18\x06 \x01(\tH\x03R\bcurrency\x88\x01\x01\x12'\n" +
+	"\rprice_list_id\x18\a \x01(\x03H\x04R\vpriceListId\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\b \x01(\x03H\x05R\bparentId\x88\x01\x01B\x0e\n" +
 	"\f_category_idB\x13\n" +
-	"\x11_include_inactive\"\x89\x01\n" +
+	"\x11_include_inactiveB\x16\n" +
+	"\x14_include_descendantsB\v\n" +
+	"\t_currencyB\x10\n" +
+	"\x0e_price_list_idB\f\n" +
+	"\n" +
+	"_parent_id\"I\n" +
+	"\x16ExportProductsResponse\x12/\n" +
+	"\bproducts\x18\x01 \x03(\v2\x13.product.v1.ProductR\bproducts\"\xba\x01\n" +
+	"\x0fAttributeFilter\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12?\n" +
+	"\boperator\x18\x02 \x01(\x0e2#.product.v1.AttributeFilterOperatorR\boperator\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\tR\x06values\x12\x15\n" +
+	"\x03min\x18\x04 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x05 \x01(\x01H\x01R\x03max\x88\x01\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"\xcc\x01\n" +
 	"\x1cListProductsInternalResponse\x12/\n" +
 	"\bproducts\x18\x01 \x03(\v2\x13.product.v1.ProductR\bproducts\x128\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x1b.common.v1.PageInfoResponseR\bpageInfo\"Y\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x1b.common.v1.PageInfoResponseR\bpageInfo\x126\n" +
+	"\x06facets\x18\x03 \x01(\v2\x19.product.v1.ProductFacetsH\x00R\x06facets\x88\x01\x01B\t\n" +
+	"\a_facets\"\xd3\x02\n" +
+	"\x14ProductFacetsRequest\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x01 \x01(\bR\n" +
+	"categories\x12!\n" +
+	"\fprice_ranges\x18\x02 \x01(\bR\vpriceRanges\x12-\n" +
+	"\x10price_boundaries\x18\x03 \x03(\x01B\x02\x18\x01R\x0fpriceBoundaries\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x12%\n" +
+	"\x0eattribute_keys\x18\x05 \x03(\tR\rattributeKeys\x12F\n" +
+	"\x16price_boundaries_money\x18\x06 \x03(\v2\x10.common.v1.MoneyR\x14priceBoundariesMoney\x12*\n" +
+	"\x0eprice_currency\x18\a \x01(\tH\x00R\rpriceCurrency\x88\x01\x01B\x11\n" +
+	"\x0f_price_currency\"\xfe\x01\n" +
+	"\rProductFacets\x129\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x19.product.v1.CategoryFacetR\n" +
+	"categories\x12>\n" +
+	"\fprice_ranges\x18\x02 \x03(\v2\x1b.product.v1.PriceRangeFacetR\vpriceRanges\x126\n" +
+	"\tis_active\x18\x03 \x03(\v2\x19.product.v1.IsActiveFacetR\bisActive\x12:\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\v2\x1a.product.v1.AttributeFacetR\n" +
+	"attributes\"[\n" +
+	"\rCategoryFacet\x12$\n" +
+	"\vcategory_id\x18\x01 \x01(\x03H\x00R\n" +
+	"categoryId\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05countB\x0e\n" +
+	"\f_category_id\"\xf1\x01\n" +
+	"\x0fPriceRangeFacet\x12\x19\n" +
+	"\x03min\x18\x01 \x01(\x01B\x02\x18\x01H\x00R\x03min\x88\x01\x01\x12\x19\n" +
+	"\x03max\x18\x02 \x01(\x01B\x02\x18\x01H\x01R\x03max\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x122\n" +
+	"\tmin_money\x18\x04 \x01(\v2\x10.common.v1.MoneyH\x02R\bminMoney\x88\x01\x01\x122\n" +
+	"\tmax_money\x18\x05 \x01(\v2\x10.common.v1.MoneyH\x03R\bmaxMoney\x88\x01\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_maxB\f\n" +
+	"\n" +
+	"_min_moneyB\f\n" +
+	"\n" +
+	"_max_money\";\n" +
+	"\rIsActiveFacet\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\bR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"[\n" +
+	"\x0eAttributeFacet\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x127\n" +
+	"\x06values\x18\x02 \x03(\v2\x1f.product.v1.AttributeValueCountR\x06values\"A\n" +
+	"\x13AttributeValueCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\x8f\x01\n" +
 	"\x0fStockUpdateItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\x12'\n" +
-	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\"t\n" +
+	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\x12$\n" +
+	"\vlocation_id\x18\x03 \x01(\x03H\x00R\n" +
+	"locationId\x88\x01\x01B\x0e\n" +
+	"\f_location_id\"\x94\x02\n" +
+	"\x15StockUpdateItemResult\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x125\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1d.product.v1.StockUpdateStatusR\x06status\x12\x1b\n" +
+	"\x06reason\x18\x03 \x01(\tH\x00R\x06reason\x88\x01\x01\x122\n" +
+	"\aproduct\x18\x04 \x01(\v2\x13.product.v1.ProductH\x01R\aproduct\x88\x01\x01\x12=\n" +
+	"\vallocations\x18\x05 \x03(\v2\x1b.product.v1.StockAllocationR\vallocationsB\t\n" +
+	"\a_reasonB\n" +
+	"\n" +
+	"\b_product\"\xa5\x01\n" +
 	"\x12UpdateStockRequest\x121\n" +
 	"\x05items\x18\x01 \x03(\v2\x1b.product.v1.StockUpdateItemR\x05items\x12\x1e\n" +
-	"\border_id\x18\x02 \x01(\tH\x00R\aorderId\x88\x01\x01B\v\n" +
-	"\t_order_id\"U\n" +
+	"\border_id\x18\x02 \x01(\tH\x00R\aorderId\x88\x01\x01\x12/\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x1b.product.v1.StockUpdateModeR\x04modeB\v\n" +
+	"\t_order_id\"\x80\x02\n" +
 	"\x13UpdateStockResponse\x12>\n" +
-	"\x10updated_products\x18\x01 \x03(\v2\x13.product.v1.ProductR\x0fupdatedProducts\"<\n" +
+	"\x10updated_products\x18\x01 \x03(\v2\x13.product.v1.ProductR\x0fupdatedProducts\x12;\n" +
+	"\aresults\x18\x02 \x03(\v2!.product.v1.StockUpdateItemResultR\aresults\x12/\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x1b.product.v1.StockUpdateModeR\x04mode\x12\x1f\n" +
+	"\vall_applied\x18\x04 \x01(\bR\n" +
+	"allApplied\x12\x1a\n" +
+	"\breplayed\x18\x05 \x01(\bR\breplayed\"<\n" +
 	"\x19GetCategoryDetailsRequest\x12\x1f\n" +
 	"\vcategory_id\x18\x01 \x01(\x03R\n" +
 	"categoryId\"N\n" +
@@ -1093,31 +4637,260 @@ const file_proto_v1_product_product_proto_rawDesc = "" +
 	"\n" +
 	"categories\x18\x01 \x03(\v2\x14.product.v1.CategoryR\n" +
 	"categories\x128\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x1b.common.v1.PageInfoResponseR\bpageInfo\"j\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x1b.common.v1.PageInfoResponseR\bpageInfo\"\xc2\x01\n" +
+	"\x15DeleteCategoryRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12>\n" +
+	"\bstrategy\x18\x02 \x01(\x0e2\".product.v1.CategoryDeleteStrategyR\bstrategy\x121\n" +
+	"\x12target_category_id\x18\x03 \x01(\x03H\x00R\x10targetCategoryId\x88\x01\x01B\x15\n" +
+	"\x13_target_category_id\"\x18\n" +
+	"\x16DeleteCategoryResponse\"\xa1\x01\n" +
+	"\x10CategoryTreeNode\x120\n" +
+	"\bcategory\x18\x01 \x01(\v2\x14.product.v1.CategoryR\bcategory\x128\n" +
+	"\bchildren\x18\x02 \x03(\v2\x1c.product.v1.CategoryTreeNodeR\bchildren\x12!\n" +
+	"\fhas_children\x18\x03 \x01(\bR\vhasChildren\"y\n" +
+	"\x16GetCategoryTreeRequest\x12-\n" +
+	"\x10root_category_id\x18\x01 \x01(\x03H\x00R\x0erootCategoryId\x88\x01\x01\x12\x1b\n" +
+	"\tmax_depth\x18\x02 \x01(\x05R\bmaxDepthB\x13\n" +
+	"\x11_root_category_id\"M\n" +
+	"\x17GetCategoryTreeResponse\x122\n" +
+	"\x05roots\x18\x01 \x03(\v2\x1c.product.v1.CategoryTreeNodeR\x05roots\">\n" +
+	"\x1bGetCategoryAncestorsRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\"R\n" +
+	"\x1cGetCategoryAncestorsResponse\x122\n" +
+	"\tancestors\x18\x01 \x03(\v2\x14.product.v1.CategoryR\tancestors\"]\n" +
+	"\x1dGetCategoryDescendantsRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x1b\n" +
+	"\tmax_depth\x18\x02 \x01(\x05R\bmaxDepth\"X\n" +
+	"\x1eGetCategoryDescendantsResponse\x126\n" +
+	"\vdescendants\x18\x01 \x03(\v2\x14.product.v1.CategoryR\vdescendants\"\xca\x02\n" +
+	"\x11CategoryAttribute\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.product.v1.AttributeTypeR\x04type\x12\x1f\n" +
+	"\venum_values\x18\x04 \x03(\tR\n" +
+	"enumValues\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\x12\x17\n" +
+	"\x04unit\x18\x06 \x01(\tH\x00R\x04unit\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\a\n" +
+	"\x05_unit\"D\n" +
+	"!GetCategoryAttributeSchemaRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\"c\n" +
+	"\"GetCategoryAttributeSchemaResponse\x12=\n" +
+	"\n" +
+	"attributes\x18\x01 \x03(\v2\x1d.product.v1.CategoryAttributeR\n" +
+	"attributes\"\xa0\x01\n" +
 	"\x1cProductAvailabilityItemInput\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\x12+\n" +
-	"\x11required_quantity\x18\x02 \x01(\x05R\x10requiredQuantity\"b\n" +
+	"\x11required_quantity\x18\x02 \x01(\x05R\x10requiredQuantity\x12$\n" +
+	"\vlocation_id\x18\x03 \x01(\x03H\x00R\n" +
+	"locationId\x88\x01\x01B\x0e\n" +
+	"\f_location_id\"\xf0\x02\n" +
 	" CheckProductsAvailabilityRequest\x12>\n" +
-	"\x05items\x18\x01 \x03(\v2(.product.v1.ProductAvailabilityItemInputR\x05items\"\x95\x02\n" +
+	"\x05items\x18\x01 \x03(\v2(.product.v1.ProductAvailabilityItemInputR\x05items\x12=\n" +
+	"\x18reservation_reference_id\x18\x02 \x01(\tH\x00R\x16reservationReferenceId\x88\x01\x01\x12\x1f\n" +
+	"\bcurrency\x18\x03 \x01(\tH\x01R\bcurrency\x88\x01\x01\x12'\n" +
+	"\rprice_list_id\x18\x04 \x01(\x03H\x02R\vpriceListId\x88\x01\x01\x12:\n" +
+	"\bprice_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\apriceAt\x88\x01\x01B\x1b\n" +
+	"\x19_reservation_reference_idB\v\n" +
+	"\t_currencyB\x10\n" +
+	"\x0e_price_list_idB\v\n" +
+	"\t_price_at\"\x97\x04\n" +
 	"\x19ProductAvailabilityStatus\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\x12!\n" +
 	"\fis_available\x18\x02 \x01(\bR\visAvailable\x12-\n" +
-	"\x12available_quantity\x18\x03 \x01(\x05R\x11availableQuantity\x12#\n" +
-	"\rcurrent_price\x18\x04 \x01(\x01R\fcurrentPrice\x12\x12\n" +
+	"\x12available_quantity\x18\x03 \x01(\x05R\x11availableQuantity\x12'\n" +
+	"\rcurrent_price\x18\x04 \x01(\x01B\x02\x18\x01R\fcurrentPrice\x12\x12\n" +
 	"\x04name\x18\x05 \x01(\tR\x04name\x125\n" +
-	"\x14reason_not_available\x18\x06 \x01(\tH\x00R\x12reasonNotAvailable\x88\x01\x01B\x17\n" +
-	"\x15_reason_not_available\"f\n" +
+	"\x14reason_not_available\x18\x06 \x01(\tH\x00R\x12reasonNotAvailable\x88\x01\x01\x12+\n" +
+	"\x11reserved_quantity\x18\a \x01(\x05R\x10reservedQuantity\x12=\n" +
+	"\vallocations\x18\b \x03(\v2\x1b.product.v1.StockAllocationR\vallocations\x12@\n" +
+	"\x13current_price_money\x18\t \x01(\v2\x10.common.v1.MoneyR\x11currentPriceMoney\x12;\n" +
+	"\x0eprice_at_money\x18\n" +
+	" \x01(\v2\x10.common.v1.MoneyH\x01R\fpriceAtMoney\x88\x01\x01B\x17\n" +
+	"\x15_reason_not_availableB\x11\n" +
+	"\x0f_price_at_money\"f\n" +
 	"!CheckProductsAvailabilityResponse\x12A\n" +
-	"\bstatuses\x18\x01 \x03(\v2%.product.v1.ProductAvailabilityStatusR\bstatuses2\x84\x05\n" +
+	"\bstatuses\x18\x01 \x03(\v2%.product.v1.ProductAvailabilityStatusR\bstatuses\"\xe8\x02\n" +
+	"\x10StockReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\freference_id\x18\x02 \x01(\tR\vreferenceId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\x03R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x125\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1d.product.v1.ReservationStatusR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"L\n" +
+	"\x0fReservationItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xa1\x01\n" +
+	"\x13ReserveStockRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x121\n" +
+	"\x05items\x18\x02 \x03(\v2\x1b.product.v1.ReservationItemR\x05items\x12$\n" +
+	"\vttl_seconds\x18\x03 \x01(\x05H\x00R\n" +
+	"ttlSeconds\x88\x01\x01B\x0e\n" +
+	"\f_ttl_seconds\"\x93\x01\n" +
+	"\x14ReserveStockResponse\x12@\n" +
+	"\freservations\x18\x01 \x03(\v2\x1c.product.v1.StockReservationR\freservations\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"=\n" +
+	"\x18CommitReservationRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\"\x9d\x01\n" +
+	"\x19CommitReservationResponse\x12@\n" +
+	"\freservations\x18\x01 \x03(\v2\x1c.product.v1.StockReservationR\freservations\x12>\n" +
+	"\x10updated_products\x18\x02 \x03(\v2\x13.product.v1.ProductR\x0fupdatedProducts\">\n" +
+	"\x19ReleaseReservationRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\"^\n" +
+	"\x1aReleaseReservationResponse\x12@\n" +
+	"\freservations\x18\x01 \x03(\v2\x1c.product.v1.StockReservationR\freservations\"\xa8\x03\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x03R\tproductId\x12%\n" +
+	"\x0equantity_delta\x18\x03 \x01(\x05R\rquantityDelta\x12#\n" +
+	"\rbalance_after\x18\x04 \x01(\x05R\fbalanceAfter\x127\n" +
+	"\x06reason\x18\x05 \x01(\x0e2\x1f.product.v1.StockMovementReasonR\x06reason\x12\x1e\n" +
+	"\border_id\x18\x06 \x01(\tH\x00R\aorderId\x88\x01\x01\x12\x19\n" +
+	"\x05actor\x18\a \x01(\tH\x01R\x05actor\x88\x01\x01\x12\x17\n" +
+	"\x04note\x18\b \x01(\tH\x02R\x04note\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12$\n" +
+	"\vlocation_id\x18\n" +
+	" \x01(\x03H\x03R\n" +
+	"locationId\x88\x01\x01B\v\n" +
+	"\t_order_idB\b\n" +
+	"\x06_actorB\a\n" +
+	"\x05_noteB\x0e\n" +
+	"\f_location_id\"\xe6\x01\n" +
+	"\x16GetStockHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x127\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x1a.common.v1.PageInfoRequestR\bpageInfo\x123\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x04from\x88\x01\x01\x12/\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x02to\x88\x01\x01B\a\n" +
+	"\x05_fromB\x05\n" +
+	"\x03_to\"\x8c\x01\n" +
+	"\x17GetStockHistoryResponse\x127\n" +
+	"\tmovements\x18\x01 \x03(\v2\x19.product.v1.StockMovementR\tmovements\x128\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x1b.common.v1.PageInfoResponseR\bpageInfo\"\xd4\x01\n" +
+	"\bLocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa6\x01\n" +
+	"\rLocationStock\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\x03R\n" +
+	"locationId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"N\n" +
+	"\x0fStockAllocation\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\x03R\n" +
+	"locationId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x16\n" +
+	"\x14ListLocationsRequest\"K\n" +
+	"\x15ListLocationsResponse\x122\n" +
+	"\tlocations\x18\x01 \x03(\v2\x14.product.v1.LocationR\tlocations\"8\n" +
+	"\x15GetStockLevelsRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\x03R\n" +
+	"productIds\"K\n" +
+	"\x16GetStockLevelsResponse\x121\n" +
+	"\x06levels\x18\x01 \x03(\v2\x19.product.v1.LocationStockR\x06levels\"\xc3\x01\n" +
+	"\x14TransferStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12(\n" +
+	"\x10from_location_id\x18\x02 \x01(\x03R\x0efromLocationId\x12$\n" +
+	"\x0eto_location_id\x18\x03 \x01(\x03R\ftoLocationId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x17\n" +
+	"\x04note\x18\x05 \x01(\tH\x00R\x04note\x88\x01\x01B\a\n" +
+	"\x05_note\"J\n" +
+	"\x15TransferStockResponse\x121\n" +
+	"\x06levels\x18\x01 \x03(\v2\x19.product.v1.LocationStockR\x06levels*\xd7\x01\n" +
+	"\x17AttributeFilterOperator\x12)\n" +
+	"%ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED\x10\x00\x12$\n" +
+	" ATTRIBUTE_FILTER_OPERATOR_EQUALS\x10\x01\x12 \n" +
+	"\x1cATTRIBUTE_FILTER_OPERATOR_IN\x10\x02\x12#\n" +
+	"\x1fATTRIBUTE_FILTER_OPERATOR_RANGE\x10\x03\x12$\n" +
+	" ATTRIBUTE_FILTER_OPERATOR_EXISTS\x10\x04*u\n" +
+	"\x0fStockUpdateMode\x12!\n" +
+	"\x1dSTOCK_UPDATE_MODE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18STOCK_UPDATE_MODE_ATOMIC\x10\x01\x12!\n" +
+	"\x1dSTOCK_UPDATE_MODE_BEST_EFFORT\x10\x02*\xc8\x02\n" +
+	"\x11StockUpdateStatus\x12#\n" +
+	"\x1fSTOCK_UPDATE_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bSTOCK_UPDATE_STATUS_APPLIED\x10\x01\x12)\n" +
+	"%STOCK_UPDATE_STATUS_PRODUCT_NOT_FOUND\x10\x02\x12*\n" +
+	"&STOCK_UPDATE_STATUS_INSUFFICIENT_STOCK\x10\x03\x12$\n" +
+	" STOCK_UPDATE_STATUS_INVALID_ITEM\x10\x04\x12\x1f\n" +
+	"\x1bSTOCK_UPDATE_STATUS_ABORTED\x10\x05\x12*\n" +
+	"&STOCK_UPDATE_STATUS_LOCATION_NOT_FOUND\x10\x06\x12#\n" +
+	"\x1fSTOCK_UPDATE_STATUS_NO_LOCATION\x10\a*\xe2\x01\n" +
+	"\x16CategoryDeleteStrategy\x12(\n" +
+	"$CATEGORY_DELETE_STRATEGY_UNSPECIFIED\x10\x00\x12%\n" +
+	"!CATEGORY_DELETE_STRATEGY_RESTRICT\x10\x01\x12%\n" +
+	"!CATEGORY_DELETE_STRATEGY_REPARENT\x10\x02\x12*\n" +
+	"&CATEGORY_DELETE_STRATEGY_MOVE_PRODUCTS\x10\x03\x12$\n" +
+	" CATEGORY_DELETE_STRATEGY_CASCADE\x10\x04*\xa1\x01\n" +
+	"\rAttributeType\x12\x1e\n" +
+	"\x1aATTRIBUTE_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ATTRIBUTE_TYPE_STRING\x10\x01\x12\x19\n" +
+	"\x15ATTRIBUTE_TYPE_NUMBER\x10\x02\x12\x1a\n" +
+	"\x16ATTRIBUTE_TYPE_BOOLEAN\x10\x03\x12\x1e\n" +
+	"\x1aATTRIBUTE_TYPE_STRING_LIST\x10\x04*\xb9\x01\n" +
+	"\x11ReservationStatus\x12\"\n" +
+	"\x1eRESERVATION_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19RESERVATION_STATUS_ACTIVE\x10\x01\x12 \n" +
+	"\x1cRESERVATION_STATUS_COMMITTED\x10\x02\x12\x1f\n" +
+	"\x1bRESERVATION_STATUS_RELEASED\x10\x03\x12\x1e\n" +
+	"\x1aRESERVATION_STATUS_EXPIRED\x10\x04*\xaf\x02\n" +
+	"\x13StockMovementReason\x12%\n" +
+	"!STOCK_MOVEMENT_REASON_UNSPECIFIED\x10\x00\x12'\n" +
+	"#STOCK_MOVEMENT_REASON_INITIAL_STOCK\x10\x01\x12(\n" +
+	"$STOCK_MOVEMENT_REASON_PRODUCT_UPDATE\x10\x02\x12&\n" +
+	"\"STOCK_MOVEMENT_REASON_STOCK_UPDATE\x10\x03\x12,\n" +
+	"(STOCK_MOVEMENT_REASON_RESERVATION_COMMIT\x10\x04\x12$\n" +
+	" STOCK_MOVEMENT_REASON_ADJUSTMENT\x10\x05\x12\"\n" +
+	"\x1eSTOCK_MOVEMENT_REASON_TRANSFER\x10\x062\xe8\x0e\n" +
 	"\x15ProductCatalogService\x12`\n" +
 	"\x11GetProductDetails\x12$.product.v1.GetProductDetailsRequest\x1a%.product.v1.GetProductDetailsResponse\x12i\n" +
-	"\x14ListProductsInternal\x12'.product.v1.ListProductsInternalRequest\x1a(.product.v1.ListProductsInternalResponse\x12N\n" +
+	"\x14ListProductsInternal\x12'.product.v1.ListProductsInternalRequest\x1a(.product.v1.ListProductsInternalResponse\x12Y\n" +
+	"\x0eExportProducts\x12!.product.v1.ExportProductsRequest\x1a\".product.v1.ExportProductsResponse0\x01\x12N\n" +
 	"\vUpdateStock\x12\x1e.product.v1.UpdateStockRequest\x1a\x1f.product.v1.UpdateStockResponse\x12c\n" +
 	"\x12GetCategoryDetails\x12%.product.v1.GetCategoryDetailsRequest\x1a&.product.v1.GetCategoryDetailsResponse\x12o\n" +
-	"\x16ListCategoriesInternal\x12).product.v1.ListCategoriesInternalRequest\x1a*.product.v1.ListCategoriesInternalResponse\x12x\n" +
-	"\x19CheckProductsAvailability\x12,.product.v1.CheckProductsAvailabilityRequest\x1a-.product.v1.CheckProductsAvailabilityResponseB4Z2product-catalog-service/proto/v1/product;productpbb\x06proto3"
+	"\x16ListCategoriesInternal\x12).product.v1.ListCategoriesInternalRequest\x1a*.product.v1.ListCategoriesInternalResponse\x12W\n" +
+	"\x0eDeleteCategory\x12!.product.v1.DeleteCategoryRequest\x1a\".product.v1.DeleteCategoryResponse\x12Z\n" +
+	"\x0fGetCategoryTree\x12\".product.v1.GetCategoryTreeRequest\x1a#.product.v1.GetCategoryTreeResponse\x12i\n" +
+	"\x14GetCategoryAncestors\x12'.product.v1.GetCategoryAncestorsRequest\x1a(.product.v1.GetCategoryAncestorsResponse\x12o\n" +
+	"\x16GetCategoryDescendants\x12).product.v1.GetCategoryDescendantsRequest\x1a*.product.v1.GetCategoryDescendantsResponse\x12{\n" +
+	"\x1aGetCategoryAttributeSchema\x12-.product.v1.GetCategoryAttributeSchemaRequest\x1a..product.v1.GetCategoryAttributeSchemaResponse\x12x\n" +
+	"\x19CheckProductsAvailability\x12,.product.v1.CheckProductsAvailabilityRequest\x1a-.product.v1.CheckProductsAvailabilityResponse\x12Q\n" +
+	"\fReserveStock\x12\x1f.product.v1.ReserveStockRequest\x1a .product.v1.ReserveStockResponse\x12`\n" +
+	"\x11CommitReservation\x12$.product.v1.CommitReservationRequest\x1a%.product.v1.CommitReservationResponse\x12c\n" +
+	"\x12ReleaseReservation\x12%.product.v1.ReleaseReservationRequest\x1a&.product.v1.ReleaseReservationResponse\x12Z\n" +
+	"\x0fGetStockHistory\x12\".product.v1.GetStockHistoryRequest\x1a#.product.v1.GetStockHistoryResponse\x12T\n" +
+	"\rListLocations\x12 .product.v1.ListLocationsRequest\x1a!.product.v1.ListLocationsResponse\x12W\n" +
+	"\x0eGetStockLevels\x12!.product.v1.GetStockLevelsRequest\x1a\".product.v1.GetStockLevelsResponse\x12T\n" +
+	"\rTransferStock\x12 .product.v1.TransferStockRequest\x1a!.product.v1.TransferStockResponseB4Z2product-catalog-service/proto/v1/product;productpbb\x06proto3"
 
 var (
 	file_proto_v1_product_product_proto_rawDescOnce sync.Once
@@ -1131,65 +4904,210 @@ func file_proto_v1_product_product_proto_rawDescGZIP() []byte {
 	return file_proto_v1_product_product_proto_rawDescData
 }
 
-var file_proto_v1_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_v1_product_product_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_proto_v1_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
 var file_proto_v1_product_product_proto_goTypes = []any{
-	(*Category)(nil),                          // 0: product.v1.Category
-	(*Product)(nil),                           // 1: product.v1.Product
-	(*GetProductDetailsRequest)(nil),          // 2: product.v1.GetProductDetailsRequest
-	(*GetProductDetailsResponse)(nil),         // 3: product.v1.GetProductDetailsResponse
-	(*ListProductsInternalRequest)(nil),       // 4: product.v1.ListProductsInternalRequest
-	(*ListProductsInternalResponse)(nil),      // 5: product.v1.ListProductsInternalResponse
-	(*StockUpdateItem)(nil),                   // 6: product.v1.StockUpdateItem
-	(*UpdateStockRequest)(nil),                // 7: product.v1.UpdateStockRequest
-	(*UpdateStockResponse)(nil),               // 8: product.v1.UpdateStockResponse
-	(*GetCategoryDetailsRequest)(nil),         // 9: product.v1.GetCategoryDetailsRequest
-	(*GetCategoryDetailsResponse)(nil),        // 10: product.v1.GetCategoryDetailsResponse
-	(*ListCategoriesInternalRequest)(nil),     // 11: product.v1.ListCategoriesInternalRequest
-	(*ListCategoriesInternalResponse)(nil),    // 12: product.v1.ListCategoriesInternalResponse
-	(*ProductAvailabilityItemInput)(nil),      // 13: product.v1.ProductAvailabilityItemInput
-	(*CheckProductsAvailabilityRequest)(nil),  // 14: product.v1.CheckProductsAvailabilityRequest
-	(*ProductAvailabilityStatus)(nil),         // 15: product.v1.ProductAvailabilityStatus
-	(*CheckProductsAvailabilityResponse)(nil), // 16: product.v1.CheckProductsAvailabilityResponse
-	(*timestamppb.Timestamp)(nil),             // 17: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),                   // 18: google.protobuf.Struct
-	(*common.PageInfoRequest)(nil),            // 19: common.v1.PageInfoRequest
-	(*common.PageInfoResponse)(nil),           // 20: common.v1.PageInfoResponse
+	(AttributeFilterOperator)(0),               // 0: product.v1.AttributeFilterOperator
+	(StockUpdateMode)(0),                       // 1: product.v1.StockUpdateMode
+	(StockUpdateStatus)(0),                     // 2: product.v1.StockUpdateStatus
+	(CategoryDeleteStrategy)(0),                // 3: product.v1.CategoryDeleteStrategy
+	(AttributeType)(0),                         // 4: product.v1.AttributeType
+	(ReservationStatus)(0),                     // 5: product.v1.ReservationStatus
+	(StockMovementReason)(0),                   // 6: product.v1.StockMovementReason
+	(*Category)(nil),                           // 7: product.v1.Category
+	(*Product)(nil),                            // 8: product.v1.Product
+	(*ProductMedia)(nil),                       // 9: product.v1.ProductMedia
+	(*GetProductDetailsRequest)(nil),           // 10: product.v1.GetProductDetailsRequest
+	(*GetProductDetailsResponse)(nil),          // 11: product.v1.GetProductDetailsResponse
+	(*ListProductsInternalRequest)(nil),        // 12: product.v1.ListProductsInternalRequest
+	(*ExportProductsRequest)(nil),              // 13: product.v1.ExportProductsRequest
+	(*ExportProductsResponse)(nil),             // 14: product.v1.ExportProductsResponse
+	(*AttributeFilter)(nil),                    // 15: product.v1.AttributeFilter
+	(*ListProductsInternalResponse)(nil),       // 16: product.v1.ListProductsInternalResponse
+	(*ProductFacetsRequest)(nil),               // 17: product.v1.ProductFacetsRequest
+	(*ProductFacets)(nil),                      // 18: product.v1.ProductFacets
+	(*CategoryFacet)(nil),                      // 19: product.v1.CategoryFacet
+	(*PriceRangeFacet)(nil),                    // 20: product.v1.PriceRangeFacet
+	(*IsActiveFacet)(nil),                      // 21: product.v1.IsActiveFacet
+	(*AttributeFacet)(nil),                     // 22: product.v1.AttributeFacet
+	(*AttributeValueCount)(nil),                // 23: product.v1.AttributeValueCount
+	(*StockUpdateItem)(nil),                    // 24: product.v1.StockUpdateItem
+	(*StockUpdateItemResult)(nil),              // 25: product.v1.StockUpdateItemResult
+	(*UpdateStockRequest)(nil),                 // 26: product.v1.UpdateStockRequest
+	(*UpdateStockResponse)(nil),                // 27: product.v1.UpdateStockResponse
+	(*GetCategoryDetailsRequest)(nil),          // 28: product.v1.GetCategoryDetailsRequest
+	(*GetCategoryDetailsResponse)(nil),         // 29: product.v1.GetCategoryDetailsResponse
+	(*ListCategoriesInternalRequest)(nil),      // 30: product.v1.ListCategoriesInternalRequest
+	(*ListCategoriesInternalResponse)(nil),     // 31: product.v1.ListCategoriesInternalResponse
+	(*DeleteCategoryRequest)(nil),              // 32: product.v1.DeleteCategoryRequest
+	(*DeleteCategoryResponse)(nil),             // 33: product.v1.DeleteCategoryResponse
+	(*CategoryTreeNode)(nil),                   // 34: product.v1.CategoryTreeNode
+	(*GetCategoryTreeRequest)(nil),             // 35: product.v1.GetCategoryTreeRequest
+	(*GetCategoryTreeResponse)(nil),            // 36: product.v1.GetCategoryTreeResponse
+	(*GetCategoryAncestorsRequest)(nil),        // 37: product.v1.GetCategoryAncestorsRequest
+	(*GetCategoryAncestorsResponse)(nil),       // 38: product.v1.GetCategoryAncestorsResponse
+	(*GetCategoryDescendantsRequest)(nil),      // 39: product.v1.GetCategoryDescendantsRequest
+	(*GetCategoryDescendantsResponse)(nil),     // 40: product.v1.GetCategoryDescendantsResponse
+	(*CategoryAttribute)(nil),                  // 41: product.v1.CategoryAttribute
+	(*GetCategoryAttributeSchemaRequest)(nil),  // 42: product.v1.GetCategoryAttributeSchemaRequest
+	(*GetCategoryAttributeSchemaResponse)(nil), // 43: product.v1.GetCategoryAttributeSchemaResponse
+	(*ProductAvailabilityItemInput)(nil),       // 44: product.v1.ProductAvailabilityItemInput
+	(*CheckProductsAvailabilityRequest)(nil),   // 45: product.v1.CheckProductsAvailabilityRequest
+	(*ProductAvailabilityStatus)(nil),          // 46: product.v1.ProductAvailabilityStatus
+	(*CheckProductsAvailabilityResponse)(nil),  // 47: product.v1.CheckProductsAvailabilityResponse
+	(*StockReservation)(nil),                   // 48: product.v1.StockReservation
+	(*ReservationItem)(nil),                    // 49: product.v1.ReservationItem
+	(*ReserveStockRequest)(nil),                // 50: product.v1.ReserveStockRequest
+	(*ReserveStockResponse)(nil),               // 51: product.v1.ReserveStockResponse
+	(*CommitReservationRequest)(nil),           // 52: product.v1.CommitReservationRequest
+	(*CommitReservationResponse)(nil),          // 53: product.v1.CommitReservationResponse
+	(*ReleaseReservationRequest)(nil),          // 54: product.v1.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),         // 55: product.v1.ReleaseReservationResponse
+	(*StockMovement)(nil),                      // 56: product.v1.StockMovement
+	(*GetStockHistoryRequest)(nil),             // 57: product.v1.GetStockHistoryRequest
+	(*GetStockHistoryResponse)(nil),            // 58: product.v1.GetStockHistoryResponse
+	(*Location)(nil),                           // 59: product.v1.Location
+	(*LocationStock)(nil),                      // 60: product.v1.LocationStock
+	(*StockAllocation)(nil),                    // 61: product.v1.StockAllocation
+	(*ListLocationsRequest)(nil),               // 62: product.v1.ListLocationsRequest
+	(*ListLocationsResponse)(nil),              // 63: product.v1.ListLocationsResponse
+	(*GetStockLevelsRequest)(nil),              // 64: product.v1.GetStockLevelsRequest
+	(*GetStockLevelsResponse)(nil),             // 65: product.v1.GetStockLevelsResponse
+	(*TransferStockRequest)(nil),               // 66: product.v1.TransferStockRequest
+	(*TransferStockResponse)(nil),              // 67: product.v1.TransferStockResponse
+	nil,                                        // 68: product.v1.Product.OptionValuesEntry
+	(*timestamppb.Timestamp)(nil),              // 69: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                    // 70: google.protobuf.Struct
+	(*common.Money)(nil),                       // 71: common.v1.Money
+	(*common.PageInfoRequest)(nil),             // 72: common.v1.PageInfoRequest
+	(*common.PageInfoResponse)(nil),            // 73: common.v1.PageInfoResponse
 }
 var file_proto_v1_product_product_proto_depIdxs = []int32{
-	17, // 0: product.v1.Category.created_at:type_name -> google.protobuf.Timestamp
-	17, // 1: product.v1.Category.updated_at:type_name -> google.protobuf.Timestamp
-	18, // 2: product.v1.Product.attributes:type_name -> google.protobuf.Struct
-	17, // 3: product.v1.Product.created_at:type_name -> google.protobuf.Timestamp
-	17, // 4: product.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 5: product.v1.GetProductDetailsResponse.product:type_name -> product.v1.Product
-	19, // 6: product.v1.ListProductsInternalRequest.page_info:type_name -> common.v1.PageInfoRequest
-	1,  // 7: product.v1.ListProductsInternalResponse.products:type_name -> product.v1.Product
-	20, // 8: product.v1.ListProductsInternalResponse.page_info:type_name -> common.v1.PageInfoResponse
-	6,  // 9: product.v1.UpdateStockRequest.items:type_name -> product.v1.StockUpdateItem
-	1,  // 10: product.v1.UpdateStockResponse.updated_products:type_name -> product.v1.Product
-	0,  // 11: product.v1.GetCategoryDetailsResponse.category:type_name -> product.v1.Category
-	19, // 12: product.v1.ListCategoriesInternalRequest.page_info:type_name -> common.v1.PageInfoRequest
-	0,  // 13: product.v1.ListCategoriesInternalResponse.categories:type_name -> product.v1.Category
-	20, // 14: product.v1.ListCategoriesInternalResponse.page_info:type_name -> common.v1.PageInfoResponse
-	13, // 15: product.v1.CheckProductsAvailabilityRequest.items:type_name -> product.v1.ProductAvailabilityItemInput
-	15, // 16: product.v1.CheckProductsAvailabilityResponse.statuses:type_name -> product.v1.ProductAvailabilityStatus
-	2,  // 17: product.v1.ProductCatalogService.GetProductDetails:input_type -> product.v1.GetProductDetailsRequest
-	4,  // 18: product.v1.ProductCatalogService.ListProductsInternal:input_type -> product.v1.ListProductsInternalRequest
-	7,  // 19: product.v1.ProductCatalogService.UpdateStock:input_type -> product.v1.UpdateStockRequest
-	9,  // 20: product.v1.ProductCatalogService.GetCategoryDetails:input_type -> product.v1.GetCategoryDetailsRequest
-	11, // 21: product.v1.ProductCatalogService.ListCategoriesInternal:input_type -> product.v1.ListCategoriesInternalRequest
-	14, // 22: product.v1.ProductCatalogService.CheckProductsAvailability:input_type -> product.v1.CheckProductsAvailabilityRequest
-	3,  // 23: product.v1.ProductCatalogService.GetProductDetails:output_type -> product.v1.GetProductDetailsResponse
-	5,  // 24: product.v1.ProductCatalogService.ListProductsInternal:output_type -> product.v1.ListProductsInternalResponse
-	8,  // 25: product.v1.ProductCatalogService.UpdateStock:output_type -> product.v1.UpdateStockResponse
-	10, // 26: product.v1.ProductCatalogService.GetCategoryDetails:output_type -> product.v1.GetCategoryDetailsResponse
-	12, // 27: product.v1.ProductCatalogService.ListCategoriesInternal:output_type -> product.v1.ListCategoriesInternalResponse
-	16, // 28: product.v1.ProductCatalogService.CheckProductsAvailability:output_type -> product.v1.CheckProductsAvailabilityResponse
-	23, // [23:29] is the sub-list for method output_type
-	17, // [17:23] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	69,  // 0: product.v1.Category.created_at:type_name -> google.protobuf.Timestamp
+	69,  // 1: product.v1.Category.updated_at:type_name -> google.protobuf.Timestamp
+	70,  // 2: product.v1.Product.attributes:type_name -> google.protobuf.Struct
+	69,  // 3: product.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	69,  // 4: product.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
+	71,  // 5: product.v1.Product.price_money:type_name -> common.v1.Money
+	71,  // 6: product.v1.Product.compare_at_price:type_name -> common.v1.Money
+	68,  // 7: product.v1.Product.option_values:type_name -> product.v1.Product.OptionValuesEntry
+	8,   // 8: product.v1.Product.variants:type_name -> product.v1.Product
+	9,   // 9: product.v1.Product.media:type_name -> product.v1.ProductMedia
+	69,  // 10: product.v1.Product.deleted_at:type_name -> google.protobuf.Timestamp
+	69,  // 11: product.v1.ProductMedia.created_at:type_name -> google.protobuf.Timestamp
+	69,  // 12: product.v1.ProductMedia.updated_at:type_name -> google.protobuf.Timestamp
+	8,   // 13: product.v1.GetProductDetailsResponse.product:type_name -> product.v1.Product
+	72,  // 14: product.v1.ListProductsInternalRequest.page_info:type_name -> common.v1.PageInfoRequest
+	17,  // 15: product.v1.ListProductsInternalRequest.facets:type_name -> product.v1.ProductFacetsRequest
+	15,  // 16: product.v1.ListProductsInternalRequest.attribute_filters:type_name -> product.v1.AttributeFilter
+	15,  // 17: product.v1.ExportProductsRequest.attribute_filters:type_name -> product.v1.AttributeFilter
+	8,   // 18: product.v1.ExportProductsResponse.products:type_name -> product.v1.Product
+	0,   // 19: product.v1.AttributeFilter.operator:type_name -> product.v1.AttributeFilterOperator
+	8,   // 20: product.v1.ListProductsInternalResponse.products:type_name -> product.v1.Product
+	73,  // 21: product.v1.ListProductsInternalResponse.page_info:type_name -> common.v1.PageInfoResponse
+	18,  // 22: product.v1.ListProductsInternalResponse.facets:type_name -> product.v1.ProductFacets
+	71,  // 23: product.v1.ProductFacetsRequest.price_boundaries_money:type_name -> common.v1.Money
+	19,  // 24: product.v1.ProductFacets.categories:type_name -> product.v1.CategoryFacet
+	20,  // 25: product.v1.ProductFacets.price_ranges:type_name -> product.v1.PriceRangeFacet
+	21,  // 26: product.v1.ProductFacets.is_active:type_name -> product.v1.IsActiveFacet
+	22,  // 27: product.v1.ProductFacets.attributes:type_name -> product.v1.AttributeFacet
+	71,  // 28: product.v1.PriceRangeFacet.min_money:type_name -> common.v1.Money
+	71,  // 29: product.v1.PriceRangeFacet.max_money:type_name -> common.v1.Money
+	23,  // 30: product.v1.AttributeFacet.values:type_name -> product.v1.AttributeValueCount
+	2,   // 31: product.v1.StockUpdateItemResult.status:type_name -> product.v1.StockUpdateStatus
+	8,   // 32: product.v1.StockUpdateItemResult.product:type_name -> product.v1.Product
+	61,  // 33: product.v1.StockUpdateItemResult.allocations:type_name -> product.v1.StockAllocation
+	24,  // 34: product.v1.UpdateStockRequest.items:type_name -> product.v1.StockUpdateItem
+	1,   // 35: product.v1.UpdateStockRequest.mode:type_name -> product.v1.StockUpdateMode
+	8,   // 36: product.v1.UpdateStockResponse.updated_products:type_name -> product.v1.Product
+	25,  // 37: product.v1.UpdateStockResponse.results:type_name -> product.v1.StockUpdateItemResult
+	1,   // 38: product.v1.UpdateStockResponse.mode:type_name -> product.v1.StockUpdateMode
+	7,   // 39: product.v1.GetCategoryDetailsResponse.category:type_name -> product.v1.Category
+	72,  // 40: product.v1.ListCategoriesInternalRequest.page_info:type_name -> common.v1.PageInfoRequest
+	7,   // 41: product.v1.ListCategoriesInternalResponse.categories:type_name -> product.v1.Category
+	73,  // 42: product.v1.ListCategoriesInternalResponse.page_info:type_name -> common.v1.PageInfoResponse
+	3,   // 43: product.v1.DeleteCategoryRequest.strategy:type_name -> product.v1.CategoryDeleteStrategy
+	7,   // 44: product.v1.CategoryTreeNode.category:type_name -> product.v1.Category
+	34,  // 45: product.v1.CategoryTreeNode.children:type_name -> product.v1.CategoryTreeNode
+	34,  // 46: product.v1.GetCategoryTreeResponse.roots:type_name -> product.v1.CategoryTreeNode
+	7,   // 47: product.v1.GetCategoryAncestorsResponse.ancestors:type_name -> product.v1.Category
+	7,   // 48: product.v1.GetCategoryDescendantsResponse.descendants:type_name -> product.v1.Category
+	4,   // 49: product.v1.CategoryAttribute.type:type_name -> product.v1.AttributeType
+	69,  // 50: product.v1.CategoryAttribute.created_at:type_name -> google.protobuf.Timestamp
+	69,  // 51: product.v1.CategoryAttribute.updated_at:type_name -> google.protobuf.Timestamp
+	41,  // 52: product.v1.GetCategoryAttributeSchemaResponse.attributes:type_name -> product.v1.CategoryAttribute
+	44,  // 53: product.v1.CheckProductsAvailabilityRequest.items:type_name -> product.v1.ProductAvailabilityItemInput
+	69,  // 54: product.v1.CheckProductsAvailabilityRequest.price_at:type_name -> google.protobuf.Timestamp
+	61,  // 55: product.v1.ProductAvailabilityStatus.allocations:type_name -> product.v1.StockAllocation
+	71,  // 56: product.v1.ProductAvailabilityStatus.current_price_money:type_name -> common.v1.Money
+	71,  // 57: product.v1.ProductAvailabilityStatus.price_at_money:type_name -> common.v1.Money
+	46,  // 58: product.v1.CheckProductsAvailabilityResponse.statuses:type_name -> product.v1.ProductAvailabilityStatus
+	5,   // 59: product.v1.StockReservation.status:type_name -> product.v1.ReservationStatus
+	69,  // 60: product.v1.StockReservation.expires_at:type_name -> google.protobuf.Timestamp
+	69,  // 61: product.v1.StockReservation.created_at:type_name -> google.protobuf.Timestamp
+	69,  // 62: product.v1.StockReservation.updated_at:type_name -> google.protobuf.Timestamp
+	49,  // 63: product.v1.ReserveStockRequest.items:type_name -> product.v1.ReservationItem
+	48,  // 64: product.v1.ReserveStockResponse.reservations:type_name -> product.v1.StockReservation
+	69,  // 65: product.v1.ReserveStockResponse.expires_at:type_name -> google.protobuf.Timestamp
+	48,  // 66: product.v1.CommitReservationResponse.reservations:type_name -> product.v1.StockReservation
+	8,   // 67: product.v1.CommitReservationResponse.updated_products:type_name -> product.v1.Product
+	48,  // 68: product.v1.ReleaseReservationResponse.reservations:type_name -> product.v1.StockReservation
+	6,   // 69: product.v1.StockMovement.reason:type_name -> product.v1.StockMovementReason
+	69,  // 70: product.v1.StockMovement.created_at:type_name -> google.protobuf.Timestamp
+	72,  // 71: product.v1.GetStockHistoryRequest.page_info:type_name -> common.v1.PageInfoRequest
+	69,  // 72: product.v1.GetStockHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	69,  // 73: product.v1.GetStockHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	56,  // 74: product.v1.GetStockHistoryResponse.movements:type_name -> product.v1.StockMovement
+	73,  // 75: product.v1.GetStockHistoryResponse.page_info:type_name -> common.v1.PageInfoResponse
+	69,  // 76: product.v1.Location.created_at:type_name -> google.protobuf.Timestamp
+	69,  // 77: product.v1.Location.updated_at:type_name -> google.protobuf.Timestamp
+	69,  // 78: product.v1.LocationStock.updated_at:type_name -> google.protobuf.Timestamp
+	59,  // 79: product.v1.ListLocationsResponse.locations:type_name -> product.v1.Location
+	60,  // 80: product.v1.GetStockLevelsResponse.levels:type_name -> product.v1.LocationStock
+	60,  // 81: product.v1.TransferStockResponse.levels:type_name -> product.v1.LocationStock
+	10,  // 82: product.v1.ProductCatalogService.GetProductDetails:input_type -> product.v1.GetProductDetailsRequest
+	12,  // 83: product.v1.ProductCatalogService.ListProductsInternal:input_type -> product.v1.ListProductsInternalRequest
+	13,  // 84: product.v1.ProductCatalogService.ExportProducts:input_type -> product.v1.ExportProductsRequest
+	26,  // 85: product.v1.ProductCatalogService.UpdateStock:input_type -> product.v1.UpdateStockRequest
+	28,  // 86: product.v1.ProductCatalogService.GetCategoryDetails:input_type -> product.v1.GetCategoryDetailsRequest
+	30,  // 87: product.v1.ProductCatalogService.ListCategoriesInternal:input_type -> product.v1.ListCategoriesInternalRequest
+	32,  // 88: product.v1.ProductCatalogService.DeleteCategory:input_type -> product.v1.DeleteCategoryRequest
+	35,  // 89: product.v1.ProductCatalogService.GetCategoryTree:input_type -> product.v1.GetCategoryTreeRequest
+	37,  // 90: product.v1.ProductCatalogService.GetCategoryAncestors:input_type -> product.v1.GetCategoryAncestorsRequest
+	39,  // 91: product.v1.ProductCatalogService.GetCategoryDescendants:input_type -> product.v1.GetCategoryDescendantsRequest
+	42,  // 92: product.v1.ProductCatalogService.GetCategoryAttributeSchema:input_type -> product.v1.GetCategoryAttributeSchemaRequest
+	45,  // 93: product.v1.ProductCatalogService.CheckProductsAvailability:input_type -> product.v1.CheckProductsAvailabilityRequest
+	50,  // 94: product.v1.ProductCatalogService.ReserveStock:input_type -> product.v1.ReserveStockRequest
+	52,  // 95: product.v1.ProductCatalogService.CommitReservation:input_type -> product.v1.CommitReservationRequest
+	54,  // 96: product.v1.ProductCatalogService.ReleaseReservation:input_type -> product.v1.ReleaseReservationRequest
+	57,  // 97: product.v1.ProductCatalogService.GetStockHistory:input_type -> product.v1.GetStockHistoryRequest
+	62,  // 98: product.v1.ProductCatalogService.ListLocations:input_type -> product.v1.ListLocationsRequest
+	64,  // 99: product.v1.ProductCatalogService.GetStockLevels:input_type -> product.v1.GetStockLevelsRequest
+	66,  // 100: product.v1.ProductCatalogService.TransferStock:input_type -> product.v1.TransferStockRequest
+	11,  // 101: product.v1.ProductCatalogService.GetProductDetails:output_type -> product.v1.GetProductDetailsResponse
+	16,  // 102: product.v1.ProductCatalogService.ListProductsInternal:output_type -> product.v1.ListProductsInternalResponse
+	14,  // 103: product.v1.ProductCatalogService.ExportProducts:output_type -> product.v1.ExportProductsResponse
+	27,  // 104: product.v1.ProductCatalogService.UpdateStock:output_type -> product.v1.UpdateStockResponse
+	29,  // 105: product.v1.ProductCatalogService.GetCategoryDetails:output_type -> product.v1.GetCategoryDetailsResponse
+	31,  // 106: product.v1.ProductCatalogService.ListCategoriesInternal:output_type -> product.v1.ListCategoriesInternalResponse
+	33,  // 107: product.v1.ProductCatalogService.DeleteCategory:output_type -> product.v1.DeleteCategoryResponse
+	36,  // 108: product.v1.ProductCatalogService.GetCategoryTree:output_type -> product.v1.GetCategoryTreeResponse
+	38,  // 109: product.v1.ProductCatalogService.GetCategoryAncestors:output_type -> product.v1.GetCategoryAncestorsResponse
+	40,  // 110: product.v1.ProductCatalogService.GetCategoryDescendants:output_type -> product.v1.GetCategoryDescendantsResponse
+	43,  // 111: product.v1.ProductCatalogService.GetCategoryAttributeSchema:output_type -> product.v1.GetCategoryAttributeSchemaResponse
+	47,  // 112: product.v1.ProductCatalogService.CheckProductsAvailability:output_type -> product.v1.CheckProductsAvailabilityResponse
+	51,  // 113: product.v1.ProductCatalogService.ReserveStock:output_type -> product.v1.ReserveStockResponse
+	53,  // 114: product.v1.ProductCatalogService.CommitReservation:output_type -> product.v1.CommitReservationResponse
+	55,  // 115: product.v1.ProductCatalogService.ReleaseReservation:output_type -> product.v1.ReleaseReservationResponse
+	58,  // 116: product.v1.ProductCatalogService.GetStockHistory:output_type -> product.v1.GetStockHistoryResponse
+	63,  // 117: product.v1.ProductCatalogService.ListLocations:output_type -> product.v1.ListLocationsResponse
+	65,  // 118: product.v1.ProductCatalogService.GetStockLevels:output_type -> product.v1.GetStockLevelsResponse
+	67,  // 119: product.v1.ProductCatalogService.TransferStock:output_type -> product.v1.TransferStockResponse
+	101, // [101:120] is the sub-list for method output_type
+	82,  // [82:101] is the sub-list for method input_type
+	82,  // [82:82] is the sub-list for extension type_name
+	82,  // [82:82] is the sub-list for extension extendee
+	0,   // [0:82] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1_product_product_proto_init() }
@@ -1199,22 +5117,42 @@ func file_proto_v1_product_product_proto_init() {
 	}
 	file_proto_v1_product_product_proto_msgTypes[0].OneofWrappers = []any{}
 	file_proto_v1_product_product_proto_msgTypes[1].OneofWrappers = []any{}
-	file_proto_v1_product_product_proto_msgTypes[4].OneofWrappers = []any{}
-	file_proto_v1_product_product_proto_msgTypes[7].OneofWrappers = []any{}
-	file_proto_v1_product_product_proto_msgTypes[11].OneofWrappers = []any{}
-	file_proto_v1_product_product_proto_msgTypes[15].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[8].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[9].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[17].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[18].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[19].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[23].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[25].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[28].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[34].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[37].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[38].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[39].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[43].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[49].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[50].OneofWrappers = []any{}
+	file_proto_v1_product_product_proto_msgTypes[59].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_product_product_proto_rawDesc), len(file_proto_v1_product_product_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      7,
+			NumMessages:   62,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_v1_product_product_proto_goTypes,
 		DependencyIndexes: file_proto_v1_product_product_proto_depIdxs,
+		EnumInfos:         file_proto_v1_product_product_proto_enumTypes,
 		MessageInfos:      file_proto_v1_product_product_proto_msgTypes,
 	}.Build()
 	File_proto_v1_product_product_proto = out.File
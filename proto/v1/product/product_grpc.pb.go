@@ -19,12 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProductCatalogService_GetProductDetails_FullMethodName         = "/product.v1.ProductCatalogService/GetProductDetails"
-	ProductCatalogService_ListProductsInternal_FullMethodName      = "/product.v1.ProductCatalogService/ListProductsInternal"
-	ProductCatalogService_UpdateStock_FullMethodName               = "/product.v1.ProductCatalogService/UpdateStock"
-	ProductCatalogService_GetCategoryDetails_FullMethodName        = "/product.v1.ProductCatalogService/GetCategoryDetails"
-	ProductCatalogService_ListCategoriesInternal_FullMethodName    = "/product.v1.ProductCatalogService/ListCategoriesInternal"
-	ProductCatalogService_CheckProductsAvailability_FullMethodName = "/product.v1.ProductCatalogService/CheckProductsAvailability"
+	ProductCatalogService_GetProductDetails_FullMethodName          = "/product.v1.ProductCatalogService/GetProductDetails"
+	ProductCatalogService_ListProductsInternal_FullMethodName       = "/product.v1.ProductCatalogService/ListProductsInternal"
+	ProductCatalogService_ExportProducts_FullMethodName             = "/product.v1.ProductCatalogService/ExportProducts"
+	ProductCatalogService_UpdateStock_FullMethodName                = "/product.v1.ProductCatalogService/UpdateStock"
+	ProductCatalogService_GetCategoryDetails_FullMethodName         = "/product.v1.ProductCatalogService/GetCategoryDetails"
+	ProductCatalogService_ListCategoriesInternal_FullMethodName     = "/product.v1.ProductCatalogService/ListCategoriesInternal"
+	ProductCatalogService_DeleteCategory_FullMethodName             = "/product.v1.ProductCatalogService/DeleteCategory"
+	ProductCatalogService_GetCategoryTree_FullMethodName            = "/product.v1.ProductCatalogService/GetCategoryTree"
+	ProductCatalogService_GetCategoryAncestors_FullMethodName       = "/product.v1.ProductCatalogService/GetCategoryAncestors"
+	ProductCatalogService_GetCategoryDescendants_FullMethodName     = "/product.v1.ProductCatalogService/GetCategoryDescendants"
+	ProductCatalogService_GetCategoryAttributeSchema_FullMethodName = "/product.v1.ProductCatalogService/GetCategoryAttributeSchema"
+	ProductCatalogService_CheckProductsAvailability_FullMethodName  = "/product.v1.ProductCatalogService/CheckProductsAvailability"
+	ProductCatalogService_ReserveStock_FullMethodName               = "/product.v1.ProductCatalogService/ReserveStock"
+	ProductCatalogService_CommitReservation_FullMethodName          = "/product.v1.ProductCatalogService/CommitReservation"
+	ProductCatalogService_ReleaseReservation_FullMethodName         = "/product.v1.ProductCatalogService/ReleaseReservation"
+	ProductCatalogService_GetStockHistory_FullMethodName            = "/product.v1.ProductCatalogService/GetStockHistory"
+	ProductCatalogService_ListLocations_FullMethodName              = "/product.v1.ProductCatalogService/ListLocations"
+	ProductCatalogService_GetStockLevels_FullMethodName             = "/product.v1.ProductCatalogService/GetStockLevels"
+	ProductCatalogService_TransferStock_FullMethodName              = "/product.v1.ProductCatalogService/TransferStock"
 )
 
 // ProductCatalogServiceClient is the client API for ProductCatalogService service.
@@ -35,15 +48,45 @@ type ProductCatalogServiceClient interface {
 	GetProductDetails(ctx context.Context, in *GetProductDetailsRequest, opts ...grpc.CallOption) (*GetProductDetailsResponse, error)
 	// Lists products, potentially for internal service-to-service use.
 	ListProductsInternal(ctx context.Context, in *ListProductsInternalRequest, opts ...grpc.CallOption) (*ListProductsInternalResponse, error)
+	// Streams every product matching the filters, in ID order, from one consistent snapshot.
+	// Each response carries a batch of products.
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProductsResponse], error)
 	// Updates the stock quantity for a given product or multiple products.
+	// Items are applied atomically by default; see UpdateStockRequest.mode.
+	// Requests carrying an order_id are idempotent: a retry returns the stored response.
 	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error)
 	// Retrieves details for a specific category by its ID.
 	GetCategoryDetails(ctx context.Context, in *GetCategoryDetailsRequest, opts ...grpc.CallOption) (*GetCategoryDetailsResponse, error)
 	// Lists categories, potentially for internal service-to-service use.
 	ListCategoriesInternal(ctx context.Context, in *ListCategoriesInternalRequest, opts ...grpc.CallOption) (*ListCategoriesInternalResponse, error)
+	// Deletes a category. The strategy decides what happens to its subcategories and products.
+	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
+	// Returns the category hierarchy as nested trees.
+	GetCategoryTree(ctx context.Context, in *GetCategoryTreeRequest, opts ...grpc.CallOption) (*GetCategoryTreeResponse, error)
+	// Returns the categories above a category, root first (its breadcrumb).
+	GetCategoryAncestors(ctx context.Context, in *GetCategoryAncestorsRequest, opts ...grpc.CallOption) (*GetCategoryAncestorsResponse, error)
+	// Returns every category below a category.
+	GetCategoryDescendants(ctx context.Context, in *GetCategoryDescendantsRequest, opts ...grpc.CallOption) (*GetCategoryDescendantsResponse, error)
+	// Returns the attribute schema products of a category must match, including inherited definitions.
+	GetCategoryAttributeSchema(ctx context.Context, in *GetCategoryAttributeSchemaRequest, opts ...grpc.CallOption) (*GetCategoryAttributeSchemaResponse, error)
 	// Checks availability (stock and price) for a list of products.
 	// Crucial for cart validation by the Order Service.
 	CheckProductsAvailability(ctx context.Context, in *CheckProductsAvailabilityRequest, opts ...grpc.CallOption) (*CheckProductsAvailabilityResponse, error)
+	// Holds stock for an order or cart until it is committed, released or expires.
+	// All items are reserved, or none are.
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
+	// Converts the active reservation of a reference into a real stock decrement.
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error)
+	// Returns the stock held by the active reservation of a reference.
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+	// Lists the stock movements (inventory ledger) of a product, newest first.
+	GetStockHistory(ctx context.Context, in *GetStockHistoryRequest, opts ...grpc.CallOption) (*GetStockHistoryResponse, error)
+	// Lists the stock locations (warehouses) in allocation order.
+	ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error)
+	// Returns the stock of products per location.
+	GetStockLevels(ctx context.Context, in *GetStockLevelsRequest, opts ...grpc.CallOption) (*GetStockLevelsResponse, error)
+	// Moves stock of a product between two locations. The aggregate stock is unchanged.
+	TransferStock(ctx context.Context, in *TransferStockRequest, opts ...grpc.CallOption) (*TransferStockResponse, error)
 }
 
 type productCatalogServiceClient struct {
@@ -74,6 +117,25 @@ func (c *productCatalogServiceClient) ListProductsInternal(ctx context.Context,
 	return out, nil
 }
 
+func (c *productCatalogServiceClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductCatalogService_ServiceDesc.Streams[0], ProductCatalogService_ExportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportProductsRequest, ExportProductsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductCatalogService_ExportProductsClient = grpc.ServerStreamingClient[ExportProductsResponse]
+
 func (c *productCatalogServiceClient) UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateStockResponse)
@@ -104,6 +166,56 @@ func (c *productCatalogServiceClient) ListCategoriesInternal(ctx context.Context
 	return out, nil
 }
 
+func (c *productCatalogServiceClient) DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCategoryResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_DeleteCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) GetCategoryTree(ctx context.Context, in *GetCategoryTreeRequest, opts ...grpc.CallOption) (*GetCategoryTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryTreeResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_GetCategoryTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) GetCategoryAncestors(ctx context.Context, in *GetCategoryAncestorsRequest, opts ...grpc.CallOption) (*GetCategoryAncestorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryAncestorsResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_GetCategoryAncestors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) GetCategoryDescendants(ctx context.Context, in *GetCategoryDescendantsRequest, opts ...grpc.CallOption) (*GetCategoryDescendantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryDescendantsResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_GetCategoryDescendants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) GetCategoryAttributeSchema(ctx context.Context, in *GetCategoryAttributeSchemaRequest, opts ...grpc.CallOption) (*GetCategoryAttributeSchemaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryAttributeSchemaResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_GetCategoryAttributeSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productCatalogServiceClient) CheckProductsAvailability(ctx context.Context, in *CheckProductsAvailabilityRequest, opts ...grpc.CallOption) (*CheckProductsAvailabilityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CheckProductsAvailabilityResponse)
@@ -114,6 +226,76 @@ func (c *productCatalogServiceClient) CheckProductsAvailability(ctx context.Cont
 	return out, nil
 }
 
+func (c *productCatalogServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveStockResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitReservationResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) GetStockHistory(ctx context.Context, in *GetStockHistoryRequest, opts ...grpc.CallOption) (*GetStockHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStockHistoryResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_GetStockHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLocationsResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_ListLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) GetStockLevels(ctx context.Context, in *GetStockLevelsRequest, opts ...grpc.CallOption) (*GetStockLevelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStockLevelsResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_GetStockLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productCatalogServiceClient) TransferStock(ctx context.Context, in *TransferStockRequest, opts ...grpc.CallOption) (*TransferStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferStockResponse)
+	err := c.cc.Invoke(ctx, ProductCatalogService_TransferStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductCatalogServiceServer is the server API for ProductCatalogService service.
 // All implementations must embed UnimplementedProductCatalogServiceServer
 // for forward compatibility.
@@ -122,15 +304,45 @@ type ProductCatalogServiceServer interface {
 	GetProductDetails(context.Context, *GetProductDetailsRequest) (*GetProductDetailsResponse, error)
 	// Lists products, potentially for internal service-to-service use.
 	ListProductsInternal(context.Context, *ListProductsInternalRequest) (*ListProductsInternalResponse, error)
+	// Streams every product matching the filters, in ID order, from one consistent snapshot.
+	// Each response carries a batch of products.
+	ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[ExportProductsResponse]) error
 	// Updates the stock quantity for a given product or multiple products.
+	// Items are applied atomically by default; see UpdateStockRequest.mode.
+	// Requests carrying an order_id are idempotent: a retry returns the stored response.
 	UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error)
 	// Retrieves details for a specific category by its ID.
 	GetCategoryDetails(context.Context, *GetCategoryDetailsRequest) (*GetCategoryDetailsResponse, error)
 	// Lists categories, potentially for internal service-to-service use.
 	ListCategoriesInternal(context.Context, *ListCategoriesInternalRequest) (*ListCategoriesInternalResponse, error)
+	// Deletes a category. The strategy decides what happens to its subcategories and products.
+	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
+	// Returns the category hierarchy as nested trees.
+	GetCategoryTree(context.Context, *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error)
+	// Returns the categories above a category, root first (its breadcrumb).
+	GetCategoryAncestors(context.Context, *GetCategoryAncestorsRequest) (*GetCategoryAncestorsResponse, error)
+	// Returns every category below a category.
+	GetCategoryDescendants(context.Context, *GetCategoryDescendantsRequest) (*GetCategoryDescendantsResponse, error)
+	// Returns the attribute schema products of a category must match, including inherited definitions.
+	GetCategoryAttributeSchema(context.Context, *GetCategoryAttributeSchemaRequest) (*GetCategoryAttributeSchemaResponse, error)
 	// Checks availability (stock and price) for a list of products.
 	// Crucial for cart validation by the Order Service.
 	CheckProductsAvailability(context.Context, *CheckProductsAvailabilityRequest) (*CheckProductsAvailabilityResponse, error)
+	// Holds stock for an order or cart until it is committed, released or expires.
+	// All items are reserved, or none are.
+	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
+	// Converts the active reservation of a reference into a real stock decrement.
+	CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error)
+	// Returns the stock held by the active reservation of a reference.
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	// Lists the stock movements (inventory ledger) of a product, newest first.
+	GetStockHistory(context.Context, *GetStockHistoryRequest) (*GetStockHistoryResponse, error)
+	// Lists the stock locations (warehouses) in allocation order.
+	ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error)
+	// Returns the stock of products per location.
+	GetStockLevels(context.Context, *GetStockLevelsRequest) (*GetStockLevelsResponse, error)
+	// Moves stock of a product between two locations. The aggregate stock is unchanged.
+	TransferStock(context.Context, *TransferStockRequest) (*TransferStockResponse, error)
 	mustEmbedUnimplementedProductCatalogServiceServer()
 }
 
@@ -147,6 +359,9 @@ func (UnimplementedProductCatalogServiceServer) GetProductDetails(context.Contex
 func (UnimplementedProductCatalogServiceServer) ListProductsInternal(context.Context, *ListProductsInternalRequest) (*ListProductsInternalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProductsInternal not implemented")
 }
+func (UnimplementedProductCatalogServiceServer) ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[ExportProductsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProducts not implemented")
+}
 func (UnimplementedProductCatalogServiceServer) UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateStock not implemented")
 }
@@ -156,9 +371,45 @@ func (UnimplementedProductCatalogServiceServer) GetCategoryDetails(context.Conte
 func (UnimplementedProductCatalogServiceServer) ListCategoriesInternal(context.Context, *ListCategoriesInternalRequest) (*ListCategoriesInternalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCategoriesInternal not implemented")
 }
+func (UnimplementedProductCatalogServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) GetCategoryTree(context.Context, *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryTree not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) GetCategoryAncestors(context.Context, *GetCategoryAncestorsRequest) (*GetCategoryAncestorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryAncestors not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) GetCategoryDescendants(context.Context, *GetCategoryDescendantsRequest) (*GetCategoryDescendantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryDescendants not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) GetCategoryAttributeSchema(context.Context, *GetCategoryAttributeSchemaRequest) (*GetCategoryAttributeSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryAttributeSchema not implemented")
+}
 func (UnimplementedProductCatalogServiceServer) CheckProductsAvailability(context.Context, *CheckProductsAvailabilityRequest) (*CheckProductsAvailabilityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckProductsAvailability not implemented")
 }
+func (UnimplementedProductCatalogServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) GetStockHistory(context.Context, *GetStockHistoryRequest) (*GetStockHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStockHistory not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLocations not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) GetStockLevels(context.Context, *GetStockLevelsRequest) (*GetStockLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStockLevels not implemented")
+}
+func (UnimplementedProductCatalogServiceServer) TransferStock(context.Context, *TransferStockRequest) (*TransferStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferStock not implemented")
+}
 func (UnimplementedProductCatalogServiceServer) mustEmbedUnimplementedProductCatalogServiceServer() {}
 func (UnimplementedProductCatalogServiceServer) testEmbeddedByValue()                               {}
 
@@ -216,6 +467,17 @@ func _ProductCatalogService_ListProductsInternal_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductCatalogService_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductCatalogServiceServer).ExportProducts(m, &grpc.GenericServerStream[ExportProductsRequest, ExportProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductCatalogService_ExportProductsServer = grpc.ServerStreamingServer[ExportProductsResponse]
+
 func _ProductCatalogService_UpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateStockRequest)
 	if err := dec(in); err != nil {
@@ -270,6 +532,96 @@ func _ProductCatalogService_ListCategoriesInternal_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductCatalogService_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).DeleteCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_DeleteCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).DeleteCategory(ctx, req.(*DeleteCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_GetCategoryTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).GetCategoryTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_GetCategoryTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).GetCategoryTree(ctx, req.(*GetCategoryTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_GetCategoryAncestors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryAncestorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).GetCategoryAncestors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_GetCategoryAncestors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).GetCategoryAncestors(ctx, req.(*GetCategoryAncestorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_GetCategoryDescendants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryDescendantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).GetCategoryDescendants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_GetCategoryDescendants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).GetCategoryDescendants(ctx, req.(*GetCategoryDescendantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_GetCategoryAttributeSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryAttributeSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).GetCategoryAttributeSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_GetCategoryAttributeSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).GetCategoryAttributeSchema(ctx, req.(*GetCategoryAttributeSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductCatalogService_CheckProductsAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckProductsAvailabilityRequest)
 	if err := dec(in); err != nil {
@@ -288,6 +640,132 @@ func _ProductCatalogService_CheckProductsAvailability_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductCatalogService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_GetStockHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).GetStockHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_GetStockHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).GetStockHistory(ctx, req.(*GetStockHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_ListLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).ListLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_ListLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).ListLocations(ctx, req.(*ListLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_GetStockLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).GetStockLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_GetStockLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).GetStockLevels(ctx, req.(*GetStockLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductCatalogService_TransferStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductCatalogServiceServer).TransferStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductCatalogService_TransferStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductCatalogServiceServer).TransferStock(ctx, req.(*TransferStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductCatalogService_ServiceDesc is the grpc.ServiceDesc for ProductCatalogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -315,11 +793,65 @@ var ProductCatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCategoriesInternal",
 			Handler:    _ProductCatalogService_ListCategoriesInternal_Handler,
 		},
+		{
+			MethodName: "DeleteCategory",
+			Handler:    _ProductCatalogService_DeleteCategory_Handler,
+		},
+		{
+			MethodName: "GetCategoryTree",
+			Handler:    _ProductCatalogService_GetCategoryTree_Handler,
+		},
+		{
+			MethodName: "GetCategoryAncestors",
+			Handler:    _ProductCatalogService_GetCategoryAncestors_Handler,
+		},
+		{
+			MethodName: "GetCategoryDescendants",
+			Handler:    _ProductCatalogService_GetCategoryDescendants_Handler,
+		},
+		{
+			MethodName: "GetCategoryAttributeSchema",
+			Handler:    _ProductCatalogService_GetCategoryAttributeSchema_Handler,
+		},
 		{
 			MethodName: "CheckProductsAvailability",
 			Handler:    _ProductCatalogService_CheckProductsAvailability_Handler,
 		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _ProductCatalogService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _ProductCatalogService_CommitReservation_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _ProductCatalogService_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "GetStockHistory",
+			Handler:    _ProductCatalogService_GetStockHistory_Handler,
+		},
+		{
+			MethodName: "ListLocations",
+			Handler:    _ProductCatalogService_ListLocations_Handler,
+		},
+		{
+			MethodName: "GetStockLevels",
+			Handler:    _ProductCatalogService_GetStockLevels_Handler,
+		},
+		{
+			MethodName: "TransferStock",
+			Handler:    _ProductCatalogService_TransferStock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _ProductCatalogService_ExportProducts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/v1/product/product.proto",
 }